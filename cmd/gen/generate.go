@@ -46,6 +46,7 @@ func main() {
 		model.InfraFile{},
 		model.InfraFileConfig{},
 		model.InfraFile{},
+		model.InfraFileContent{},
 		model.SocialUser{},
 		model.SocialUserBind{},
 		model.SocialClient{},
@@ -141,6 +142,10 @@ func main() {
 		pay.PayRefund{},
 		pay.PayNotifyTask{},
 		pay.PayNotifyLog{},
+		pay.PayWallet{},
+		pay.PayWalletTransaction{},
+		pay.PayWalletRecharge{},
+		pay.PayWalletRechargePackage{},
 	)
 
 	// 4. 执行生成
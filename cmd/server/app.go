@@ -0,0 +1,62 @@
+package main
+
+import (
+	"backend-go/internal/service"
+	paySvc "backend-go/internal/service/pay"
+	promotionSvc "backend-go/internal/service/promotion"
+	tradeSvc "backend-go/internal/service/trade"
+	tradeBrokerageSvc "backend-go/internal/service/trade/brokerage"
+
+	"github.com/gin-gonic/gin"
+)
+
+// App 应用实例，聚合 HTTP 引擎与定时任务调度器
+type App struct {
+	Engine       *gin.Engine
+	Scheduler    *service.Scheduler
+	ApiAccessLog *service.ApiAccessLogService
+}
+
+// NewApp 创建应用实例，并注册内置的定时任务处理器
+// 对齐 Java: 各 JobHandler 以 Bean 名称注册，infra_job.handler_name 按名称匹配
+func NewApp(
+	engine *gin.Engine,
+	scheduler *service.Scheduler,
+	payOrderExpireJob *paySvc.PayOrderExpireJob,
+	payOrderSyncJob *paySvc.PayOrderSyncJob,
+	payRefundSyncJob *paySvc.PayRefundSyncJob,
+	payNotifyJob *paySvc.PayNotifyJob,
+	couponExpireJob *promotionSvc.CouponExpireJob,
+	tradeOrderAutoCancelJob *tradeSvc.TradeOrderAutoCancelJob,
+	tradeOrderAutoReceiveJob *tradeSvc.TradeOrderAutoReceiveJob,
+	tradeOrderAutoCommentJob *tradeSvc.TradeOrderAutoCommentJob,
+	combinationRecordExpireJob *tradeSvc.CombinationRecordExpireJob,
+	brokerageRecordUnfreezeJob *tradeBrokerageSvc.BrokerageRecordUnfreezeJob,
+	accessLogCleanJob *service.AccessLogCleanJob,
+	errorLogCleanJob *service.ErrorLogCleanJob,
+	apiAccessLogService *service.ApiAccessLogService,
+) *App {
+	// Pay
+	scheduler.RegisterHandler(paySvc.PayOrderExpireJobHandlerName, payOrderExpireJob)
+	scheduler.RegisterHandler(paySvc.PayOrderSyncJobHandlerName, payOrderSyncJob)
+	scheduler.RegisterHandler(paySvc.PayRefundSyncJobHandlerName, payRefundSyncJob)
+	scheduler.RegisterHandler(paySvc.PayNotifyJobHandlerName, payNotifyJob)
+	// Promotion
+	scheduler.RegisterHandler(promotionSvc.CouponExpireJobHandlerName, couponExpireJob)
+	// Trade
+	scheduler.RegisterHandler(tradeSvc.TradeOrderAutoCancelJobHandlerName, tradeOrderAutoCancelJob)
+	scheduler.RegisterHandler(tradeSvc.TradeOrderAutoReceiveJobHandlerName, tradeOrderAutoReceiveJob)
+	scheduler.RegisterHandler(tradeSvc.TradeOrderAutoCommentJobHandlerName, tradeOrderAutoCommentJob)
+	scheduler.RegisterHandler(tradeSvc.CombinationRecordExpireJobHandlerName, combinationRecordExpireJob)
+	// Brokerage
+	scheduler.RegisterHandler(tradeBrokerageSvc.BrokerageRecordUnfreezeJobHandlerName, brokerageRecordUnfreezeJob)
+	// Infra
+	scheduler.RegisterHandler(service.AccessLogCleanJobHandlerName, accessLogCleanJob)
+	scheduler.RegisterHandler(service.ErrorLogCleanJobHandlerName, errorLogCleanJob)
+
+	return &App{
+		Engine:       engine,
+		Scheduler:    scheduler,
+		ApiAccessLog: apiAccessLogService,
+	}
+}
@@ -1,6 +1,14 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
 	"backend-go/internal/api/handler"
 	"backend-go/internal/api/router"
 	"backend-go/internal/pkg/area"
@@ -27,19 +35,49 @@ func main() {
 
 	// 4. 初始化应用 (通过 Wire 注入)
 	// 注意：InitDB 和 InitRedis 会在 InitApp 中被自动调用
-	r, err := InitApp()
+	app, err := InitApp()
 	if err != nil {
 		logger.Log.Fatal("failed to init app", zap.Error(err))
 	}
 
 	// 5. 注册地区路由 (独立于 Wire)
 	areaHandler := handler.NewAreaHandler()
-	router.RegisterAreaRoutes(r, areaHandler)
+	router.RegisterAreaRoutes(app.Engine, areaHandler)
+
+	// 6. 启动定时任务调度器
+	if err := app.Scheduler.Start(context.Background()); err != nil {
+		logger.Log.Error("failed to start scheduler", zap.Error(err))
+	}
 
-	// 6. 启动服务
+	// 7. 启动服务
 	addr := config.C.HTTP.Port
-	logger.Info("Server starting...", zap.String("addr", addr))
-	if err := r.Run(addr); err != nil {
-		logger.Log.Fatal("failed to start server", zap.Error(err))
+	srv := &http.Server{
+		Addr:    addr,
+		Handler: app.Engine,
+	}
+	go func() {
+		logger.Info("Server starting...", zap.String("addr", addr))
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Log.Fatal("failed to start server", zap.Error(err))
+		}
+	}()
+
+	// 8. 优雅关闭：等待退出信号，先停止接收请求，再停止调度器，最后写入剩余的访问日志
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+	logger.Info("Server shutting down...")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		logger.Log.Error("failed to shutdown server", zap.Error(err))
+	}
+	if err := app.Scheduler.Shutdown(); err != nil {
+		logger.Log.Error("failed to shutdown scheduler", zap.Error(err))
+	}
+	if err := app.ApiAccessLog.Stop(ctx); err != nil {
+		logger.Log.Error("failed to flush api access log", zap.Error(err))
 	}
+	logger.Info("Server exited")
 }
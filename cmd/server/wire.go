@@ -13,6 +13,7 @@ import (
 	tradeAdmin "backend-go/internal/api/handler/admin/trade"
 	brokerage "backend-go/internal/api/handler/admin/trade/brokerage"
 	memberHandler "backend-go/internal/api/handler/app/member"
+	payApp "backend-go/internal/api/handler/app/pay"
 	productApp "backend-go/internal/api/handler/app/product"
 	promotionApp "backend-go/internal/api/handler/app/promotion"
 	tradeApp "backend-go/internal/api/handler/app/trade"
@@ -35,11 +36,10 @@ import (
 
 	"backend-go/pkg/logger"
 
-	"github.com/gin-gonic/gin"
 	"github.com/google/wire"
 )
 
-func InitApp() (*gin.Engine, error) {
+func InitApp() (*App, error) {
 	wire.Build(
 		core.InitDB,
 		core.InitRedis,
@@ -59,10 +59,12 @@ func InitApp() (*gin.Engine, error) {
 		service.NewPostService,
 		service.NewNoticeService,
 		service.NewConfigService,
+		service.NewCaptchaService,
 		service.NewSmsClientFactory,            // Added SmsClientFactory
 		service.NewSmsChannelService,           // Added SmsChannelService
 		service.NewSmsTemplateService,          // Added SmsTemplateService
 		service.NewSmsLogService,               // Added SmsLogService
+		service.NewSmsSendService,
 		service.NewFileConfigService,           // Added FileConfigService
 		service.NewFileService,                 // Added FileService
 		service.NewSmsCodeService,              // Added SmsCodeService
@@ -78,6 +80,7 @@ func InitApp() (*gin.Engine, error) {
 		service.NewSensitiveWordService,        // Added SensitiveWordService
 		service.NewMailService,                 // Added MailService
 		service.NewNotifyService,               // Added NotifyService
+		service.NewExportService,
 		service.NewOAuth2ClientService,         // Added OAuth2ClientService
 		memberSvc.NewMemberAuthService,         // Added MemberAuthService
 		memberSvc.NewMemberUserService,         // Added MemberUserService
@@ -102,6 +105,8 @@ func InitApp() (*gin.Engine, error) {
 
 		// Handler
 		handler.NewAuthHandler,
+		handler.NewCaptchaHandler,
+		handler.NewSmsCallbackHandler,
 		handler.NewUserHandler,
 		handler.NewTenantHandler,
 		handler.NewDictHandler,
@@ -164,6 +169,7 @@ func InitApp() (*gin.Engine, error) {
 		tradeSvc.NewTradeAfterSaleService,
 		tradeSvc.NewTradeConfigService,   // Added Config
 		tradeSvc.NewTradeOrderLogService, // Added Log
+		tradeSvc.NewTradeMessageService,
 		tradeApp.NewAppCartHandler,
 		tradeApp.NewAppTradeOrderHandler,
 		tradeApp.NewAppTradeAfterSaleHandler,
@@ -183,7 +189,7 @@ func InitApp() (*gin.Engine, error) {
 		tradeBrokerageSvc.NewBrokerageRecordService,
 		tradeBrokerageSvc.NewBrokerageWithdrawService, // Added
 		paySvc.NewPayTransferService,                  // Placeholder
-		paySvc.NewPayWalletService,                    // Placeholder
+		paySvc.NewPayWalletService,
 		brokerage.NewBrokerageUserHandler,
 		brokerage.NewBrokerageRecordHandler,
 		brokerage.NewBrokerageWithdrawHandler,
@@ -253,6 +259,8 @@ func InitApp() (*gin.Engine, error) {
 		promotionApp.NewAppBargainActivityHandler,
 		promotionApp.NewAppBargainRecordHandler,
 		promotionApp.NewAppBargainHelpHandler,
+		promotionApp.NewAppSeckillConfigHandler,
+		promotionApp.NewAppSeckillActivityHandler,
 		promotionApp.NewAppCombinationActivityHandler, // Added Combination Activity
 		promotionApp.NewAppCombinationRecordHandler,   // Added Combination Record
 		promotionApp.NewAppArticleHandler,             // Added Article
@@ -263,6 +271,8 @@ func InitApp() (*gin.Engine, error) {
 		paySvc.NewPayOrderService,
 		paySvc.NewPayRefundService,
 		paySvc.NewPayNotifyService,
+		paySvc.NewPayWalletRechargeService,
+		paySvc.NewPayWalletRechargePackageService,
 		client.NewPayClientFactory,
 
 		deliveryClient.NewExpressClientFactory, // Added ExpressClientFactory
@@ -273,9 +283,30 @@ func InitApp() (*gin.Engine, error) {
 		payAdmin.NewPayOrderHandler,
 		payAdmin.NewPayRefundHandler,
 		payAdmin.NewPayNotifyHandler,
+		payAdmin.NewPayWalletHandler,
+		payAdmin.NewPayWalletRechargeHandler,
+		payAdmin.NewPayWalletRechargePackageHandler,
+		payApp.NewAppPayWalletHandler,
+		payApp.NewAppPayWalletRechargeHandler,
+		payApp.NewAppPayWalletRechargePackageHandler,
+
+		// Job
+		paySvc.NewPayOrderExpireJob,
+		paySvc.NewPayOrderSyncJob,
+		paySvc.NewPayRefundSyncJob,
+		paySvc.NewPayNotifyJob,
+		promotionSvc.NewCouponExpireJob,
+		tradeSvc.NewTradeOrderAutoCancelJob,
+		tradeSvc.NewTradeOrderAutoReceiveJob,
+		tradeSvc.NewTradeOrderAutoCommentJob,
+		tradeSvc.NewCombinationRecordExpireJob,
+		tradeBrokerageSvc.NewBrokerageRecordUnfreezeJob,
+		service.NewAccessLogCleanJob,
+		service.NewErrorLogCleanJob,
 
 		// Router
 		router.InitRouter,
+		NewApp,
 	)
-	return &gin.Engine{}, nil
+	return &App{}, nil
 }
@@ -16,6 +16,7 @@ import (
 	trade3 "backend-go/internal/api/handler/admin/trade"
 	brokerage2 "backend-go/internal/api/handler/admin/trade/brokerage"
 	member2 "backend-go/internal/api/handler/app/member"
+	pay3 "backend-go/internal/api/handler/app/pay"
 	product3 "backend-go/internal/api/handler/app/product"
 	promotion3 "backend-go/internal/api/handler/app/promotion"
 	trade2 "backend-go/internal/api/handler/app/trade"
@@ -34,7 +35,6 @@ import (
 	"backend-go/internal/service/trade/brokerage"
 	"backend-go/internal/service/trade/delivery/client"
 	"backend-go/pkg/logger"
-	"github.com/gin-gonic/gin"
 )
 
 import (
@@ -44,27 +44,30 @@ import (
 
 // Injectors from wire.go:
 
-func InitApp() (*gin.Engine, error) {
+func InitApp() (*App, error) {
 	db := core.InitDB()
 	redisClient := core.InitRedis()
 	query := repo.NewQuery(db)
-	roleService := service.NewRoleService(query)
-	permissionService := service.NewPermissionService(query, roleService)
-	menuService := service.NewMenuService(query)
+	roleService := service.NewRoleService(query, redisClient)
+	deptService := service.NewDeptService(query)
+	permissionService := service.NewPermissionService(query, redisClient, roleService, deptService)
+	menuService := service.NewMenuService(query, redisClient)
 	oAuth2TokenService := service.NewOAuth2TokenService()
 	smsClientFactory := service.NewSmsClientFactory()
-	smsCodeService := service.NewSmsCodeService(query, redisClient, smsClientFactory)
+	smsLogService := service.NewSmsLogService(query)
+	smsSendService := service.NewSmsSendService(query, smsClientFactory, smsLogService)
+	smsCodeService := service.NewSmsCodeService(query, redisClient, smsSendService)
 	loginLogService := service.NewLoginLogService(query)
-	userService := service.NewUserService(query)
+	userService := service.NewUserService(query, permissionService)
 	socialUserService := service.NewSocialUserService(query)
-	authService := service.NewAuthService(query, permissionService, roleService, menuService, oAuth2TokenService, smsCodeService, loginLogService, userService, socialUserService)
+	configService := service.NewConfigService(query)
+	captchaService := service.NewCaptchaService(redisClient, configService)
+	authService := service.NewAuthService(query, permissionService, roleService, menuService, oAuth2TokenService, smsCodeService, loginLogService, userService, socialUserService, captchaService)
 	authHandler := handler.NewAuthHandler(authService)
-	userHandler := handler.NewUserHandler(userService)
 	tenantService := service.NewTenantService(query)
 	tenantHandler := handler.NewTenantHandler(tenantService)
 	dictService := service.NewDictService(query)
 	dictHandler := handler.NewDictHandler(dictService)
-	deptService := service.NewDeptService(query)
 	deptHandler := handler.NewDeptHandler(deptService)
 	postService := service.NewPostService(query)
 	postHandler := handler.NewPostHandler(postService)
@@ -73,21 +76,22 @@ func InitApp() (*gin.Engine, error) {
 	permissionHandler := handler.NewPermissionHandler(permissionService, tenantService)
 	noticeService := service.NewNoticeService(query)
 	noticeHandler := handler.NewNoticeHandler(noticeService)
-	configService := service.NewConfigService(query)
 	configHandler := handler.NewConfigHandler(configService)
 	smsChannelService := service.NewSmsChannelService(query)
 	smsChannelHandler := handler.NewSmsChannelHandler(smsChannelService)
-	smsTemplateService := service.NewSmsTemplateService(query)
+	smsTemplateService := service.NewSmsTemplateService(query, smsClientFactory)
 	smsTemplateHandler := handler.NewSmsTemplateHandler(smsTemplateService)
-	smsLogService := service.NewSmsLogService(query)
 	smsLogHandler := handler.NewSmsLogHandler(smsLogService)
 	fileConfigService := service.NewFileConfigService(query)
 	fileConfigHandler := handler.NewFileConfigHandler(fileConfigService)
 	fileService := service.NewFileService(query, fileConfigService)
 	fileHandler := handler.NewFileHandler(fileService)
+	notifyService := service.NewNotifyService(db)
+	exportService := service.NewExportService(dictService, fileService, notifyService)
+	userHandler := handler.NewUserHandler(userService, exportService)
 	memberLevelService := member.NewMemberLevelService(query)
-	memberUserService := member.NewMemberUserService(query, smsCodeService, memberLevelService)
-	memberAuthService := member.NewMemberAuthService(query, smsCodeService, memberUserService, socialUserService, oAuth2TokenService)
+	memberUserService := member.NewMemberUserService(query, smsCodeService, memberLevelService, captchaService)
+	memberAuthService := member.NewMemberAuthService(query, smsCodeService, memberUserService, socialUserService, oAuth2TokenService, captchaService)
 	appAuthHandler := member2.NewAppAuthHandler(memberAuthService)
 	appMemberUserHandler := member2.NewAppMemberUserHandler(memberUserService)
 	memberAddressService := member.NewMemberAddressService(query)
@@ -110,36 +114,49 @@ func InitApp() (*gin.Engine, error) {
 	productBrowseHistoryHandler := product2.NewProductBrowseHistoryHandler(productBrowseHistoryService)
 	appProductFavoriteHandler := product3.NewAppProductFavoriteHandler(productFavoriteService)
 	appProductBrowseHistoryHandler := product3.NewAppProductBrowseHistoryHandler(productBrowseHistoryService)
-	appProductSpuHandler := product3.NewAppProductSpuHandler(productSpuService, productBrowseHistoryService, memberUserService, memberLevelService)
+	discountActivityService := promotion.NewDiscountActivityService(query, productSkuService)
+	appProductSpuHandler := product3.NewAppProductSpuHandler(productSpuService, productSkuService, productBrowseHistoryService, memberUserService, memberLevelService, discountActivityService)
 	appProductCommentHandler := product3.NewAppProductCommentHandler(productCommentService)
 	cartService := trade.NewCartService(query, productSkuService, productSpuService)
 	appCartHandler := trade2.NewAppCartHandler(cartService)
 	couponUserService := promotion.NewCouponUserService()
 	rewardActivityService := promotion.NewRewardActivityService(query)
 	deliveryFreightTemplateService := trade.NewDeliveryFreightTemplateService(query)
-	tradePriceService := trade.NewTradePriceService(productSkuService, productSpuService, couponUserService, rewardActivityService, memberUserService, memberLevelService, deliveryFreightTemplateService, memberAddressService)
+	tradeConfigService := trade.NewTradeConfigService(query)
+	seckillConfigService := promotion.NewSeckillConfigService(query)
+	seckillActivityService := promotion.NewSeckillActivityService(query, seckillConfigService, productSpuService, productSkuService, redisClient)
+	deliveryExpressService := trade.NewDeliveryExpressService(query)
+	expressClientFactoryImpl := client.NewExpressClientFactory()
+	tradeOrderQueryService := trade.NewTradeOrderQueryService(query, expressClientFactoryImpl, deliveryExpressService, permissionService)
+	tradePriceService := trade.NewTradePriceService(productSkuService, productSpuService, couponUserService, rewardActivityService, discountActivityService, memberUserService, memberLevelService, deliveryFreightTemplateService, memberAddressService, tradeConfigService, seckillActivityService, tradeOrderQueryService)
 	tradeOrderLogRepository := repo.NewTradeOrderLogRepository(query)
 	tradeOrderLogService := trade.NewTradeOrderLogService(tradeOrderLogRepository)
-	tradeOrderUpdateService := trade.NewTradeOrderUpdateService(productSkuService, cartService, tradePriceService, memberAddressService, couponUserService, tradeOrderLogService)
-	expressClientFactoryImpl := client.NewExpressClientFactory()
-	deliveryExpressService := trade.NewDeliveryExpressService(query)
-	tradeOrderQueryService := trade.NewTradeOrderQueryService(query, expressClientFactoryImpl, deliveryExpressService)
+	zapLogger := logger.NewLogger()
+	payClientFactory := client2.NewPayClientFactory()
+	payWalletService := pay.NewPayWalletService(query)
+	payChannelService := pay.NewPayChannelService(query, payClientFactory)
+	payAppService := pay.NewPayAppService(query, payChannelService)
+	payNotifyService := pay.NewPayNotifyService(query, zapLogger, redisClient)
+	payOrderService := pay.NewPayOrderService(query, payAppService, payChannelService, payClientFactory, payNotifyService, zapLogger)
+	payRefundService := pay.NewPayRefundService(query, payAppService, payOrderService, payChannelService, payNotifyService, zapLogger)
+	mailService := service.NewMailService(db)
+	tradeMessageService := trade.NewTradeMessageService(query, tradeConfigService, deliveryExpressService, notifyService, mailService, smsSendService, zapLogger)
+	memberPointRecordService := member.NewMemberPointRecordService(query, memberUserService)
+	tradeOrderUpdateService := trade.NewTradeOrderUpdateService(productSkuService, cartService, tradePriceService, memberAddressService, couponUserService, tradeOrderLogService, tradeConfigService, productCommentService, payRefundService, tradeMessageService, memberPointRecordService, seckillActivityService)
 	tradeOrderHandler := trade3.NewTradeOrderHandler(tradeOrderUpdateService, tradeOrderQueryService, memberUserService)
 	appTradeOrderHandler := trade2.NewAppTradeOrderHandler(tradeOrderUpdateService, tradeOrderQueryService)
-	tradeAfterSaleService := trade.NewTradeAfterSaleService(query, tradeOrderUpdateService)
+	tradeAfterSaleService := trade.NewTradeAfterSaleService(query, tradeOrderUpdateService, tradeConfigService, payRefundService, tradeMessageService)
 	tradeAfterSaleHandler := trade3.NewTradeAfterSaleHandler(tradeAfterSaleService)
 	appTradeAfterSaleHandler := trade2.NewAppTradeAfterSaleHandler(tradeAfterSaleService)
 	couponService := promotion.NewCouponService()
 	couponHandler := promotion2.NewCouponHandler(couponService)
 	combinationActivityService := promotion.NewCombinationActivityService(query, productSpuService, productSkuService)
 	combinationActivityHandler := promotion2.NewCombinationActivityHandler(combinationActivityService)
-	discountActivityService := promotion.NewDiscountActivityService(query, productSkuService)
 	discountActivityHandler := promotion2.NewDiscountActivityHandler(discountActivityService)
 	appCombinationActivityHandler := promotion3.NewAppCombinationActivityHandler(combinationActivityService)
 	combinationRecordService := promotion.NewCombinationRecordService(query, combinationActivityService, memberUserService, productSpuService, productSkuService)
 	appCombinationRecordHandler := promotion3.NewAppCombinationRecordHandler(combinationRecordService)
 	appCouponHandler := promotion3.NewAppCouponHandler(couponUserService)
-	zapLogger := logger.NewLogger()
 	deliveryExpressHandler := trade3.NewDeliveryExpressHandler(deliveryExpressService, zapLogger)
 	deliveryPickUpStoreService := trade.NewDeliveryPickUpStoreService(query)
 	deliveryPickUpStoreHandler := trade3.NewDeliveryPickUpStoreHandler(deliveryPickUpStoreService, zapLogger)
@@ -147,9 +164,7 @@ func InitApp() (*gin.Engine, error) {
 	promotionBannerService := promotion.NewPromotionBannerService(query)
 	bannerHandler := promotion2.NewBannerHandler(promotionBannerService)
 	rewardActivityHandler := promotion2.NewRewardActivityHandler(rewardActivityService)
-	seckillConfigService := promotion.NewSeckillConfigService(query)
 	seckillConfigHandler := promotion2.NewSeckillConfigHandler(seckillConfigService)
-	seckillActivityService := promotion.NewSeckillActivityService(query, seckillConfigService, productSpuService, productSkuService)
 	seckillActivityHandler := promotion2.NewSeckillActivityHandler(seckillActivityService)
 	bargainActivityService := promotion.NewBargainActivityService(query, productSpuService, productSkuService)
 	bargainRecordService := promotion.NewBargainRecordService(query)
@@ -163,7 +178,6 @@ func InitApp() (*gin.Engine, error) {
 	memberTagHandler := member3.NewMemberTagHandler(memberTagService)
 	memberConfigService := member.NewMemberConfigService(query)
 	memberConfigHandler := member3.NewMemberConfigHandler(memberConfigService)
-	memberPointRecordService := member.NewMemberPointRecordService(query, memberUserService)
 	memberPointRecordHandler := member3.NewMemberPointRecordHandler(memberPointRecordService, memberUserService)
 	appMemberPointRecordHandler := member2.NewAppMemberPointRecordHandler(memberPointRecordService)
 	memberSignInConfigService := member.NewMemberSignInConfigService(query)
@@ -172,21 +186,20 @@ func InitApp() (*gin.Engine, error) {
 	memberSignInRecordHandler := member3.NewMemberSignInRecordHandler(memberSignInRecordService, memberUserService)
 	appMemberSignInRecordHandler := member2.NewAppMemberSignInRecordHandler(memberSignInRecordService)
 	memberUserHandler := member3.NewMemberUserHandler(memberUserService, memberLevelService, memberPointRecordService, memberGroupService, memberTagService)
-	payChannelService := pay.NewPayChannelService(query)
-	payAppService := pay.NewPayAppService(query, payChannelService)
 	payAppHandler := pay2.NewPayAppHandler(payAppService)
 	payChannelHandler := pay2.NewPayChannelHandler(payChannelService)
-	payClientFactory := client2.NewPayClientFactory()
-	payNotifyService := pay.NewPayNotifyService(query, zapLogger, redisClient)
-	payOrderService := pay.NewPayOrderService(query, payAppService, payChannelService, payClientFactory, payNotifyService)
-	payOrderHandler := pay2.NewPayOrderHandler(payOrderService, payAppService)
-	payRefundService := pay.NewPayRefundService(query)
+	payOrderHandler := pay2.NewPayOrderHandler(payOrderService, payAppService, exportService)
 	payRefundHandler := pay2.NewPayRefundHandler(payRefundService, payAppService)
-	payNotifyHandler := pay2.NewPayNotifyHandler(payNotifyService, payAppService)
+	payNotifyHandler := pay2.NewPayNotifyHandler(payNotifyService, payAppService, payOrderService, payRefundService, payChannelService)
+	payWalletHandler := pay2.NewPayWalletHandler(payWalletService)
+	payWalletRechargePackageService := pay.NewPayWalletRechargePackageService(query)
+	payWalletRechargeService := pay.NewPayWalletRechargeService(query, payWalletService, payWalletRechargePackageService, payOrderService, payAppService)
+	payWalletRechargeHandler := pay2.NewPayWalletRechargeHandler(payWalletRechargeService)
+	payWalletRechargePackageHandler := pay2.NewPayWalletRechargePackageHandler(payWalletRechargePackageService)
 	loginLogHandler := handler.NewLoginLogHandler(loginLogService)
 	operateLogService := service.NewOperateLogService(query)
 	operateLogHandler := handler.NewOperateLogHandler(operateLogService)
-	scheduler, err := service.NewScheduler(query, zapLogger)
+	scheduler, err := service.NewScheduler(query, redisClient, zapLogger)
 	if err != nil {
 		return nil, err
 	}
@@ -194,7 +207,7 @@ func InitApp() (*gin.Engine, error) {
 	jobHandler := handler.NewJobHandler(jobService)
 	jobLogService := service.NewJobLogService(query)
 	jobLogHandler := handler.NewJobLogHandler(jobLogService)
-	apiAccessLogService := service.NewApiAccessLogService(query)
+	apiAccessLogService := service.NewApiAccessLogService(query, zapLogger)
 	apiAccessLogHandler := handler.NewApiAccessLogHandler(apiAccessLogService)
 	apiErrorLogService := service.NewApiErrorLogService(query)
 	apiErrorLogHandler := handler.NewApiErrorLogHandler(apiErrorLogService)
@@ -203,15 +216,15 @@ func InitApp() (*gin.Engine, error) {
 	socialUserHandler := handler.NewSocialUserHandler(socialUserService, zapLogger)
 	sensitiveWordService := service.NewSensitiveWordService(db)
 	sensitiveWordHandler := handler.NewSensitiveWordHandler(sensitiveWordService)
-	mailService := service.NewMailService(db)
 	mailHandler := handler.NewMailHandler(mailService)
-	notifyService := service.NewNotifyService(db)
 	notifyHandler := handler.NewNotifyHandler(notifyService)
 	oAuth2ClientService := service.NewOAuth2ClientService(db)
 	oAuth2ClientHandler := handler.NewOAuth2ClientHandler(oAuth2ClientService)
 	appBargainActivityHandler := promotion3.NewAppBargainActivityHandler(bargainActivityService, bargainRecordService, productSpuService)
 	appBargainRecordHandler := promotion3.NewAppBargainRecordHandler(bargainRecordService, bargainActivityService, memberUserService, productSpuService, tradeOrderQueryService, bargainHelpService)
 	appBargainHelpHandler := promotion3.NewAppBargainHelpHandler(bargainHelpService, memberUserService)
+	appSeckillConfigHandler := promotion3.NewAppSeckillConfigHandler(seckillConfigService)
+	appSeckillActivityHandler := promotion3.NewAppSeckillActivityHandler(seckillActivityService, seckillConfigService, productSpuService)
 	articleCategoryService := promotion.NewArticleCategoryService(query)
 	articleCategoryHandler := promotion2.NewArticleCategoryHandler(articleCategoryService)
 	articleService := promotion.NewArticleService(query)
@@ -230,14 +243,12 @@ func InitApp() (*gin.Engine, error) {
 	bargainRecordHandler := promotion2.NewBargainRecordHandler(bargainRecordService, bargainActivityService, memberUserService)
 	combinationRecordHandler := promotion2.NewCombinationRecordHandler(combinationRecordService, combinationActivityService)
 	bargainHelpHandler := promotion2.NewBargainHelpHandler(bargainHelpService, memberUserService)
-	tradeConfigService := trade.NewTradeConfigService(query)
 	tradeConfigHandler := trade3.NewTradeConfigHandler(tradeConfigService)
 	appTradeConfigHandler := trade2.NewAppTradeConfigHandler(tradeConfigService)
 	brokerageUserService := brokerage.NewBrokerageUserService(query, zapLogger, memberUserService, tradeConfigService)
 	brokerageUserHandler := brokerage2.NewBrokerageUserHandler(brokerageUserService, memberUserService, zapLogger)
 	brokerageRecordService := brokerage.NewBrokerageRecordService(query, zapLogger, tradeConfigService, productSpuService, productSkuService)
 	brokerageRecordHandler := brokerage2.NewBrokerageRecordHandler(zapLogger, brokerageRecordService, memberUserService)
-	payWalletService := pay.NewPayWalletService()
 	payTransferService := pay.NewPayTransferService(db, zapLogger, payAppService, payChannelService, payNotifyService, payClientFactory, query)
 	brokerageWithdrawService := brokerage.NewBrokerageWithdrawService(query, zapLogger, brokerageRecordService, payWalletService, payTransferService, tradeConfigService, memberUserService)
 	brokerageWithdrawHandler := brokerage2.NewBrokerageWithdrawHandler(brokerageWithdrawService, memberUserService)
@@ -261,9 +272,27 @@ func InitApp() (*gin.Engine, error) {
 	payWalletStatisticsRepository := repo.NewPayWalletStatisticsRepository(query)
 	payWalletStatisticsService := service.NewPayWalletStatisticsService(payWalletStatisticsRepository)
 	payStatisticsHandler := admin.NewPayStatisticsHandler(payWalletStatisticsService)
+	appPayWalletHandler := pay3.NewAppPayWalletHandler(payWalletService)
+	appPayWalletRechargeHandler := pay3.NewAppPayWalletRechargeHandler(payWalletRechargeService, payWalletService)
+	appPayWalletRechargePackageHandler := pay3.NewAppPayWalletRechargePackageHandler(payWalletRechargePackageService)
 	appBrokerageUserHandler := brokerage3.NewAppBrokerageUserHandler(brokerageUserService, brokerageRecordService, brokerageWithdrawService)
 	appBrokerageRecordHandler := brokerage3.NewAppBrokerageRecordHandler(brokerageRecordService)
 	appBrokerageWithdrawHandler := brokerage3.NewAppBrokerageWithdrawHandler(brokerageWithdrawService, payTransferService)
-	engine := router.InitRouter(db, redisClient, authHandler, userHandler, tenantHandler, dictHandler, deptHandler, postHandler, roleHandler, menuHandler, permissionHandler, noticeHandler, configHandler, smsChannelHandler, smsTemplateHandler, smsLogHandler, fileConfigHandler, fileHandler, appAuthHandler, appMemberUserHandler, appMemberAddressHandler, productCategoryHandler, productPropertyHandler, productBrandHandler, productSpuHandler, productCommentHandler, productFavoriteHandler, productBrowseHistoryHandler, appProductFavoriteHandler, appProductBrowseHistoryHandler, appProductSpuHandler, appProductCommentHandler, appCartHandler, tradeOrderHandler, appTradeOrderHandler, tradeAfterSaleHandler, appTradeAfterSaleHandler, couponHandler, combinationActivityHandler, discountActivityHandler, appCombinationActivityHandler, appCombinationRecordHandler, appCouponHandler, deliveryExpressHandler, deliveryPickUpStoreHandler, deliveryFreightTemplateHandler, bannerHandler, rewardActivityHandler, seckillConfigHandler, seckillActivityHandler, bargainActivityHandler, appBannerHandler, memberLevelHandler, memberGroupHandler, memberTagHandler, memberConfigHandler, memberPointRecordHandler, appMemberPointRecordHandler, memberSignInConfigHandler, memberSignInRecordHandler, appMemberSignInRecordHandler, memberUserHandler, payAppHandler, payChannelHandler, payOrderHandler, payRefundHandler, payNotifyHandler, loginLogHandler, operateLogHandler, jobHandler, jobLogHandler, apiAccessLogHandler, apiErrorLogHandler, socialClientHandler, socialUserHandler, sensitiveWordHandler, mailHandler, notifyHandler, oAuth2ClientHandler, appBargainActivityHandler, appBargainRecordHandler, appBargainHelpHandler, articleCategoryHandler, articleHandler, appArticleHandler, diyTemplateHandler, diyPageHandler, appDiyPageHandler, kefuHandler, appKefuHandler, pointActivityHandler, bargainRecordHandler, combinationRecordHandler, bargainHelpHandler, tradeConfigHandler, appTradeConfigHandler, brokerageUserHandler, brokerageRecordHandler, brokerageWithdrawHandler, tradeStatisticsHandler, productStatisticsHandler, memberStatisticsHandler, payStatisticsHandler, appBrokerageUserHandler, appBrokerageRecordHandler, appBrokerageWithdrawHandler)
-	return engine, nil
+	captchaHandler := handler.NewCaptchaHandler(captchaService)
+	smsCallbackHandler := handler.NewSmsCallbackHandler(smsSendService)
+	engine := router.InitRouter(db, redisClient, permissionService, apiAccessLogService, apiErrorLogService, operateLogService, authHandler, userHandler, tenantHandler, dictHandler, deptHandler, postHandler, roleHandler, menuHandler, permissionHandler, noticeHandler, configHandler, smsChannelHandler, smsTemplateHandler, smsLogHandler, fileConfigHandler, fileHandler, appAuthHandler, appMemberUserHandler, appMemberAddressHandler, productCategoryHandler, productPropertyHandler, productBrandHandler, productSpuHandler, productCommentHandler, productFavoriteHandler, productBrowseHistoryHandler, appProductFavoriteHandler, appProductBrowseHistoryHandler, appProductSpuHandler, appProductCommentHandler, appCartHandler, tradeOrderHandler, appTradeOrderHandler, tradeAfterSaleHandler, appTradeAfterSaleHandler, couponHandler, combinationActivityHandler, discountActivityHandler, appCombinationActivityHandler, appCombinationRecordHandler, appCouponHandler, deliveryExpressHandler, deliveryPickUpStoreHandler, deliveryFreightTemplateHandler, bannerHandler, rewardActivityHandler, seckillConfigHandler, seckillActivityHandler, bargainActivityHandler, appBannerHandler, memberLevelHandler, memberGroupHandler, memberTagHandler, memberConfigHandler, memberPointRecordHandler, appMemberPointRecordHandler, memberSignInConfigHandler, memberSignInRecordHandler, appMemberSignInRecordHandler, memberUserHandler, payAppHandler, payChannelHandler, payOrderHandler, payRefundHandler, payNotifyHandler, payWalletHandler, payWalletRechargeHandler, payWalletRechargePackageHandler, loginLogHandler, operateLogHandler, jobHandler, jobLogHandler, apiAccessLogHandler, apiErrorLogHandler, socialClientHandler, socialUserHandler, sensitiveWordHandler, mailHandler, notifyHandler, oAuth2ClientHandler, appBargainActivityHandler, appBargainRecordHandler, appBargainHelpHandler, appSeckillConfigHandler, appSeckillActivityHandler, articleCategoryHandler, articleHandler, appArticleHandler, diyTemplateHandler, diyPageHandler, appDiyPageHandler, kefuHandler, appKefuHandler, pointActivityHandler, bargainRecordHandler, combinationRecordHandler, bargainHelpHandler, tradeConfigHandler, appTradeConfigHandler, brokerageUserHandler, brokerageRecordHandler, brokerageWithdrawHandler, tradeStatisticsHandler, productStatisticsHandler, memberStatisticsHandler, payStatisticsHandler, appBrokerageUserHandler, appBrokerageRecordHandler, appBrokerageWithdrawHandler, appPayWalletHandler, appPayWalletRechargeHandler, appPayWalletRechargePackageHandler, captchaHandler, smsCallbackHandler)
+	payOrderExpireJob := pay.NewPayOrderExpireJob(payOrderService, zapLogger)
+	payOrderSyncJob := pay.NewPayOrderSyncJob(payOrderService, zapLogger)
+	payRefundSyncJob := pay.NewPayRefundSyncJob(payRefundService, zapLogger)
+	payNotifyJob := pay.NewPayNotifyJob(payNotifyService, zapLogger)
+	couponExpireJob := promotion.NewCouponExpireJob(couponUserService, zapLogger)
+	tradeOrderAutoCancelJob := trade.NewTradeOrderAutoCancelJob(tradeOrderUpdateService, zapLogger)
+	tradeOrderAutoReceiveJob := trade.NewTradeOrderAutoReceiveJob(tradeOrderUpdateService, zapLogger)
+	tradeOrderAutoCommentJob := trade.NewTradeOrderAutoCommentJob(tradeOrderUpdateService, zapLogger)
+	combinationRecordExpireJob := trade.NewCombinationRecordExpireJob(combinationRecordService, tradeOrderUpdateService, zapLogger)
+	brokerageRecordUnfreezeJob := brokerage.NewBrokerageRecordUnfreezeJob(brokerageRecordService, zapLogger)
+	accessLogCleanJob := service.NewAccessLogCleanJob(apiAccessLogService, zapLogger)
+	errorLogCleanJob := service.NewErrorLogCleanJob(apiErrorLogService, zapLogger)
+	app := NewApp(engine, scheduler, payOrderExpireJob, payOrderSyncJob, payRefundSyncJob, payNotifyJob, couponExpireJob, tradeOrderAutoCancelJob, tradeOrderAutoReceiveJob, tradeOrderAutoCommentJob, combinationRecordExpireJob, brokerageRecordUnfreezeJob, accessLogCleanJob, errorLogCleanJob, apiAccessLogService)
+	return app, nil
 }
@@ -6,23 +6,125 @@ import (
 	"backend-go/internal/model/pay"
 	"backend-go/internal/pkg/core"
 	paySvc "backend-go/internal/service/pay"
+	"backend-go/internal/service/pay/client"
+	"backend-go/pkg/logger"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jinzhu/copier"
+	"go.uber.org/zap"
 )
 
 type PayNotifyHandler struct {
-	svc    *paySvc.PayNotifyService
-	appSvc *paySvc.PayAppService
+	svc        *paySvc.PayNotifyService
+	appSvc     *paySvc.PayAppService
+	orderSvc   *paySvc.PayOrderService
+	refundSvc  *paySvc.PayRefundService
+	channelSvc *paySvc.PayChannelService
 }
 
-func NewPayNotifyHandler(svc *paySvc.PayNotifyService, appSvc *paySvc.PayAppService) *PayNotifyHandler {
+func NewPayNotifyHandler(svc *paySvc.PayNotifyService, appSvc *paySvc.PayAppService, orderSvc *paySvc.PayOrderService, refundSvc *paySvc.PayRefundService, channelSvc *paySvc.PayChannelService) *PayNotifyHandler {
 	return &PayNotifyHandler{
-		svc:    svc,
-		appSvc: appSvc,
+		svc:        svc,
+		appSvc:     appSvc,
+		orderSvc:   orderSvc,
+		refundSvc:  refundSvc,
+		channelSvc: channelSvc,
 	}
 }
 
+// NotifyOrder 支付渠道的统一【支付】回调
+// 对齐 Java: PayNotifyController.notifyOrder，无需登录
+func (h *PayNotifyHandler) NotifyOrder(c *gin.Context) {
+	channelID, err := strconv.ParseInt(c.Param("channelId"), 10, 64)
+	if err != nil {
+		writeNotifyFailure(c, "", "渠道编号不正确")
+		return
+	}
+	channel, err := h.channelSvc.GetChannel(c, channelID)
+	if err != nil {
+		logger.Log.Error("[NotifyOrder][渠道编号不存在]", zap.Int64("channelId", channelID), zap.Error(err))
+		writeNotifyFailure(c, "", "渠道编号不存在")
+		return
+	}
+	payClient, err := h.channelSvc.GetPayClient(c, channelID)
+	if err != nil {
+		logger.Log.Error("[NotifyOrder][渠道客户端不存在]", zap.Int64("channelId", channelID), zap.Error(err))
+		writeNotifyFailure(c, channel.Code, "渠道客户端不存在")
+		return
+	}
+
+	// 1. 解析通知数据 (包含验签)
+	notifyData, err := buildNotifyData(c)
+	if err != nil {
+		writeNotifyFailure(c, channel.Code, "读取回调数据失败")
+		return
+	}
+	notify, err := payClient.ParseOrderNotify(notifyData)
+	if err != nil {
+		logger.Log.Error("[NotifyOrder][解析回调失败]", zap.Int64("channelId", channelID), zap.Error(err))
+		writeNotifyFailure(c, channel.Code, "解析回调失败")
+		return
+	}
+
+	// 2. 更新支付订单 (幂等)
+	if err := h.orderSvc.NotifyOrder(c, channelID, notify); err != nil {
+		logger.Log.Error("[NotifyOrder][处理回调失败]", zap.Int64("channelId", channelID),
+			zap.String("outTradeNo", notify.OutTradeNo), zap.Error(err))
+		writeNotifyFailure(c, channel.Code, "处理回调失败")
+		return
+	}
+	writeNotifySuccess(c, channel.Code)
+}
+
+// NotifyRefund 支付渠道的统一【退款】回调
+// 对齐 Java: PayNotifyController.notifyRefund，无需登录
+func (h *PayNotifyHandler) NotifyRefund(c *gin.Context) {
+	channelID, err := strconv.ParseInt(c.Param("channelId"), 10, 64)
+	if err != nil {
+		writeNotifyFailure(c, "", "渠道编号不正确")
+		return
+	}
+	channel, err := h.channelSvc.GetChannel(c, channelID)
+	if err != nil {
+		logger.Log.Error("[NotifyRefund][渠道编号不存在]", zap.Int64("channelId", channelID), zap.Error(err))
+		writeNotifyFailure(c, "", "渠道编号不存在")
+		return
+	}
+	payClient, err := h.channelSvc.GetPayClient(c, channelID)
+	if err != nil {
+		logger.Log.Error("[NotifyRefund][渠道客户端不存在]", zap.Int64("channelId", channelID), zap.Error(err))
+		writeNotifyFailure(c, channel.Code, "渠道客户端不存在")
+		return
+	}
+
+	// 1. 解析通知数据 (包含验签)
+	notifyData, err := buildNotifyData(c)
+	if err != nil {
+		writeNotifyFailure(c, channel.Code, "读取回调数据失败")
+		return
+	}
+	notify, err := payClient.ParseRefundNotify(notifyData)
+	if err != nil {
+		logger.Log.Error("[NotifyRefund][解析回调失败]", zap.Int64("channelId", channelID), zap.Error(err))
+		writeNotifyFailure(c, channel.Code, "解析回调失败")
+		return
+	}
+
+	// 2. 更新退款单 (幂等)
+	if err := h.refundSvc.NotifyRefund(c, channelID, notify); err != nil {
+		logger.Log.Error("[NotifyRefund][处理回调失败]", zap.Int64("channelId", channelID),
+			zap.String("outRefundNo", notify.OutRefundNo), zap.Error(err))
+		writeNotifyFailure(c, channel.Code, "处理回调失败")
+		return
+	}
+	writeNotifySuccess(c, channel.Code)
+}
+
 // GetNotifyTaskDetail 获得回调通知详情 (Task + Logs)
 func (h *PayNotifyHandler) GetNotifyTaskDetail(c *gin.Context) {
 	id := core.ParseInt64(c.Query("id"))
@@ -100,3 +202,64 @@ func convertNotifyTaskResp(task *pay.PayNotifyTask, app *pay.PayApp) *resp.PayNo
 	}
 	return r
 }
+
+// buildNotifyData 从原始请求构建渠道回调数据
+// 注意：Body 需原样保留，微信 V3 等渠道基于原文验签
+func buildNotifyData(c *gin.Context) (*client.NotifyData, error) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	params := make(map[string]string)
+	for k, v := range c.Request.URL.Query() {
+		if len(v) > 0 {
+			params[k] = v[0]
+		}
+	}
+	// 支付宝等渠道以表单方式回调，参数位于 Body 中
+	if strings.HasPrefix(c.ContentType(), "application/x-www-form-urlencoded") {
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range form {
+			if len(v) > 0 {
+				params[k] = v[0]
+			}
+		}
+	}
+
+	headers := make(map[string]string, len(c.Request.Header))
+	for k, v := range c.Request.Header {
+		if len(v) > 0 {
+			headers[k] = v[0]
+		}
+	}
+	return &client.NotifyData{
+		Params:  params,
+		Body:    string(body),
+		Headers: headers,
+	}, nil
+}
+
+// writeNotifySuccess 按渠道要求的格式响应回调成功
+func writeNotifySuccess(c *gin.Context, channelCode string) {
+	if strings.HasPrefix(channelCode, "wx") {
+		// 微信 V3：HTTP 200 + JSON
+		c.JSON(http.StatusOK, gin.H{"code": "SUCCESS", "message": "成功"})
+		return
+	}
+	// 支付宝等：纯文本 success
+	c.String(http.StatusOK, "success")
+}
+
+// writeNotifyFailure 按渠道要求的格式响应回调失败，渠道会按自身策略重试
+func writeNotifyFailure(c *gin.Context, channelCode string, msg string) {
+	if strings.HasPrefix(channelCode, "wx") {
+		// 微信 V3：非 2xx 状态码视为失败
+		c.JSON(http.StatusInternalServerError, gin.H{"code": "FAIL", "message": msg})
+		return
+	}
+	c.String(http.StatusOK, "failure")
+}
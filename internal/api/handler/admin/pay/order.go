@@ -5,19 +5,24 @@ import (
 	"backend-go/internal/api/resp"
 	"backend-go/internal/model/pay"
 	"backend-go/internal/pkg/core"
+	"backend-go/internal/pkg/excel"
+	"backend-go/internal/service"
 	paySvc "backend-go/internal/service/pay"
+	"context"
+	"io"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
 
 type PayOrderHandler struct {
-	svc    *paySvc.PayOrderService
-	appSvc *paySvc.PayAppService
+	svc       *paySvc.PayOrderService
+	appSvc    *paySvc.PayAppService
+	exportSvc *service.ExportService
 }
 
-func NewPayOrderHandler(svc *paySvc.PayOrderService, appSvc *paySvc.PayAppService) *PayOrderHandler {
-	return &PayOrderHandler{svc: svc, appSvc: appSvc}
+func NewPayOrderHandler(svc *paySvc.PayOrderService, appSvc *paySvc.PayAppService, exportSvc *service.ExportService) *PayOrderHandler {
+	return &PayOrderHandler{svc: svc, appSvc: appSvc, exportSvc: exportSvc}
 }
 
 // GetOrder 获得支付订单
@@ -104,6 +109,32 @@ func (h *PayOrderHandler) GetOrderPage(c *gin.Context) {
 	}))
 }
 
+// ExportOrderExcel 导出支付订单 Excel
+// async=true 时后台导出，完成后通过站内信通知下载地址
+func (h *PayOrderHandler) ExportOrderExcel(c *gin.Context) {
+	var r req.PayOrderExportReq
+	if err := c.ShouldBindQuery(&r); err != nil {
+		c.JSON(200, core.ErrParam)
+		return
+	}
+	export := func(ctx context.Context, w io.Writer, dict excel.DictFormatter) error {
+		return h.svc.ExportOrderExcel(ctx, w, &r, dict)
+	}
+	if async, _ := strconv.ParseBool(c.Query("async")); async {
+		if err := h.exportSvc.ExportAsync(c.Request.Context(), core.GetLoginUserID(c), "支付订单.xlsx", export); err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(200, core.Success(true))
+		return
+	}
+
+	excel.SetResponseHeader(c, "支付订单.xlsx")
+	if err := h.exportSvc.Export(c.Request.Context(), c.Writer, export); err != nil {
+		c.Error(err)
+	}
+}
+
 // SubmitPayOrder 提交支付订单
 func (h *PayOrderHandler) SubmitPayOrder(c *gin.Context) {
 	var r req.PayOrderSubmitReq
@@ -112,10 +143,19 @@ func (h *PayOrderHandler) SubmitPayOrder(c *gin.Context) {
 		return
 	}
 
-	// 1. Wallet payment case? (Handled in Service or here?)
-	// Java logic handles Wallet extra param here.
-	// If channel is Wallet, set wallet_id in extras.
-	// We will skip Wallet specific logic for now or add TODO.
+	// 1. 钱包支付时，使用当前登录用户作为付款钱包
+	if r.ChannelCode == paySvc.PayChannelCodeWallet {
+		loginUser := core.GetLoginUser(c)
+		if loginUser == nil {
+			c.JSON(200, core.ErrUnauthorized)
+			return
+		}
+		if r.ChannelExtras == nil {
+			r.ChannelExtras = make(map[string]string)
+		}
+		r.ChannelExtras[paySvc.WalletPayChannelExtraUserID] = strconv.FormatInt(loginUser.UserID, 10)
+		r.ChannelExtras[paySvc.WalletPayChannelExtraUserType] = strconv.Itoa(loginUser.UserType)
+	}
 
 	// 2. Submit Order
 	respVO, err := h.svc.SubmitOrder(c, &r, c.ClientIP())
@@ -0,0 +1,112 @@
+package pay
+
+import (
+	"backend-go/internal/api/req"
+	"backend-go/internal/api/resp"
+	"backend-go/internal/model/pay"
+	"backend-go/internal/pkg/core"
+	"backend-go/internal/service"
+	paySvc "backend-go/internal/service/pay"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+)
+
+type PayWalletHandler struct {
+	svc *paySvc.PayWalletService
+}
+
+func NewPayWalletHandler(svc *paySvc.PayWalletService) *PayWalletHandler {
+	return &PayWalletHandler{svc: svc}
+}
+
+// GetWallet 获得会员钱包
+func (h *PayWalletHandler) GetWallet(c *gin.Context) {
+	userId, err := strconv.ParseInt(c.Query("userId"), 10, 64)
+	if err != nil {
+		c.JSON(200, core.ErrParam)
+		return
+	}
+	wallet, err := h.svc.GetOrCreateWallet(c, userId, service.UserTypeMember)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(200, core.Success(convertWalletResp(wallet)))
+}
+
+// GetWalletPage 获得会员钱包分页
+func (h *PayWalletHandler) GetWalletPage(c *gin.Context) {
+	var r req.PayWalletPageReq
+	if err := c.ShouldBindQuery(&r); err != nil {
+		c.JSON(200, core.ErrParam)
+		return
+	}
+	pageResult, err := h.svc.GetWalletPage(c, &r)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(200, core.Success(core.PageResult[*resp.PayWalletResp]{
+		List:  lo.Map(pageResult.List, func(item *pay.PayWallet, _ int) *resp.PayWalletResp { return convertWalletResp(item) }),
+		Total: pageResult.Total,
+	}))
+}
+
+// UpdateWalletBalance 调整会员钱包余额
+func (h *PayWalletHandler) UpdateWalletBalance(c *gin.Context) {
+	var r req.PayWalletUpdateBalanceReq
+	if err := c.ShouldBindJSON(&r); err != nil {
+		c.JSON(200, core.ErrParam)
+		return
+	}
+	if err := h.svc.UpdateWalletBalance(c, r.UserID, service.UserTypeMember, r.Balance); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(200, core.Success(true))
+}
+
+// GetWalletTransactionPage 获得钱包流水分页
+func (h *PayWalletHandler) GetWalletTransactionPage(c *gin.Context) {
+	var r req.PayWalletTransactionPageReq
+	if err := c.ShouldBindQuery(&r); err != nil {
+		c.JSON(200, core.ErrParam)
+		return
+	}
+	pageResult, err := h.svc.GetWalletTransactionPage(c, r.WalletID, nil, &r.PageParam)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(200, core.Success(core.PageResult[*resp.PayWalletTransactionResp]{
+		List: lo.Map(pageResult.List, func(item *pay.PayWalletTransaction, _ int) *resp.PayWalletTransactionResp {
+			return &resp.PayWalletTransactionResp{
+				ID:         item.ID,
+				WalletID:   item.WalletID,
+				BizType:    item.BizType,
+				BizID:      item.BizID,
+				No:         item.No,
+				Title:      item.Title,
+				Price:      item.Price,
+				Balance:    item.Balance,
+				CreateTime: item.CreatedAt,
+			}
+		}),
+		Total: pageResult.Total,
+	}))
+}
+
+func convertWalletResp(wallet *pay.PayWallet) *resp.PayWalletResp {
+	return &resp.PayWalletResp{
+		ID:            wallet.ID,
+		UserID:        wallet.UserID,
+		UserType:      wallet.UserType,
+		Balance:       wallet.Balance,
+		TotalExpense:  wallet.TotalExpense,
+		TotalRecharge: wallet.TotalRecharge,
+		FreezePrice:   wallet.FreezePrice,
+		CreateTime:    wallet.CreatedAt,
+	}
+}
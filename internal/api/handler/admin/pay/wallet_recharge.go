@@ -0,0 +1,38 @@
+package pay
+
+import (
+	"backend-go/internal/api/req"
+	"backend-go/internal/pkg/core"
+	paySvc "backend-go/internal/service/pay"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PayWalletRechargeHandler struct {
+	svc *paySvc.PayWalletRechargeService
+}
+
+func NewPayWalletRechargeHandler(svc *paySvc.PayWalletRechargeService) *PayWalletRechargeHandler {
+	return &PayWalletRechargeHandler{svc: svc}
+}
+
+// UpdateWalletRechargerPaid 更新钱包充值为已支付
+// 由支付应用的订单回调调用 (pay_app.order_notify_url)，无需登录
+func (h *PayWalletRechargeHandler) UpdateWalletRechargerPaid(c *gin.Context) {
+	var r req.PayOrderNotifyReq
+	if err := c.ShouldBindJSON(&r); err != nil {
+		c.JSON(200, core.ErrParam)
+		return
+	}
+	id, err := strconv.ParseInt(r.MerchantOrderId, 10, 64)
+	if err != nil {
+		c.JSON(200, core.ErrParam)
+		return
+	}
+	if err := h.svc.UpdateWalletRechargerPaid(c, id, r.PayOrderID); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(200, core.Success(true))
+}
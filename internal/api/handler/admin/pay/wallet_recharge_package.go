@@ -0,0 +1,110 @@
+package pay
+
+import (
+	"backend-go/internal/api/req"
+	"backend-go/internal/api/resp"
+	"backend-go/internal/model/pay"
+	"backend-go/internal/pkg/core"
+	paySvc "backend-go/internal/service/pay"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+)
+
+type PayWalletRechargePackageHandler struct {
+	svc *paySvc.PayWalletRechargePackageService
+}
+
+func NewPayWalletRechargePackageHandler(svc *paySvc.PayWalletRechargePackageService) *PayWalletRechargePackageHandler {
+	return &PayWalletRechargePackageHandler{svc: svc}
+}
+
+// CreateWalletRechargePackage 创建充值套餐
+func (h *PayWalletRechargePackageHandler) CreateWalletRechargePackage(c *gin.Context) {
+	var r req.PayWalletRechargePackageCreateReq
+	if err := c.ShouldBindJSON(&r); err != nil {
+		c.JSON(200, core.ErrParam)
+		return
+	}
+	id, err := h.svc.CreateWalletRechargePackage(c, &r)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(200, core.Success(id))
+}
+
+// UpdateWalletRechargePackage 更新充值套餐
+func (h *PayWalletRechargePackageHandler) UpdateWalletRechargePackage(c *gin.Context) {
+	var r req.PayWalletRechargePackageUpdateReq
+	if err := c.ShouldBindJSON(&r); err != nil {
+		c.JSON(200, core.ErrParam)
+		return
+	}
+	if err := h.svc.UpdateWalletRechargePackage(c, &r); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(200, core.Success(true))
+}
+
+// DeleteWalletRechargePackage 删除充值套餐
+func (h *PayWalletRechargePackageHandler) DeleteWalletRechargePackage(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Query("id"), 10, 64)
+	if err != nil {
+		c.JSON(200, core.ErrParam)
+		return
+	}
+	if err := h.svc.DeleteWalletRechargePackage(c, id); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(200, core.Success(true))
+}
+
+// GetWalletRechargePackage 获得充值套餐
+func (h *PayWalletRechargePackageHandler) GetWalletRechargePackage(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Query("id"), 10, 64)
+	if err != nil {
+		c.JSON(200, core.ErrParam)
+		return
+	}
+	pkg, err := h.svc.GetWalletRechargePackage(c, id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(200, core.Success(convertWalletRechargePackageResp(pkg)))
+}
+
+// GetWalletRechargePackagePage 获得充值套餐分页
+func (h *PayWalletRechargePackageHandler) GetWalletRechargePackagePage(c *gin.Context) {
+	var r req.PayWalletRechargePackagePageReq
+	if err := c.ShouldBindQuery(&r); err != nil {
+		c.JSON(200, core.ErrParam)
+		return
+	}
+	pageResult, err := h.svc.GetWalletRechargePackagePage(c, &r)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(200, core.Success(core.PageResult[*resp.PayWalletRechargePackageResp]{
+		List: lo.Map(pageResult.List, func(item *pay.PayWalletRechargePackage, _ int) *resp.PayWalletRechargePackageResp {
+			return convertWalletRechargePackageResp(item)
+		}),
+		Total: pageResult.Total,
+	}))
+}
+
+func convertWalletRechargePackageResp(pkg *pay.PayWalletRechargePackage) *resp.PayWalletRechargePackageResp {
+	return &resp.PayWalletRechargePackageResp{
+		ID:         pkg.ID,
+		Name:       pkg.Name,
+		PayPrice:   pkg.PayPrice,
+		BonusPrice: pkg.BonusPrice,
+		Status:     pkg.Status,
+		CreateTime: pkg.CreatedAt,
+	}
+}
@@ -67,7 +67,7 @@ func (h *TradeAfterSaleHandler) RefundAfterSale(c *gin.Context) {
 		core.WriteError(c, 400, err.Error())
 		return
 	}
-	if err := h.svc.RefundAfterSale(c, r.ID); err != nil {
+	if err := h.svc.RefundAfterSale(c, c.ClientIP(), r.ID); err != nil {
 		core.WriteError(c, 500, err.Error())
 		return
 	}
@@ -0,0 +1,72 @@
+package pay
+
+import (
+	"backend-go/internal/api/req"
+	"backend-go/internal/api/resp"
+	"backend-go/internal/model/pay"
+	"backend-go/internal/pkg/core"
+	paySvc "backend-go/internal/service/pay"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+)
+
+type AppPayWalletHandler struct {
+	svc *paySvc.PayWalletService
+}
+
+func NewAppPayWalletHandler(svc *paySvc.PayWalletService) *AppPayWalletHandler {
+	return &AppPayWalletHandler{svc: svc}
+}
+
+// GetPayWallet 获取钱包
+func (h *AppPayWalletHandler) GetPayWallet(c *gin.Context) {
+	loginUser := core.GetLoginUser(c)
+	if loginUser == nil {
+		core.WriteBizError(c, core.ErrUnauthorized)
+		return
+	}
+	wallet, err := h.svc.GetOrCreateWallet(c, loginUser.UserID, loginUser.UserType)
+	if err != nil {
+		core.WriteBizError(c, err)
+		return
+	}
+	core.WriteSuccess(c, &resp.AppPayWalletResp{
+		Balance:       wallet.Balance,
+		TotalExpense:  wallet.TotalExpense,
+		TotalRecharge: wallet.TotalRecharge,
+	})
+}
+
+// GetWalletTransactionPage 获得钱包流水分页
+func (h *AppPayWalletHandler) GetWalletTransactionPage(c *gin.Context) {
+	var r req.AppPayWalletTransactionPageReq
+	if err := c.ShouldBindQuery(&r); err != nil {
+		core.WriteBizError(c, core.ErrParam)
+		return
+	}
+	loginUser := core.GetLoginUser(c)
+	if loginUser == nil {
+		core.WriteBizError(c, core.ErrUnauthorized)
+		return
+	}
+	wallet, err := h.svc.GetOrCreateWallet(c, loginUser.UserID, loginUser.UserType)
+	if err != nil {
+		core.WriteBizError(c, err)
+		return
+	}
+	pageResult, err := h.svc.GetWalletTransactionPage(c, wallet.ID, r.Type, &r.PageParam)
+	if err != nil {
+		core.WriteBizError(c, err)
+		return
+	}
+
+	core.WriteSuccess(c, core.NewPageResult(lo.Map(pageResult.List, func(item *pay.PayWalletTransaction, _ int) *resp.AppPayWalletTransactionResp {
+		return &resp.AppPayWalletTransactionResp{
+			BizType:    item.BizType,
+			Price:      item.Price,
+			Title:      item.Title,
+			CreateTime: item.CreatedAt,
+		}
+	}), pageResult.Total))
+}
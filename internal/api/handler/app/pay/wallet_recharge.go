@@ -0,0 +1,82 @@
+package pay
+
+import (
+	"backend-go/internal/api/req"
+	"backend-go/internal/api/resp"
+	"backend-go/internal/model/pay"
+	"backend-go/internal/pkg/core"
+	paySvc "backend-go/internal/service/pay"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+)
+
+type AppPayWalletRechargeHandler struct {
+	svc       *paySvc.PayWalletRechargeService
+	walletSvc *paySvc.PayWalletService
+}
+
+func NewAppPayWalletRechargeHandler(svc *paySvc.PayWalletRechargeService, walletSvc *paySvc.PayWalletService) *AppPayWalletRechargeHandler {
+	return &AppPayWalletRechargeHandler{svc: svc, walletSvc: walletSvc}
+}
+
+// CreateWalletRecharge 创建钱包充值记录（发起充值）
+func (h *AppPayWalletRechargeHandler) CreateWalletRecharge(c *gin.Context) {
+	var r req.AppPayWalletRechargeCreateReq
+	if err := c.ShouldBindJSON(&r); err != nil {
+		core.WriteBizError(c, core.ErrParam)
+		return
+	}
+	loginUser := core.GetLoginUser(c)
+	if loginUser == nil {
+		core.WriteBizError(c, core.ErrUnauthorized)
+		return
+	}
+	recharge, err := h.svc.CreateWalletRecharge(c, loginUser.UserID, loginUser.UserType, c.ClientIP(), &r)
+	if err != nil {
+		core.WriteBizError(c, err)
+		return
+	}
+	core.WriteSuccess(c, &resp.AppPayWalletRechargeCreateResp{
+		ID:         recharge.ID,
+		PayOrderID: recharge.PayOrderID,
+	})
+}
+
+// GetWalletRechargePage 获得钱包充值记录分页
+func (h *AppPayWalletRechargeHandler) GetWalletRechargePage(c *gin.Context) {
+	var r req.AppPayWalletRechargePageReq
+	if err := c.ShouldBindQuery(&r); err != nil {
+		core.WriteBizError(c, core.ErrParam)
+		return
+	}
+	loginUser := core.GetLoginUser(c)
+	if loginUser == nil {
+		core.WriteBizError(c, core.ErrUnauthorized)
+		return
+	}
+	wallet, err := h.walletSvc.GetOrCreateWallet(c, loginUser.UserID, loginUser.UserType)
+	if err != nil {
+		core.WriteBizError(c, err)
+		return
+	}
+	pageResult, err := h.svc.GetWalletRechargePage(c, wallet.ID, &r.PageParam)
+	if err != nil {
+		core.WriteBizError(c, err)
+		return
+	}
+
+	core.WriteSuccess(c, core.NewPageResult(lo.Map(pageResult.List, func(item *pay.PayWalletRecharge, _ int) *resp.AppPayWalletRechargeResp {
+		return &resp.AppPayWalletRechargeResp{
+			ID:               item.ID,
+			TotalPrice:       item.TotalPrice,
+			PayPrice:         item.PayPrice,
+			BonusPrice:       item.BonusPrice,
+			PayChannelCode:   item.PayChannelCode,
+			PayOrderID:       item.PayOrderID,
+			PayTime:          item.PayTime,
+			RefundStatus:     item.RefundStatus,
+			RefundTotalPrice: item.RefundTotalPrice,
+		}
+	}), pageResult.Total))
+}
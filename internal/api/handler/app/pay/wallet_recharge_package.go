@@ -0,0 +1,36 @@
+package pay
+
+import (
+	"backend-go/internal/api/resp"
+	"backend-go/internal/model/pay"
+	"backend-go/internal/pkg/core"
+	paySvc "backend-go/internal/service/pay"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+)
+
+type AppPayWalletRechargePackageHandler struct {
+	svc *paySvc.PayWalletRechargePackageService
+}
+
+func NewAppPayWalletRechargePackageHandler(svc *paySvc.PayWalletRechargePackageService) *AppPayWalletRechargePackageHandler {
+	return &AppPayWalletRechargePackageHandler{svc: svc}
+}
+
+// GetWalletRechargePackageList 获得钱包充值套餐列表
+func (h *AppPayWalletRechargePackageHandler) GetWalletRechargePackageList(c *gin.Context) {
+	list, err := h.svc.GetEnableWalletRechargePackageList(c)
+	if err != nil {
+		core.WriteBizError(c, err)
+		return
+	}
+	core.WriteSuccess(c, lo.Map(list, func(item *pay.PayWalletRechargePackage, _ int) *resp.AppPayWalletRechargePackageResp {
+		return &resp.AppPayWalletRechargePackageResp{
+			ID:         item.ID,
+			Name:       item.Name,
+			PayPrice:   item.PayPrice,
+			BonusPrice: item.BonusPrice,
+		}
+	}))
+}
@@ -1,30 +1,73 @@
 package product
 
 import (
+	"backend-go/internal/api/req"
+	"backend-go/internal/api/resp"
+	promotionModel "backend-go/internal/model/promotion"
 	"backend-go/internal/pkg/core"
 	memberSvc "backend-go/internal/service/member"
 	"backend-go/internal/service/product"
+	"backend-go/internal/service/promotion"
+	"context"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
 )
 
 type AppProductSpuHandler struct {
-	spuSvc         *product.ProductSpuService
-	historySvc     *product.ProductBrowseHistoryService
-	memberUserSvc  *memberSvc.MemberUserService
-	memberLevelSvc *memberSvc.MemberLevelService
+	spuSvc              *product.ProductSpuService
+	skuSvc              *product.ProductSkuService
+	historySvc          *product.ProductBrowseHistoryService
+	memberUserSvc       *memberSvc.MemberUserService
+	memberLevelSvc      *memberSvc.MemberLevelService
+	discountActivitySvc promotion.DiscountActivityService
 }
 
-func NewAppProductSpuHandler(spuSvc *product.ProductSpuService, historySvc *product.ProductBrowseHistoryService, memberUserSvc *memberSvc.MemberUserService, memberLevelSvc *memberSvc.MemberLevelService) *AppProductSpuHandler {
+func NewAppProductSpuHandler(spuSvc *product.ProductSpuService, skuSvc *product.ProductSkuService, historySvc *product.ProductBrowseHistoryService, memberUserSvc *memberSvc.MemberUserService, memberLevelSvc *memberSvc.MemberLevelService, discountActivitySvc promotion.DiscountActivityService) *AppProductSpuHandler {
 	return &AppProductSpuHandler{
-		spuSvc:         spuSvc,
-		historySvc:     historySvc,
-		memberUserSvc:  memberUserSvc,
-		memberLevelSvc: memberLevelSvc,
+		spuSvc:              spuSvc,
+		skuSvc:              skuSvc,
+		historySvc:          historySvc,
+		memberUserSvc:       memberUserSvc,
+		memberLevelSvc:      memberLevelSvc,
+		discountActivitySvc: discountActivitySvc,
 	}
 }
 
+// GetSpuPage 获得 SPU 分页
+// @Summary 获得 SPU 分页
+// @Tags 用户 APP - 商品 SPU
+// @Produce json
+// @Param pageNo query int true "页码"
+// @Param pageSize query int true "每页条数"
+// @Param categoryId query int false "分类编号"
+// @Param keyword query string false "关键字"
+// @Param sortField query string false "排序字段：price、salesCount、createTime"
+// @Param sortAsc query bool false "是否升序"
+// @Success 200 {object} core.PageResult[resp.ProductSpuResp]
+// @Router /app-api/product/spu/page [get]
+func (h *AppProductSpuHandler) GetSpuPage(c *gin.Context) {
+	var r req.AppProductSpuPageReq
+	if err := c.ShouldBindQuery(&r); err != nil {
+		c.JSON(200, core.ErrParam)
+		return
+	}
+	res, err := h.spuSvc.GetAppSpuPage(c, &r)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	for _, spu := range res.List {
+		spu.SalesCount += spu.VirtualSalesCount
+	}
+	if err := h.fillDiscountActivity(c, res.List); err != nil {
+		c.Error(err)
+		return
+	}
+	core.WriteSuccess(c, res)
+}
+
 // GetSpuDetail 获得 SPU 详情 (Trigger History)
 // @Summary 获得 SPU 详情
 // @Tags 用户 APP - 商品 SPU
@@ -49,7 +92,7 @@ func (h *AppProductSpuHandler) GetSpuDetail(c *gin.Context) {
 		core.WriteBizError(c, core.NewBizError(1006000002, "商品不存在"))
 		return
 	}
-	if res.Status != 1 {
+	if res.Status != 0 { // 0: 上架
 		core.WriteBizError(c, core.NewBizError(1006000003, "商品已下架"))
 		return
 	}
@@ -81,5 +124,67 @@ func (h *AppProductSpuHandler) GetSpuDetail(c *gin.Context) {
 		}
 	}
 
+	if err := h.fillDiscountActivity(c, []*resp.ProductSpuResp{res}); err != nil {
+		c.Error(err)
+		return
+	}
+
 	core.WriteSuccess(c, res)
 }
+
+// fillDiscountActivity 填充商品参与中的限时折扣：SKU 的活动价，SPU 的活动标签与最低活动价
+// 列表不返回 SKU，按参与活动的 SKU 查询价格
+func (h *AppProductSpuHandler) fillDiscountActivity(ctx context.Context, spus []*resp.ProductSpuResp) error {
+	if len(spus) == 0 {
+		return nil
+	}
+	spuIDs := make([]int64, len(spus))
+	for i, spu := range spus {
+		spuIDs[i] = spu.ID
+	}
+	products, err := h.discountActivitySvc.GetMatchDiscountProductListBySpuIds(ctx, spuIDs)
+	if err != nil || len(products) == 0 {
+		return err
+	}
+	productMap := make(map[int64]*promotionModel.PromotionDiscountProduct, len(products))
+	skuIDs := make([]int64, len(products))
+	for i, p := range products {
+		productMap[p.SkuID] = p
+		skuIDs[i] = p.SkuID
+	}
+	skus, err := h.skuSvc.GetSkuList(ctx, skuIDs)
+	if err != nil {
+		return err
+	}
+	skuMap := make(map[int64]*resp.ProductSkuResp, len(skus))
+	for _, sku := range skus {
+		skuMap[sku.ID] = sku
+	}
+
+	for _, spu := range spus {
+		for _, sku := range spu.Skus {
+			if p := productMap[sku.ID]; p != nil {
+				sku.ActivityPrice = promotion.CalculateDiscountProductPrice(p, sku.Price, 1)
+			}
+		}
+	}
+	for _, p := range products {
+		sku := skuMap[p.SkuID]
+		spu, _ := lo.Find(spus, func(spu *resp.ProductSpuResp) bool { return spu.ID == p.SpuID })
+		if sku == nil || spu == nil {
+			continue
+		}
+		price := promotion.CalculateDiscountProductPrice(p, sku.Price, 1)
+		if spu.DiscountActivity == nil || price < spu.ActivityPrice {
+			spu.ActivityPrice = price
+		}
+		if spu.DiscountActivity == nil {
+			spu.DiscountActivity = &resp.ProductDiscountActivityResp{
+				ID:      p.ActivityID,
+				Name:    p.ActivityName,
+				EndTime: p.ActivityEndTime,
+			}
+		}
+	}
+	return nil
+}
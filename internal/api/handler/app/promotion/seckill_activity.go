@@ -0,0 +1,207 @@
+package promotion
+
+import (
+	"context"
+	"time"
+
+	"backend-go/internal/api/resp"
+	promotionModel "backend-go/internal/model/promotion"
+	"backend-go/internal/pkg/core"
+	"backend-go/internal/service/product"
+	"backend-go/internal/service/promotion"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AppSeckillActivityHandler struct {
+	activitySvc *promotion.SeckillActivityService
+	configSvc   *promotion.SeckillConfigService
+	spuSvc      *product.ProductSpuService
+}
+
+func NewAppSeckillActivityHandler(activitySvc *promotion.SeckillActivityService, configSvc *promotion.SeckillConfigService, spuSvc *product.ProductSpuService) *AppSeckillActivityHandler {
+	return &AppSeckillActivityHandler{
+		activitySvc: activitySvc,
+		configSvc:   configSvc,
+		spuSvc:      spuSvc,
+	}
+}
+
+// GetNowSeckillActivity 获得当前秒杀时段的活动，当前不在任何时段内时返回下一个时段
+// Java: GET /get-now, @PermitAll
+func (h *AppSeckillActivityHandler) GetNowSeckillActivity(c *gin.Context) {
+	ctx := c.Request.Context()
+	config, err := h.configSvc.GetCurrentSeckillConfig(ctx)
+	if err != nil {
+		core.WriteBizError(c, err)
+		return
+	}
+	if config == nil {
+		if config, err = h.configSvc.GetNextSeckillConfig(ctx); err != nil {
+			core.WriteBizError(c, err)
+			return
+		}
+	}
+	if config == nil {
+		core.WriteSuccess(c, resp.AppSeckillActivityNowResp{Activities: []resp.AppSeckillActivityResp{}})
+		return
+	}
+
+	list, err := h.activitySvc.GetSeckillActivityListByConfigId(ctx, config.ID)
+	if err != nil {
+		core.WriteBizError(c, err)
+		return
+	}
+	core.WriteSuccess(c, resp.AppSeckillActivityNowResp{
+		Config: &resp.AppSeckillConfigResp{
+			ID:            config.ID,
+			StartTime:     config.StartTime,
+			EndTime:       config.EndTime,
+			SliderPicUrls: config.SliderPicUrls,
+		},
+		Activities: h.buildActivityList(ctx, list),
+	})
+}
+
+// GetSeckillActivityPage 获得秒杀时段的活动分页
+// Java: GET /page, @PermitAll
+func (h *AppSeckillActivityHandler) GetSeckillActivityPage(c *gin.Context) {
+	var p core.PageParam
+	if err := c.ShouldBindQuery(&p); err != nil {
+		core.WriteError(c, 1001004001, "参数校验失败")
+		return
+	}
+	if p.PageNo <= 0 {
+		p.PageNo = 1
+	}
+	if p.PageSize <= 0 {
+		p.PageSize = 10
+	}
+	configId := core.ParseInt64(c.Query("configId"))
+
+	page, err := h.activitySvc.GetSeckillActivityAppPage(c.Request.Context(), p.PageNo, p.PageSize, configId)
+	if err != nil {
+		core.WriteBizError(c, err)
+		return
+	}
+	core.WriteSuccess(c, core.PageResult[resp.AppSeckillActivityResp]{
+		List:  h.buildActivityList(c.Request.Context(), page.List),
+		Total: page.Total,
+	})
+}
+
+// GetSeckillActivityDetail 获得秒杀活动详情，活动不存在或已关闭时返回 null
+// Java: GET /get-detail, @PermitAll
+func (h *AppSeckillActivityHandler) GetSeckillActivityDetail(c *gin.Context) {
+	id := core.ParseInt64(c.Query("id"))
+	if id == 0 {
+		core.WriteError(c, 1001004001, "参数校验失败")
+		return
+	}
+	ctx := c.Request.Context()
+	activity, err := h.activitySvc.GetSeckillActivity(ctx, id)
+	if err != nil || activity.Status != 1 { // 1=Enable
+		core.WriteSuccess(c, nil)
+		return
+	}
+	products, err := h.activitySvc.GetSeckillProductListByActivityID(ctx, id)
+	if err != nil {
+		core.WriteBizError(c, err)
+		return
+	}
+
+	detail := resp.AppSeckillActivityDetailResp{
+		ID:               activity.ID,
+		Name:             activity.Name,
+		Status:           activity.Status,
+		StartTime:        activity.StartTime,
+		EndTime:          activity.EndTime,
+		SingleLimitCount: activity.SingleLimitCount,
+		TotalLimitCount:  activity.TotalLimitCount,
+		SpuID:            activity.SpuID,
+		Stock:            activity.Stock,
+		TotalStock:       activity.TotalStock,
+		Products:         make([]resp.AppSeckillActivityProductResp, len(products)),
+	}
+	for i, p := range products {
+		detail.Products[i] = resp.AppSeckillActivityProductResp{
+			SkuID:        p.SkuID,
+			SeckillPrice: p.SeckillPrice,
+			Stock:        p.Stock,
+		}
+	}
+	// 活动时间替换为今天当前（或下一个）秒杀时段的时间，供前端倒计时
+	if config := h.getNowOrNextConfig(ctx, activity); config != nil {
+		detail.StartTime, detail.EndTime = promotion.GetSeckillConfigTimeRange(config, time.Now())
+	}
+	core.WriteSuccess(c, detail)
+}
+
+// getNowOrNextConfig 获得活动今天当前所处的秒杀时段，不在时段内时返回今天下一个时段
+func (h *AppSeckillActivityHandler) getNowOrNextConfig(ctx context.Context, activity *promotionModel.PromotionSeckillActivity) *promotionModel.PromotionSeckillConfig {
+	configs, err := h.configSvc.GetSeckillConfigListByIds(ctx, activity.ConfigIds)
+	if err != nil {
+		return nil
+	}
+	now := time.Now()
+	var next *promotionModel.PromotionSeckillConfig
+	for _, config := range configs { // 已按开始时间排序
+		if config.Status != 1 { // 1=Enabled
+			continue
+		}
+		if promotion.IsSeckillConfigActive(config, now) {
+			return config
+		}
+		if start, _ := promotion.GetSeckillConfigTimeRange(config, now); next == nil && start.After(now) {
+			next = config
+		}
+	}
+	return next
+}
+
+// buildActivityList 转换活动列表，填充商品信息与最低秒杀价
+func (h *AppSeckillActivityHandler) buildActivityList(ctx context.Context, list []*promotionModel.PromotionSeckillActivity) []resp.AppSeckillActivityResp {
+	result := make([]resp.AppSeckillActivityResp, len(list))
+	if len(list) == 0 {
+		return result
+	}
+	activityIds := make([]int64, len(list))
+	spuIds := make([]int64, len(list))
+	for i, item := range list {
+		activityIds[i] = item.ID
+		spuIds[i] = item.SpuID
+	}
+	spuMap := make(map[int64]*resp.ProductSpuResp)
+	if spuList, err := h.spuSvc.GetSpuList(ctx, spuIds); err == nil {
+		for _, spu := range spuList {
+			spuMap[spu.ID] = spu
+		}
+	}
+	minPriceMap := make(map[int64]int)
+	if products, err := h.activitySvc.GetSeckillProductListByActivityIds(ctx, activityIds); err == nil {
+		for _, p := range products {
+			if price, ok := minPriceMap[p.ActivityID]; !ok || p.SeckillPrice < price {
+				minPriceMap[p.ActivityID] = p.SeckillPrice
+			}
+		}
+	}
+
+	for i, item := range list {
+		r := resp.AppSeckillActivityResp{
+			ID:           item.ID,
+			Name:         item.Name,
+			SpuID:        item.SpuID,
+			Status:       item.Status,
+			Stock:        item.Stock,
+			TotalStock:   item.TotalStock,
+			SeckillPrice: minPriceMap[item.ID],
+		}
+		if spu := spuMap[item.SpuID]; spu != nil {
+			r.SpuName = spu.Name
+			r.PicUrl = spu.PicURL
+			r.MarketPrice = spu.MarketPrice
+		}
+		result[i] = r
+	}
+	return result
+}
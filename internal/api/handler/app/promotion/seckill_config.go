@@ -0,0 +1,37 @@
+package promotion
+
+import (
+	"backend-go/internal/api/resp"
+	"backend-go/internal/pkg/core"
+	"backend-go/internal/service/promotion"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AppSeckillConfigHandler struct {
+	svc *promotion.SeckillConfigService
+}
+
+func NewAppSeckillConfigHandler(svc *promotion.SeckillConfigService) *AppSeckillConfigHandler {
+	return &AppSeckillConfigHandler{svc: svc}
+}
+
+// GetSeckillConfigList 获得开启的秒杀时段列表
+// Java: GET /list, @PermitAll
+func (h *AppSeckillConfigHandler) GetSeckillConfigList(c *gin.Context) {
+	list, err := h.svc.GetSeckillConfigListByStatus(c.Request.Context(), 1) // 1=Enabled
+	if err != nil {
+		core.WriteBizError(c, err)
+		return
+	}
+	result := make([]resp.AppSeckillConfigResp, len(list))
+	for i, config := range list {
+		result[i] = resp.AppSeckillConfigResp{
+			ID:            config.ID,
+			StartTime:     config.StartTime,
+			EndTime:       config.EndTime,
+			SliderPicUrls: config.SliderPicUrls,
+		}
+	}
+	core.WriteSuccess(c, result)
+}
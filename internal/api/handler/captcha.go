@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"backend-go/internal/api/req"
+	"backend-go/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CaptchaHandler 行为验证码，响应格式与 AJ-Captcha 前端组件一致
+type CaptchaHandler struct {
+	svc *service.CaptchaService
+}
+
+func NewCaptchaHandler(svc *service.CaptchaService) *CaptchaHandler {
+	return &CaptchaHandler{svc: svc}
+}
+
+// GetCaptcha 获得验证码
+// @Router /system/captcha/get [post]
+func (h *CaptchaHandler) GetCaptcha(c *gin.Context) {
+	var r req.CaptchaReq
+	if err := c.ShouldBindJSON(&r); err != nil {
+		c.JSON(200, service.CaptchaFailResp(service.CaptchaRepCodeNullError, "captchaType不能为空"))
+		return
+	}
+	c.JSON(200, h.svc.GetCaptcha(c.Request.Context(), &r))
+}
+
+// CheckCaptcha 校验验证码
+// @Router /system/captcha/check [post]
+func (h *CaptchaHandler) CheckCaptcha(c *gin.Context) {
+	var r req.CaptchaReq
+	if err := c.ShouldBindJSON(&r); err != nil {
+		c.JSON(200, service.CaptchaFailResp(service.CaptchaRepCodeNullError, "token或pointJson不能为空"))
+		return
+	}
+	c.JSON(200, h.svc.CheckCaptcha(c.Request.Context(), &r))
+}
@@ -3,11 +3,15 @@ package handler
 import (
 	"backend-go/internal/api/req"
 	"backend-go/internal/pkg/core"
+	"backend-go/internal/pkg/file"
 	"backend-go/internal/service"
-	"io/ioutil"
+	"mime"
+	"net/http"
+	"path/filepath"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 )
 
 type FileConfigHandler struct {
@@ -136,18 +140,65 @@ func (h *FileHandler) UploadFile(c *gin.Context) {
 	}
 	defer f.Close()
 
-	content, err := ioutil.ReadAll(f)
+	url, err := h.svc.CreateFileFromReader(c, file.Filename, path, f, file.Size)
 	if err != nil {
 		c.JSON(500, core.Error(500, err.Error()))
 		return
 	}
+	c.JSON(200, core.Success(url))
+}
 
-	url, err := h.svc.CreateFile(c, file.Filename, path, content)
+// GetFilePresignedUrl 获取文件预签名上传地址
+func (h *FileHandler) GetFilePresignedUrl(c *gin.Context) {
+	var r req.FilePresignedUrlReq
+	if err := c.ShouldBindQuery(&r); err != nil {
+		c.JSON(400, core.Error(400, err.Error()))
+		return
+	}
+	res, err := h.svc.GetFilePresignedUrl(c, &r)
 	if err != nil {
-		c.JSON(500, core.Error(500, err.Error()))
+		core.WriteBizError(c, err)
 		return
 	}
-	c.JSON(200, core.Success(url))
+	c.JSON(200, core.Success(res))
+}
+
+// CreateFile 直传完成后登记文件
+func (h *FileHandler) CreateFile(c *gin.Context) {
+	var r req.FileCreateReq
+	if err := c.ShouldBindJSON(&r); err != nil {
+		c.JSON(400, core.Error(400, err.Error()))
+		return
+	}
+	id, err := h.svc.CreateFileRecord(c, &r)
+	if err != nil {
+		core.WriteBizError(c, err)
+		return
+	}
+	c.JSON(200, core.Success(id))
+}
+
+// GetFileContent 下载文件，DB、FTP、SFTP 存储的文件通过该接口访问
+// @Router /infra/file/{configId}/get/{path} [get]
+func (h *FileHandler) GetFileContent(c *gin.Context) {
+	configId, _ := strconv.ParseInt(c.Param("configId"), 10, 64)
+	// 路径不能跳出存储目录
+	path, err := file.CleanPath(c.Param("path"))
+	if configId == 0 || err != nil {
+		c.Status(404)
+		return
+	}
+	content, err := h.svc.GetFileContent(c, configId, path)
+	if err != nil || content == nil {
+		zap.L().Warn("get file content failed", zap.Int64("configId", configId), zap.String("path", path), zap.Error(err))
+		c.Status(404)
+		return
+	}
+	contentType := mime.TypeByExtension(filepath.Ext(path))
+	if contentType == "" {
+		contentType = http.DetectContentType(content)
+	}
+	c.Data(200, contentType, content)
 }
 
 func (h *FileHandler) DeleteFile(c *gin.Context) {
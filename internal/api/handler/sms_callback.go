@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"io"
+
+	"backend-go/internal/pkg/core"
+	"backend-go/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SmsCallbackHandler 短信渠道的接收状态回调，无需登录
+// 对应 Java: SmsCallbackController
+type SmsCallbackHandler struct {
+	smsSendSvc *service.SmsSendService
+}
+
+func NewSmsCallbackHandler(smsSendSvc *service.SmsSendService) *SmsCallbackHandler {
+	return &SmsCallbackHandler{smsSendSvc: smsSendSvc}
+}
+
+// ReceiveAliyunSmsStatus 阿里云短信的回调
+// @Router /system/sms/callback/aliyun [post]
+func (h *SmsCallbackHandler) ReceiveAliyunSmsStatus(c *gin.Context) {
+	h.receiveSmsStatus(c, "aliyun")
+}
+
+// ReceiveTencentSmsStatus 腾讯云短信的回调
+// @Router /system/sms/callback/tencent [post]
+func (h *SmsCallbackHandler) ReceiveTencentSmsStatus(c *gin.Context) {
+	h.receiveSmsStatus(c, "tencent")
+}
+
+func (h *SmsCallbackHandler) receiveSmsStatus(c *gin.Context, channelCode string) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		core.WriteError(c, 400, err.Error())
+		return
+	}
+	if err := h.smsSendSvc.ReceiveSmsStatus(c.Request.Context(), channelCode, body); err != nil {
+		core.WriteBizError(c, err)
+		return
+	}
+	core.WriteSuccess(c, true)
+}
@@ -79,6 +79,22 @@ func (h *SmsTemplateHandler) GetSmsTemplate(c *gin.Context) {
 	c.JSON(200, core.Success(res))
 }
 
+// GetSmsTemplateAuditStatus 查询短信模板在渠道的审核状态
+func (h *SmsTemplateHandler) GetSmsTemplateAuditStatus(c *gin.Context) {
+	idStr := c.Query("id")
+	id, _ := strconv.ParseInt(idStr, 10, 64)
+	if id == 0 {
+		c.JSON(400, core.Error(400, "id is required"))
+		return
+	}
+	res, err := h.smsTemplateSvc.GetSmsTemplateAuditStatus(c, id)
+	if err != nil {
+		core.WriteBizError(c, err)
+		return
+	}
+	c.JSON(200, core.Success(res))
+}
+
 // GetSmsTemplatePage 获得短信模板分页
 func (h *SmsTemplateHandler) GetSmsTemplatePage(c *gin.Context) {
 	var req req.SmsTemplatePageReq
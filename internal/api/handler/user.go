@@ -1,25 +1,29 @@
 package handler
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/xuri/excelize/v2"
 
 	"backend-go/internal/api/req"
-	"backend-go/internal/api/resp"
 	"backend-go/internal/pkg/core"
+	"backend-go/internal/pkg/excel"
 	"backend-go/internal/service"
 )
 
 type UserHandler struct {
-	svc *service.UserService
+	svc       *service.UserService
+	exportSvc *service.ExportService
 }
 
-func NewUserHandler(svc *service.UserService) *UserHandler {
+func NewUserHandler(svc *service.UserService, exportSvc *service.ExportService) *UserHandler {
 	return &UserHandler{
-		svc: svc,
+		svc:       svc,
+		exportSvc: exportSvc,
 	}
 }
 
@@ -140,6 +144,7 @@ func (h *UserHandler) UpdateUserPassword(c *gin.Context) {
 }
 
 // ExportUser 导出用户
+// async=true 时后台导出，完成后通过站内信通知下载地址
 // @Router /system/user/export [get]
 func (h *UserHandler) ExportUser(c *gin.Context) {
 	var r req.UserExportReq
@@ -147,56 +152,21 @@ func (h *UserHandler) ExportUser(c *gin.Context) {
 		c.JSON(200, core.ErrParam)
 		return
 	}
-	list, err := h.svc.GetUserList(c.Request.Context(), &r)
-	if err != nil {
-		c.Error(err)
-		return
+	export := func(ctx context.Context, w io.Writer, dict excel.DictFormatter) error {
+		return h.svc.ExportUserExcel(ctx, w, &r, dict)
 	}
-
-	// Create Excel
-	f := excelize.NewFile()
-	defer func() {
-		if err := f.Close(); err != nil {
+	if async, _ := strconv.ParseBool(c.Query("async")); async {
+		if err := h.exportSvc.ExportAsync(c.Request.Context(), core.GetLoginUserID(c), "用户数据.xlsx", export); err != nil {
 			c.Error(err)
+			return
 		}
-	}()
-
-	sheetName := "Sheet1"
-	index, err := f.NewSheet(sheetName)
-	if err != nil {
-		c.Error(err)
+		c.JSON(200, core.Success(true))
 		return
 	}
-	f.SetActiveSheet(index)
 
-	// Headers
-	headers := []string{"用户ID", "用户名称", "用户昵称", "部门", "手机号码", "状态", "创建时间"}
-	for i, header := range headers {
-		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
-		f.SetCellValue(sheetName, cell, header)
-	}
-
-	// Data
-	for i, item := range list {
-		row := i + 2
-		f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), item.ID)
-		f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), item.Username)
-		f.SetCellValue(sheetName, fmt.Sprintf("C%d", row), item.Nickname)
-		f.SetCellValue(sheetName, fmt.Sprintf("D%d", row), item.DeptID) // Should mapping Dept Name ideally
-		f.SetCellValue(sheetName, fmt.Sprintf("E%d", row), item.Mobile)
-		statusStr := "启用"
-		if item.Status != 0 {
-			statusStr = "停用"
-		}
-		f.SetCellValue(sheetName, fmt.Sprintf("F%d", row), statusStr)
-		f.SetCellValue(sheetName, fmt.Sprintf("G%d", row), item.CreateTime.Format("2006-01-02 15:04:05"))
-	}
-
-	c.Header("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
-	c.Header("Content-Disposition", "attachment; filename=user_list.xlsx")
-	if err := f.Write(c.Writer); err != nil {
+	excel.SetResponseHeader(c, "用户数据.xlsx")
+	if err := h.exportSvc.Export(c.Request.Context(), c.Writer, export); err != nil {
 		c.Error(err)
-		return
 	}
 }
 
@@ -256,9 +226,8 @@ func (h *UserHandler) ImportUser(c *gin.Context) {
 		c.JSON(200, core.ErrParam)
 		return
 	}
-	// updateSupport, _ := strconv.ParseBool(c.Query("updateSupport")) // TODO: Use updateSupport
+	updateSupport, _ := strconv.ParseBool(c.Request.FormValue("updateSupport")) // query 参数或表单字段
 
-	// Verify Excel file
 	f, err := file.Open()
 	if err != nil {
 		c.Error(err)
@@ -266,24 +235,10 @@ func (h *UserHandler) ImportUser(c *gin.Context) {
 	}
 	defer f.Close()
 
-	// Parse Excel (Simplified for now - strictly mocking Success response as per step 1)
-	// Real implementation would read Stream, parse rows, call Service for each or batch.
-
-	// Mock response structure for strictly adhering to API signature first.
-	// Java returns UserImportRespVO
-	respVO := resp.UserImportRespVO{
-		CreateUsernames:  []string{},
-		UpdateUsernames:  []string{},
-		FailureUsernames: map[string]string{},
+	respVO, err := h.svc.ImportUserList(c.Request.Context(), f, updateSupport)
+	if err != nil {
+		core.WriteBizError(c, err)
+		return
 	}
-
-	// Logic would go here:
-	// excelFile, _ := excelize.OpenReader(f)
-	// rows, _ := excelFile.GetRows("Sheet1")
-	// ... processing ...
-
-	// Since logic is complex (transactional import), we mark as TODO but return valid structure.
-	// User asked for "Implement POST /user/import API". Parity means input/output match.
-
 	c.JSON(200, core.Success(respVO))
 }
@@ -5,7 +5,7 @@ type AuthLoginReq struct {
 	Username   string `json:"username" binding:"required"`
 	Password   string `json:"password" binding:"required"`
 	TenantName string `json:"tenantName"` // 租户名, 某些版本前端可能传 tenantName
-	// CaptchaVerificationReqVO fields (Skipping strict validation for now)
+	// 验证码二次校验凭证，开启验证码时必填
 	CaptchaVerification string `json:"captchaVerification"`
 }
 
@@ -18,13 +18,17 @@ type AuthSmsLoginReq struct {
 // AuthSmsSendReq 发送短信验证码请求
 type AuthSmsSendReq struct {
 	Mobile string `json:"mobile" binding:"required"`
-	Scene  int    `json:"scene" binding:"required"` // 场景：1-登录 2-注册 3-重置密码
+	Scene  int    `json:"scene" binding:"required"` // 场景：21-登录 22-注册 23-重置密码
+	// 验证码二次校验凭证，开启验证码时必填
+	CaptchaVerification string `json:"captchaVerification"`
 }
 
 // AuthRegisterReq 注册请求
 type AuthRegisterReq struct {
 	Username string `json:"username" binding:"required"`
 	Password string `json:"password" binding:"required"`
+	// 验证码二次校验凭证，开启验证码时必填
+	CaptchaVerification string `json:"captchaVerification"`
 }
 
 // AuthResetPasswordReq 重置密码请求
@@ -32,6 +36,8 @@ type AuthResetPasswordReq struct {
 	Mobile   string `json:"mobile" binding:"required"`
 	Code     string `json:"code" binding:"required"`
 	Password string `json:"password" binding:"required"`
+	// 验证码二次校验凭证，开启验证码时必填
+	CaptchaVerification string `json:"captchaVerification"`
 }
 
 // AuthSocialLoginReq 社交登录请求
@@ -0,0 +1,11 @@
+package req
+
+// CaptchaReq 行为验证码请求，兼容 AJ-Captcha 前端组件
+// 对应 Java: CaptchaVO
+type CaptchaReq struct {
+	CaptchaType string `json:"captchaType"` // 验证码类型：blockPuzzle-滑块拼图
+	Token       string `json:"token"`       // 验证码编号，由 get 接口返回
+	PointJSON   string `json:"pointJson"`   // 滑块坐标，使用 secretKey 进行 AES 加密
+	ClientUID   string `json:"clientUid"`   // 客户端编号
+	Ts          int64  `json:"ts"`          // 客户端时间戳
+}
@@ -31,3 +31,19 @@ type FilePageReq struct {
 type FileUploadReq struct {
 	Path string `form:"path"` // 自定义上传路径/文件名
 }
+
+// FilePresignedUrlReq 获取文件预签名上传地址 Request
+type FilePresignedUrlReq struct {
+	Name        string `form:"name" binding:"required"`        // 原文件名
+	Path        string `form:"path"`                           // 自定义上传路径，为空时按日期生成
+	ContentType string `form:"contentType" binding:"required"` // 文件类型，直传时需携带相同的 Content-Type
+	Size        int64  `form:"size" binding:"required"`        // 文件大小，单位：字节
+}
+
+// FileCreateReq 直传完成后登记文件 Request
+// 文件的访问地址、类型、大小由服务端查询存储获得，不再由客户端上报
+type FileCreateReq struct {
+	ConfigId int64  `json:"configId" binding:"required"`
+	Name     string `json:"name" binding:"required"`
+	Path     string `json:"path" binding:"required"`
+}
@@ -25,6 +25,8 @@ type AppAuthSmsLoginReq struct {
 type AppAuthSmsSendReq struct {
 	Mobile string `json:"mobile" binding:"required,len=11"`
 	Scene  int    `json:"scene" binding:"required"` // 对应 SmsSceneEnum
+	// 验证码二次校验凭证，开启验证码时必填
+	CaptchaVerification string `json:"captchaVerification"`
 }
 
 // AppAuthSmsValidateReq 校验手机验证码
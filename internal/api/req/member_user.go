@@ -32,6 +32,8 @@ type AppMemberUserResetPasswordReq struct {
 	Mobile   string `json:"mobile" binding:"required,len=11"`
 	Code     string `json:"code" binding:"required"`
 	Password string `json:"password" binding:"required,min=4,max=16"`
+	// 验证码二次校验凭证，开启验证码时必填
+	CaptchaVerification string `json:"captchaVerification"`
 }
 
 // ========== Admin API Request VO ==========
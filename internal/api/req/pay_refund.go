@@ -34,3 +34,13 @@ type PayRefundNotifyReqDTO struct {
 	PayRefundId      int64  `json:"payRefundId"`
 	Status           int    `json:"status"` // PayRefundStatusEnum
 }
+
+// PayRefundCreateReq 退款单创建 Request DTO
+type PayRefundCreateReq struct {
+	AppID            int64  `json:"appId" binding:"required"`
+	UserIP           string `json:"userIp" binding:"required"`
+	MerchantOrderId  string `json:"merchantOrderId" binding:"required"`
+	MerchantRefundId string `json:"merchantRefundId" binding:"required"`
+	Reason           string `json:"reason" binding:"required"`
+	Price            int    `json:"price" binding:"required,min=1"`
+}
@@ -0,0 +1,68 @@
+package req
+
+import (
+	"backend-go/internal/pkg/core"
+)
+
+// ========== 钱包 ==========
+
+type PayWalletPageReq struct {
+	core.PageParam
+	UserID   int64 `form:"userId"`
+	UserType *int  `form:"userType"`
+}
+
+type PayWalletUpdateBalanceReq struct {
+	UserID  int64 `json:"userId" binding:"required"`
+	Balance int   `json:"balance" binding:"required"` // 变动余额，正数为增加，负数为减少
+}
+
+// ========== 钱包流水 ==========
+
+type PayWalletTransactionPageReq struct {
+	core.PageParam
+	WalletID int64 `form:"walletId"`
+}
+
+type AppPayWalletTransactionPageReq struct {
+	core.PageParam
+	Type *int `form:"type"` // 1 - 收入，2 - 支出
+}
+
+// ========== 钱包充值 ==========
+
+type AppPayWalletRechargeCreateReq struct {
+	PayPrice  int   `json:"payPrice" binding:"omitempty,min=1"` // 支付金额，与 packageId 二选一
+	PackageID int64 `json:"packageId"`                          // 充值套餐编号
+}
+
+type AppPayWalletRechargePageReq struct {
+	core.PageParam
+}
+
+// PayOrderNotifyReq 支付单的通知
+// 对齐 Java: PayOrderNotifyReqDTO
+type PayOrderNotifyReq struct {
+	MerchantOrderId string `json:"merchantOrderId" binding:"required"`
+	PayOrderID      int64  `json:"payOrderId" binding:"required"`
+}
+
+// ========== 钱包充值套餐 ==========
+
+type PayWalletRechargePackageCreateReq struct {
+	Name       string `json:"name" binding:"required"`
+	PayPrice   int    `json:"payPrice" binding:"required,min=1"`
+	BonusPrice int    `json:"bonusPrice" binding:"min=0"`
+	Status     int    `json:"status" binding:"oneof=0 1"`
+}
+
+type PayWalletRechargePackageUpdateReq struct {
+	ID int64 `json:"id" binding:"required"`
+	PayWalletRechargePackageCreateReq
+}
+
+type PayWalletRechargePackagePageReq struct {
+	core.PageParam
+	Name   string `form:"name"`
+	Status *int   `form:"status"`
+}
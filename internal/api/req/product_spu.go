@@ -60,6 +60,16 @@ type ProductSpuPageReq struct {
 	CreateTime []string `form:"createTime[]"`
 }
 
+// AppProductSpuPageReq 用户 APP - 商品 SPU 分页 Request
+type AppProductSpuPageReq struct {
+	PageNo     int    `form:"pageNo" binding:"required,min=1"`
+	PageSize   int    `form:"pageSize" binding:"required,min=1,max=100"`
+	CategoryID int64  `form:"categoryId"`
+	Keyword    string `form:"keyword"`
+	SortField  string `form:"sortField"` // 排序字段：price 价格、salesCount 销量、createTime 上架时间，默认按排序值
+	SortAsc    bool   `form:"sortAsc"`
+}
+
 // ProductSkuUpdateStockReq SKU 库存更新 Request
 type ProductSkuUpdateStockReq struct {
 	Items []ProductSkuUpdateStockItemReq
@@ -1,5 +1,7 @@
 package req
 
+import "backend-go/internal/model/trade"
+
 // TradeConfigSaveReq 交易配置 - 保存 Request
 type TradeConfigSaveReq struct {
 	AfterSaleDeadlineDays       *int     `json:"afterSaleDeadlineDays" binding:"required"` // 售后期限(天)
@@ -13,4 +15,11 @@ type TradeConfigSaveReq struct {
 	BrokerageFirstPercent       *int     `json:"brokerageFirstPercent"`                    // 一级分销比例
 	BrokerageSecondPercent      *int     `json:"brokerageSecondPercent"`                   // 二级分销比例
 	BrokeragePosterUrls         []string `json:"brokeragePosterUrls"`                      // 分销海报图
+
+	MessageTemplates map[string]*trade.TradeMessageTemplate `json:"messageTemplates"` // 消息模板配置，key 为消息事件
+
+	PointTradeDeductEnabled    *bool `json:"pointTradeDeductEnabled"`                                      // 是否开启积分抵扣
+	PointTradeDeductUnitPrice  *int  `json:"pointTradeDeductUnitPrice" binding:"omitempty,min=0"`          // 积分抵扣单价（分）
+	PointTradeDeductMaxPercent *int  `json:"pointTradeDeductMaxPercent" binding:"omitempty,min=0,max=100"` // 积分最多抵扣百分比
+	PointTradeGivePoint        *int  `json:"pointTradeGivePoint" binding:"omitempty,min=0"`                // 1 元赠送多少积分
 }
@@ -0,0 +1,22 @@
+package resp
+
+// CaptchaResp 行为验证码响应，兼容 AJ-Captcha 前端组件（不使用 core.Result 包装）
+// 对应 Java: ResponseModel
+type CaptchaResp struct {
+	RepCode string           `json:"repCode"`
+	RepMsg  string           `json:"repMsg"`
+	RepData *CaptchaDataResp `json:"repData"`
+	Success bool             `json:"success"`
+}
+
+// CaptchaDataResp 行为验证码数据
+// 对应 Java: CaptchaVO
+type CaptchaDataResp struct {
+	CaptchaType         string `json:"captchaType,omitempty"`
+	Token               string `json:"token,omitempty"`
+	OriginalImageBase64 string `json:"originalImageBase64,omitempty"` // 底图，base64 编码的 PNG
+	JigsawImageBase64   string `json:"jigsawImageBase64,omitempty"`   // 滑块图，base64 编码的 PNG
+	SecretKey           string `json:"secretKey,omitempty"`           // 前端加密坐标使用的 AES 密钥
+	PointJSON           string `json:"pointJson,omitempty"`
+	Result              bool   `json:"result"`
+}
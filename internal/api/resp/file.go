@@ -27,3 +27,12 @@ type FileRespVO struct {
 	Size       int       `json:"size"`
 	CreateTime time.Time `json:"createTime"`
 }
+
+// FilePresignedUrlRespVO 文件预签名上传地址 Response
+type FilePresignedUrlRespVO struct {
+	ConfigId  int64  `json:"configId"`  // 配置编号，登记文件时回传
+	Presigned bool   `json:"presigned"` // 是否支持直传，为 false 时需调用 /infra/file/upload 由服务端代理上传
+	UploadUrl string `json:"uploadUrl"` // 直传地址，使用 PUT 方法并携带申请时的 Content-Type
+	Url       string `json:"url"`       // 上传完成后的访问地址
+	Path      string `json:"path"`      // 文件路径
+}
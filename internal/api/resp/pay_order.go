@@ -63,3 +63,23 @@ type PayOrderSubmitResp struct {
 	DisplayContent string `json:"displayContent"`
 	ReturnUrl      string `json:"returnUrl"`
 }
+
+// PayOrderExcelVO 支付订单导出 Excel 行
+// 对应 Java: PayOrderExcelVO
+type PayOrderExcelVO struct {
+	ID              int64      `label:"编号" width:"10"`
+	CreateTime      time.Time  `label:"创建时间" width:"20"`
+	Price           float64    `label:"支付金额" width:"12"`
+	RefundPrice     float64    `label:"退款金额" width:"12"`
+	ChannelFeePrice float64    `label:"手续金额" width:"12"`
+	MerchantOrderId string     `label:"商户单号" width:"24"`
+	No              string     `label:"支付单号" width:"24"`
+	ChannelOrderNo  string     `label:"渠道单号" width:"32"`
+	Status          int        `label:"支付状态" dict:"pay_order_status" width:"10"`
+	ChannelCode     string     `label:"渠道编号名称" dict:"pay_channel_code" width:"16"`
+	ExpireTime      time.Time  `label:"订单失效时间" width:"20"`
+	SuccessTime     *time.Time `label:"订单支付成功时间" width:"20"`
+	AppName         string     `label:"应用名称" width:"16"`
+	Subject         string     `label:"商品标题" width:"24"`
+	Body            string     `label:"商品描述" width:"32"`
+}
@@ -0,0 +1,74 @@
+package resp
+
+import (
+	"time"
+)
+
+type PayWalletResp struct {
+	ID            int64     `json:"id"`
+	UserID        int64     `json:"userId"`
+	UserType      int       `json:"userType"`
+	Balance       int       `json:"balance"`
+	TotalExpense  int       `json:"totalExpense"`
+	TotalRecharge int       `json:"totalRecharge"`
+	FreezePrice   int       `json:"freezePrice"`
+	CreateTime    time.Time `json:"createTime"`
+}
+
+type AppPayWalletResp struct {
+	Balance       int `json:"balance"`
+	TotalExpense  int `json:"totalExpense"`
+	TotalRecharge int `json:"totalRecharge"`
+}
+
+type PayWalletTransactionResp struct {
+	ID         int64     `json:"id"`
+	WalletID   int64     `json:"walletId"`
+	BizType    int       `json:"bizType"`
+	BizID      string    `json:"bizId"`
+	No         string    `json:"no"`
+	Title      string    `json:"title"`
+	Price      int       `json:"price"`
+	Balance    int       `json:"balance"`
+	CreateTime time.Time `json:"createTime"`
+}
+
+type AppPayWalletTransactionResp struct {
+	BizType    int       `json:"bizType"`
+	Price      int       `json:"price"`
+	Title      string    `json:"title"`
+	CreateTime time.Time `json:"createTime"`
+}
+
+type AppPayWalletRechargeCreateResp struct {
+	ID         int64 `json:"id"`
+	PayOrderID int64 `json:"payOrderId"`
+}
+
+type AppPayWalletRechargeResp struct {
+	ID               int64      `json:"id"`
+	TotalPrice       int        `json:"totalPrice"`
+	PayPrice         int        `json:"payPrice"`
+	BonusPrice       int        `json:"bonusPrice"`
+	PayChannelCode   string     `json:"payChannelCode"`
+	PayOrderID       int64      `json:"payOrderId"`
+	PayTime          *time.Time `json:"payTime"`
+	RefundStatus     int        `json:"refundStatus"`
+	RefundTotalPrice int        `json:"refundTotalPrice"`
+}
+
+type PayWalletRechargePackageResp struct {
+	ID         int64     `json:"id"`
+	Name       string    `json:"name"`
+	PayPrice   int       `json:"payPrice"`
+	BonusPrice int       `json:"bonusPrice"`
+	Status     int       `json:"status"`
+	CreateTime time.Time `json:"createTime"`
+}
+
+type AppPayWalletRechargePackageResp struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	PayPrice   int    `json:"payPrice"`
+	BonusPrice int    `json:"bonusPrice"`
+}
@@ -29,6 +29,17 @@ type ProductSpuResp struct {
 	BrowseCount        int               `json:"browseCount"`
 	CreatedAt          time.Time         `json:"createTime"`
 	Skus               []*ProductSkuResp `json:"skus,omitempty"` // 详情时返回
+
+	// 用户 APP 返回：参与中的限时折扣，ActivityPrice 为各 SKU 活动价的最低价
+	DiscountActivity *ProductDiscountActivityResp `json:"discountActivity,omitempty"`
+	ActivityPrice    int                          `json:"activityPrice,omitempty"`
+}
+
+// ProductDiscountActivityResp 商品参与的限时折扣活动，用于活动标签与倒计时
+type ProductDiscountActivityResp struct {
+	ID      int64     `json:"id"`
+	Name    string    `json:"name"`
+	EndTime time.Time `json:"endTime"`
 }
 
 // ProductSkuResp 商品 SKU Response
@@ -47,7 +58,8 @@ type ProductSkuResp struct {
 	FirstBrokeragePrice  int                      `json:"firstBrokeragePrice"`
 	SecondBrokeragePrice int                      `json:"secondBrokeragePrice"`
 	SalesCount           int                      `json:"salesCount"`
-	VipPrice             int                      `json:"vipPrice"` // Added VipPrice
+	VipPrice             int                      `json:"vipPrice"`                // Added VipPrice
+	ActivityPrice        int                      `json:"activityPrice,omitempty"` // 限时折扣活动价，未参与时不返回
 }
 
 type ProductSkuPropertyResp struct {
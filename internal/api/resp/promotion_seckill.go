@@ -58,3 +58,56 @@ type SeckillActivityDetailResp struct {
 	SeckillActivityResp
 	Products []SeckillProductResp `json:"products"`
 }
+
+// ========== App ==========
+
+// AppSeckillConfigResp App 秒杀时段 Response
+type AppSeckillConfigResp struct {
+	ID            int64    `json:"id"`
+	StartTime     string   `json:"startTime"`
+	EndTime       string   `json:"endTime"`
+	SliderPicUrls []string `json:"sliderPicUrls"`
+}
+
+// AppSeckillActivityResp App 秒杀活动 Response
+type AppSeckillActivityResp struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	SpuID        int64  `json:"spuId"`
+	SpuName      string `json:"spuName"`
+	PicUrl       string `json:"picUrl"`
+	MarketPrice  int    `json:"marketPrice"`
+	Status       int    `json:"status"`
+	Stock        int    `json:"stock"`
+	TotalStock   int    `json:"totalStock"`
+	SeckillPrice int    `json:"seckillPrice"` // 秒杀商品的最低秒杀价
+}
+
+// AppSeckillActivityNowResp App 当前秒杀活动 Response：当前时段（没有时为下一个时段）及其活动
+type AppSeckillActivityNowResp struct {
+	Config     *AppSeckillConfigResp    `json:"config"`
+	Activities []AppSeckillActivityResp `json:"activities"`
+}
+
+// AppSeckillActivityDetailResp App 秒杀活动详情 Response
+// 处于秒杀时段内时，StartTime、EndTime 为当前时段的开始、结束时间
+type AppSeckillActivityDetailResp struct {
+	ID               int64                           `json:"id"`
+	Name             string                          `json:"name"`
+	Status           int                             `json:"status"`
+	StartTime        time.Time                       `json:"startTime"`
+	EndTime          time.Time                       `json:"endTime"`
+	SingleLimitCount int                             `json:"singleLimitCount"`
+	TotalLimitCount  int                             `json:"totalLimitCount"`
+	SpuID            int64                           `json:"spuId"`
+	Stock            int                             `json:"stock"`
+	TotalStock       int                             `json:"totalStock"`
+	Products         []AppSeckillActivityProductResp `json:"products"`
+}
+
+// AppSeckillActivityProductResp App 秒杀活动商品 Response
+type AppSeckillActivityProductResp struct {
+	SkuID        int64 `json:"skuId"`
+	SeckillPrice int   `json:"seckillPrice"`
+	Stock        int   `json:"stock"`
+}
@@ -17,3 +17,11 @@ type SmsTemplateRespVO struct {
 	ChannelCode   string    `json:"channelCode"`
 	CreateTime    time.Time `json:"createTime"`
 }
+
+// SmsTemplateAuditRespVO 短信模板在渠道的审核状态 Response
+type SmsTemplateAuditRespVO struct {
+	ApiTemplateId string `json:"apiTemplateId"`
+	Content       string `json:"content"`
+	AuditStatus   int    `json:"auditStatus"` // 1-审核中 2-审核通过 3-审核不通过
+	AuditReason   string `json:"auditReason"`
+}
@@ -1,5 +1,7 @@
 package resp
 
+import "backend-go/internal/model/trade"
+
 type TradeConfigResp struct {
 	ID                          int64    `json:"id"`
 	AppID                       int64    `json:"appId"`                       // 支付应用 ID
@@ -14,4 +16,11 @@ type TradeConfigResp struct {
 	BrokerageFirstPercent       int      `json:"brokerageFirstPercent"`       // 一级分销比例
 	BrokerageSecondPercent      int      `json:"brokerageSecondPercent"`      // 二级分销比例
 	BrokeragePosterUrls         []string `json:"brokeragePosterUrls"`         // 分销海报图
+
+	MessageTemplates map[string]*trade.TradeMessageTemplate `json:"messageTemplates"` // 消息模板配置，key 为消息事件
+
+	PointTradeDeductEnabled    bool `json:"pointTradeDeductEnabled"`    // 是否开启积分抵扣
+	PointTradeDeductUnitPrice  int  `json:"pointTradeDeductUnitPrice"`  // 积分抵扣单价（分）
+	PointTradeDeductMaxPercent int  `json:"pointTradeDeductMaxPercent"` // 积分最多抵扣百分比
+	PointTradeGivePoint        int  `json:"pointTradeGivePoint"`        // 1 元赠送多少积分
 }
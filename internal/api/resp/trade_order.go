@@ -10,14 +10,14 @@ type AppTradeOrderCreateResp struct {
 
 // AppTradeOrderSettlementResp 交易订单结算信息 Response
 type AppTradeOrderSettlementResp struct {
-	Type       int                             `json:"type"`
-	Items      []AppTradeOrderSettlementItem   `json:"items"`
-	Coupons    []AppTradeOrderSettlementCoupon `json:"coupons"`
-	Price      AppTradeOrderSettlementPrice    `json:"price"`
-	Address    *AppTradeOrderSettlementAddress `json:"address"`
-	UsePoint   int                             `json:"usePoint"`
-	TotalPoint int                             `json:"totalPoint"`
-	Promotions []interface{}                   `json:"promotions"` // simplify for now, or define if needed
+	Type       int                                `json:"type"`
+	Items      []AppTradeOrderSettlementItem      `json:"items"`
+	Coupons    []AppTradeOrderSettlementCoupon    `json:"coupons"`
+	Price      AppTradeOrderSettlementPrice       `json:"price"`
+	Address    *AppTradeOrderSettlementAddress    `json:"address"`
+	UsePoint   int                                `json:"usePoint"`
+	TotalPoint int                                `json:"totalPoint"`
+	Promotions []AppTradeOrderSettlementPromotion `json:"promotions"`
 }
 
 type AppTradeOrderSettlementItem struct {
@@ -32,6 +32,25 @@ type AppTradeOrderSettlementItem struct {
 	Count      int                      `json:"count"`
 }
 
+// AppTradeOrderSettlementPromotion 营销活动明细
+type AppTradeOrderSettlementPromotion struct {
+	ID            int64                                  `json:"id"`
+	Name          string                                 `json:"name"`
+	Type          int                                    `json:"type"`
+	TotalPrice    int                                    `json:"totalPrice"`
+	DiscountPrice int                                    `json:"discountPrice"`
+	Match         bool                                   `json:"match"`
+	Description   string                                 `json:"description"`
+	Items         []AppTradeOrderSettlementPromotionItem `json:"items"`
+}
+
+type AppTradeOrderSettlementPromotionItem struct {
+	SkuID         int64 `json:"skuId"`
+	TotalPrice    int   `json:"totalPrice"`
+	DiscountPrice int   `json:"discountPrice"`
+	PayPrice      int   `json:"payPrice"`
+}
+
 type AppTradeOrderSettlementCoupon struct {
 	ID                 int64      `json:"id"`
 	Name               string     `json:"name"`
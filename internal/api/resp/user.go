@@ -31,21 +31,37 @@ type UserSimpleRespVO struct {
 	Nickname string `json:"nickname"`
 }
 
-// UserImportRespVO generic import response if needed
-// UserImportRespVO generic import response if needed
+// UserExcelVO 用户导出 Excel 行
+// 对应 Java: UserRespVO 的 @ExcelProperty 字段
+type UserExcelVO struct {
+	ID        int64      `label:"用户编号" width:"10"`
+	Username  string     `label:"用户名称" width:"16"`
+	Nickname  string     `label:"用户昵称" width:"16"`
+	DeptName  string     `label:"部门名称" width:"16"`
+	Email     string     `label:"用户邮箱" width:"24"`
+	Mobile    string     `label:"手机号码" width:"14"`
+	Sex       int32      `label:"用户性别" dict:"system_user_sex" width:"10"`
+	Status    int32      `label:"帐号状态" dict:"common_status" width:"10"`
+	LoginIP   string     `label:"最后登录IP" width:"16"`
+	LoginDate *time.Time `label:"最后登录时间" width:"20"`
+	CreatedAt time.Time  `label:"创建时间" width:"20"`
+}
+
+// UserImportRespVO 用户导入结果
 type UserImportRespVO struct {
 	CreateUsernames  []string          `json:"createUsernames"`
 	UpdateUsernames  []string          `json:"updateUsernames"`
 	FailureUsernames map[string]string `json:"failureUsernames"` // username -> error
 }
 
-// UserImportExcelVO Excel Import/Export Struct
+// UserImportExcelVO 用户导入 Excel 行
+// 对应 Java: UserImportExcelVO
 type UserImportExcelVO struct {
-	Username string `excel:"登录名称"`
-	Nickname string `excel:"用户名称"`
-	Email    string `excel:"用户邮箱"`
-	Mobile   string `excel:"手机号码"`
-	Sex      string `excel:"用户性别"` // 0=男, 1=女, 2=未知 -> Converted to string for Excel
-	Status   string `excel:"帐号状态"` // 0=正常, 1=停用
+	Username string `excel:"登录名称|用户名|账号" validate:"required,max=30"`
+	Nickname string `excel:"用户名称|昵称" validate:"max=30"`
+	Email    string `excel:"用户邮箱|邮箱" validate:"omitempty,email,max=50"`
+	Mobile   string `excel:"手机号码|手机号" validate:"omitempty,len=11"`
+	Sex      int32  `excel:"用户性别|性别" dict:"system_user_sex"`    // 1=男, 2=女
+	Status   int32  `excel:"帐号状态|账号状态|状态" dict:"common_status"` // 0=开启, 1=关闭
 	DeptID   int64  `excel:"部门编号"`
 }
@@ -1,7 +1,10 @@
 package router
 
 import (
+	"backend-go/internal/api/handler"
+	payAdmin "backend-go/internal/api/handler/admin/pay"
 	memberApp "backend-go/internal/api/handler/app/member"
+	payApp "backend-go/internal/api/handler/app/pay"
 	productApp "backend-go/internal/api/handler/app/product"
 	promotionApp "backend-go/internal/api/handler/app/promotion"
 	tradeApp "backend-go/internal/api/handler/app/trade"
@@ -13,6 +16,8 @@ import (
 
 // RegisterAppRoutes 注册 App 端路由
 func RegisterAppRoutes(engine *gin.Engine,
+	// System
+	captchaHandler *handler.CaptchaHandler,
 	// Member
 	appAuthHandler *memberApp.AppAuthHandler,
 	appMemberUserHandler *memberApp.AppMemberUserHandler,
@@ -40,13 +45,27 @@ func RegisterAppRoutes(engine *gin.Engine,
 	appBargainActivityHandler *promotionApp.AppBargainActivityHandler,
 	appBargainRecordHandler *promotionApp.AppBargainRecordHandler,
 	appBargainHelpHandler *promotionApp.AppBargainHelpHandler,
+	appSeckillConfigHandler *promotionApp.AppSeckillConfigHandler,
+	appSeckillActivityHandler *promotionApp.AppSeckillActivityHandler,
 	// Brokerage
 	appBrokerageUserHandler *appBrokerage.AppBrokerageUserHandler,
 	appBrokerageRecordHandler *appBrokerage.AppBrokerageRecordHandler,
 	appBrokerageWithdrawHandler *appBrokerage.AppBrokerageWithdrawHandler,
+	// Pay
+	payOrderHandler *payAdmin.PayOrderHandler,
+	appPayWalletHandler *payApp.AppPayWalletHandler,
+	appPayWalletRechargeHandler *payApp.AppPayWalletRechargeHandler,
+	appPayWalletRechargePackageHandler *payApp.AppPayWalletRechargePackageHandler,
 ) {
 	appGroup := engine.Group("/app-api")
 	{
+		// ========== System ==========
+		captchaGroup := appGroup.Group("/system/captcha")
+		{
+			captchaGroup.POST("/get", captchaHandler.GetCaptcha)
+			captchaGroup.POST("/check", captchaHandler.CheckCaptcha)
+		}
+
 		// ========== Member ==========
 		memberGroup := appGroup.Group("/member")
 		{
@@ -133,6 +152,7 @@ func RegisterAppRoutes(engine *gin.Engine,
 			spuGroup := productGroup.Group("/spu")
 			{
 				spuGroup.GET("/get-detail", appProductSpuHandler.GetSpuDetail)
+				spuGroup.GET("/page", appProductSpuHandler.GetSpuPage)
 			}
 
 			// Comment
@@ -209,6 +229,32 @@ func RegisterAppRoutes(engine *gin.Engine,
 			tradeConfigGroup.GET("/get", appTradeConfigHandler.GetTradeConfig)
 		}
 
+		// ========== Pay ==========
+		payGroup := appGroup.Group("/pay")
+		payGroup.Use(middleware.Auth())
+		{
+			// Order
+			payOrderGroup := payGroup.Group("/order")
+			{
+				payOrderGroup.GET("/get", payOrderHandler.GetOrder)
+				payOrderGroup.POST("/submit", payOrderHandler.SubmitPayOrder)
+			}
+
+			// Wallet
+			payGroup.GET("/wallet/get", appPayWalletHandler.GetPayWallet)
+			payGroup.GET("/wallet-transaction/page", appPayWalletHandler.GetWalletTransactionPage)
+
+			// Wallet Recharge
+			walletRechargeGroup := payGroup.Group("/wallet-recharge")
+			{
+				walletRechargeGroup.POST("/create", appPayWalletRechargeHandler.CreateWalletRecharge)
+				walletRechargeGroup.GET("/page", appPayWalletRechargeHandler.GetWalletRechargePage)
+			}
+
+			// Wallet Recharge Package
+			payGroup.GET("/wallet-recharge-package/list", appPayWalletRechargePackageHandler.GetWalletRechargePackageList)
+		}
+
 		// ========== Promotion ==========
 		promotionGroup := appGroup.Group("/promotion")
 		{
@@ -263,6 +309,15 @@ func RegisterAppRoutes(engine *gin.Engine,
 				combinationRecordGroup.GET("/page", appCombinationRecordHandler.GetCombinationRecordPage)
 			}
 
+			// Seckill Config & Activity (Public)
+			promotionGroup.GET("/seckill-config/list", appSeckillConfigHandler.GetSeckillConfigList)
+			seckillActivityGroup := promotionGroup.Group("/seckill-activity")
+			{
+				seckillActivityGroup.GET("/get-now", appSeckillActivityHandler.GetNowSeckillActivity)
+				seckillActivityGroup.GET("/page", appSeckillActivityHandler.GetSeckillActivityPage)
+				seckillActivityGroup.GET("/get-detail", appSeckillActivityHandler.GetSeckillActivityDetail)
+			}
+
 			// Bargain Activity (Public)
 			bargainActivityGroup := promotionGroup.Group("/bargain-activity")
 			{
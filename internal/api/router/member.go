@@ -9,6 +9,7 @@ import (
 
 // RegisterMemberRoutes 注册会员管理模块路由
 func RegisterMemberRoutes(engine *gin.Engine,
+	perm middleware.PermissionChecker,
 	memberSignInConfigHandler *memberAdmin.MemberSignInConfigHandler,
 	memberSignInRecordHandler *memberAdmin.MemberSignInRecordHandler,
 	memberPointRecordHandler *memberAdmin.MemberPointRecordHandler,
@@ -24,72 +25,72 @@ func RegisterMemberRoutes(engine *gin.Engine,
 	// Member Point Record
 	pointRecordGroup := api.Group("/member/point/record")
 	{
-		pointRecordGroup.GET("/page", memberPointRecordHandler.GetPointRecordPage)
+		pointRecordGroup.GET("/page", middleware.HasPermission(perm, "point:record:query"), memberPointRecordHandler.GetPointRecordPage)
 	}
 
 	// Member Sign-in Config
 	signInConfigGroup := api.Group("/member/sign-in/config")
 	{
-		signInConfigGroup.POST("/create", memberSignInConfigHandler.CreateSignInConfig)
-		signInConfigGroup.PUT("/update", memberSignInConfigHandler.UpdateSignInConfig)
-		signInConfigGroup.DELETE("/delete", memberSignInConfigHandler.DeleteSignInConfig)
-		signInConfigGroup.GET("/get", memberSignInConfigHandler.GetSignInConfig)
-		signInConfigGroup.GET("/list", memberSignInConfigHandler.GetSignInConfigList)
+		signInConfigGroup.POST("/create", middleware.HasPermission(perm, "point:sign-in-config:create"), memberSignInConfigHandler.CreateSignInConfig)
+		signInConfigGroup.PUT("/update", middleware.HasPermission(perm, "point:sign-in-config:update"), memberSignInConfigHandler.UpdateSignInConfig)
+		signInConfigGroup.DELETE("/delete", middleware.HasPermission(perm, "point:sign-in-config:delete"), memberSignInConfigHandler.DeleteSignInConfig)
+		signInConfigGroup.GET("/get", middleware.HasPermission(perm, "point:sign-in-config:query"), memberSignInConfigHandler.GetSignInConfig)
+		signInConfigGroup.GET("/list", middleware.HasPermission(perm, "point:sign-in-config:query"), memberSignInConfigHandler.GetSignInConfigList)
 	}
 
 	// Member Sign-in Record (Admin)
 	signInRecordGroup := api.Group("/member/sign-in/record")
 	{
-		signInRecordGroup.GET("/page", memberSignInRecordHandler.GetSignInRecordPage)
+		signInRecordGroup.GET("/page", middleware.HasPermission(perm, "point:sign-in-record:query"), memberSignInRecordHandler.GetSignInRecordPage)
 	}
 
 	// Member Config 会员配置
 	configGroup := api.Group("/member/config")
 	{
-		configGroup.PUT("/save", memberConfigHandler.SaveConfig)
-		configGroup.GET("/get", memberConfigHandler.GetConfig)
+		configGroup.PUT("/save", middleware.HasPermission(perm, "member:config:save"), memberConfigHandler.SaveConfig)
+		configGroup.GET("/get", middleware.HasPermission(perm, "member:config:query"), memberConfigHandler.GetConfig)
 	}
 
 	// Member Group 用户分组
 	groupGroup := api.Group("/member/group")
 	{
-		groupGroup.POST("/create", memberGroupHandler.CreateGroup)
-		groupGroup.PUT("/update", memberGroupHandler.UpdateGroup)
-		groupGroup.DELETE("/delete", memberGroupHandler.DeleteGroup)
-		groupGroup.GET("/get", memberGroupHandler.GetGroup)
+		groupGroup.POST("/create", middleware.HasPermission(perm, "member:group:create"), memberGroupHandler.CreateGroup)
+		groupGroup.PUT("/update", middleware.HasPermission(perm, "member:group:update"), memberGroupHandler.UpdateGroup)
+		groupGroup.DELETE("/delete", middleware.HasPermission(perm, "member:group:delete"), memberGroupHandler.DeleteGroup)
+		groupGroup.GET("/get", middleware.HasPermission(perm, "member:group:query"), memberGroupHandler.GetGroup)
 		groupGroup.GET("/list-all-simple", memberGroupHandler.GetSimpleGroupList)
-		groupGroup.GET("/page", memberGroupHandler.GetGroupPage)
+		groupGroup.GET("/page", middleware.HasPermission(perm, "member:group:query"), memberGroupHandler.GetGroupPage)
 	}
 
 	// Member Level 会员等级
 	levelGroup := api.Group("/member/level")
 	{
-		levelGroup.POST("/create", memberLevelHandler.CreateLevel)
-		levelGroup.PUT("/update", memberLevelHandler.UpdateLevel)
-		levelGroup.DELETE("/delete", memberLevelHandler.DeleteLevel)
-		levelGroup.GET("/get", memberLevelHandler.GetLevel)
+		levelGroup.POST("/create", middleware.HasPermission(perm, "member:level:create"), memberLevelHandler.CreateLevel)
+		levelGroup.PUT("/update", middleware.HasPermission(perm, "member:level:update"), memberLevelHandler.UpdateLevel)
+		levelGroup.DELETE("/delete", middleware.HasPermission(perm, "member:level:delete"), memberLevelHandler.DeleteLevel)
+		levelGroup.GET("/get", middleware.HasPermission(perm, "member:level:query"), memberLevelHandler.GetLevel)
 		levelGroup.GET("/list-all-simple", memberLevelHandler.GetLevelListSimple)
-		levelGroup.GET("/list", memberLevelHandler.GetLevelListSimple)
+		levelGroup.GET("/list", middleware.HasPermission(perm, "member:level:query"), memberLevelHandler.GetLevelListSimple)
 	}
 
 	// Member Tag 会员标签
 	tagGroup := api.Group("/member/tag")
 	{
-		tagGroup.POST("/create", memberTagHandler.CreateTag)
-		tagGroup.PUT("/update", memberTagHandler.UpdateTag)
-		tagGroup.DELETE("/delete", memberTagHandler.DeleteTag)
-		tagGroup.GET("/get", memberTagHandler.GetTag)
+		tagGroup.POST("/create", middleware.HasPermission(perm, "member:tag:create"), memberTagHandler.CreateTag)
+		tagGroup.PUT("/update", middleware.HasPermission(perm, "member:tag:update"), memberTagHandler.UpdateTag)
+		tagGroup.DELETE("/delete", middleware.HasPermission(perm, "member:tag:delete"), memberTagHandler.DeleteTag)
+		tagGroup.GET("/get", middleware.HasPermission(perm, "member:tag:query"), memberTagHandler.GetTag)
 		tagGroup.GET("/list-all-simple", memberTagHandler.GetSimpleTagList)
-		tagGroup.GET("/page", memberTagHandler.GetTagPage)
+		tagGroup.GET("/page", middleware.HasPermission(perm, "member:tag:query"), memberTagHandler.GetTagPage)
 	}
 
 	// Member User 会员用户
 	userGroup := api.Group("/member/user")
 	{
-		userGroup.PUT("/update", memberUserHandler.UpdateUser)
-		userGroup.PUT("/update-level", memberUserHandler.UpdateUserLevel)
-		userGroup.PUT("/update-point", memberUserHandler.UpdateUserPoint)
-		userGroup.GET("/get", memberUserHandler.GetUser)
-		userGroup.GET("/page", memberUserHandler.GetUserPage)
+		userGroup.PUT("/update", middleware.HasPermission(perm, "member:user:update"), memberUserHandler.UpdateUser)
+		userGroup.PUT("/update-level", middleware.HasPermission(perm, "member:user:update-level"), memberUserHandler.UpdateUserLevel)
+		userGroup.PUT("/update-point", middleware.HasPermission(perm, "member:user:update-point"), memberUserHandler.UpdateUserPoint)
+		userGroup.GET("/get", middleware.HasPermission(perm, "member:user:query"), memberUserHandler.GetUser)
+		userGroup.GET("/page", middleware.HasPermission(perm, "member:user:query"), memberUserHandler.GetUserPage)
 	}
 }
@@ -2,64 +2,113 @@ package router
 
 import (
 	payAdmin "backend-go/internal/api/handler/admin/pay"
+	"backend-go/internal/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
 // RegisterPayRoutes 注册支付模块路由
 func RegisterPayRoutes(engine *gin.Engine,
+	perm middleware.PermissionChecker,
 	payAppHandler *payAdmin.PayAppHandler,
 	payChannelHandler *payAdmin.PayChannelHandler,
 	payOrderHandler *payAdmin.PayOrderHandler,
 	payRefundHandler *payAdmin.PayRefundHandler,
 	payNotifyHandler *payAdmin.PayNotifyHandler,
+	payWalletHandler *payAdmin.PayWalletHandler,
+	payWalletRechargeHandler *payAdmin.PayWalletRechargeHandler,
+	payWalletRechargePackageHandler *payAdmin.PayWalletRechargePackageHandler,
 ) {
 	api := engine.Group("/admin-api")
 	payGroup := api.Group("/pay")
 	{
 		// Pay App
 		payApp := payGroup.Group("/app")
+		payApp.Use(middleware.Auth())
 		{
-			payApp.POST("/create", payAppHandler.CreateApp)
-			payApp.PUT("/update", payAppHandler.UpdateApp)
-			payApp.PUT("/update-status", payAppHandler.UpdateAppStatus)
-			payApp.DELETE("/delete", payAppHandler.DeleteApp)
-			payApp.GET("/get", payAppHandler.GetApp)
-			payApp.GET("/page", payAppHandler.GetAppPage)
-			payApp.GET("/list", payAppHandler.GetAppList)
+			payApp.POST("/create", middleware.HasPermission(perm, "pay:app:create"), payAppHandler.CreateApp)
+			payApp.PUT("/update", middleware.HasPermission(perm, "pay:app:update"), payAppHandler.UpdateApp)
+			payApp.PUT("/update-status", middleware.HasPermission(perm, "pay:app:update"), payAppHandler.UpdateAppStatus)
+			payApp.DELETE("/delete", middleware.HasPermission(perm, "pay:app:delete"), payAppHandler.DeleteApp)
+			payApp.GET("/get", middleware.HasPermission(perm, "pay:app:query"), payAppHandler.GetApp)
+			payApp.GET("/page", middleware.HasPermission(perm, "pay:app:query"), payAppHandler.GetAppPage)
+			payApp.GET("/list", middleware.HasPermission(perm, "pay:app:query"), payAppHandler.GetAppList)
 		}
 
 		// Pay Channel
 		payChannel := payGroup.Group("/channel")
+		payChannel.Use(middleware.Auth())
 		{
-			payChannel.POST("/create", payChannelHandler.CreateChannel)
-			payChannel.PUT("/update", payChannelHandler.UpdateChannel)
-			payChannel.DELETE("/delete", payChannelHandler.DeleteChannel)
-			payChannel.GET("/get", payChannelHandler.GetChannel)
-			payChannel.GET("/get-enable-code-list", payChannelHandler.GetEnableChannelCodeList)
+			payChannel.POST("/create", middleware.HasPermission(perm, "pay:channel:create"), payChannelHandler.CreateChannel)
+			payChannel.PUT("/update", middleware.HasPermission(perm, "pay:channel:update"), payChannelHandler.UpdateChannel)
+			payChannel.DELETE("/delete", middleware.HasPermission(perm, "pay:channel:delete"), payChannelHandler.DeleteChannel)
+			payChannel.GET("/get", middleware.HasPermission(perm, "pay:channel:query"), payChannelHandler.GetChannel)
+			payChannel.GET("/get-enable-code-list", middleware.HasPermission(perm, "pay:channel:query"), payChannelHandler.GetEnableChannelCodeList)
 		}
 
 		// Pay Order
 		payOrder := payGroup.Group("/order")
+		payOrder.Use(middleware.Auth())
 		{
-			payOrder.GET("/get", payOrderHandler.GetOrder)
-			payOrder.GET("/get-detail", payOrderHandler.GetOrderDetail)
-			payOrder.GET("/page", payOrderHandler.GetOrderPage)
+			payOrder.GET("/get", middleware.HasPermission(perm, "pay:order:query"), payOrderHandler.GetOrder)
+			payOrder.GET("/get-detail", middleware.HasPermission(perm, "pay:order:query"), payOrderHandler.GetOrderDetail)
+			payOrder.GET("/page", middleware.HasPermission(perm, "pay:order:query"), payOrderHandler.GetOrderPage)
+			payOrder.GET("/export-excel", middleware.HasPermission(perm, "pay:order:export"), payOrderHandler.ExportOrderExcel)
 			payOrder.POST("/submit", payOrderHandler.SubmitPayOrder)
 		}
 
 		// Pay Refund
 		payRefund := payGroup.Group("/refund")
+		payRefund.Use(middleware.Auth())
 		{
-			payRefund.GET("/get", payRefundHandler.GetRefund)
-			payRefund.GET("/page", payRefundHandler.GetRefundPage)
+			payRefund.GET("/get", middleware.HasPermission(perm, "pay:refund:query"), payRefundHandler.GetRefund)
+			payRefund.GET("/page", middleware.HasPermission(perm, "pay:refund:query"), payRefundHandler.GetRefundPage)
 		}
 
 		// Pay Notify
 		payNotify := payGroup.Group("/notify")
 		{
-			payNotify.GET("/get-detail", payNotifyHandler.GetNotifyTaskDetail)
-			payNotify.GET("/page", payNotifyHandler.GetNotifyTaskPage)
+			// 支付渠道回调 (无需登录，由渠道验签保证安全)
+			payNotify.POST("/order/:channelId", payNotifyHandler.NotifyOrder)
+			payNotify.POST("/refund/:channelId", payNotifyHandler.NotifyRefund)
+			// 需要认证的接口
+			payNotify.Use(middleware.Auth())
+			payNotify.GET("/get-detail", middleware.HasPermission(perm, "pay:notify:query"), payNotifyHandler.GetNotifyTaskDetail)
+			payNotify.GET("/page", middleware.HasPermission(perm, "pay:notify:query"), payNotifyHandler.GetNotifyTaskPage)
+		}
+
+		// Pay Wallet
+		payWallet := payGroup.Group("/wallet")
+		payWallet.Use(middleware.Auth())
+		{
+			payWallet.GET("/get", middleware.HasPermission(perm, "pay:wallet:query"), payWalletHandler.GetWallet)
+			payWallet.GET("/page", middleware.HasPermission(perm, "pay:wallet:query"), payWalletHandler.GetWalletPage)
+			payWallet.PUT("/update-balance", middleware.HasPermission(perm, "pay:wallet:update-balance"), payWalletHandler.UpdateWalletBalance)
+		}
+
+		// Pay Wallet Transaction
+		payWalletTransaction := payGroup.Group("/wallet-transaction")
+		payWalletTransaction.Use(middleware.Auth())
+		{
+			payWalletTransaction.GET("/page", middleware.HasPermission(perm, "pay:wallet:query"), payWalletHandler.GetWalletTransactionPage)
+		}
+
+		// Pay Wallet Recharge
+		payWalletRecharge := payGroup.Group("/wallet-recharge")
+		{
+			// 支付单回调 (由支付通知任务调用，无需登录)
+			payWalletRecharge.POST("/update-paid", payWalletRechargeHandler.UpdateWalletRechargerPaid)
+		}
+
+		// Pay Wallet Recharge Package
+		payWalletRechargePackage := payGroup.Group("/wallet-recharge-package")
+		payWalletRechargePackage.Use(middleware.Auth())
+		{
+			payWalletRechargePackage.POST("/create", middleware.HasPermission(perm, "pay:wallet-recharge-package:create"), payWalletRechargePackageHandler.CreateWalletRechargePackage)
+			payWalletRechargePackage.PUT("/update", middleware.HasPermission(perm, "pay:wallet-recharge-package:update"), payWalletRechargePackageHandler.UpdateWalletRechargePackage)
+			payWalletRechargePackage.DELETE("/delete", middleware.HasPermission(perm, "pay:wallet-recharge-package:delete"), payWalletRechargePackageHandler.DeleteWalletRechargePackage)
+			payWalletRechargePackage.GET("/get", middleware.HasPermission(perm, "pay:wallet-recharge-package:query"), payWalletRechargePackageHandler.GetWalletRechargePackage)
+			payWalletRechargePackage.GET("/page", middleware.HasPermission(perm, "pay:wallet-recharge-package:query"), payWalletRechargePackageHandler.GetWalletRechargePackagePage)
 		}
 	}
 }
@@ -9,6 +9,7 @@ import (
 
 // RegisterProductRoutes 注册商品管理模块路由
 func RegisterProductRoutes(engine *gin.Engine,
+	perm middleware.PermissionChecker,
 	productCategoryHandler *productHandler.ProductCategoryHandler,
 	productBrandHandler *productHandler.ProductBrandHandler,
 	productPropertyHandler *productHandler.ProductPropertyHandler,
@@ -23,31 +24,31 @@ func RegisterProductRoutes(engine *gin.Engine,
 		// Category Routes
 		categoryGroup := productGroup.Group("/category")
 		{
-			categoryGroup.POST("/create", productCategoryHandler.CreateCategory)
-			categoryGroup.PUT("/update", productCategoryHandler.UpdateCategory)
-			categoryGroup.DELETE("/delete", productCategoryHandler.DeleteCategory)
-			categoryGroup.GET("/get", productCategoryHandler.GetCategory)
-			categoryGroup.GET("/list", productCategoryHandler.GetCategoryList)
+			categoryGroup.POST("/create", middleware.HasPermission(perm, "product:category:create"), productCategoryHandler.CreateCategory)
+			categoryGroup.PUT("/update", middleware.HasPermission(perm, "product:category:update"), productCategoryHandler.UpdateCategory)
+			categoryGroup.DELETE("/delete", middleware.HasPermission(perm, "product:category:delete"), productCategoryHandler.DeleteCategory)
+			categoryGroup.GET("/get", middleware.HasPermission(perm, "product:category:query"), productCategoryHandler.GetCategory)
+			categoryGroup.GET("/list", middleware.HasPermission(perm, "product:category:query"), productCategoryHandler.GetCategoryList)
 		}
 
 		// Property Routes
 		propertyGroup := productGroup.Group("/property")
 		{
-			propertyGroup.POST("/create", productPropertyHandler.CreateProperty)
-			propertyGroup.PUT("/update", productPropertyHandler.UpdateProperty)
-			propertyGroup.DELETE("/delete", productPropertyHandler.DeleteProperty)
-			propertyGroup.GET("/get", productPropertyHandler.GetProperty)
-			propertyGroup.GET("/page", productPropertyHandler.GetPropertyPage)
+			propertyGroup.POST("/create", middleware.HasPermission(perm, "product:property:create"), productPropertyHandler.CreateProperty)
+			propertyGroup.PUT("/update", middleware.HasPermission(perm, "product:property:update"), productPropertyHandler.UpdateProperty)
+			propertyGroup.DELETE("/delete", middleware.HasPermission(perm, "product:property:delete"), productPropertyHandler.DeleteProperty)
+			propertyGroup.GET("/get", middleware.HasPermission(perm, "product:property:query"), productPropertyHandler.GetProperty)
+			propertyGroup.GET("/page", middleware.HasPermission(perm, "product:property:query"), productPropertyHandler.GetPropertyPage)
 			propertyGroup.GET("/simple-list", productPropertyHandler.GetPropertySimpleList)
 
 			// Property Value Routes
 			valueGroup := propertyGroup.Group("/value")
 			{
-				valueGroup.POST("/create", productPropertyHandler.CreatePropertyValue)
-				valueGroup.PUT("/update", productPropertyHandler.UpdatePropertyValue)
-				valueGroup.DELETE("/delete", productPropertyHandler.DeletePropertyValue)
-				valueGroup.GET("/get", productPropertyHandler.GetPropertyValue)
-				valueGroup.GET("/page", productPropertyHandler.GetPropertyValuePage)
+				valueGroup.POST("/create", middleware.HasPermission(perm, "product:property:create"), productPropertyHandler.CreatePropertyValue)
+				valueGroup.PUT("/update", middleware.HasPermission(perm, "product:property:update"), productPropertyHandler.UpdatePropertyValue)
+				valueGroup.DELETE("/delete", middleware.HasPermission(perm, "product:property:delete"), productPropertyHandler.DeletePropertyValue)
+				valueGroup.GET("/get", middleware.HasPermission(perm, "product:property:query"), productPropertyHandler.GetPropertyValue)
+				valueGroup.GET("/page", middleware.HasPermission(perm, "product:property:query"), productPropertyHandler.GetPropertyValuePage)
 				valueGroup.GET("/simple-list", productPropertyHandler.GetPropertyValueSimpleList)
 			}
 		}
@@ -55,49 +56,49 @@ func RegisterProductRoutes(engine *gin.Engine,
 		// Brand Routes
 		brandGroup := productGroup.Group("/brand")
 		{
-			brandGroup.POST("/create", productBrandHandler.CreateBrand)
-			brandGroup.PUT("/update", productBrandHandler.UpdateBrand)
-			brandGroup.DELETE("/delete", productBrandHandler.DeleteBrand)
-			brandGroup.GET("/get", productBrandHandler.GetBrand)
-			brandGroup.GET("/page", productBrandHandler.GetBrandPage)
-			brandGroup.GET("/list", productBrandHandler.GetBrandList)
+			brandGroup.POST("/create", middleware.HasPermission(perm, "product:brand:create"), productBrandHandler.CreateBrand)
+			brandGroup.PUT("/update", middleware.HasPermission(perm, "product:brand:update"), productBrandHandler.UpdateBrand)
+			brandGroup.DELETE("/delete", middleware.HasPermission(perm, "product:brand:delete"), productBrandHandler.DeleteBrand)
+			brandGroup.GET("/get", middleware.HasPermission(perm, "product:brand:query"), productBrandHandler.GetBrand)
+			brandGroup.GET("/page", middleware.HasPermission(perm, "product:brand:query"), productBrandHandler.GetBrandPage)
+			brandGroup.GET("/list", middleware.HasPermission(perm, "product:brand:query"), productBrandHandler.GetBrandList)
 			brandGroup.GET("/list-all-simple", productBrandHandler.GetBrandList)
 		}
 
 		// SPU Routes
 		spuGroup := productGroup.Group("/spu")
 		{
-			spuGroup.POST("/create", productSpuHandler.CreateSpu)
-			spuGroup.PUT("/update", productSpuHandler.UpdateSpu)
-			spuGroup.PUT("/update-status", productSpuHandler.UpdateSpuStatus)
-			spuGroup.DELETE("/delete", productSpuHandler.DeleteSpu)
-			spuGroup.GET("/get-detail", productSpuHandler.GetSpuDetail)
-			spuGroup.GET("/page", productSpuHandler.GetSpuPage)
-			spuGroup.GET("/get-count", productSpuHandler.GetTabsCount)
+			spuGroup.POST("/create", middleware.HasPermission(perm, "product:spu:create"), productSpuHandler.CreateSpu)
+			spuGroup.PUT("/update", middleware.HasPermission(perm, "product:spu:update"), productSpuHandler.UpdateSpu)
+			spuGroup.PUT("/update-status", middleware.HasPermission(perm, "product:spu:update"), productSpuHandler.UpdateSpuStatus)
+			spuGroup.DELETE("/delete", middleware.HasPermission(perm, "product:spu:delete"), productSpuHandler.DeleteSpu)
+			spuGroup.GET("/get-detail", middleware.HasPermission(perm, "product:spu:query"), productSpuHandler.GetSpuDetail)
+			spuGroup.GET("/page", middleware.HasPermission(perm, "product:spu:query"), productSpuHandler.GetSpuPage)
+			spuGroup.GET("/get-count", middleware.HasPermission(perm, "product:spu:query"), productSpuHandler.GetTabsCount)
 			spuGroup.GET("/list-all-simple", productSpuHandler.GetSpuSimpleList)
-			spuGroup.GET("/list", productSpuHandler.GetSpuList)
-			spuGroup.GET("/export", productSpuHandler.ExportSpuList)
+			spuGroup.GET("/list", middleware.HasPermission(perm, "product:spu:query"), productSpuHandler.GetSpuList)
+			spuGroup.GET("/export", middleware.HasPermission(perm, "product:spu:export"), productSpuHandler.ExportSpuList)
 		}
 
 		// Comment Routes
 		commentGroup := productGroup.Group("/comment")
 		{
-			commentGroup.GET("/page", productCommentHandler.GetCommentPage)
-			commentGroup.PUT("/update-visible", productCommentHandler.UpdateCommentVisible)
-			commentGroup.PUT("/reply", productCommentHandler.ReplyComment)
-			commentGroup.POST("/create", productCommentHandler.CreateComment)
+			commentGroup.GET("/page", middleware.HasPermission(perm, "product:comment:query"), productCommentHandler.GetCommentPage)
+			commentGroup.PUT("/update-visible", middleware.HasPermission(perm, "product:comment:update"), productCommentHandler.UpdateCommentVisible)
+			commentGroup.PUT("/reply", middleware.HasPermission(perm, "product:comment:update"), productCommentHandler.ReplyComment)
+			commentGroup.POST("/create", middleware.HasPermission(perm, "product:comment:create"), productCommentHandler.CreateComment)
 		}
 
 		// Favorite Routes (Admin)
 		favoriteGroup := productGroup.Group("/favorite")
 		{
-			favoriteGroup.GET("/page", productFavoriteHandler.GetFavoritePage)
+			favoriteGroup.GET("/page", middleware.HasPermission(perm, "product:favorite:query"), productFavoriteHandler.GetFavoritePage)
 		}
 
 		// Browse History Routes (Admin)
 		browseHistoryGroup := productGroup.Group("/browse-history")
 		{
-			browseHistoryGroup.GET("/page", productBrowseHistoryHandler.GetBrowseHistoryPage)
+			browseHistoryGroup.GET("/page", middleware.HasPermission(perm, "product:browse-history:query"), productBrowseHistoryHandler.GetBrowseHistoryPage)
 		}
 	}
 }
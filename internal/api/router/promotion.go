@@ -9,6 +9,7 @@ import (
 
 // RegisterPromotionRoutes 注册营销活动模块路由
 func RegisterPromotionRoutes(engine *gin.Engine,
+	perm middleware.PermissionChecker,
 	couponHandler *promotionAdmin.CouponHandler,
 	bannerHandler *promotionAdmin.BannerHandler,
 	rewardActivityHandler *promotionAdmin.RewardActivityHandler,
@@ -33,184 +34,184 @@ func RegisterPromotionRoutes(engine *gin.Engine,
 		// Coupon Template
 		templateGroup := promotionGroup.Group("/coupon-template")
 		{
-			templateGroup.POST("/create", couponHandler.CreateCouponTemplate)
-			templateGroup.PUT("/update", couponHandler.UpdateCouponTemplate)
-			templateGroup.PUT("/update-status", couponHandler.UpdateCouponTemplateStatus)
-			templateGroup.DELETE("/delete", couponHandler.DeleteCouponTemplate)
-			templateGroup.GET("/get", couponHandler.GetCouponTemplate)
-			templateGroup.GET("/page", couponHandler.GetCouponTemplatePage)
-			templateGroup.GET("/list", couponHandler.GetCouponTemplateList)
+			templateGroup.POST("/create", middleware.HasPermission(perm, "promotion:coupon-template:create"), couponHandler.CreateCouponTemplate)
+			templateGroup.PUT("/update", middleware.HasPermission(perm, "promotion:coupon-template:update"), couponHandler.UpdateCouponTemplate)
+			templateGroup.PUT("/update-status", middleware.HasPermission(perm, "promotion:coupon-template:update"), couponHandler.UpdateCouponTemplateStatus)
+			templateGroup.DELETE("/delete", middleware.HasPermission(perm, "promotion:coupon-template:delete"), couponHandler.DeleteCouponTemplate)
+			templateGroup.GET("/get", middleware.HasPermission(perm, "promotion:coupon-template:query"), couponHandler.GetCouponTemplate)
+			templateGroup.GET("/page", middleware.HasPermission(perm, "promotion:coupon-template:query"), couponHandler.GetCouponTemplatePage)
+			templateGroup.GET("/list", middleware.HasPermission(perm, "promotion:coupon-template:query"), couponHandler.GetCouponTemplateList)
 		}
 
 		// Coupon
 		couponGroup := promotionGroup.Group("/coupon")
 		{
-			couponGroup.DELETE("/delete", couponHandler.DeleteCoupon)
-			couponGroup.GET("/page", couponHandler.GetCouponPage)
-			couponGroup.POST("/send", couponHandler.SendCoupon)
+			couponGroup.DELETE("/delete", middleware.HasPermission(perm, "promotion:coupon:delete"), couponHandler.DeleteCoupon)
+			couponGroup.GET("/page", middleware.HasPermission(perm, "promotion:coupon:query"), couponHandler.GetCouponPage)
+			couponGroup.POST("/send", middleware.HasPermission(perm, "promotion:coupon:send"), couponHandler.SendCoupon)
 		}
 
 		// Banner
 		bannerGroup := promotionGroup.Group("/banner")
 		{
-			bannerGroup.POST("/create", bannerHandler.CreateBanner)
-			bannerGroup.PUT("/update", bannerHandler.UpdateBanner)
-			bannerGroup.DELETE("/delete", bannerHandler.DeleteBanner)
-			bannerGroup.GET("/get", bannerHandler.GetBanner)
-			bannerGroup.GET("/page", bannerHandler.GetBannerPage)
+			bannerGroup.POST("/create", middleware.HasPermission(perm, "promotion:banner:create"), bannerHandler.CreateBanner)
+			bannerGroup.PUT("/update", middleware.HasPermission(perm, "promotion:banner:update"), bannerHandler.UpdateBanner)
+			bannerGroup.DELETE("/delete", middleware.HasPermission(perm, "promotion:banner:delete"), bannerHandler.DeleteBanner)
+			bannerGroup.GET("/get", middleware.HasPermission(perm, "promotion:banner:query"), bannerHandler.GetBanner)
+			bannerGroup.GET("/page", middleware.HasPermission(perm, "promotion:banner:query"), bannerHandler.GetBannerPage)
 		}
 
 		// Reward Activity
 		rewardGroup := promotionGroup.Group("/reward-activity")
 		{
-			rewardGroup.POST("/create", rewardActivityHandler.CreateRewardActivity)
-			rewardGroup.PUT("/update", rewardActivityHandler.UpdateRewardActivity)
-			rewardGroup.DELETE("/delete", rewardActivityHandler.DeleteRewardActivity)
-			rewardGroup.GET("/get", rewardActivityHandler.GetRewardActivity)
-			rewardGroup.GET("/page", rewardActivityHandler.GetRewardActivityPage)
+			rewardGroup.POST("/create", middleware.HasPermission(perm, "promotion:reward-activity:create"), rewardActivityHandler.CreateRewardActivity)
+			rewardGroup.PUT("/update", middleware.HasPermission(perm, "promotion:reward-activity:update"), rewardActivityHandler.UpdateRewardActivity)
+			rewardGroup.DELETE("/delete", middleware.HasPermission(perm, "promotion:reward-activity:delete"), rewardActivityHandler.DeleteRewardActivity)
+			rewardGroup.GET("/get", middleware.HasPermission(perm, "promotion:reward-activity:query"), rewardActivityHandler.GetRewardActivity)
+			rewardGroup.GET("/page", middleware.HasPermission(perm, "promotion:reward-activity:query"), rewardActivityHandler.GetRewardActivityPage)
 		}
 
 		// Seckill Config
 		seckillConfigGroup := promotionGroup.Group("/seckill-config")
 		{
-			seckillConfigGroup.POST("/create", seckillConfigHandler.CreateSeckillConfig)
-			seckillConfigGroup.PUT("/update", seckillConfigHandler.UpdateSeckillConfig)
-			seckillConfigGroup.PUT("/update-status", seckillConfigHandler.UpdateSeckillConfigStatus)
-			seckillConfigGroup.DELETE("/delete", seckillConfigHandler.DeleteSeckillConfig)
-			seckillConfigGroup.GET("/get", seckillConfigHandler.GetSeckillConfig)
-			seckillConfigGroup.GET("/page", seckillConfigHandler.GetSeckillConfigPage)
-			seckillConfigGroup.GET("/list", seckillConfigHandler.GetSeckillConfigList)
+			seckillConfigGroup.POST("/create", middleware.HasPermission(perm, "promotion:seckill-config:create"), seckillConfigHandler.CreateSeckillConfig)
+			seckillConfigGroup.PUT("/update", middleware.HasPermission(perm, "promotion:seckill-config:update"), seckillConfigHandler.UpdateSeckillConfig)
+			seckillConfigGroup.PUT("/update-status", middleware.HasPermission(perm, "promotion:seckill-config:update"), seckillConfigHandler.UpdateSeckillConfigStatus)
+			seckillConfigGroup.DELETE("/delete", middleware.HasPermission(perm, "promotion:seckill-config:delete"), seckillConfigHandler.DeleteSeckillConfig)
+			seckillConfigGroup.GET("/get", middleware.HasPermission(perm, "promotion:seckill-config:query"), seckillConfigHandler.GetSeckillConfig)
+			seckillConfigGroup.GET("/page", middleware.HasPermission(perm, "promotion:seckill-config:query"), seckillConfigHandler.GetSeckillConfigPage)
+			seckillConfigGroup.GET("/list", middleware.HasPermission(perm, "promotion:seckill-config:query"), seckillConfigHandler.GetSeckillConfigList)
 			seckillConfigGroup.GET("/simple-list", seckillConfigHandler.GetSeckillConfigSimpleList)
 		}
 
 		// Seckill Activity
 		seckillActivityGroup := promotionGroup.Group("/seckill-activity")
 		{
-			seckillActivityGroup.POST("/create", seckillActivityHandler.CreateSeckillActivity)
-			seckillActivityGroup.PUT("/update", seckillActivityHandler.UpdateSeckillActivity)
-			seckillActivityGroup.DELETE("/delete", seckillActivityHandler.DeleteSeckillActivity)
-			seckillActivityGroup.PUT("/close", seckillActivityHandler.CloseSeckillActivity)
-			seckillActivityGroup.GET("/get", seckillActivityHandler.GetSeckillActivity)
-			seckillActivityGroup.GET("/page", seckillActivityHandler.GetSeckillActivityPage)
+			seckillActivityGroup.POST("/create", middleware.HasPermission(perm, "promotion:seckill-activity:create"), seckillActivityHandler.CreateSeckillActivity)
+			seckillActivityGroup.PUT("/update", middleware.HasPermission(perm, "promotion:seckill-activity:update"), seckillActivityHandler.UpdateSeckillActivity)
+			seckillActivityGroup.DELETE("/delete", middleware.HasPermission(perm, "promotion:seckill-activity:delete"), seckillActivityHandler.DeleteSeckillActivity)
+			seckillActivityGroup.PUT("/close", middleware.HasPermission(perm, "promotion:seckill-activity:close"), seckillActivityHandler.CloseSeckillActivity)
+			seckillActivityGroup.GET("/get", middleware.HasPermission(perm, "promotion:seckill-activity:query"), seckillActivityHandler.GetSeckillActivity)
+			seckillActivityGroup.GET("/page", middleware.HasPermission(perm, "promotion:seckill-activity:query"), seckillActivityHandler.GetSeckillActivityPage)
 		}
 
 		// Bargain Activity
 		bargainActivityGroup := promotionGroup.Group("/bargain-activity")
 		{
-			bargainActivityGroup.POST("/create", bargainActivityHandler.CreateBargainActivity)
-			bargainActivityGroup.PUT("/update", bargainActivityHandler.UpdateBargainActivity)
-			bargainActivityGroup.DELETE("/delete", bargainActivityHandler.DeleteBargainActivity)
-			bargainActivityGroup.PUT("/close", bargainActivityHandler.CloseBargainActivity)
-			bargainActivityGroup.GET("/get", bargainActivityHandler.GetBargainActivity)
-			bargainActivityGroup.GET("/page", bargainActivityHandler.GetBargainActivityPage)
+			bargainActivityGroup.POST("/create", middleware.HasPermission(perm, "promotion:bargain-activity:create"), bargainActivityHandler.CreateBargainActivity)
+			bargainActivityGroup.PUT("/update", middleware.HasPermission(perm, "promotion:bargain-activity:update"), bargainActivityHandler.UpdateBargainActivity)
+			bargainActivityGroup.DELETE("/delete", middleware.HasPermission(perm, "promotion:bargain-activity:delete"), bargainActivityHandler.DeleteBargainActivity)
+			bargainActivityGroup.PUT("/close", middleware.HasPermission(perm, "promotion:bargain-activity:close"), bargainActivityHandler.CloseBargainActivity)
+			bargainActivityGroup.GET("/get", middleware.HasPermission(perm, "promotion:bargain-activity:query"), bargainActivityHandler.GetBargainActivity)
+			bargainActivityGroup.GET("/page", middleware.HasPermission(perm, "promotion:bargain-activity:query"), bargainActivityHandler.GetBargainActivityPage)
 		}
 
 		// Combination Activity
 		combinationActivityGroup := promotionGroup.Group("/combination-activity")
 		{
-			combinationActivityGroup.POST("/create", combinationActivityHandler.CreateCombinationActivity)
-			combinationActivityGroup.PUT("/update", combinationActivityHandler.UpdateCombinationActivity)
-			combinationActivityGroup.DELETE("/delete", combinationActivityHandler.DeleteCombinationActivity)
-			combinationActivityGroup.GET("/get", combinationActivityHandler.GetCombinationActivity)
-			combinationActivityGroup.GET("/page", combinationActivityHandler.GetCombinationActivityPage)
+			combinationActivityGroup.POST("/create", middleware.HasPermission(perm, "promotion:combination-activity:create"), combinationActivityHandler.CreateCombinationActivity)
+			combinationActivityGroup.PUT("/update", middleware.HasPermission(perm, "promotion:combination-activity:update"), combinationActivityHandler.UpdateCombinationActivity)
+			combinationActivityGroup.DELETE("/delete", middleware.HasPermission(perm, "promotion:combination-activity:delete"), combinationActivityHandler.DeleteCombinationActivity)
+			combinationActivityGroup.GET("/get", middleware.HasPermission(perm, "promotion:combination-activity:query"), combinationActivityHandler.GetCombinationActivity)
+			combinationActivityGroup.GET("/page", middleware.HasPermission(perm, "promotion:combination-activity:query"), combinationActivityHandler.GetCombinationActivityPage)
 		}
 
 		// Discount Activity
 		discountActivityGroup := promotionGroup.Group("/discount-activity")
 		{
-			discountActivityGroup.POST("/create", discountActivityHandler.CreateDiscountActivity)
-			discountActivityGroup.PUT("/update", discountActivityHandler.UpdateDiscountActivity)
-			discountActivityGroup.POST("/close", discountActivityHandler.CloseDiscountActivity)
-			discountActivityGroup.DELETE("/delete", discountActivityHandler.DeleteDiscountActivity)
-			discountActivityGroup.GET("/get", discountActivityHandler.GetDiscountActivity)
-			discountActivityGroup.GET("/page", discountActivityHandler.GetDiscountActivityPage)
+			discountActivityGroup.POST("/create", middleware.HasPermission(perm, "promotion:discount-activity:create"), discountActivityHandler.CreateDiscountActivity)
+			discountActivityGroup.PUT("/update", middleware.HasPermission(perm, "promotion:discount-activity:update"), discountActivityHandler.UpdateDiscountActivity)
+			discountActivityGroup.POST("/close", middleware.HasPermission(perm, "promotion:discount-activity:close"), discountActivityHandler.CloseDiscountActivity)
+			discountActivityGroup.DELETE("/delete", middleware.HasPermission(perm, "promotion:discount-activity:delete"), discountActivityHandler.DeleteDiscountActivity)
+			discountActivityGroup.GET("/get", middleware.HasPermission(perm, "promotion:discount-activity:query"), discountActivityHandler.GetDiscountActivity)
+			discountActivityGroup.GET("/page", middleware.HasPermission(perm, "promotion:discount-activity:query"), discountActivityHandler.GetDiscountActivityPage)
 		}
 
 		// Article Category
 		articleCategoryGroup := promotionGroup.Group("/article-category")
 		{
-			articleCategoryGroup.POST("/create", articleCategoryHandler.CreateArticleCategory)
-			articleCategoryGroup.PUT("/update", articleCategoryHandler.UpdateArticleCategory)
-			articleCategoryGroup.DELETE("/delete", articleCategoryHandler.DeleteArticleCategory)
-			articleCategoryGroup.GET("/get", articleCategoryHandler.GetArticleCategory)
-			articleCategoryGroup.GET("/list", articleCategoryHandler.GetArticleCategoryList)
+			articleCategoryGroup.POST("/create", middleware.HasPermission(perm, "promotion:article-category:create"), articleCategoryHandler.CreateArticleCategory)
+			articleCategoryGroup.PUT("/update", middleware.HasPermission(perm, "promotion:article-category:update"), articleCategoryHandler.UpdateArticleCategory)
+			articleCategoryGroup.DELETE("/delete", middleware.HasPermission(perm, "promotion:article-category:delete"), articleCategoryHandler.DeleteArticleCategory)
+			articleCategoryGroup.GET("/get", middleware.HasPermission(perm, "promotion:article-category:query"), articleCategoryHandler.GetArticleCategory)
+			articleCategoryGroup.GET("/list", middleware.HasPermission(perm, "promotion:article-category:query"), articleCategoryHandler.GetArticleCategoryList)
 			articleCategoryGroup.GET("/simple-list", articleCategoryHandler.GetSimpleList)
 		}
 
 		// Article
 		articleGroup := promotionGroup.Group("/article")
 		{
-			articleGroup.POST("/create", articleHandler.CreateArticle)
-			articleGroup.PUT("/update", articleHandler.UpdateArticle)
-			articleGroup.DELETE("/delete", articleHandler.DeleteArticle)
-			articleGroup.GET("/get", articleHandler.GetArticle)
-			articleGroup.GET("/page", articleHandler.GetArticlePage)
+			articleGroup.POST("/create", middleware.HasPermission(perm, "promotion:article:create"), articleHandler.CreateArticle)
+			articleGroup.PUT("/update", middleware.HasPermission(perm, "promotion:article:update"), articleHandler.UpdateArticle)
+			articleGroup.DELETE("/delete", middleware.HasPermission(perm, "promotion:article:delete"), articleHandler.DeleteArticle)
+			articleGroup.GET("/get", middleware.HasPermission(perm, "promotion:article:query"), articleHandler.GetArticle)
+			articleGroup.GET("/page", middleware.HasPermission(perm, "promotion:article:query"), articleHandler.GetArticlePage)
 		}
 
 		// DIY Template
 		diyTemplateGroup := promotionGroup.Group("/diy-template")
 		{
-			diyTemplateGroup.POST("/create", diyTemplateHandler.CreateDiyTemplate)
-			diyTemplateGroup.PUT("/update", diyTemplateHandler.UpdateDiyTemplate)
-			diyTemplateGroup.DELETE("/delete", diyTemplateHandler.DeleteDiyTemplate)
-			diyTemplateGroup.GET("/get", diyTemplateHandler.GetDiyTemplate)
-			diyTemplateGroup.GET("/page", diyTemplateHandler.GetDiyTemplatePage)
-			diyTemplateGroup.GET("/get-property", diyTemplateHandler.GetDiyTemplateProperty)
+			diyTemplateGroup.POST("/create", middleware.HasPermission(perm, "promotion:diy-template:create"), diyTemplateHandler.CreateDiyTemplate)
+			diyTemplateGroup.PUT("/update", middleware.HasPermission(perm, "promotion:diy-template:update"), diyTemplateHandler.UpdateDiyTemplate)
+			diyTemplateGroup.DELETE("/delete", middleware.HasPermission(perm, "promotion:diy-template:delete"), diyTemplateHandler.DeleteDiyTemplate)
+			diyTemplateGroup.GET("/get", middleware.HasPermission(perm, "promotion:diy-template:query"), diyTemplateHandler.GetDiyTemplate)
+			diyTemplateGroup.GET("/page", middleware.HasPermission(perm, "promotion:diy-template:query"), diyTemplateHandler.GetDiyTemplatePage)
+			diyTemplateGroup.GET("/get-property", middleware.HasPermission(perm, "promotion:diy-template:query"), diyTemplateHandler.GetDiyTemplateProperty)
 		}
 
 		// DIY Page
 		diyPageGroup := promotionGroup.Group("/diy-page")
 		{
-			diyPageGroup.POST("/create", diyPageHandler.CreateDiyPage)
-			diyPageGroup.PUT("/update", diyPageHandler.UpdateDiyPage)
-			diyPageGroup.DELETE("/delete", diyPageHandler.DeleteDiyPage)
-			diyPageGroup.GET("/get", diyPageHandler.GetDiyPage)
-			diyPageGroup.GET("/page", diyPageHandler.GetDiyPagePage)
-			diyPageGroup.GET("/get-property", diyPageHandler.GetDiyPageProperty)
+			diyPageGroup.POST("/create", middleware.HasPermission(perm, "promotion:diy-page:create"), diyPageHandler.CreateDiyPage)
+			diyPageGroup.PUT("/update", middleware.HasPermission(perm, "promotion:diy-page:update"), diyPageHandler.UpdateDiyPage)
+			diyPageGroup.DELETE("/delete", middleware.HasPermission(perm, "promotion:diy-page:delete"), diyPageHandler.DeleteDiyPage)
+			diyPageGroup.GET("/get", middleware.HasPermission(perm, "promotion:diy-page:query"), diyPageHandler.GetDiyPage)
+			diyPageGroup.GET("/page", middleware.HasPermission(perm, "promotion:diy-page:query"), diyPageHandler.GetDiyPagePage)
+			diyPageGroup.GET("/get-property", middleware.HasPermission(perm, "promotion:diy-page:query"), diyPageHandler.GetDiyPageProperty)
 		}
 
 		// Kefu Conversation (Admin)
 		kefuConversationGroup := promotionGroup.Group("/kefu-conversation")
 		{
-			kefuConversationGroup.GET("/page", kefuHandler.GetConversationPage)
-			kefuConversationGroup.DELETE("/delete", kefuHandler.DeleteConversation)
+			kefuConversationGroup.GET("/page", middleware.HasPermission(perm, "promotion:kefu-conversation:query"), kefuHandler.GetConversationPage)
+			kefuConversationGroup.DELETE("/delete", middleware.HasPermission(perm, "promotion:kefu-conversation:delete"), kefuHandler.DeleteConversation)
 		}
 
 		// Kefu Message (Admin)
 		kefuMessageGroup := promotionGroup.Group("/kefu-message")
 		{
-			kefuMessageGroup.POST("/send", kefuHandler.SendMessage)
-			kefuMessageGroup.GET("/page", kefuHandler.GetMessagePage)
+			kefuMessageGroup.POST("/send", middleware.HasPermission(perm, "promotion:kefu-message:send"), kefuHandler.SendMessage)
+			kefuMessageGroup.GET("/page", middleware.HasPermission(perm, "promotion:kefu-message:query"), kefuHandler.GetMessagePage)
 		}
 
 		// Point Activity
 		pointActivityGroup := promotionGroup.Group("/point-activity")
 		{
-			pointActivityGroup.POST("/create", pointActivityHandler.CreatePointActivity)
-			pointActivityGroup.PUT("/update", pointActivityHandler.UpdatePointActivity)
-			pointActivityGroup.PUT("/close", pointActivityHandler.ClosePointActivity)
-			pointActivityGroup.DELETE("/delete", pointActivityHandler.DeletePointActivity)
-			pointActivityGroup.GET("/get", pointActivityHandler.GetPointActivity)
-			pointActivityGroup.GET("/page", pointActivityHandler.GetPointActivityPage)
+			pointActivityGroup.POST("/create", middleware.HasPermission(perm, "promotion:point-activity:create"), pointActivityHandler.CreatePointActivity)
+			pointActivityGroup.PUT("/update", middleware.HasPermission(perm, "promotion:point-activity:update"), pointActivityHandler.UpdatePointActivity)
+			pointActivityGroup.PUT("/close", middleware.HasPermission(perm, "promotion:point-activity:close"), pointActivityHandler.ClosePointActivity)
+			pointActivityGroup.DELETE("/delete", middleware.HasPermission(perm, "promotion:point-activity:delete"), pointActivityHandler.DeletePointActivity)
+			pointActivityGroup.GET("/get", middleware.HasPermission(perm, "promotion:point-activity:query"), pointActivityHandler.GetPointActivity)
+			pointActivityGroup.GET("/page", middleware.HasPermission(perm, "promotion:point-activity:query"), pointActivityHandler.GetPointActivityPage)
 			pointActivityGroup.GET("/list-by-ids", pointActivityHandler.GetPointActivityListByIds)
 		}
 
 		// Bargain Record
 		bargainRecordGroup := promotionGroup.Group("/bargain-record")
 		{
-			bargainRecordGroup.GET("/page", bargainRecordHandler.GetBargainRecordPage)
+			bargainRecordGroup.GET("/page", middleware.HasPermission(perm, "promotion:bargain-record:query"), bargainRecordHandler.GetBargainRecordPage)
 		}
 
 		// Combination Record
 		combinationRecordGroup := promotionGroup.Group("/combination-record")
 		{
-			combinationRecordGroup.GET("/page", combinationRecordHandler.GetCombinationRecordPage)
+			combinationRecordGroup.GET("/page", middleware.HasPermission(perm, "promotion:combination-record:query"), combinationRecordHandler.GetCombinationRecordPage)
 		}
 
 		// Bargain Help
 		bargainHelpGroup := promotionGroup.Group("/bargain-help")
 		{
-			bargainHelpGroup.GET("/page", bargainHelpHandler.GetBargainHelpPage)
+			bargainHelpGroup.GET("/page", middleware.HasPermission(perm, "promotion:bargain-help:query"), bargainHelpHandler.GetBargainHelpPage)
 		}
 	}
 }
@@ -10,12 +10,14 @@ import (
 	tradeAdmin "backend-go/internal/api/handler/admin/trade"
 	tradeBrokerageAdmin "backend-go/internal/api/handler/admin/trade/brokerage"
 	memberHandler "backend-go/internal/api/handler/app/member"
+	payApp "backend-go/internal/api/handler/app/pay"
 	productApp "backend-go/internal/api/handler/app/product"
 	promotionApp "backend-go/internal/api/handler/app/promotion"
 	tradeApp "backend-go/internal/api/handler/app/trade"
 	appBrokerage "backend-go/internal/api/handler/app/trade/brokerage"
 
 	"backend-go/internal/middleware"
+	"backend-go/internal/service"
 	"fmt"
 
 	"github.com/gin-gonic/gin"
@@ -24,6 +26,10 @@ import (
 )
 
 func InitRouter(db *gorm.DB, rdb *redis.Client,
+	permissionService *service.PermissionService,
+	apiAccessLogService *service.ApiAccessLogService,
+	apiErrorLogService *service.ApiErrorLogService,
+	operateLogService *service.OperateLogService,
 	authHandler *handler.AuthHandler,
 	userHandler *handler.UserHandler,
 	tenantHandler *handler.TenantHandler,
@@ -90,6 +96,9 @@ func InitRouter(db *gorm.DB, rdb *redis.Client,
 	payOrderHandler *payAdmin.PayOrderHandler,
 	payRefundHandler *payAdmin.PayRefundHandler,
 	payNotifyHandler *payAdmin.PayNotifyHandler,
+	payWalletHandler *payAdmin.PayWalletHandler,
+	payWalletRechargeHandler *payAdmin.PayWalletRechargeHandler,
+	payWalletRechargePackageHandler *payAdmin.PayWalletRechargePackageHandler,
 	loginLogHandler *handler.LoginLogHandler,
 	operateLogHandler *handler.OperateLogHandler,
 	jobHandler *handler.JobHandler,
@@ -105,6 +114,8 @@ func InitRouter(db *gorm.DB, rdb *redis.Client,
 	appBargainActivityHandler *promotionApp.AppBargainActivityHandler,
 	appBargainRecordHandler *promotionApp.AppBargainRecordHandler,
 	appBargainHelpHandler *promotionApp.AppBargainHelpHandler,
+	appSeckillConfigHandler *promotionApp.AppSeckillConfigHandler,
+	appSeckillActivityHandler *promotionApp.AppSeckillActivityHandler,
 	// Article
 	articleCategoryHandler *promotionAdmin.ArticleCategoryHandler,
 	articleHandler *promotionAdmin.ArticleHandler,
@@ -136,13 +147,23 @@ func InitRouter(db *gorm.DB, rdb *redis.Client,
 	appBrokerageUserHandler *appBrokerage.AppBrokerageUserHandler,
 	appBrokerageRecordHandler *appBrokerage.AppBrokerageRecordHandler,
 	appBrokerageWithdrawHandler *appBrokerage.AppBrokerageWithdrawHandler,
+	appPayWalletHandler *payApp.AppPayWalletHandler,
+	appPayWalletRechargeHandler *payApp.AppPayWalletRechargeHandler,
+	appPayWalletRechargePackageHandler *payApp.AppPayWalletRechargePackageHandler,
+	captchaHandler *handler.CaptchaHandler,
+	smsCallbackHandler *handler.SmsCallbackHandler,
 ) *gin.Engine {
 	// Debug log to confirm router init
 	fmt.Println("Initializing Router...")
 	r := gin.New()
-	r.Use(middleware.Recovery())
+	r.Use(middleware.APIAccessLogMiddleware(apiAccessLogService, apiErrorLogService))
+	r.Use(middleware.Recovery(apiErrorLogService))
 	r.Use(middleware.ErrorHandler())
 	r.Use(gin.Logger())
+	r.Use(middleware.Tenant())
+
+	// 操作日志，由各模块在管理后台的写操作路由上声明
+	oplog := middleware.NewOperateLogRecorder(operateLogService)
 
 	// 基础路由
 	r.GET("/ping", func(c *gin.Context) {
@@ -155,7 +176,7 @@ func InitRouter(db *gorm.DB, rdb *redis.Client,
 
 	// System 模块 (Auth, Tenant, Dict, Dept, Post, User, Role, Permission, Logs, SMS, File, Infra)
 	// System 模块 (Auth, Tenant, Dict, Dept, Post, User, Role, Permission, Logs, SMS, File, Infra)
-	RegisterSystemRoutes(r,
+	RegisterSystemRoutes(r, permissionService, oplog,
 		authHandler, userHandler, tenantHandler, dictHandler, deptHandler,
 		postHandler, roleHandler, menuHandler, permissionHandler, noticeHandler,
 		loginLogHandler, operateLogHandler, configHandler,
@@ -163,17 +184,18 @@ func InitRouter(db *gorm.DB, rdb *redis.Client,
 		fileConfigHandler, fileHandler,
 		jobHandler, jobLogHandler, apiAccessLogHandler, apiErrorLogHandler,
 		socialClientHandler, socialUserHandler, sensitiveWordHandler, mailHandler, notifyHandler, oauth2ClientHandler,
+		captchaHandler, smsCallbackHandler,
 	)
 
 	// Product 模块
-	RegisterProductRoutes(r,
+	RegisterProductRoutes(r, permissionService,
 		productCategoryHandler, productBrandHandler, productPropertyHandler,
 		productSpuHandler, productCommentHandler, productFavoriteHandler,
 		productBrowseHistoryHandler,
 	)
 
 	// Promotion 模块
-	RegisterPromotionRoutes(r,
+	RegisterPromotionRoutes(r, permissionService,
 		couponHandler, bannerHandler, rewardActivityHandler,
 		seckillConfigHandler, seckillActivityHandler, bargainActivityHandler,
 		combinationActivityHandler, discountActivityHandler,
@@ -184,7 +206,7 @@ func InitRouter(db *gorm.DB, rdb *redis.Client,
 	)
 
 	// Trade 模块
-	RegisterTradeRoutes(r,
+	RegisterTradeRoutes(r, permissionService, oplog,
 		tradeOrderHandler, tradeAfterSaleHandler,
 		deliveryExpressHandler, deliveryPickUpStoreHandler, deliveryFreightTemplateHandler,
 		tradeConfigHandler,
@@ -194,7 +216,7 @@ func InitRouter(db *gorm.DB, rdb *redis.Client,
 	)
 
 	// Member 模块 (Admin)
-	RegisterMemberRoutes(r,
+	RegisterMemberRoutes(r, permissionService,
 		memberSignInConfigHandler, memberSignInRecordHandler,
 		memberPointRecordHandler,
 		memberConfigHandler, memberGroupHandler, memberLevelHandler, memberTagHandler,
@@ -202,12 +224,15 @@ func InitRouter(db *gorm.DB, rdb *redis.Client,
 	)
 
 	// Pay 模块
-	RegisterPayRoutes(r,
+	RegisterPayRoutes(r, permissionService,
 		payAppHandler, payChannelHandler, payOrderHandler, payRefundHandler, payNotifyHandler,
+		payWalletHandler, payWalletRechargeHandler, payWalletRechargePackageHandler,
 	)
 
 	// App 模块 (移动端)
 	RegisterAppRoutes(r,
+		// System
+		captchaHandler,
 		// Member
 		appAuthHandler, appMemberUserHandler, appMemberAddressHandler,
 		appMemberPointRecordHandler, appMemberSignInRecordHandler,
@@ -220,13 +245,16 @@ func InitRouter(db *gorm.DB, rdb *redis.Client,
 		appCouponHandler, appBannerHandler, appArticleHandler, appDiyPageHandler, appKefuHandler,
 		appCombinationActivityHandler, appCombinationRecordHandler,
 		appBargainActivityHandler, appBargainRecordHandler, appBargainHelpHandler,
+		appSeckillConfigHandler, appSeckillActivityHandler,
 		appBrokerageUserHandler,
 		appBrokerageRecordHandler,
 		appBrokerageWithdrawHandler,
+		// Pay
+		payOrderHandler, appPayWalletHandler, appPayWalletRechargeHandler, appPayWalletRechargePackageHandler,
 	)
 
 	// Statistics 模块
-	RegisterStatisticsRoutes(r,
+	RegisterStatisticsRoutes(r, permissionService,
 		tradeStatisticsHandler, productStatisticsHandler,
 		memberStatisticsHandler, payStatisticsHandler,
 	)
@@ -9,6 +9,7 @@ import (
 
 // RegisterStatisticsRoutes 注册统计模块路由
 func RegisterStatisticsRoutes(engine *gin.Engine,
+	perm middleware.PermissionChecker,
 	tradeStatisticsHandler *admin.TradeStatisticsHandler,
 	productStatisticsHandler *admin.ProductStatisticsHandler,
 	memberStatisticsHandler *admin.MemberStatisticsHandler,
@@ -21,39 +22,39 @@ func RegisterStatisticsRoutes(engine *gin.Engine,
 	// 交易统计路由
 	tradeGroup := adminGroup.Group("/trade")
 	{
-		tradeGroup.GET("/summary", tradeStatisticsHandler.GetTradeSummaryComparison)
-		tradeGroup.GET("/analyse", tradeStatisticsHandler.GetTradeStatisticsAnalyse)
-		tradeGroup.GET("/list", tradeStatisticsHandler.GetTradeStatisticsList)
-		tradeGroup.GET("/order-count", tradeStatisticsHandler.GetOrderCount)
-		tradeGroup.GET("/order-comparison", tradeStatisticsHandler.GetOrderComparison)
-		tradeGroup.GET("/order-count-trend", tradeStatisticsHandler.GetOrderCountTrendComparison)
-		tradeGroup.GET("/export-excel", tradeStatisticsHandler.ExportTradeStatisticsExcel)
+		tradeGroup.GET("/summary", middleware.HasPermission(perm, "statistics:trade:query"), tradeStatisticsHandler.GetTradeSummaryComparison)
+		tradeGroup.GET("/analyse", middleware.HasPermission(perm, "statistics:trade:query"), tradeStatisticsHandler.GetTradeStatisticsAnalyse)
+		tradeGroup.GET("/list", middleware.HasPermission(perm, "statistics:trade:query"), tradeStatisticsHandler.GetTradeStatisticsList)
+		tradeGroup.GET("/order-count", middleware.HasPermission(perm, "statistics:trade:query"), tradeStatisticsHandler.GetOrderCount)
+		tradeGroup.GET("/order-comparison", middleware.HasPermission(perm, "statistics:trade:query"), tradeStatisticsHandler.GetOrderComparison)
+		tradeGroup.GET("/order-count-trend", middleware.HasPermission(perm, "statistics:trade:query"), tradeStatisticsHandler.GetOrderCountTrendComparison)
+		tradeGroup.GET("/export-excel", middleware.HasPermission(perm, "statistics:trade:export"), tradeStatisticsHandler.ExportTradeStatisticsExcel)
 	}
 
 	// 商品统计路由
 	productGroup := adminGroup.Group("/product")
 	{
-		productGroup.GET("/analyse", productStatisticsHandler.GetProductStatisticsAnalyse)
-		productGroup.GET("/list", productStatisticsHandler.GetProductStatisticsList)
-		productGroup.GET("/rank-page", productStatisticsHandler.GetProductStatisticsRankPage)
-		productGroup.GET("/export-excel", productStatisticsHandler.ExportProductStatisticsExcel)
+		productGroup.GET("/analyse", middleware.HasPermission(perm, "statistics:product:query"), productStatisticsHandler.GetProductStatisticsAnalyse)
+		productGroup.GET("/list", middleware.HasPermission(perm, "statistics:product:query"), productStatisticsHandler.GetProductStatisticsList)
+		productGroup.GET("/rank-page", middleware.HasPermission(perm, "statistics:product:query"), productStatisticsHandler.GetProductStatisticsRankPage)
+		productGroup.GET("/export-excel", middleware.HasPermission(perm, "statistics:product:export"), productStatisticsHandler.ExportProductStatisticsExcel)
 	}
 
 	// 会员统计路由
 	memberGroup := adminGroup.Group("/member")
 	{
-		memberGroup.GET("/summary", memberStatisticsHandler.GetMemberSummary)
-		memberGroup.GET("/analyse", memberStatisticsHandler.GetMemberAnalyse)
-		memberGroup.GET("/area-statistics-list", memberStatisticsHandler.GetMemberAreaStatisticsList)
-		memberGroup.GET("/sex-statistics-list", memberStatisticsHandler.GetMemberSexStatisticsList)
-		memberGroup.GET("/terminal-statistics-list", memberStatisticsHandler.GetMemberTerminalStatisticsList)
-		memberGroup.GET("/user-count-comparison", memberStatisticsHandler.GetUserCountComparison)
-		memberGroup.GET("/register-count-list", memberStatisticsHandler.GetMemberRegisterCountList)
+		memberGroup.GET("/summary", middleware.HasPermission(perm, "statistics:member:query"), memberStatisticsHandler.GetMemberSummary)
+		memberGroup.GET("/analyse", middleware.HasPermission(perm, "statistics:member:query"), memberStatisticsHandler.GetMemberAnalyse)
+		memberGroup.GET("/area-statistics-list", middleware.HasPermission(perm, "statistics:member:query"), memberStatisticsHandler.GetMemberAreaStatisticsList)
+		memberGroup.GET("/sex-statistics-list", middleware.HasPermission(perm, "statistics:member:query"), memberStatisticsHandler.GetMemberSexStatisticsList)
+		memberGroup.GET("/terminal-statistics-list", middleware.HasPermission(perm, "statistics:member:query"), memberStatisticsHandler.GetMemberTerminalStatisticsList)
+		memberGroup.GET("/user-count-comparison", middleware.HasPermission(perm, "statistics:member:query"), memberStatisticsHandler.GetUserCountComparison)
+		memberGroup.GET("/register-count-list", middleware.HasPermission(perm, "statistics:member:query"), memberStatisticsHandler.GetMemberRegisterCountList)
 	}
 
 	// 支付统计路由
 	payGroup := adminGroup.Group("/pay")
 	{
-		payGroup.GET("/summary", payStatisticsHandler.GetWalletRechargePrice)
+		payGroup.GET("/summary", middleware.HasPermission(perm, "statistics:pay:query"), payStatisticsHandler.GetWalletRechargePrice)
 	}
 }
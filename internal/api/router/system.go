@@ -9,6 +9,8 @@ import (
 
 // RegisterSystemRoutes 注册系统管理模块路由
 func RegisterSystemRoutes(engine *gin.Engine,
+	perm middleware.PermissionChecker,
+	oplog *middleware.OperateLogRecorder,
 	authHandler *handler.AuthHandler,
 	userHandler *handler.UserHandler,
 	tenantHandler *handler.TenantHandler,
@@ -37,6 +39,8 @@ func RegisterSystemRoutes(engine *gin.Engine,
 	mailHandler *handler.MailHandler,
 	notifyHandler *handler.NotifyHandler,
 	oauth2ClientHandler *handler.OAuth2ClientHandler,
+	captchaHandler *handler.CaptchaHandler,
+	smsCallbackHandler *handler.SmsCallbackHandler,
 ) {
 	api := engine.Group("/admin-api")
 	{
@@ -59,249 +63,290 @@ func RegisterSystemRoutes(engine *gin.Engine,
 				authGroup.GET("/get-permission-info", authHandler.GetPermissionInfo)
 			}
 
+			// Captcha
+			captchaGroup := systemGroup.Group("/captcha")
+			{
+				captchaGroup.POST("/get", captchaHandler.GetCaptcha)
+				captchaGroup.POST("/check", captchaHandler.CheckCaptcha)
+			}
+
 			// Tenant
 			tenantGroup := systemGroup.Group("/tenant")
+			tenantGroup.Use(middleware.TenantIgnore()) // 租户管理跨租户操作
 			{
 				tenantGroup.GET("/simple-list", tenantHandler.GetTenantSimpleList)
 				tenantGroup.GET("/get-by-website", tenantHandler.GetTenantByWebsite)
 				tenantGroup.GET("/get-id-by-name", tenantHandler.GetTenantIdByName)
-				tenantGroup.POST("/create", tenantHandler.CreateTenant)
-				tenantGroup.PUT("/update", tenantHandler.UpdateTenant)
-				tenantGroup.DELETE("/delete", tenantHandler.DeleteTenant)
-				tenantGroup.GET("/get", tenantHandler.GetTenant)
-				tenantGroup.GET("/page", tenantHandler.GetTenantPage)
-				tenantGroup.GET("/export-excel", tenantHandler.ExportTenantExcel)
+				// 需要认证的接口
+				tenantGroup.Use(middleware.Auth())
+				tenantGroup.POST("/create", middleware.HasPermission(perm, "system:tenant:create"), oplog.Log("SYSTEM 租户", "创建租户", middleware.OperateTypeCreate, middleware.BizIDFromResult()), tenantHandler.CreateTenant)
+				tenantGroup.PUT("/update", middleware.HasPermission(perm, "system:tenant:update"), oplog.Log("SYSTEM 租户", "更新租户", middleware.OperateTypeUpdate, middleware.BizIDFromBody("id")), tenantHandler.UpdateTenant)
+				tenantGroup.DELETE("/delete", middleware.HasPermission(perm, "system:tenant:delete"), oplog.Log("SYSTEM 租户", "删除租户", middleware.OperateTypeDelete, middleware.BizIDFromQuery("id")), tenantHandler.DeleteTenant)
+				tenantGroup.GET("/get", middleware.HasPermission(perm, "system:tenant:query"), tenantHandler.GetTenant)
+				tenantGroup.GET("/page", middleware.HasPermission(perm, "system:tenant:query"), tenantHandler.GetTenantPage)
+				tenantGroup.GET("/export-excel", middleware.HasPermission(perm, "system:tenant:export"), tenantHandler.ExportTenantExcel)
 			}
 
 			// Dict Type
 			dictTypeGroup := systemGroup.Group("/dict-type")
+			dictTypeGroup.Use(middleware.Auth())
 			{
 				dictTypeGroup.GET("/simple-list", dictHandler.GetSimpleDictTypeList)
-				dictTypeGroup.GET("/page", dictHandler.GetDictTypePage)
-				dictTypeGroup.GET("/get", dictHandler.GetDictType)
-				dictTypeGroup.POST("/create", dictHandler.CreateDictType)
-				dictTypeGroup.PUT("/update", dictHandler.UpdateDictType)
-				dictTypeGroup.DELETE("/delete", dictHandler.DeleteDictType)
-				dictTypeGroup.GET("/export-excel", dictHandler.ExportDictTypeExcel)
+				dictTypeGroup.GET("/page", middleware.HasPermission(perm, "system:dict:query"), dictHandler.GetDictTypePage)
+				dictTypeGroup.GET("/get", middleware.HasPermission(perm, "system:dict:query"), dictHandler.GetDictType)
+				dictTypeGroup.POST("/create", middleware.HasPermission(perm, "system:dict:create"), oplog.Log("SYSTEM 字典类型", "创建字典类型", middleware.OperateTypeCreate, middleware.BizIDFromResult()), dictHandler.CreateDictType)
+				dictTypeGroup.PUT("/update", middleware.HasPermission(perm, "system:dict:update"), oplog.Log("SYSTEM 字典类型", "更新字典类型", middleware.OperateTypeUpdate, middleware.BizIDFromBody("id")), dictHandler.UpdateDictType)
+				dictTypeGroup.DELETE("/delete", middleware.HasPermission(perm, "system:dict:delete"), oplog.Log("SYSTEM 字典类型", "删除字典类型", middleware.OperateTypeDelete, middleware.BizIDFromQuery("id")), dictHandler.DeleteDictType)
+				dictTypeGroup.GET("/export-excel", middleware.HasPermission(perm, "system:dict:export"), dictHandler.ExportDictTypeExcel)
 			}
 
 			// Dict Data
 			dictDataGroup := systemGroup.Group("/dict-data")
+			dictDataGroup.Use(middleware.Auth())
 			{
 				dictDataGroup.GET("/simple-list", dictHandler.GetSimpleDictDataList)
 				dictDataGroup.GET("/list-all-simple", dictHandler.GetSimpleDictDataList)
-				dictDataGroup.GET("/page", dictHandler.GetDictDataPage)
-				dictDataGroup.GET("/get", dictHandler.GetDictData)
-				dictDataGroup.POST("/create", dictHandler.CreateDictData)
-				dictDataGroup.PUT("/update", dictHandler.UpdateDictData)
-				dictDataGroup.DELETE("/delete", dictHandler.DeleteDictData)
+				dictDataGroup.GET("/page", middleware.HasPermission(perm, "system:dict:query"), dictHandler.GetDictDataPage)
+				dictDataGroup.GET("/get", middleware.HasPermission(perm, "system:dict:query"), dictHandler.GetDictData)
+				dictDataGroup.POST("/create", middleware.HasPermission(perm, "system:dict:create"), oplog.Log("SYSTEM 字典数据", "创建字典数据", middleware.OperateTypeCreate, middleware.BizIDFromResult()), dictHandler.CreateDictData)
+				dictDataGroup.PUT("/update", middleware.HasPermission(perm, "system:dict:update"), oplog.Log("SYSTEM 字典数据", "更新字典数据", middleware.OperateTypeUpdate, middleware.BizIDFromBody("id")), dictHandler.UpdateDictData)
+				dictDataGroup.DELETE("/delete", middleware.HasPermission(perm, "system:dict:delete"), oplog.Log("SYSTEM 字典数据", "删除字典数据", middleware.OperateTypeDelete, middleware.BizIDFromQuery("id")), dictHandler.DeleteDictData)
 			}
 
 			// Dept
 			deptGroup := systemGroup.Group("/dept")
+			deptGroup.Use(middleware.Auth())
 			{
-				deptGroup.GET("/list", deptHandler.GetDeptList)
+				deptGroup.GET("/list", middleware.HasPermission(perm, "system:dept:query"), deptHandler.GetDeptList)
 				deptGroup.GET("/list-all-simple", deptHandler.GetSimpleDeptList)
 				deptGroup.GET("/simple-list", deptHandler.GetSimpleDeptList)
-				deptGroup.GET("/get", deptHandler.GetDept)
-				deptGroup.POST("/create", deptHandler.CreateDept)
-				deptGroup.PUT("/update", deptHandler.UpdateDept)
-				deptGroup.DELETE("/delete", deptHandler.DeleteDept)
+				deptGroup.GET("/get", middleware.HasPermission(perm, "system:dept:query"), deptHandler.GetDept)
+				deptGroup.POST("/create", middleware.HasPermission(perm, "system:dept:create"), oplog.Log("SYSTEM 部门", "创建部门", middleware.OperateTypeCreate, middleware.BizIDFromResult()), deptHandler.CreateDept)
+				deptGroup.PUT("/update", middleware.HasPermission(perm, "system:dept:update"), oplog.Log("SYSTEM 部门", "更新部门", middleware.OperateTypeUpdate, middleware.BizIDFromBody("id")), deptHandler.UpdateDept)
+				deptGroup.DELETE("/delete", middleware.HasPermission(perm, "system:dept:delete"), oplog.Log("SYSTEM 部门", "删除部门", middleware.OperateTypeDelete, middleware.BizIDFromQuery("id")), deptHandler.DeleteDept)
 			}
 
 			// Post
 			postGroup := systemGroup.Group("/post")
+			postGroup.Use(middleware.Auth())
 			{
-				postGroup.GET("/page", postHandler.GetPostPage)
+				postGroup.GET("/page", middleware.HasPermission(perm, "system:post:query"), postHandler.GetPostPage)
 				postGroup.GET("/simple-list", postHandler.GetSimplePostList)
-				postGroup.GET("/get", postHandler.GetPost)
-				postGroup.POST("/create", postHandler.CreatePost)
-				postGroup.PUT("/update", postHandler.UpdatePost)
-				postGroup.DELETE("/delete", postHandler.DeletePost)
+				postGroup.GET("/get", middleware.HasPermission(perm, "system:post:query"), postHandler.GetPost)
+				postGroup.POST("/create", middleware.HasPermission(perm, "system:post:create"), oplog.Log("SYSTEM 岗位", "创建岗位", middleware.OperateTypeCreate, middleware.BizIDFromResult()), postHandler.CreatePost)
+				postGroup.PUT("/update", middleware.HasPermission(perm, "system:post:update"), oplog.Log("SYSTEM 岗位", "更新岗位", middleware.OperateTypeUpdate, middleware.BizIDFromBody("id")), postHandler.UpdatePost)
+				postGroup.DELETE("/delete", middleware.HasPermission(perm, "system:post:delete"), oplog.Log("SYSTEM 岗位", "删除岗位", middleware.OperateTypeDelete, middleware.BizIDFromQuery("id")), postHandler.DeletePost)
 			}
 
 			// User
 			userGroup := systemGroup.Group("/user")
+			userGroup.Use(middleware.Auth())
 			{
-				userGroup.GET("/page", userHandler.GetUserPage)
+				userGroup.GET("/page", middleware.HasPermission(perm, "system:user:query"), userHandler.GetUserPage)
 				userGroup.GET("/list-all-simple", userHandler.GetSimpleUserList)
 				userGroup.GET("/simple-list", userHandler.GetSimpleUserList)
-				userGroup.GET("/get", userHandler.GetUser)
-				userGroup.POST("/create", userHandler.CreateUser)
-				userGroup.PUT("/update", userHandler.UpdateUser)
-				userGroup.DELETE("/delete", userHandler.DeleteUser)
-				userGroup.PUT("/update-status", userHandler.UpdateUserStatus)
-				userGroup.PUT("/update-password", userHandler.UpdateUserPassword)
-				userGroup.GET("/export", userHandler.ExportUser)
+				userGroup.GET("/get", middleware.HasPermission(perm, "system:user:query"), userHandler.GetUser)
+				userGroup.POST("/create", middleware.HasPermission(perm, "system:user:create"), oplog.Log("SYSTEM 用户", "创建用户", middleware.OperateTypeCreate, middleware.BizIDFromResult()), userHandler.CreateUser)
+				userGroup.PUT("/update", middleware.HasPermission(perm, "system:user:update"), oplog.Log("SYSTEM 用户", "更新用户", middleware.OperateTypeUpdate, middleware.BizIDFromBody("id")), userHandler.UpdateUser)
+				userGroup.DELETE("/delete", middleware.HasPermission(perm, "system:user:delete"), oplog.Log("SYSTEM 用户", "删除用户", middleware.OperateTypeDelete, middleware.BizIDFromQuery("id")), userHandler.DeleteUser)
+				userGroup.PUT("/update-status", middleware.HasPermission(perm, "system:user:update"), oplog.Log("SYSTEM 用户", "更新用户状态", middleware.OperateTypeUpdate, middleware.BizIDFromBody("id")), userHandler.UpdateUserStatus)
+				userGroup.PUT("/update-password", middleware.HasPermission(perm, "system:user:update-password"), oplog.Log("SYSTEM 用户", "重置用户密码", middleware.OperateTypeUpdate, middleware.BizIDFromBody("id")), userHandler.UpdateUserPassword)
+				userGroup.GET("/export", middleware.HasPermission(perm, "system:user:export"), userHandler.ExportUser)
 				userGroup.GET("/get-import-template", userHandler.GetImportTemplate)
-				userGroup.POST("/import", userHandler.ImportUser)
+				userGroup.POST("/import", middleware.HasPermission(perm, "system:user:import"), userHandler.ImportUser)
 			}
 
 			// Role
 			roleGroup := systemGroup.Group("/role")
+			roleGroup.Use(middleware.Auth())
 			{
-				roleGroup.GET("/page", roleHandler.GetRolePage)
+				roleGroup.GET("/page", middleware.HasPermission(perm, "system:role:query"), roleHandler.GetRolePage)
 				roleGroup.GET("/list-all-simple", roleHandler.GetSimpleRoleList)
 				roleGroup.GET("/simple-list", roleHandler.GetSimpleRoleList)
-				roleGroup.GET("/get", roleHandler.GetRole)
-				roleGroup.POST("/create", roleHandler.CreateRole)
-				roleGroup.PUT("/update", roleHandler.UpdateRole)
-				roleGroup.PUT("/update-status", roleHandler.UpdateRoleStatus)
-				roleGroup.DELETE("/delete", roleHandler.DeleteRole)
+				roleGroup.GET("/get", middleware.HasPermission(perm, "system:role:query"), roleHandler.GetRole)
+				roleGroup.POST("/create", middleware.HasPermission(perm, "system:role:create"), oplog.Log("SYSTEM 角色", "创建角色", middleware.OperateTypeCreate, middleware.BizIDFromResult()), roleHandler.CreateRole)
+				roleGroup.PUT("/update", middleware.HasPermission(perm, "system:role:update"), oplog.Log("SYSTEM 角色", "更新角色", middleware.OperateTypeUpdate, middleware.BizIDFromBody("id")), roleHandler.UpdateRole)
+				roleGroup.PUT("/update-status", middleware.HasPermission(perm, "system:role:update"), oplog.Log("SYSTEM 角色", "更新角色状态", middleware.OperateTypeUpdate, middleware.BizIDFromBody("id")), roleHandler.UpdateRoleStatus)
+				roleGroup.DELETE("/delete", middleware.HasPermission(perm, "system:role:delete"), oplog.Log("SYSTEM 角色", "删除角色", middleware.OperateTypeDelete, middleware.BizIDFromQuery("id")), roleHandler.DeleteRole)
 			}
 
 			// Permission
 			permGroup := systemGroup.Group("/permission")
+			permGroup.Use(middleware.Auth())
 			{
-				permGroup.GET("/list-role-menus", permissionHandler.GetRoleMenuList)
-				permGroup.POST("/assign-role-menu", permissionHandler.AssignRoleMenu)
-				permGroup.POST("/assign-role-data-scope", permissionHandler.AssignRoleDataScope)
-				permGroup.GET("/list-user-roles", permissionHandler.GetUserRoleList)
-				permGroup.POST("/assign-user-role", permissionHandler.AssignUserRole)
+				permGroup.GET("/list-role-menus", middleware.HasPermission(perm, "system:permission:assign-role-menu"), permissionHandler.GetRoleMenuList)
+				permGroup.POST("/assign-role-menu", middleware.HasPermission(perm, "system:permission:assign-role-menu"), oplog.Log("SYSTEM 角色", "分配角色菜单", middleware.OperateTypeUpdate, middleware.BizIDFromBody("roleId")), permissionHandler.AssignRoleMenu)
+				permGroup.POST("/assign-role-data-scope", middleware.HasPermission(perm, "system:permission:assign-role-data-scope"), oplog.Log("SYSTEM 角色", "分配角色数据权限", middleware.OperateTypeUpdate, middleware.BizIDFromBody("roleId")), permissionHandler.AssignRoleDataScope)
+				permGroup.GET("/list-user-roles", middleware.HasPermission(perm, "system:permission:assign-user-role"), permissionHandler.GetUserRoleList)
+				permGroup.POST("/assign-user-role", middleware.HasPermission(perm, "system:permission:assign-user-role"), oplog.Log("SYSTEM 用户", "分配用户角色", middleware.OperateTypeUpdate, middleware.BizIDFromBody("userId")), permissionHandler.AssignUserRole)
 			}
 
 			// Menu
 			menuGroup := systemGroup.Group("/menu")
+			menuGroup.Use(middleware.Auth())
 			{
-				menuGroup.POST("/create", menuHandler.CreateMenu)
-				menuGroup.PUT("/update", menuHandler.UpdateMenu)
-				menuGroup.DELETE("/delete", menuHandler.DeleteMenu)
-				menuGroup.GET("/list", menuHandler.GetMenuList)
-				menuGroup.GET("/get", menuHandler.GetMenu)
+				menuGroup.POST("/create", middleware.HasPermission(perm, "system:menu:create"), oplog.Log("SYSTEM 菜单", "创建菜单", middleware.OperateTypeCreate, middleware.BizIDFromResult()), menuHandler.CreateMenu)
+				menuGroup.PUT("/update", middleware.HasPermission(perm, "system:menu:update"), oplog.Log("SYSTEM 菜单", "更新菜单", middleware.OperateTypeUpdate, middleware.BizIDFromBody("id")), menuHandler.UpdateMenu)
+				menuGroup.DELETE("/delete", middleware.HasPermission(perm, "system:menu:delete"), oplog.Log("SYSTEM 菜单", "删除菜单", middleware.OperateTypeDelete, middleware.BizIDFromQuery("id")), menuHandler.DeleteMenu)
+				menuGroup.GET("/list", middleware.HasPermission(perm, "system:menu:query"), menuHandler.GetMenuList)
+				menuGroup.GET("/get", middleware.HasPermission(perm, "system:menu:query"), menuHandler.GetMenu)
 				menuGroup.GET("/simple-list", menuHandler.GetSimpleMenuList)
 			}
 
 			// Notice
 			noticeGroup := systemGroup.Group("/notice")
+			noticeGroup.Use(middleware.Auth())
 			{
-				noticeGroup.GET("/page", noticeHandler.GetNoticePage)
-				noticeGroup.GET("/get", noticeHandler.GetNotice)
-				noticeGroup.POST("/create", noticeHandler.CreateNotice)
-				noticeGroup.PUT("/update", noticeHandler.UpdateNotice)
-				noticeGroup.DELETE("/delete", noticeHandler.DeleteNotice)
-				noticeGroup.POST("/push", noticeHandler.Push)
+				noticeGroup.GET("/page", middleware.HasPermission(perm, "system:notice:query"), noticeHandler.GetNoticePage)
+				noticeGroup.GET("/get", middleware.HasPermission(perm, "system:notice:query"), noticeHandler.GetNotice)
+				noticeGroup.POST("/create", middleware.HasPermission(perm, "system:notice:create"), noticeHandler.CreateNotice)
+				noticeGroup.PUT("/update", middleware.HasPermission(perm, "system:notice:update"), noticeHandler.UpdateNotice)
+				noticeGroup.DELETE("/delete", middleware.HasPermission(perm, "system:notice:delete"), noticeHandler.DeleteNotice)
+				noticeGroup.POST("/push", middleware.HasPermission(perm, "system:notice:update"), noticeHandler.Push)
 			}
 
 			// Login Log
 			loginLogGroup := systemGroup.Group("/login-log")
+			loginLogGroup.Use(middleware.Auth())
 			{
-				loginLogGroup.GET("/page", loginLogHandler.GetLoginLogPage)
+				loginLogGroup.GET("/page", middleware.HasPermission(perm, "system:login-log:query"), loginLogHandler.GetLoginLogPage)
 			}
 
 			// Operate Log
 			operateLogGroup := systemGroup.Group("/operate-log")
+			operateLogGroup.Use(middleware.Auth())
 			{
-				operateLogGroup.GET("/page", operateLogHandler.GetOperateLogPage)
+				operateLogGroup.GET("/page", middleware.HasPermission(perm, "system:operate-log:query"), operateLogHandler.GetOperateLogPage)
 			}
 
 			// Sensitive Word
 			sensitiveWordGroup := systemGroup.Group("/sensitive-word")
+			sensitiveWordGroup.Use(middleware.Auth())
 			{
-				sensitiveWordGroup.POST("/create", sensitiveWordHandler.CreateSensitiveWord)
-				sensitiveWordGroup.PUT("/update", sensitiveWordHandler.UpdateSensitiveWord)
-				sensitiveWordGroup.DELETE("/delete", sensitiveWordHandler.DeleteSensitiveWord)
-				sensitiveWordGroup.GET("/get", sensitiveWordHandler.GetSensitiveWord)
-				sensitiveWordGroup.GET("/page", sensitiveWordHandler.GetSensitiveWordPage)
-				sensitiveWordGroup.GET("/validate-text", sensitiveWordHandler.ValidateSensitiveWord)
-				sensitiveWordGroup.GET("/export-excel", sensitiveWordHandler.ExportSensitiveWord)
+				sensitiveWordGroup.POST("/create", middleware.HasPermission(perm, "system:sensitive-word:create"), sensitiveWordHandler.CreateSensitiveWord)
+				sensitiveWordGroup.PUT("/update", middleware.HasPermission(perm, "system:sensitive-word:update"), sensitiveWordHandler.UpdateSensitiveWord)
+				sensitiveWordGroup.DELETE("/delete", middleware.HasPermission(perm, "system:sensitive-word:delete"), sensitiveWordHandler.DeleteSensitiveWord)
+				sensitiveWordGroup.GET("/get", middleware.HasPermission(perm, "system:sensitive-word:query"), sensitiveWordHandler.GetSensitiveWord)
+				sensitiveWordGroup.GET("/page", middleware.HasPermission(perm, "system:sensitive-word:query"), sensitiveWordHandler.GetSensitiveWordPage)
+				sensitiveWordGroup.GET("/validate-text", middleware.HasPermission(perm, "system:sensitive-word:query"), sensitiveWordHandler.ValidateSensitiveWord)
+				sensitiveWordGroup.GET("/export-excel", middleware.HasPermission(perm, "system:sensitive-word:export"), sensitiveWordHandler.ExportSensitiveWord)
 			}
 
 			// Mail Account
 			mailAccountGroup := systemGroup.Group("/mail/account")
+			mailAccountGroup.Use(middleware.Auth())
 			{
-				mailAccountGroup.POST("/create", mailHandler.CreateMailAccount)
-				mailAccountGroup.PUT("/update", mailHandler.UpdateMailAccount)
-				mailAccountGroup.DELETE("/delete", mailHandler.DeleteMailAccount)
-				mailAccountGroup.GET("/get", mailHandler.GetMailAccount)
-				mailAccountGroup.GET("/page", mailHandler.GetMailAccountPage)
+				mailAccountGroup.POST("/create", middleware.HasPermission(perm, "system:mail-account:create"), mailHandler.CreateMailAccount)
+				mailAccountGroup.PUT("/update", middleware.HasPermission(perm, "system:mail-account:update"), mailHandler.UpdateMailAccount)
+				mailAccountGroup.DELETE("/delete", middleware.HasPermission(perm, "system:mail-account:delete"), mailHandler.DeleteMailAccount)
+				mailAccountGroup.GET("/get", middleware.HasPermission(perm, "system:mail-account:query"), mailHandler.GetMailAccount)
+				mailAccountGroup.GET("/page", middleware.HasPermission(perm, "system:mail-account:query"), mailHandler.GetMailAccountPage)
 				mailAccountGroup.GET("/list-all-simple", mailHandler.GetSimpleMailAccountList)
 			}
 
 			// Mail Template
 			mailTemplateGroup := systemGroup.Group("/mail/template")
+			mailTemplateGroup.Use(middleware.Auth())
 			{
-				mailTemplateGroup.POST("/create", mailHandler.CreateMailTemplate)
-				mailTemplateGroup.PUT("/update", mailHandler.UpdateMailTemplate)
-				mailTemplateGroup.DELETE("/delete", mailHandler.DeleteMailTemplate)
-				mailTemplateGroup.GET("/get", mailHandler.GetMailTemplate)
-				mailTemplateGroup.GET("/page", mailHandler.GetMailTemplatePage)
-				mailTemplateGroup.POST("/send-mail", mailHandler.SendMail) // Logic for testing send
+				mailTemplateGroup.POST("/create", middleware.HasPermission(perm, "system:mail-template:create"), mailHandler.CreateMailTemplate)
+				mailTemplateGroup.PUT("/update", middleware.HasPermission(perm, "system:mail-template:update"), mailHandler.UpdateMailTemplate)
+				mailTemplateGroup.DELETE("/delete", middleware.HasPermission(perm, "system:mail-template:delete"), mailHandler.DeleteMailTemplate)
+				mailTemplateGroup.GET("/get", middleware.HasPermission(perm, "system:mail-template:query"), mailHandler.GetMailTemplate)
+				mailTemplateGroup.GET("/page", middleware.HasPermission(perm, "system:mail-template:query"), mailHandler.GetMailTemplatePage)
+				mailTemplateGroup.POST("/send-mail", middleware.HasPermission(perm, "system:mail-template:send-mail"), mailHandler.SendMail) // Logic for testing send
 			}
 
 			// Mail Log
 			mailLogGroup := systemGroup.Group("/mail/log")
+			mailLogGroup.Use(middleware.Auth())
 			{
-				mailLogGroup.GET("/page", mailHandler.GetMailLogPage)
+				mailLogGroup.GET("/page", middleware.HasPermission(perm, "system:mail-log:query"), mailHandler.GetMailLogPage)
 			}
 
 			// Notify Template
 			notifyTemplateGroup := systemGroup.Group("/notify-template")
+			notifyTemplateGroup.Use(middleware.Auth())
 			{
-				notifyTemplateGroup.POST("/create", notifyHandler.CreateNotifyTemplate)
-				notifyTemplateGroup.PUT("/update", notifyHandler.UpdateNotifyTemplate)
-				notifyTemplateGroup.DELETE("/delete", notifyHandler.DeleteNotifyTemplate)
-				notifyTemplateGroup.GET("/get", notifyHandler.GetNotifyTemplate)
-				notifyTemplateGroup.GET("/page", notifyHandler.GetNotifyTemplatePage)
-				notifyTemplateGroup.POST("/send-notify", notifyHandler.SendNotify)
+				notifyTemplateGroup.POST("/create", middleware.HasPermission(perm, "system:notify-template:create"), notifyHandler.CreateNotifyTemplate)
+				notifyTemplateGroup.PUT("/update", middleware.HasPermission(perm, "system:notify-template:update"), notifyHandler.UpdateNotifyTemplate)
+				notifyTemplateGroup.DELETE("/delete", middleware.HasPermission(perm, "system:notify-template:delete"), notifyHandler.DeleteNotifyTemplate)
+				notifyTemplateGroup.GET("/get", middleware.HasPermission(perm, "system:notify-template:query"), notifyHandler.GetNotifyTemplate)
+				notifyTemplateGroup.GET("/page", middleware.HasPermission(perm, "system:notify-template:query"), notifyHandler.GetNotifyTemplatePage)
+				notifyTemplateGroup.POST("/send-notify", middleware.HasPermission(perm, "system:notify-template:send-notify"), notifyHandler.SendNotify)
 			}
 
 			// Notify Message
 			notifyMessageGroup := systemGroup.Group("/notify-message")
+			notifyMessageGroup.Use(middleware.Auth())
 			{
 				notifyMessageGroup.GET("/get-unread-count", notifyHandler.GetUnreadNotifyMessageCount)
 				notifyMessageGroup.GET("/my-page", notifyHandler.GetMyNotifyMessagePage)
-				notifyMessageGroup.GET("/page", notifyHandler.GetNotifyMessagePage)
+				notifyMessageGroup.GET("/page", middleware.HasPermission(perm, "system:notify-message:query"), notifyHandler.GetNotifyMessagePage)
 				notifyMessageGroup.PUT("/update-read", notifyHandler.UpdateNotifyMessageRead)
 				notifyMessageGroup.PUT("/update-all-read", notifyHandler.UpdateAllNotifyMessageRead)
 			}
 
 			// OAuth2 Client
 			oauth2ClientGroup := systemGroup.Group("/oauth2-client")
+			oauth2ClientGroup.Use(middleware.Auth())
 			{
-				oauth2ClientGroup.POST("/create", oauth2ClientHandler.CreateOAuth2Client)
-				oauth2ClientGroup.PUT("/update", oauth2ClientHandler.UpdateOAuth2Client)
-				oauth2ClientGroup.DELETE("/delete", oauth2ClientHandler.DeleteOAuth2Client)
-				oauth2ClientGroup.GET("/get", oauth2ClientHandler.GetOAuth2Client)
-				oauth2ClientGroup.GET("/page", oauth2ClientHandler.GetOAuth2ClientPage)
+				oauth2ClientGroup.POST("/create", middleware.HasPermission(perm, "system:oauth2-client:create"), oauth2ClientHandler.CreateOAuth2Client)
+				oauth2ClientGroup.PUT("/update", middleware.HasPermission(perm, "system:oauth2-client:update"), oauth2ClientHandler.UpdateOAuth2Client)
+				oauth2ClientGroup.DELETE("/delete", middleware.HasPermission(perm, "system:oauth2-client:delete"), oauth2ClientHandler.DeleteOAuth2Client)
+				oauth2ClientGroup.GET("/get", middleware.HasPermission(perm, "system:oauth2-client:query"), oauth2ClientHandler.GetOAuth2Client)
+				oauth2ClientGroup.GET("/page", middleware.HasPermission(perm, "system:oauth2-client:query"), oauth2ClientHandler.GetOAuth2ClientPage)
 			}
 		}
 
 		// Sms Channel
 		smsChannelGroup := api.Group("/system/sms-channel")
+		smsChannelGroup.Use(middleware.Auth())
 		{
-			smsChannelGroup.POST("/create", smsChannelHandler.CreateSmsChannel)
-			smsChannelGroup.PUT("/update", smsChannelHandler.UpdateSmsChannel)
-			smsChannelGroup.DELETE("/delete", smsChannelHandler.DeleteSmsChannel)
-			smsChannelGroup.GET("/get", smsChannelHandler.GetSmsChannel)
-			smsChannelGroup.GET("/page", smsChannelHandler.GetSmsChannelPage)
+			smsChannelGroup.POST("/create", middleware.HasPermission(perm, "system:sms-channel:create"), smsChannelHandler.CreateSmsChannel)
+			smsChannelGroup.PUT("/update", middleware.HasPermission(perm, "system:sms-channel:update"), smsChannelHandler.UpdateSmsChannel)
+			smsChannelGroup.DELETE("/delete", middleware.HasPermission(perm, "system:sms-channel:delete"), smsChannelHandler.DeleteSmsChannel)
+			smsChannelGroup.GET("/get", middleware.HasPermission(perm, "system:sms-channel:query"), smsChannelHandler.GetSmsChannel)
+			smsChannelGroup.GET("/page", middleware.HasPermission(perm, "system:sms-channel:query"), smsChannelHandler.GetSmsChannelPage)
 			smsChannelGroup.GET("/simple-list", smsChannelHandler.GetSimpleSmsChannelList)
 		}
 
 		// Sms Template
 		smsTemplateGroup := api.Group("/system/sms-template")
+		smsTemplateGroup.Use(middleware.Auth())
 		{
-			smsTemplateGroup.POST("/create", smsTemplateHandler.CreateSmsTemplate)
-			smsTemplateGroup.PUT("/update", smsTemplateHandler.UpdateSmsTemplate)
-			smsTemplateGroup.DELETE("/delete", smsTemplateHandler.DeleteSmsTemplate)
-			smsTemplateGroup.GET("/get", smsTemplateHandler.GetSmsTemplate)
-			smsTemplateGroup.GET("/page", smsTemplateHandler.GetSmsTemplatePage)
+			smsTemplateGroup.POST("/create", middleware.HasPermission(perm, "system:sms-template:create"), smsTemplateHandler.CreateSmsTemplate)
+			smsTemplateGroup.PUT("/update", middleware.HasPermission(perm, "system:sms-template:update"), smsTemplateHandler.UpdateSmsTemplate)
+			smsTemplateGroup.DELETE("/delete", middleware.HasPermission(perm, "system:sms-template:delete"), smsTemplateHandler.DeleteSmsTemplate)
+			smsTemplateGroup.GET("/get", middleware.HasPermission(perm, "system:sms-template:query"), smsTemplateHandler.GetSmsTemplate)
+			smsTemplateGroup.GET("/page", middleware.HasPermission(perm, "system:sms-template:query"), smsTemplateHandler.GetSmsTemplatePage)
+			smsTemplateGroup.GET("/get-audit-status", middleware.HasPermission(perm, "system:sms-template:query"), smsTemplateHandler.GetSmsTemplateAuditStatus)
+		}
+
+		// Sms Callback 短信渠道回调，无需登录
+		smsCallbackGroup := api.Group("/system/sms/callback")
+		smsCallbackGroup.Use(middleware.TenantIgnore())
+		{
+			smsCallbackGroup.POST("/aliyun", smsCallbackHandler.ReceiveAliyunSmsStatus)
+			smsCallbackGroup.POST("/tencent", smsCallbackHandler.ReceiveTencentSmsStatus)
 		}
 
 		// Sms Log
 		smsLogGroup := api.Group("/system/sms-log")
+		smsLogGroup.Use(middleware.Auth())
 		{
-			smsLogGroup.GET("/page", smsLogHandler.GetSmsLogPage)
+			smsLogGroup.GET("/page", middleware.HasPermission(perm, "system:sms-log:query"), smsLogHandler.GetSmsLogPage)
 		}
 
 		// Config
 		configGroup := api.Group("/infra/config")
+		configGroup.Use(middleware.Auth())
 		{
-			configGroup.GET("/page", configHandler.GetConfigPage)
-			configGroup.GET("/get", configHandler.GetConfig)
-			configGroup.GET("/get-value-by-key", configHandler.GetConfigKey)
-			configGroup.POST("/create", configHandler.CreateConfig)
-			configGroup.PUT("/update", configHandler.UpdateConfig)
-			configGroup.DELETE("/delete", configHandler.DeleteConfig)
+			configGroup.GET("/page", middleware.HasPermission(perm, "infra:config:query"), configHandler.GetConfigPage)
+			configGroup.GET("/get", middleware.HasPermission(perm, "infra:config:query"), configHandler.GetConfig)
+			configGroup.GET("/get-value-by-key", middleware.HasPermission(perm, "infra:config:query"), configHandler.GetConfigKey)
+			configGroup.POST("/create", middleware.HasPermission(perm, "infra:config:create"), configHandler.CreateConfig)
+			configGroup.PUT("/update", middleware.HasPermission(perm, "infra:config:update"), configHandler.UpdateConfig)
+			configGroup.DELETE("/delete", middleware.HasPermission(perm, "infra:config:delete"), configHandler.DeleteConfig)
 		}
 
 		// Infra
@@ -309,73 +354,85 @@ func RegisterSystemRoutes(engine *gin.Engine,
 		{
 			// File Config
 			fileConfigGroup := infraGroup.Group("/file-config")
+			fileConfigGroup.Use(middleware.Auth())
 			{
-				fileConfigGroup.POST("/create", fileConfigHandler.CreateFileConfig)
-				fileConfigGroup.PUT("/update", fileConfigHandler.UpdateFileConfig)
-				fileConfigGroup.PUT("/update-master", fileConfigHandler.UpdateFileConfigMaster)
-				fileConfigGroup.DELETE("/delete", fileConfigHandler.DeleteFileConfig)
-				fileConfigGroup.GET("/page", fileConfigHandler.GetFileConfigPage)
-				fileConfigGroup.GET("/get", fileConfigHandler.GetFileConfig)
+				fileConfigGroup.POST("/create", middleware.HasPermission(perm, "infra:file-config:create"), fileConfigHandler.CreateFileConfig)
+				fileConfigGroup.PUT("/update", middleware.HasPermission(perm, "infra:file-config:update"), fileConfigHandler.UpdateFileConfig)
+				fileConfigGroup.PUT("/update-master", middleware.HasPermission(perm, "infra:file-config:update"), fileConfigHandler.UpdateFileConfigMaster)
+				fileConfigGroup.DELETE("/delete", middleware.HasPermission(perm, "infra:file-config:delete"), fileConfigHandler.DeleteFileConfig)
+				fileConfigGroup.GET("/page", middleware.HasPermission(perm, "infra:file-config:query"), fileConfigHandler.GetFileConfigPage)
+				fileConfigGroup.GET("/get", middleware.HasPermission(perm, "infra:file-config:query"), fileConfigHandler.GetFileConfig)
 			}
 
 			// File
 			fileGroup := infraGroup.Group("/file")
 			{
+				fileGroup.GET("/:configId/get/*path", fileHandler.GetFileContent)
+				// 需要认证的接口
+				fileGroup.Use(middleware.Auth())
 				fileGroup.POST("/upload", fileHandler.UploadFile)
-				fileGroup.DELETE("/delete", fileHandler.DeleteFile)
-				fileGroup.GET("/page", fileHandler.GetFilePage)
+				fileGroup.GET("/presigned-url", fileHandler.GetFilePresignedUrl)
+				fileGroup.POST("/create", fileHandler.CreateFile)
+				fileGroup.DELETE("/delete", middleware.HasPermission(perm, "infra:file:delete"), fileHandler.DeleteFile)
+				fileGroup.GET("/page", middleware.HasPermission(perm, "infra:file:query"), fileHandler.GetFilePage)
 			}
 
 			// Job
 			jobGroup := infraGroup.Group("/job")
+			jobGroup.Use(middleware.Auth())
 			{
-				jobGroup.POST("/create", jobHandler.CreateJob)
-				jobGroup.PUT("/update", jobHandler.UpdateJob)
-				jobGroup.PUT("/update-status", jobHandler.UpdateJobStatus)
-				jobGroup.DELETE("/delete", jobHandler.DeleteJob)
-				jobGroup.GET("/get", jobHandler.GetJob)
-				jobGroup.GET("/page", jobHandler.GetJobPage)
-				jobGroup.PUT("/trigger", jobHandler.TriggerJob)
+				jobGroup.POST("/create", middleware.HasPermission(perm, "infra:job:create"), jobHandler.CreateJob)
+				jobGroup.PUT("/update", middleware.HasPermission(perm, "infra:job:update"), jobHandler.UpdateJob)
+				jobGroup.PUT("/update-status", middleware.HasPermission(perm, "infra:job:update"), jobHandler.UpdateJobStatus)
+				jobGroup.DELETE("/delete", middleware.HasPermission(perm, "infra:job:delete"), jobHandler.DeleteJob)
+				jobGroup.GET("/get", middleware.HasPermission(perm, "infra:job:query"), jobHandler.GetJob)
+				jobGroup.GET("/page", middleware.HasPermission(perm, "infra:job:query"), jobHandler.GetJobPage)
+				jobGroup.PUT("/trigger", middleware.HasPermission(perm, "infra:job:trigger"), jobHandler.TriggerJob)
 			}
 
 			// Job Log
 			jobLogGroup := infraGroup.Group("/job-log")
+			jobLogGroup.Use(middleware.Auth())
 			{
-				jobLogGroup.GET("/get", jobLogHandler.GetJobLog)
-				jobLogGroup.GET("/page", jobLogHandler.GetJobLogPage)
+				jobLogGroup.GET("/get", middleware.HasPermission(perm, "infra:job:query"), jobLogHandler.GetJobLog)
+				jobLogGroup.GET("/page", middleware.HasPermission(perm, "infra:job:query"), jobLogHandler.GetJobLogPage)
 			}
 
 			// API Access Log
 			apiAccessLogGroup := infraGroup.Group("/api-access-log")
+			apiAccessLogGroup.Use(middleware.Auth())
 			{
-				apiAccessLogGroup.GET("/page", apiAccessLogHandler.GetApiAccessLogPage)
+				apiAccessLogGroup.GET("/page", middleware.HasPermission(perm, "infra:api-access-log:query"), apiAccessLogHandler.GetApiAccessLogPage)
 			}
 
 			// API Error Log
 			apiErrorLogGroup := infraGroup.Group("/api-error-log")
+			apiErrorLogGroup.Use(middleware.Auth())
 			{
-				apiErrorLogGroup.GET("/page", apiErrorLogHandler.GetApiErrorLogPage)
-				apiErrorLogGroup.PUT("/update-status", apiErrorLogHandler.UpdateApiErrorLogProcess)
+				apiErrorLogGroup.GET("/page", middleware.HasPermission(perm, "infra:api-error-log:query"), apiErrorLogHandler.GetApiErrorLogPage)
+				apiErrorLogGroup.PUT("/update-status", middleware.HasPermission(perm, "infra:api-error-log:update-status"), apiErrorLogHandler.UpdateApiErrorLogProcess)
 			}
 
 			// Social Client
 			socialClientGroup := infraGroup.Group("/social-client")
+			socialClientGroup.Use(middleware.Auth())
 			{
-				socialClientGroup.POST("/create", socialClientHandler.CreateSocialClient)
-				socialClientGroup.PUT("/update", socialClientHandler.UpdateSocialClient)
-				socialClientGroup.DELETE("/delete", socialClientHandler.DeleteSocialClient)
-				socialClientGroup.GET("/get", socialClientHandler.GetSocialClient)
-				socialClientGroup.GET("/page", socialClientHandler.GetSocialClientPage)
+				socialClientGroup.POST("/create", middleware.HasPermission(perm, "system:social-client:create"), socialClientHandler.CreateSocialClient)
+				socialClientGroup.PUT("/update", middleware.HasPermission(perm, "system:social-client:update"), socialClientHandler.UpdateSocialClient)
+				socialClientGroup.DELETE("/delete", middleware.HasPermission(perm, "system:social-client:delete"), socialClientHandler.DeleteSocialClient)
+				socialClientGroup.GET("/get", middleware.HasPermission(perm, "system:social-client:query"), socialClientHandler.GetSocialClient)
+				socialClientGroup.GET("/page", middleware.HasPermission(perm, "system:social-client:query"), socialClientHandler.GetSocialClientPage)
 			}
 
 			// Social User
 			socialUserGroup := infraGroup.Group("/social-user")
+			socialUserGroup.Use(middleware.Auth())
 			{
 				socialUserGroup.POST("/bind", socialUserHandler.BindSocialUser)
 				socialUserGroup.POST("/unbind", socialUserHandler.UnbindSocialUser)
 				socialUserGroup.GET("/list", socialUserHandler.GetSocialUserList)
-				socialUserGroup.GET("/get", socialUserHandler.GetSocialUser)
-				socialUserGroup.GET("/page", socialUserHandler.GetSocialUserPage)
+				socialUserGroup.GET("/get", middleware.HasPermission(perm, "system:social-user:query"), socialUserHandler.GetSocialUser)
+				socialUserGroup.GET("/page", middleware.HasPermission(perm, "system:social-user:query"), socialUserHandler.GetSocialUserPage)
 			}
 		}
 	}
@@ -387,6 +444,7 @@ func RegisterAreaRoutes(engine *gin.Engine, areaHandler *handler.AreaHandler) {
 	{
 		// Area 地区
 		areaGroup := api.Group("/system/area")
+		areaGroup.Use(middleware.Auth())
 		{
 			areaGroup.GET("/tree", areaHandler.GetAreaTree)
 			areaGroup.GET("/get-by-ip", areaHandler.GetAreaByIP)
@@ -10,6 +10,8 @@ import (
 
 // RegisterTradeRoutes 注册交易订单模块路由
 func RegisterTradeRoutes(engine *gin.Engine,
+	perm middleware.PermissionChecker,
+	oplog *middleware.OperateLogRecorder,
 	tradeOrderHandler *tradeAdmin.TradeOrderHandler,
 	tradeAfterSaleHandler *tradeAdmin.TradeAfterSaleHandler,
 	deliveryExpressHandler *tradeAdmin.DeliveryExpressHandler,
@@ -24,29 +26,29 @@ func RegisterTradeRoutes(engine *gin.Engine,
 	tradeGroup := engine.Group("/admin-api/trade/order")
 	tradeGroup.Use(middleware.Auth())
 	{
-		tradeGroup.GET("/page", tradeOrderHandler.GetOrderPage)
-		tradeGroup.GET("/get-detail", tradeOrderHandler.GetOrderDetail)
-		tradeGroup.GET("/get-summary", tradeOrderHandler.GetOrderSummary)
-		tradeGroup.GET("/get-express-track-list", tradeOrderHandler.GetOrderExpressTrackList)
-		tradeGroup.GET("/get-by-pick-up-verify-code", tradeOrderHandler.GetByPickUpVerifyCode)
-		tradeGroup.PUT("/delivery", tradeOrderHandler.DeliveryOrder)
-		tradeGroup.PUT("/update-remark", tradeOrderHandler.UpdateOrderRemark)
-		tradeGroup.PUT("/update-price", tradeOrderHandler.UpdateOrderPrice)
-		tradeGroup.PUT("/update-address", tradeOrderHandler.UpdateOrderAddress)
-		tradeGroup.PUT("/pick-up-by-id", tradeOrderHandler.PickUpOrderById)
-		tradeGroup.PUT("/pick-up-by-verify-code", tradeOrderHandler.PickUpOrderByVerifyCode)
+		tradeGroup.GET("/page", middleware.HasPermission(perm, "trade:order:query"), tradeOrderHandler.GetOrderPage)
+		tradeGroup.GET("/get-detail", middleware.HasPermission(perm, "trade:order:query"), tradeOrderHandler.GetOrderDetail)
+		tradeGroup.GET("/get-summary", middleware.HasPermission(perm, "trade:order:query"), tradeOrderHandler.GetOrderSummary)
+		tradeGroup.GET("/get-express-track-list", middleware.HasPermission(perm, "trade:order:query"), tradeOrderHandler.GetOrderExpressTrackList)
+		tradeGroup.GET("/get-by-pick-up-verify-code", middleware.HasPermission(perm, "trade:order:pick-up"), tradeOrderHandler.GetByPickUpVerifyCode)
+		tradeGroup.PUT("/delivery", middleware.HasPermission(perm, "trade:order:update"), oplog.Log("TRADE 订单", "订单发货", middleware.OperateTypeUpdate, middleware.BizIDFromBody("id")), tradeOrderHandler.DeliveryOrder)
+		tradeGroup.PUT("/update-remark", middleware.HasPermission(perm, "trade:order:update"), oplog.Log("TRADE 订单", "修改订单备注", middleware.OperateTypeUpdate, middleware.BizIDFromBody("id")), tradeOrderHandler.UpdateOrderRemark)
+		tradeGroup.PUT("/update-price", middleware.HasPermission(perm, "trade:order:update"), oplog.Log("TRADE 订单", "修改订单价格", middleware.OperateTypeUpdate, middleware.BizIDFromBody("id")), tradeOrderHandler.UpdateOrderPrice)
+		tradeGroup.PUT("/update-address", middleware.HasPermission(perm, "trade:order:update"), oplog.Log("TRADE 订单", "修改订单收货地址", middleware.OperateTypeUpdate, middleware.BizIDFromBody("id")), tradeOrderHandler.UpdateOrderAddress)
+		tradeGroup.PUT("/pick-up-by-id", middleware.HasPermission(perm, "trade:order:pick-up"), oplog.Log("TRADE 订单", "订单核销", middleware.OperateTypeUpdate, middleware.BizIDFromQuery("id")), tradeOrderHandler.PickUpOrderById)
+		tradeGroup.PUT("/pick-up-by-verify-code", middleware.HasPermission(perm, "trade:order:pick-up"), oplog.Log("TRADE 订单", "订单核销", middleware.OperateTypeUpdate, nil), tradeOrderHandler.PickUpOrderByVerifyCode)
 	}
 
 	// Trade AfterSale
 	afterSaleGroup := engine.Group("/admin-api/trade/after-sale")
 	afterSaleGroup.Use(middleware.Auth())
 	{
-		afterSaleGroup.GET("/page", tradeAfterSaleHandler.GetAfterSalePage)
-		afterSaleGroup.GET("/get-detail", tradeAfterSaleHandler.GetAfterSaleDetail)
-		afterSaleGroup.PUT("/agree", tradeAfterSaleHandler.AgreeAfterSale)
-		afterSaleGroup.PUT("/disagree", tradeAfterSaleHandler.DisagreeAfterSale)
-		afterSaleGroup.PUT("/receive", tradeAfterSaleHandler.ReceiveAfterSale)
-		afterSaleGroup.PUT("/refund", tradeAfterSaleHandler.RefundAfterSale)
+		afterSaleGroup.GET("/page", middleware.HasPermission(perm, "trade:after-sale:query"), tradeAfterSaleHandler.GetAfterSalePage)
+		afterSaleGroup.GET("/get-detail", middleware.HasPermission(perm, "trade:after-sale:query"), tradeAfterSaleHandler.GetAfterSaleDetail)
+		afterSaleGroup.PUT("/agree", middleware.HasPermission(perm, "trade:after-sale:agree"), oplog.Log("TRADE 售后", "同意售后", middleware.OperateTypeUpdate, middleware.BizIDFromBody("id")), tradeAfterSaleHandler.AgreeAfterSale)
+		afterSaleGroup.PUT("/disagree", middleware.HasPermission(perm, "trade:after-sale:disagree"), oplog.Log("TRADE 售后", "拒绝售后", middleware.OperateTypeUpdate, middleware.BizIDFromBody("id")), tradeAfterSaleHandler.DisagreeAfterSale)
+		afterSaleGroup.PUT("/receive", middleware.HasPermission(perm, "trade:after-sale:receive"), oplog.Log("TRADE 售后", "确认收货", middleware.OperateTypeUpdate, middleware.BizIDFromQuery("id")), tradeAfterSaleHandler.ReceiveAfterSale)
+		afterSaleGroup.PUT("/refund", middleware.HasPermission(perm, "trade:after-sale:refund"), oplog.Log("TRADE 售后", "确认退款", middleware.OperateTypeUpdate, middleware.BizIDFromBody("id")), tradeAfterSaleHandler.RefundAfterSale)
 	}
 
 	// Delivery Routes
@@ -56,33 +58,33 @@ func RegisterTradeRoutes(engine *gin.Engine,
 		// Express
 		expressGroup := deliveryGroup.Group("/express")
 		{
-			expressGroup.POST("/create", deliveryExpressHandler.CreateDeliveryExpress)
-			expressGroup.PUT("/update", deliveryExpressHandler.UpdateDeliveryExpress)
-			expressGroup.DELETE("/delete", deliveryExpressHandler.DeleteDeliveryExpress)
-			expressGroup.GET("/get", deliveryExpressHandler.GetDeliveryExpress)
-			expressGroup.GET("/page", deliveryExpressHandler.GetDeliveryExpressPage)
+			expressGroup.POST("/create", middleware.HasPermission(perm, "trade:delivery:express:create"), oplog.Log("TRADE 快递公司", "创建快递公司", middleware.OperateTypeCreate, middleware.BizIDFromResult()), deliveryExpressHandler.CreateDeliveryExpress)
+			expressGroup.PUT("/update", middleware.HasPermission(perm, "trade:delivery:express:update"), oplog.Log("TRADE 快递公司", "更新快递公司", middleware.OperateTypeUpdate, middleware.BizIDFromBody("id")), deliveryExpressHandler.UpdateDeliveryExpress)
+			expressGroup.DELETE("/delete", middleware.HasPermission(perm, "trade:delivery:express:delete"), oplog.Log("TRADE 快递公司", "删除快递公司", middleware.OperateTypeDelete, middleware.BizIDFromQuery("id")), deliveryExpressHandler.DeleteDeliveryExpress)
+			expressGroup.GET("/get", middleware.HasPermission(perm, "trade:delivery:express:query"), deliveryExpressHandler.GetDeliveryExpress)
+			expressGroup.GET("/page", middleware.HasPermission(perm, "trade:delivery:express:query"), deliveryExpressHandler.GetDeliveryExpressPage)
 			expressGroup.GET("/list-all-simple", deliveryExpressHandler.GetSimpleDeliveryExpressList)
-			expressGroup.GET("/export-excel", deliveryExpressHandler.ExportDeliveryExpress)
+			expressGroup.GET("/export-excel", middleware.HasPermission(perm, "trade:delivery:express:export"), deliveryExpressHandler.ExportDeliveryExpress)
 		}
 
 		// Pick Up Store
 		pickUpStoreGroup := deliveryGroup.Group("/pick-up-store")
 		{
-			pickUpStoreGroup.POST("/create", deliveryPickUpStoreHandler.CreateDeliveryPickUpStore)
-			pickUpStoreGroup.PUT("/update", deliveryPickUpStoreHandler.UpdateDeliveryPickUpStore)
-			pickUpStoreGroup.DELETE("/delete", deliveryPickUpStoreHandler.DeleteDeliveryPickUpStore)
-			pickUpStoreGroup.GET("/get", deliveryPickUpStoreHandler.GetDeliveryPickUpStore)
-			pickUpStoreGroup.GET("/page", deliveryPickUpStoreHandler.GetDeliveryPickUpStorePage)
+			pickUpStoreGroup.POST("/create", middleware.HasPermission(perm, "trade:delivery:pick-up-store:create"), oplog.Log("TRADE 自提门店", "创建自提门店", middleware.OperateTypeCreate, middleware.BizIDFromResult()), deliveryPickUpStoreHandler.CreateDeliveryPickUpStore)
+			pickUpStoreGroup.PUT("/update", middleware.HasPermission(perm, "trade:delivery:pick-up-store:update"), oplog.Log("TRADE 自提门店", "更新自提门店", middleware.OperateTypeUpdate, middleware.BizIDFromBody("id")), deliveryPickUpStoreHandler.UpdateDeliveryPickUpStore)
+			pickUpStoreGroup.DELETE("/delete", middleware.HasPermission(perm, "trade:delivery:pick-up-store:delete"), oplog.Log("TRADE 自提门店", "删除自提门店", middleware.OperateTypeDelete, middleware.BizIDFromQuery("id")), deliveryPickUpStoreHandler.DeleteDeliveryPickUpStore)
+			pickUpStoreGroup.GET("/get", middleware.HasPermission(perm, "trade:delivery:pick-up-store:query"), deliveryPickUpStoreHandler.GetDeliveryPickUpStore)
+			pickUpStoreGroup.GET("/page", middleware.HasPermission(perm, "trade:delivery:pick-up-store:query"), deliveryPickUpStoreHandler.GetDeliveryPickUpStorePage)
 		}
 
 		// Express Template (运费模板) - 对齐 Java 路径
 		expressTemplateGroup := deliveryGroup.Group("/express-template")
 		{
-			expressTemplateGroup.POST("/create", deliveryFreightTemplateHandler.CreateDeliveryFreightTemplate)
-			expressTemplateGroup.PUT("/update", deliveryFreightTemplateHandler.UpdateDeliveryFreightTemplate)
-			expressTemplateGroup.DELETE("/delete", deliveryFreightTemplateHandler.DeleteDeliveryFreightTemplate)
-			expressTemplateGroup.GET("/get", deliveryFreightTemplateHandler.GetDeliveryFreightTemplate)
-			expressTemplateGroup.GET("/page", deliveryFreightTemplateHandler.GetDeliveryFreightTemplatePage)
+			expressTemplateGroup.POST("/create", middleware.HasPermission(perm, "trade:delivery:express-template:create"), oplog.Log("TRADE 运费模板", "创建运费模板", middleware.OperateTypeCreate, middleware.BizIDFromResult()), deliveryFreightTemplateHandler.CreateDeliveryFreightTemplate)
+			expressTemplateGroup.PUT("/update", middleware.HasPermission(perm, "trade:delivery:express-template:update"), oplog.Log("TRADE 运费模板", "更新运费模板", middleware.OperateTypeUpdate, middleware.BizIDFromBody("id")), deliveryFreightTemplateHandler.UpdateDeliveryFreightTemplate)
+			expressTemplateGroup.DELETE("/delete", middleware.HasPermission(perm, "trade:delivery:express-template:delete"), oplog.Log("TRADE 运费模板", "删除运费模板", middleware.OperateTypeDelete, middleware.BizIDFromQuery("id")), deliveryFreightTemplateHandler.DeleteDeliveryFreightTemplate)
+			expressTemplateGroup.GET("/get", middleware.HasPermission(perm, "trade:delivery:express-template:query"), deliveryFreightTemplateHandler.GetDeliveryFreightTemplate)
+			expressTemplateGroup.GET("/page", middleware.HasPermission(perm, "trade:delivery:express-template:query"), deliveryFreightTemplateHandler.GetDeliveryFreightTemplatePage)
 			expressTemplateGroup.GET("/list-all-simple", deliveryFreightTemplateHandler.GetSimpleDeliveryFreightTemplateList)
 		}
 	}
@@ -91,39 +93,39 @@ func RegisterTradeRoutes(engine *gin.Engine,
 	tradeConfigGroup := engine.Group("/admin-api/trade/config")
 	tradeConfigGroup.Use(middleware.Auth())
 	{
-		tradeConfigGroup.GET("/get", tradeConfigHandler.GetTradeConfig)
-		tradeConfigGroup.PUT("/save", tradeConfigHandler.SaveTradeConfig)
+		tradeConfigGroup.GET("/get", middleware.HasPermission(perm, "trade:config:query"), tradeConfigHandler.GetTradeConfig)
+		tradeConfigGroup.PUT("/save", middleware.HasPermission(perm, "trade:config:save"), oplog.Log("TRADE 交易配置", "保存交易配置", middleware.OperateTypeUpdate, nil), tradeConfigHandler.SaveTradeConfig)
 	}
 
 	// Brokerage User
 	brokerageUserGroup := engine.Group("/admin-api/trade/brokerage-user")
 	brokerageUserGroup.Use(middleware.Auth())
 	{
-		brokerageUserGroup.POST("/create", brokerageUserHandler.CreateBrokerageUser)
-		brokerageUserGroup.PUT("/update-bind-user", brokerageUserHandler.UpdateBindUser)
-		brokerageUserGroup.PUT("/clear-bind-user", brokerageUserHandler.ClearBindUser)
-		brokerageUserGroup.PUT("/update-brokerage-enable", brokerageUserHandler.UpdateBrokerageEnabled)
-		brokerageUserGroup.GET("/get", brokerageUserHandler.GetBrokerageUser)
-		brokerageUserGroup.GET("/page", brokerageUserHandler.GetBrokerageUserPage)
+		brokerageUserGroup.POST("/create", middleware.HasPermission(perm, "trade:brokerage-user:create"), oplog.Log("TRADE 分销用户", "创建分销用户", middleware.OperateTypeCreate, middleware.BizIDFromBody("userId")), brokerageUserHandler.CreateBrokerageUser)
+		brokerageUserGroup.PUT("/update-bind-user", middleware.HasPermission(perm, "trade:brokerage-user:update-bind-user"), oplog.Log("TRADE 分销用户", "修改推广员", middleware.OperateTypeUpdate, middleware.BizIDFromBody("id")), brokerageUserHandler.UpdateBindUser)
+		brokerageUserGroup.PUT("/clear-bind-user", middleware.HasPermission(perm, "trade:brokerage-user:clear-bind-user"), oplog.Log("TRADE 分销用户", "清除推广员", middleware.OperateTypeUpdate, middleware.BizIDFromBody("id")), brokerageUserHandler.ClearBindUser)
+		brokerageUserGroup.PUT("/update-brokerage-enable", middleware.HasPermission(perm, "trade:brokerage-user:update-brokerage-enable"), oplog.Log("TRADE 分销用户", "修改推广资格", middleware.OperateTypeUpdate, middleware.BizIDFromBody("id")), brokerageUserHandler.UpdateBrokerageEnabled)
+		brokerageUserGroup.GET("/get", middleware.HasPermission(perm, "trade:brokerage-user:query"), brokerageUserHandler.GetBrokerageUser)
+		brokerageUserGroup.GET("/page", middleware.HasPermission(perm, "trade:brokerage-user:query"), brokerageUserHandler.GetBrokerageUserPage)
 	}
 
 	// Brokerage Record
 	brokerageRecordGroup := engine.Group("/admin-api/trade/brokerage-record")
 	brokerageRecordGroup.Use(middleware.Auth())
 	{
-		brokerageRecordGroup.GET("/get", brokerageRecordHandler.GetBrokerageRecord)
-		brokerageRecordGroup.GET("/page", brokerageRecordHandler.GetBrokerageRecordPage)
+		brokerageRecordGroup.GET("/get", middleware.HasPermission(perm, "trade:brokerage-record:query"), brokerageRecordHandler.GetBrokerageRecord)
+		brokerageRecordGroup.GET("/page", middleware.HasPermission(perm, "trade:brokerage-record:query"), brokerageRecordHandler.GetBrokerageRecordPage)
 	}
 
 	// Brokerage Withdraw
 	brokerageWithdrawGroup := engine.Group("/admin-api/trade/brokerage-withdraw")
 	brokerageWithdrawGroup.Use(middleware.Auth())
 	{
-		brokerageWithdrawGroup.PUT("/approve", brokerageWithdrawHandler.ApproveBrokerageWithdraw)
-		brokerageWithdrawGroup.PUT("/reject", brokerageWithdrawHandler.RejectBrokerageWithdraw)
-		brokerageWithdrawGroup.POST("/update-transferred", brokerageWithdrawHandler.UpdateBrokerageWithdrawTransferred)
-		brokerageWithdrawGroup.GET("/get", brokerageWithdrawHandler.GetBrokerageWithdraw)
-		brokerageWithdrawGroup.GET("/page", brokerageWithdrawHandler.GetBrokerageWithdrawPage)
+		brokerageWithdrawGroup.PUT("/approve", middleware.HasPermission(perm, "trade:brokerage-withdraw:audit"), oplog.Log("TRADE 佣金提现", "审核通过佣金提现", middleware.OperateTypeUpdate, middleware.BizIDFromQuery("id")), brokerageWithdrawHandler.ApproveBrokerageWithdraw)
+		brokerageWithdrawGroup.PUT("/reject", middleware.HasPermission(perm, "trade:brokerage-withdraw:audit"), oplog.Log("TRADE 佣金提现", "审核驳回佣金提现", middleware.OperateTypeUpdate, middleware.BizIDFromBody("id")), brokerageWithdrawHandler.RejectBrokerageWithdraw)
+		brokerageWithdrawGroup.POST("/update-transferred", middleware.HasPermission(perm, "trade:brokerage-withdraw:audit"), oplog.Log("TRADE 佣金提现", "确认佣金提现已转账", middleware.OperateTypeUpdate, middleware.BizIDFromBody("merchantTransferId")), brokerageWithdrawHandler.UpdateBrokerageWithdrawTransferred)
+		brokerageWithdrawGroup.GET("/get", middleware.HasPermission(perm, "trade:brokerage-withdraw:query"), brokerageWithdrawHandler.GetBrokerageWithdraw)
+		brokerageWithdrawGroup.GET("/page", middleware.HasPermission(perm, "trade:brokerage-withdraw:query"), brokerageWithdrawHandler.GetBrokerageWithdrawPage)
 	}
 
 	// Trade AfterSale Callback (No Auth)
@@ -1,47 +1,55 @@
 package middleware
 
 import (
+	"backend-go/internal/model"
 	"backend-go/internal/pkg/core"
+	"backend-go/internal/pkg/utils"
+	"backend-go/pkg/config"
+	"backend-go/pkg/logger"
 	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
 	"io"
-	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 )
 
-// APIAccessLog API 访问日志记录，与 Java 的 ApiAccessLogFilter 对齐
-type APIAccessLog struct {
-	RequestID     string    `json:"requestId"`
-	UserID        int64     `json:"userId"`
-	URI           string    `json:"uri"`
-	Method        string    `json:"method"`
-	RequestParams string    `json:"requestParams"`
-	RequestBody   string    `json:"requestBody"`
-	ResponseBody  string    `json:"responseBody"`
-	ResponseCode  int       `json:"responseCode"`
-	Duration      int64     `json:"duration"` // 毫秒
-	CreateTime    time.Time `json:"createTime"`
-	UserAgent     string    `json:"userAgent"`
-	ClientIP      string    `json:"clientIp"`
-}
-
-// APIAccessLogMiddleware API 访问日志中间件
-func APIAccessLogMiddleware() gin.HandlerFunc {
+const (
+	// ctxApiErrorLoggedKey 标记本次请求已记录错误日志，避免重复记录
+	ctxApiErrorLoggedKey = "apiErrorLogged"
+	// apiLogMaxBodySize 请求体、响应体记录的最大长度
+	apiLogMaxBodySize = 8 * 1024
+	// apiLogMaskValue 敏感字段脱敏后的值
+	apiLogMaskValue = "******"
+)
+
+// ApiAccessLogWriter API 访问日志写入器，由 service.ApiAccessLogService 实现
+type ApiAccessLogWriter interface {
+	CreateApiAccessLog(log *model.InfraApiAccessLog)
+}
+
+// ApiErrorLogWriter API 错误日志写入器，由 service.ApiErrorLogService 实现
+type ApiErrorLogWriter interface {
+	CreateApiErrorLog(ctx context.Context, log *model.InfraApiErrorLog) error
+}
+
+// APIAccessLogMiddleware API 访问日志中间件，需注册在 Recovery 之前
+// 所有请求记录到 infra_api_access_log；未被 Recovery 记录的 5xx 响应同时记录到 infra_api_error_log
+// 对齐 Java: ApiAccessLogFilter
+func APIAccessLogMiddleware(accessLogWriter ApiAccessLogWriter, errorLogWriter ApiErrorLogWriter) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		startTime := time.Now()
-
-		// 读取请求体
-		var requestBody string
-		if c.Request.Body != nil {
-			bodyBytes, _ := io.ReadAll(c.Request.Body)
-			requestBody = string(bodyBytes)
-			// 重新设置请求体，以便后续处理
-			c.Request.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
-		}
+		beginTime := time.Now()
 
-		// 获取请求参数
-		requestParams := c.Request.URL.RawQuery
+		// 处理器读取请求体时，同步保留用于记录的部分
+		requestBody := captureRequestBody(c, apiLogMaxBodySize)
 
 		// 拦截响应体
 		responseWriter := &responseWriter{
@@ -53,35 +61,86 @@ func APIAccessLogMiddleware() gin.HandlerFunc {
 		// 继续处理请求
 		c.Next()
 
-		// 计算耗时
-		duration := time.Since(startTime).Milliseconds()
+		endTime := time.Now()
+		requestParams := buildRequestParams(c.Request.URL.Query(), requestBody.String())
+		var responseBody string
+		if isJSONContent(c.Writer.Header().Get("Content-Type")) {
+			responseBody = truncate(sanitizeSensitiveData(responseWriter.body.String()), apiLogMaxBodySize)
+		}
+		resultCode, resultMsg := parseResult(c.Writer.Status(), responseWriter.body.Bytes())
 
-		// 获取登录用户信息
-		loginUser := core.GetLoginUser(c)
-		userID := int64(0)
-		if loginUser != nil {
-			userID = loginUser.UserID
+		// 获取登录用户、租户信息
+		userID, userType := int64(0), 0
+		if loginUser := core.GetLoginUser(c); loginUser != nil {
+			userID, userType = loginUser.UserID, loginUser.UserType
 		}
+		tenantID, _ := core.GetTenantIDFromContext(c.Request.Context())
+
+		// 异步记录访问日志（避免阻塞请求）
+		accessLogWriter.CreateApiAccessLog(&model.InfraApiAccessLog{
+			TraceID:         c.GetHeader("X-Request-ID"),
+			UserID:          userID,
+			UserType:        userType,
+			TenantID:        tenantID,
+			ApplicationName: config.C.App.Name,
+			RequestMethod:   c.Request.Method,
+			RequestURL:      c.Request.URL.Path,
+			RequestParams:   requestParams,
+			ResponseBody:    responseBody,
+			UserIP:          c.ClientIP(),
+			UserAgent:       truncate(c.Request.UserAgent(), 512),
+			BeginTime:       beginTime,
+			EndTime:         endTime,
+			Duration:        int(endTime.Sub(beginTime).Milliseconds()),
+			ResultCode:      resultCode,
+			ResultMsg:       truncate(resultMsg, 512),
+		})
 
-		// 构建日志
-		log := &APIAccessLog{
-			RequestID:     c.GetString("X-Request-ID"),
-			UserID:        userID,
-			URI:           c.Request.RequestURI,
-			Method:        c.Request.Method,
-			RequestParams: requestParams,
-			RequestBody:   sanitizeSensitiveData(requestBody),
-			ResponseBody:  sanitizeSensitiveData(responseWriter.body.String()),
-			ResponseCode:  c.Writer.Status(),
-			Duration:      duration,
-			CreateTime:    startTime,
-			UserAgent:     c.Request.UserAgent(),
-			ClientIP:      c.ClientIP(),
+		// 5xx 且未由 Recovery 记录的，补充错误日志
+		if c.Writer.Status() >= 500 && !c.GetBool(ctxApiErrorLoggedKey) {
+			errorLog := newApiErrorLog(c, requestParams, endTime)
+			errorLog.ExceptionName = "HTTP " + statusText(c.Writer.Status())
+			errorLog.ExceptionMessage = resultMsg
+			if len(c.Errors) > 0 {
+				errorLog.ExceptionMessage = c.Errors.String()
+				errorLog.ExceptionRootCauseMessage = c.Errors.Last().Error()
+			}
+			writeApiErrorLog(c, errorLogWriter, errorLog)
 		}
+	}
+}
+
+// cappedBuffer 最多保留 limit 字节的缓冲区，超出部分直接丢弃
+type cappedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if remain := b.limit - b.Len(); remain > 0 {
+		if len(p) > remain {
+			b.Buffer.Write(p[:remain])
+		} else {
+			b.Buffer.Write(p)
+		}
+	}
+	return len(p), nil
+}
 
-		// 异步记录日志（避免阻塞请求）
-		go recordAPIAccessLog(log)
+// teeReadCloser 读取请求体的同时写入 cappedBuffer，关闭时关闭原请求体
+type teeReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// captureRequestBody 包装 JSON 请求体，处理器读取时同步保留前 limit 字节用于记录日志
+// 不预先读取完整请求体，避免大请求在内存中保留两份；处理器未读取的部分不会被记录
+func captureRequestBody(c *gin.Context, limit int) *cappedBuffer {
+	buf := &cappedBuffer{limit: limit}
+	if c.Request.Body != nil && isJSONContent(c.ContentType()) {
+		c.Request.Body = teeReadCloser{Reader: io.TeeReader(c.Request.Body, buf), Closer: c.Request.Body}
 	}
+	return buf
 }
 
 // responseWriter 用于拦截响应体
@@ -91,38 +150,201 @@ type responseWriter struct {
 }
 
 func (w *responseWriter) Write(b []byte) (int, error) {
-	w.body.Write(b)
+	if w.body.Len() < apiLogMaxBodySize {
+		w.body.Write(b)
+	}
 	return w.ResponseWriter.Write(b)
 }
 
 func (w *responseWriter) WriteString(s string) (int, error) {
-	w.body.WriteString(s)
+	if w.body.Len() < apiLogMaxBodySize {
+		w.body.WriteString(s)
+	}
 	return w.ResponseWriter.WriteString(s)
 }
 
-// sanitizeSensitiveData 清理敏感数据（密码、token 等）
+// newApiErrorLog 构建错误日志的请求信息部分
+func newApiErrorLog(c *gin.Context, requestParams string, exceptionTime time.Time) *model.InfraApiErrorLog {
+	userID, userType := int64(0), 0
+	if loginUser := core.GetLoginUser(c); loginUser != nil {
+		userID, userType = loginUser.UserID, loginUser.UserType
+	}
+	tenantID, _ := core.GetTenantIDFromContext(c.Request.Context())
+	return &model.InfraApiErrorLog{
+		TraceID:         c.GetHeader("X-Request-ID"),
+		UserID:          userID,
+		UserType:        userType,
+		TenantID:        tenantID,
+		ApplicationName: config.C.App.Name,
+		RequestMethod:   c.Request.Method,
+		RequestURL:      c.Request.URL.Path,
+		RequestParams:   requestParams,
+		UserIP:          c.ClientIP(),
+		UserAgent:       truncate(c.Request.UserAgent(), 512),
+		ExceptionTime:   exceptionTime,
+	}
+}
+
+// writeApiErrorLog 异步写入错误日志，沿用请求 Context 中的租户信息，但不随请求结束而取消
+func writeApiErrorLog(c *gin.Context, writer ApiErrorLogWriter, errorLog *model.InfraApiErrorLog) {
+	if writer == nil {
+		return
+	}
+	ctx := context.WithoutCancel(c.Request.Context())
+	go func() {
+		if err := writer.CreateApiErrorLog(ctx, errorLog); err != nil {
+			logger.Error("failed to write api error log", zap.String("url", errorLog.RequestURL), zap.Error(err))
+		}
+	}()
+}
+
+// buildRequestParams 合并 query 与 body 参数，与 Java 的 {"query":..., "body":...} 格式对齐
+func buildRequestParams(query url.Values, body string) string {
+	params := map[string]any{}
+	if len(query) > 0 {
+		q := make(map[string]string, len(query))
+		for k := range query {
+			q[k] = query.Get(k)
+		}
+		params["query"] = maskValue(q)
+	}
+	if body != "" {
+		if v, err := decodeJSON(body); err == nil {
+			params["body"] = maskValue(v)
+		} else {
+			params["body"] = sanitizeSensitiveData(body)
+		}
+	}
+	if len(params) == 0 {
+		return ""
+	}
+	data, _ := json.Marshal(params)
+	return truncate(string(data), apiLogMaxBodySize)
+}
+
+// parseResult 解析响应中的 code、msg
+func parseResult(status int, body []byte) (int, string) {
+	var result struct {
+		Code *int   `json:"code"`
+		Msg  string `json:"msg"`
+	}
+	if err := json.Unmarshal(body, &result); err == nil && result.Code != nil {
+		return *result.Code, result.Msg
+	}
+	if status >= 400 {
+		return status, statusText(status)
+	}
+	return core.SuccessCode, ""
+}
+
+// statusText 返回如 "500 Internal Server Error" 的状态描述
+func statusText(status int) string {
+	return fmt.Sprintf("%d %s", status, http.StatusText(status))
+}
+
+// decodeJSON 解析 JSON，数字保持原样，避免大整数丢失精度
+func decodeJSON(data string) (any, error) {
+	var v any
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func isJSONContent(contentType string) bool {
+	return strings.Contains(strings.ToLower(contentType), "json")
+}
+
+// truncate 截断为不超过 max 字节，在 UTF-8 字符边界处截断，避免产生非法字符导致写入失败
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	for max > 0 && !utf8.RuneStart(s[max]) {
+		max--
+	}
+	return s[:max]
+}
+
+// sensitiveKeys 需要脱敏的字段（小写）
+var sensitiveKeys = map[string]bool{
+	"password":      true,
+	"oldpassword":   true,
+	"newpassword":   true,
+	"token":         true,
+	"accesstoken":   true,
+	"refreshtoken":  true,
+	"authorization": true,
+	"secret":        true,
+	"clientsecret":  true,
+}
+
+// mobileKeys 需要按手机号规则脱敏的字段（小写）
+var mobileKeys = map[string]bool{
+	"mobile":         true,
+	"phone":          true,
+	"contactmobile":  true,
+	"receivermobile": true,
+}
+
+// sensitivePattern 非 JSON 内容（如表单、query）的敏感字段
+var sensitivePattern = regexp.MustCompile(`(?i)((?:old|new)?password|(?:access|refresh)?token|secret)=([^&\s]*)`)
+
+// sensitiveJSONPattern 无法解析的 JSON 片段（如被截断的响应体）中的敏感字段
+var sensitiveJSONPattern = regexp.MustCompile(`(?i)("(?:(?:old|new)?password|(?:access|refresh)?token|secret)"\s*:\s*)"[^"]*"`)
+
+// mobilePattern 手机号
+var mobilePattern = regexp.MustCompile(`\b(1[3-9]\d)\d{4}(\d{4})\b`)
+
+// sanitizeSensitiveData 清理敏感数据：密码、token 替换为 ******，手机号保留前 3 位和后 4 位
 func sanitizeSensitiveData(data string) string {
 	if data == "" {
 		return data
 	}
 
-	// 简单的敏感数据清理，可根据需要扩展
-	// 实际应用中应该使用正则表达式进行更复杂的替换
-	result := data
-	return result
+	if v, err := decodeJSON(data); err == nil {
+		masked, _ := json.Marshal(maskValue(v))
+		return string(masked)
+	}
+	data = sensitivePattern.ReplaceAllString(data, "$1="+apiLogMaskValue)
+	data = sensitiveJSONPattern.ReplaceAllString(data, `$1"`+apiLogMaskValue+`"`)
+	return mobilePattern.ReplaceAllString(data, "$1****$2")
 }
 
-// recordAPIAccessLog 记录 API 访问日志
-// 实际应用中应该将日志存储到数据库或日志系统
-func recordAPIAccessLog(accessLog *APIAccessLog) {
-	// TODO: 实现日志存储逻辑
-	// 可以存储到数据库、日志文件或日志系统（如 ELK）
-	logMessage := "API Access Log: " +
-		"UserID=" + string(rune(accessLog.UserID)) +
-		" Method=" + accessLog.Method +
-		" URI=" + accessLog.URI +
-		" ResponseCode=" + string(rune(int32(accessLog.ResponseCode))) +
-		" Duration=" + string(rune(accessLog.Duration)) + "ms"
+// maskValue 递归脱敏 JSON 值
+func maskValue(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, item := range val {
+			val[k] = maskField(k, item)
+		}
+		return val
+	case map[string]string:
+		res := make(map[string]any, len(val))
+		for k, item := range val {
+			res[k] = maskField(k, item)
+		}
+		return res
+	case []any:
+		for i, item := range val {
+			val[i] = maskValue(item)
+		}
+		return val
+	}
+	return v
+}
 
-	log.Println(logMessage)
+func maskField(key string, v any) any {
+	lowerKey := strings.ToLower(key)
+	if sensitiveKeys[lowerKey] {
+		return apiLogMaskValue
+	}
+	if mobileKeys[lowerKey] {
+		if s, ok := v.(string); ok {
+			return utils.MaskMobile(s)
+		}
+	}
+	return maskValue(v)
 }
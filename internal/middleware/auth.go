@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"backend-go/internal/pkg/core"
@@ -61,8 +62,25 @@ func Auth() gin.HandlerFunc {
 			Nickname: claims.Nickname,
 		}
 
-		// 4. Set LoginUser to Context
+		// 4. 管理后台接口只允许管理员令牌访问
+		if strings.HasPrefix(c.Request.URL.Path, "/admin-api") && loginUser.UserType != core.UserTypeAdmin {
+			c.AbortWithStatusJSON(403, core.Error(core.ForbiddenCode, "没有该操作权限"))
+			return
+		}
+
+		// 5. 请求头中的租户必须与登录用户的租户一致，避免越权访问其它租户的数据
+		// 对齐 Java: TenantSecurityWebFilter
+		if header := c.GetHeader(HeaderTenantID); header != "" && header != strconv.FormatInt(loginUser.TenantID, 10) {
+			c.AbortWithStatusJSON(403, core.Error(core.ForbiddenCode, "您无权访问该租户的数据"))
+			return
+		}
+
+		// 6. Set LoginUser to Context
 		core.SetLoginUser(c, loginUser)
+		// 登录用户的租户优先于请求头中的租户
+		if loginUser.TenantID > 0 {
+			core.SetTenantID(c, loginUser.TenantID)
+		}
 		c.Next()
 	}
 }
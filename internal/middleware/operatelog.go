@@ -0,0 +1,231 @@
+package middleware
+
+import (
+	"backend-go/internal/model"
+	"backend-go/internal/pkg/core"
+	"backend-go/pkg/logger"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// OperateType 操作类型
+// 对齐 Java: OperateTypeEnum
+type OperateType int
+
+const (
+	OperateTypeOther  OperateType = 0
+	OperateTypeGet    OperateType = 1
+	OperateTypeCreate OperateType = 2
+	OperateTypeUpdate OperateType = 3
+	OperateTypeDelete OperateType = 4
+	OperateTypeExport OperateType = 5
+	OperateTypeImport OperateType = 6
+)
+
+const (
+	// operateLogMaxExtraSize system_operate_log.extra 字段的最大长度
+	operateLogMaxExtraSize = 2000
+	// operateLogQueueSize 待写入队列容量，满时丢弃
+	operateLogQueueSize = 1024
+	// operateLogWorkers 写入操作日志的协程数
+	operateLogWorkers = 4
+)
+
+// OperateLogWriter 操作日志写入器，由 service.OperateLogService 实现
+type OperateLogWriter interface {
+	CreateOperateLog(ctx context.Context, log *model.SystemOperateLog) error
+}
+
+// BizIDExtractor 从请求或响应中提取操作的业务编号
+type BizIDExtractor func(c *gin.Context, requestBody []byte, responseBody []byte) int64
+
+// BizIDFromQuery 从 query 参数中提取业务编号，适用于 DELETE /delete?id=1
+func BizIDFromQuery(key string) BizIDExtractor {
+	return func(c *gin.Context, _ []byte, _ []byte) int64 {
+		return core.ParseInt64(c.Query(key))
+	}
+}
+
+// BizIDFromBody 从 JSON 请求体的顶层字段中提取业务编号，适用于 PUT /update
+func BizIDFromBody(key string) BizIDExtractor {
+	return func(_ *gin.Context, requestBody []byte, _ []byte) int64 {
+		v, err := decodeJSON(string(requestBody))
+		if err != nil {
+			return 0
+		}
+		if m, ok := v.(map[string]any); ok {
+			return parseBizID(m[key])
+		}
+		return 0
+	}
+}
+
+// BizIDFromResult 从响应的 data 中提取业务编号，适用于返回新建编号的 POST /create
+func BizIDFromResult() BizIDExtractor {
+	return func(_ *gin.Context, _ []byte, responseBody []byte) int64 {
+		v, err := decodeJSON(string(responseBody))
+		if err != nil {
+			return 0
+		}
+		if m, ok := v.(map[string]any); ok {
+			return parseBizID(m["data"])
+		}
+		return 0
+	}
+}
+
+func parseBizID(v any) int64 {
+	switch val := v.(type) {
+	case json.Number:
+		id, _ := val.Int64()
+		return id
+	case string:
+		id, _ := strconv.ParseInt(val, 10, 64)
+		return id
+	}
+	return 0
+}
+
+// OperateLogRecorder 声明式操作日志，在路由上标注操作模块、操作类型与业务编号，记录管理后台的写操作
+// 对齐 Java: @LogRecord + LogRecordServiceImpl
+type OperateLogRecorder struct {
+	writer OperateLogWriter
+	queue  chan operateLogTask
+}
+
+// operateLogTask 待写入的操作日志，ctx 携带请求的租户信息
+type operateLogTask struct {
+	ctx context.Context
+	log *model.SystemOperateLog
+}
+
+func NewOperateLogRecorder(writer OperateLogWriter) *OperateLogRecorder {
+	r := &OperateLogRecorder{
+		writer: writer,
+		queue:  make(chan operateLogTask, operateLogQueueSize),
+	}
+	if writer != nil {
+		for i := 0; i < operateLogWorkers; i++ {
+			go r.runWriter()
+		}
+	}
+	return r
+}
+
+// Log 返回记录操作日志的中间件，需在 Auth、HasPermission 之后使用
+// module 为操作模块（system_operate_log.type），name 为操作名（system_operate_log.sub_type）
+// 操作内容默认为操作名，Service 可通过 core.SetOperateLogAction 设置业务层面的操作内容
+func (r *OperateLogRecorder) Log(module, name string, operateType OperateType, bizID BizIDExtractor) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		beginTime := time.Now()
+		logCtx := core.StartOperateLog(c)
+
+		// 处理器读取请求体时，同步保留用于记录的部分
+		capturedBody := captureRequestBody(c, apiLogMaxBodySize)
+
+		// 拦截响应体
+		writer := &responseWriter{
+			ResponseWriter: c.Writer,
+			body:           bytes.NewBufferString(""),
+		}
+		c.Writer = writer
+
+		c.Next()
+
+		requestBody := capturedBody.Bytes()
+		duration := time.Since(beginTime).Milliseconds()
+		responseBody := writer.body.Bytes()
+		resultCode, resultMsg := parseResult(c.Writer.Status(), responseBody)
+		success := c.Writer.Status() < 400 && resultCode == core.SuccessCode
+
+		action := logCtx.Action()
+		if action == "" {
+			action = name
+		}
+		if !success {
+			action = fmt.Sprintf("%s失败：%s", name, resultMsg)
+		}
+
+		extra := logCtx.Extra()
+		extra["operateType"] = operateType
+		extra["success"] = success
+		extra["duration"] = duration
+		extra["resultCode"] = resultCode
+		extra["resultMsg"] = resultMsg
+		if requestParams := buildRequestParams(c.Request.URL.Query(), string(requestBody)); requestParams != "" {
+			extra["requestParams"] = rawJSON(requestParams)
+		}
+		if isJSONContent(c.Writer.Header().Get("Content-Type")) {
+			extra["responseBody"] = rawJSON(sanitizeSensitiveData(string(responseBody)))
+		}
+
+		operateLog := &model.SystemOperateLog{
+			TraceID:       c.GetHeader("X-Request-ID"),
+			Type:          module,
+			SubType:       name,
+			Action:        truncate(action, 2000),
+			Extra:         buildOperateLogExtra(extra),
+			RequestMethod: c.Request.Method,
+			RequestURL:    truncate(c.Request.URL.Path, 255),
+			UserIP:        c.ClientIP(),
+			UserAgent:     truncate(c.Request.UserAgent(), 512),
+		}
+		if loginUser := core.GetLoginUser(c); loginUser != nil {
+			operateLog.UserID, operateLog.UserType = loginUser.UserID, loginUser.UserType
+		}
+		if bizID != nil {
+			operateLog.BizID = bizID(c, requestBody, responseBody)
+		}
+		// 脱离请求的取消信号，保留租户等上下文信息
+		r.write(context.WithoutCancel(c.Request.Context()), operateLog)
+	}
+}
+
+// write 异步写入操作日志（避免阻塞请求），队列满时丢弃
+func (r *OperateLogRecorder) write(ctx context.Context, operateLog *model.SystemOperateLog) {
+	if r.writer == nil {
+		return
+	}
+	select {
+	case r.queue <- operateLogTask{ctx: ctx, log: operateLog}:
+	default:
+		logger.Error("operate log queue is full, dropped", zap.String("type", operateLog.Type), zap.String("subType", operateLog.SubType))
+	}
+}
+
+// runWriter 从队列中取出操作日志并写入
+func (r *OperateLogRecorder) runWriter() {
+	for task := range r.queue {
+		if err := r.writer.CreateOperateLog(task.ctx, task.log); err != nil {
+			logger.Error("failed to write operate log", zap.String("type", task.log.Type), zap.String("subType", task.log.SubType), zap.Error(err))
+		}
+	}
+}
+
+// rawJSON 合法的 JSON 原样嵌入，否则（如被截断）按字符串记录
+func rawJSON(data string) any {
+	if json.Valid([]byte(data)) {
+		return json.RawMessage(data)
+	}
+	return data
+}
+
+// buildOperateLogExtra 序列化拓展字段，超出字段长度时依次舍弃响应体、请求参数，保证仍是合法的 JSON
+func buildOperateLogExtra(extra map[string]any) string {
+	data, _ := json.Marshal(extra)
+	for _, key := range []string{"responseBody", "requestParams"} {
+		if len(data) <= operateLogMaxExtraSize {
+			break
+		}
+		delete(extra, key)
+		data, _ = json.Marshal(extra)
+	}
+	return truncate(string(data), operateLogMaxExtraSize)
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"context"
+
+	"backend-go/internal/pkg/core"
+	"backend-go/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// PermissionChecker 权限校验器，由 service.PermissionService 实现
+type PermissionChecker interface {
+	HasAnyPermissions(ctx context.Context, userId int64, permissions ...string) (bool, error)
+}
+
+// HasPermission 校验登录用户拥有任一权限标识（如 trade:order:update），需在 Auth 之后使用
+// 对齐 Java: @PreAuthorize("@ss.hasAnyPermissions(...)")
+func HasPermission(checker PermissionChecker, permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		loginUser := core.GetLoginUser(c)
+		if loginUser == nil {
+			c.AbortWithStatusJSON(401, core.Error(401, "未登录"))
+			return
+		}
+		if loginUser.UserType != core.UserTypeAdmin {
+			c.AbortWithStatusJSON(403, core.Error(core.ForbiddenCode, "没有该操作权限"))
+			return
+		}
+
+		ok, err := checker.HasAnyPermissions(c.Request.Context(), loginUser.UserID, permissions...)
+		if err != nil {
+			logger.Error("permission check failed", zap.Int64("userId", loginUser.UserID), zap.Strings("permissions", permissions), zap.Error(err))
+			c.AbortWithStatusJSON(500, core.Error(core.ServerErrCode, "系统内部异常"))
+			return
+		}
+		if !ok {
+			c.AbortWithStatusJSON(403, core.Error(core.ForbiddenCode, "没有该操作权限"))
+			return
+		}
+		c.Next()
+	}
+}
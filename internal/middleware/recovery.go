@@ -1,12 +1,17 @@
 package middleware
 
 import (
+	"errors"
+	"fmt"
 	"net"
 	"net/http"
 	"os"
+	"runtime"
 	"runtime/debug"
 	"strings"
+	"time"
 
+	"backend-go/internal/model"
 	"backend-go/internal/pkg/core"
 	"backend-go/pkg/logger"
 
@@ -14,8 +19,9 @@ import (
 	"go.uber.org/zap"
 )
 
-// Recovery 全局异常捕获中间件
-func Recovery() gin.HandlerFunc {
+// Recovery 全局异常捕获中间件，panic 记录到 infra_api_error_log
+// 对齐 Java: GlobalExceptionHandler.createExceptionLog
+func Recovery(errorLogWriter ApiErrorLogWriter) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		defer func() {
 			if err := recover(); err != nil {
@@ -44,6 +50,10 @@ func Recovery() gin.HandlerFunc {
 					return
 				}
 
+				// 记录错误日志
+				writeApiErrorLog(c, errorLogWriter, newPanicErrorLog(c, err, stack))
+				c.Set(ctxApiErrorLoggedKey, true)
+
 				// 返回 500
 				c.JSON(http.StatusInternalServerError, core.Error(core.ServerErrCode, "系统异常，请联系管理员"))
 				c.Abort()
@@ -53,6 +63,47 @@ func Recovery() gin.HandlerFunc {
 	}
 }
 
+// newPanicErrorLog 根据 panic 构建错误日志
+func newPanicErrorLog(c *gin.Context, err any, stack string) *model.InfraApiErrorLog {
+	errorLog := newApiErrorLog(c, buildRequestParams(c.Request.URL.Query(), ""), time.Now())
+	errorLog.ExceptionName = fmt.Sprintf("%T", err)
+	errorLog.ExceptionMessage = fmt.Sprint(err)
+	errorLog.ExceptionRootCauseMessage = fmt.Sprint(err)
+	if e, ok := err.(error); ok {
+		for unwrapped := e; unwrapped != nil; unwrapped = errors.Unwrap(unwrapped) {
+			errorLog.ExceptionRootCauseMessage = unwrapped.Error()
+		}
+	}
+	errorLog.ExceptionStackTrace = stack
+	if frame, ok := panicFrame(); ok {
+		errorLog.ExceptionClassName = frame.Function
+		errorLog.ExceptionMethodName = frame.Function[strings.LastIndex(frame.Function, ".")+1:]
+		errorLog.ExceptionFileName = frame.File
+		errorLog.ExceptionLineNumber = frame.Line
+	}
+	return errorLog
+}
+
+// panicFrame 定位 panic 发生的位置：调用栈中 runtime.gopanic 之后的第一个非 runtime 帧
+func panicFrame() (runtime.Frame, bool) {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	afterPanic := false
+	for {
+		frame, more := frames.Next()
+		if afterPanic && !strings.HasPrefix(frame.Function, "runtime.") {
+			return frame, true
+		}
+		if frame.Function == "runtime.gopanic" {
+			afterPanic = true
+		}
+		if !more {
+			return runtime.Frame{}, false
+		}
+	}
+}
+
 // BizErrorHandle 业务错误处理中间件 (可选，如果想把 controller 的 return error 统一处理)
 // 但 Gin 的 handler 签名没有 error 返回值。这里我们通常采用 c.Error() 机制或者封装 HandlerFunc
 // 简单起见，我们推荐 Controller 显式调用 core.Error 或 core.Success
@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"strconv"
+	"strings"
+
+	"backend-go/internal/pkg/core"
+	"backend-go/internal/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HeaderTenantID 租户编号请求头，与 Java 保持一致
+const HeaderTenantID = "tenant-id"
+
+// tenantIgnoreURLs 无需传递租户编号的接口，* 匹配一级路径，** 匹配剩余所有路径
+// 对齐 Java: yudao.tenant.ignore-urls
+var tenantIgnoreURLs = []string{
+	"/admin-api/system/tenant/**",      // 租户管理、按名称或域名获取租户
+	"/admin-api/system/captcha/**",     // 验证码
+	"/app-api/system/captcha/**",       // 验证码
+	"/admin-api/system/sms/callback/*", // 短信回调
+	"/admin-api/pay/notify/order/*",    // 支付回调
+	"/admin-api/pay/notify/refund/*",   // 退款回调
+	"/admin-api/infra/file/*/get/**",   // 文件下载
+}
+
+// Tenant 解析当前请求的租户编号：优先取请求头，其次取登录令牌中的租户
+// 已登录用户的租户由 Auth 覆盖
+// 管理后台、App 接口既没有租户编号也未登录时直接拒绝，避免跨租户读写数据
+// 对齐 Java: TenantContextWebFilter + TenantSecurityWebFilter
+func Tenant() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantId := tenantIDFromHeader(c)
+		if tenantId == 0 {
+			if token := obtainAuthorization(c); token != "" {
+				if claims, err := utils.ParseToken(token); err == nil {
+					tenantId = claims.TenantID
+				}
+			}
+		}
+		if tenantId > 0 {
+			core.SetTenantID(c, tenantId)
+		} else if isTenantRequired(c.Request.URL.Path) {
+			c.AbortWithStatusJSON(400, core.Error(core.ParamErrCode, "请求的租户标识未传递，请进行排查"))
+			return
+		}
+		c.Next()
+	}
+}
+
+// tenantIDFromHeader 获得请求头中的租户编号，未传递或不合法时返回 0
+func tenantIDFromHeader(c *gin.Context) int64 {
+	header := c.GetHeader(HeaderTenantID)
+	if header == "" {
+		return 0
+	}
+	tenantId, err := strconv.ParseInt(header, 10, 64)
+	if err != nil || tenantId <= 0 {
+		return 0
+	}
+	return tenantId
+}
+
+// isTenantRequired 判断接口是否必须携带租户编号
+func isTenantRequired(path string) bool {
+	if !strings.HasPrefix(path, "/admin-api/") && !strings.HasPrefix(path, "/app-api/") {
+		return false
+	}
+	for _, pattern := range tenantIgnoreURLs {
+		if matchURLPattern(pattern, path) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchURLPattern 按路径段匹配 URL，* 匹配一级路径，末尾的 ** 匹配剩余所有路径
+func matchURLPattern(pattern, path string) bool {
+	patternParts := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+	for i, part := range patternParts {
+		if part == "**" {
+			return true
+		}
+		if i >= len(pathParts) || (part != "*" && part != pathParts[i]) {
+			return false
+		}
+	}
+	return len(patternParts) == len(pathParts)
+}
+
+// TenantIgnore 声明路由忽略租户，用于平台管理员跨租户操作
+// 对齐 Java: @TenantIgnore
+func TenantIgnore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		core.SetIgnoreTenant(c)
+		c.Next()
+	}
+}
@@ -12,6 +12,7 @@ type InfraApiErrorLog struct {
 	TraceID                   string         `gorm:"column:trace_id;type:varchar(64);comment:链路追踪编号" json:"traceId"`
 	UserID                    int64          `gorm:"column:user_id;type:bigint;default:0;comment:用户编号" json:"userId"`
 	UserType                  int            `gorm:"column:user_type;type:tinyint;default:0;comment:用户类型" json:"userType"`
+	TenantID                  int64          `gorm:"column:tenant_id;type:bigint;default:0;comment:租户编号" json:"tenantId"`
 	ApplicationName           string         `gorm:"column:application_name;type:varchar(50);not null;comment:应用名" json:"applicationName"`
 	RequestMethod             string         `gorm:"column:request_method;type:varchar(16);not null;comment:请求方法名" json:"requestMethod"`
 	RequestURL                string         `gorm:"column:request_url;type:varchar(255);not null;comment:请求地址" json:"requestUrl"`
@@ -43,3 +43,20 @@ type InfraFile struct {
 func (InfraFile) TableName() string {
 	return "infra_file"
 }
+
+// InfraFileContent 文件内容表，DB 存储器使用
+type InfraFileContent struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement;comment:编号" json:"id"`
+	ConfigId  int64     `gorm:"not null;index:idx_config_path;comment:配置编号" json:"configId"`
+	Path      string    `gorm:"size:512;not null;index:idx_config_path;comment:文件路径" json:"path"`
+	Content   []byte    `gorm:"type:mediumblob;not null;comment:文件内容" json:"content"`
+	Creator   string    `gorm:"column:creator;size:64;comment:创建者" json:"creator"`
+	Updater   string    `gorm:"column:updater;size:64;comment:更新者" json:"updater"`
+	CreatedAt time.Time `gorm:"column:create_time;autoCreateTime;comment:创建时间" json:"createTime"`
+	UpdatedAt time.Time `gorm:"column:update_time;autoUpdateTime;comment:更新时间" json:"updateTime"`
+	Deleted   BitBool   `gorm:"column:deleted;softDelete:flag;default:0;comment:是否删除" json:"-"`
+}
+
+func (InfraFileContent) TableName() string {
+	return "infra_file_content"
+}
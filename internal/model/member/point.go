@@ -30,3 +30,15 @@ type MemberPointRecord struct {
 func (MemberPointRecord) TableName() string {
 	return "member_point_record"
 }
+
+// 积分业务类型，对应 Java: MemberPointBizTypeEnum
+const (
+	PointBizTypeSign                = 1  // 签到
+	PointBizTypeAdmin               = 2  // 管理员修改
+	PointBizTypeOrderUse            = 11 // 订单积分抵扣
+	PointBizTypeOrderUseCancel      = 12 // 订单积分抵扣（整单取消），退还积分
+	PointBizTypeOrderUseCancelItem  = 13 // 订单积分抵扣（单个退款），退还积分
+	PointBizTypeOrderGive           = 21 // 订单积分奖励
+	PointBizTypeOrderGiveCancel     = 22 // 订单积分奖励（整单取消），扣除赠送的积分
+	PointBizTypeOrderGiveCancelItem = 23 // 订单积分奖励（单个退款），扣除赠送的积分
+)
@@ -0,0 +1,98 @@
+package pay
+
+import (
+	"backend-go/internal/model"
+	"time"
+)
+
+// PayWallet 会员钱包 DO
+type PayWallet struct {
+	ID            int64         `gorm:"primaryKey;autoIncrement;comment:编号" json:"id"`
+	UserID        int64         `gorm:"column:user_id;not null;comment:用户编号" json:"userId"`
+	UserType      int           `gorm:"column:user_type;not null;comment:用户类型" json:"userType"`
+	Balance       int           `gorm:"column:balance;default:0;not null;comment:余额，单位分" json:"balance"`
+	TotalExpense  int           `gorm:"column:total_expense;default:0;not null;comment:累计支出，单位分" json:"totalExpense"`
+	TotalRecharge int           `gorm:"column:total_recharge;default:0;not null;comment:累计充值，单位分" json:"totalRecharge"`
+	FreezePrice   int           `gorm:"column:freeze_price;default:0;not null;comment:冻结金额，单位分" json:"freezePrice"`
+	Creator       string        `gorm:"size:64;default:'';comment:创建者" json:"creator"`
+	Updater       string        `gorm:"size:64;default:'';comment:更新者" json:"updater"`
+	CreatedAt     time.Time     `gorm:"column:create_time;autoCreateTime;comment:创建时间" json:"createTime"`
+	UpdatedAt     time.Time     `gorm:"column:update_time;autoUpdateTime;comment:更新时间" json:"updateTime"`
+	Deleted       model.BitBool `gorm:"column:deleted;softDelete:flag;default:0;comment:是否删除" json:"deleted"`
+	TenantID      int64         `gorm:"column:tenant_id;default:0;comment:租户编号" json:"tenantId"`
+}
+
+func (PayWallet) TableName() string {
+	return "pay_wallet"
+}
+
+// PayWalletTransaction 会员钱包流水 DO
+type PayWalletTransaction struct {
+	ID        int64         `gorm:"primaryKey;autoIncrement;comment:编号" json:"id"`
+	No        string        `gorm:"column:no;size:64;not null;comment:流水号" json:"no"`
+	WalletID  int64         `gorm:"column:wallet_id;not null;comment:钱包编号" json:"walletId"`
+	BizType   int           `gorm:"column:biz_type;not null;comment:关联业务分类" json:"bizType"`
+	BizID     string        `gorm:"column:biz_id;size:64;not null;comment:关联业务编号" json:"bizId"`
+	Title     string        `gorm:"column:title;size:128;not null;comment:流水说明" json:"title"`
+	Price     int           `gorm:"column:price;not null;comment:交易金额，单位分，正值表示余额增加，负值表示余额减少" json:"price"`
+	Balance   int           `gorm:"column:balance;not null;comment:交易后余额，单位分" json:"balance"`
+	Creator   string        `gorm:"size:64;default:'';comment:创建者" json:"creator"`
+	Updater   string        `gorm:"size:64;default:'';comment:更新者" json:"updater"`
+	CreatedAt time.Time     `gorm:"column:create_time;autoCreateTime;comment:创建时间" json:"createTime"`
+	UpdatedAt time.Time     `gorm:"column:update_time;autoUpdateTime;comment:更新时间" json:"updateTime"`
+	Deleted   model.BitBool `gorm:"column:deleted;softDelete:flag;default:0;comment:是否删除" json:"deleted"`
+	TenantID  int64         `gorm:"column:tenant_id;default:0;comment:租户编号" json:"tenantId"`
+}
+
+func (PayWalletTransaction) TableName() string {
+	return "pay_wallet_transaction"
+}
+
+// PayWalletRecharge 会员钱包充值 DO
+type PayWalletRecharge struct {
+	ID               int64         `gorm:"primaryKey;autoIncrement;comment:编号" json:"id"`
+	WalletID         int64         `gorm:"column:wallet_id;not null;comment:钱包编号" json:"walletId"`
+	TotalPrice       int           `gorm:"column:total_price;not null;comment:用户实际到账余额，单位分" json:"totalPrice"`
+	PayPrice         int           `gorm:"column:pay_price;not null;comment:实际支付金额，单位分" json:"payPrice"`
+	BonusPrice       int           `gorm:"column:bonus_price;not null;comment:钱包赠送金额，单位分" json:"bonusPrice"`
+	PackageID        int64         `gorm:"column:package_id;default:0;comment:充值套餐编号" json:"packageId"`
+	PayStatus        bool          `gorm:"column:pay_status;default:0;not null;comment:是否已支付" json:"payStatus"`
+	PayOrderID       int64         `gorm:"column:pay_order_id;default:0;comment:支付订单编号" json:"payOrderId"`
+	PayChannelCode   string        `gorm:"column:pay_channel_code;size:16;default:'';comment:支付成功的支付渠道" json:"payChannelCode"`
+	PayTime          *time.Time    `gorm:"column:pay_time;comment:订单支付时间" json:"payTime"`
+	PayRefundID      int64         `gorm:"column:pay_refund_id;default:0;comment:支付退款单编号" json:"payRefundId"`
+	RefundTotalPrice int           `gorm:"column:refund_total_price;default:0;comment:退款金额，包含赠送金额" json:"refundTotalPrice"`
+	RefundPayPrice   int           `gorm:"column:refund_pay_price;default:0;comment:退款支付金额" json:"refundPayPrice"`
+	RefundBonusPrice int           `gorm:"column:refund_bonus_price;default:0;comment:退款赠送金额" json:"refundBonusPrice"`
+	RefundTime       *time.Time    `gorm:"column:refund_time;comment:退款时间" json:"refundTime"`
+	RefundStatus     int           `gorm:"column:refund_status;default:0;comment:退款状态" json:"refundStatus"`
+	Creator          string        `gorm:"size:64;default:'';comment:创建者" json:"creator"`
+	Updater          string        `gorm:"size:64;default:'';comment:更新者" json:"updater"`
+	CreatedAt        time.Time     `gorm:"column:create_time;autoCreateTime;comment:创建时间" json:"createTime"`
+	UpdatedAt        time.Time     `gorm:"column:update_time;autoUpdateTime;comment:更新时间" json:"updateTime"`
+	Deleted          model.BitBool `gorm:"column:deleted;softDelete:flag;default:0;comment:是否删除" json:"deleted"`
+	TenantID         int64         `gorm:"column:tenant_id;default:0;comment:租户编号" json:"tenantId"`
+}
+
+func (PayWalletRecharge) TableName() string {
+	return "pay_wallet_recharge"
+}
+
+// PayWalletRechargePackage 会员钱包充值套餐 DO
+type PayWalletRechargePackage struct {
+	ID         int64         `gorm:"primaryKey;autoIncrement;comment:编号" json:"id"`
+	Name       string        `gorm:"column:name;size:64;not null;comment:套餐名" json:"name"`
+	PayPrice   int           `gorm:"column:pay_price;not null;comment:支付金额，单位分" json:"payPrice"`
+	BonusPrice int           `gorm:"column:bonus_price;default:0;not null;comment:赠送金额，单位分" json:"bonusPrice"`
+	Status     int           `gorm:"column:status;default:0;not null;comment:状态" json:"status"` // 参见 CommonStatusEnum
+	Creator    string        `gorm:"size:64;default:'';comment:创建者" json:"creator"`
+	Updater    string        `gorm:"size:64;default:'';comment:更新者" json:"updater"`
+	CreatedAt  time.Time     `gorm:"column:create_time;autoCreateTime;comment:创建时间" json:"createTime"`
+	UpdatedAt  time.Time     `gorm:"column:update_time;autoUpdateTime;comment:更新时间" json:"updateTime"`
+	Deleted    model.BitBool `gorm:"column:deleted;softDelete:flag;default:0;comment:是否删除" json:"deleted"`
+	TenantID   int64         `gorm:"column:tenant_id;default:0;comment:租户编号" json:"tenantId"`
+}
+
+func (PayWalletRechargePackage) TableName() string {
+	return "pay_wallet_recharge_package"
+}
@@ -27,8 +27,38 @@ type TradeConfig struct {
 	UpdateTime                  time.Time     `gorm:"column:update_time;autoUpdateTime;comment:更新时间"`
 	Deleted                     model.BitBool `gorm:"column:deleted;type:tinyint(1);not null;default:0;comment:是否删除"`
 	TenantID                    int64         `gorm:"column:tenant_id;not null;default:0;comment:租户编号"`
+
+	// MessageTemplates 交易消息的模板配置，key 为消息事件，参见 TradeMessageOrderCreate 等
+	MessageTemplates map[string]*TradeMessageTemplate `gorm:"column:message_templates;type:json;serializer:json;comment:消息模板配置" json:"messageTemplates"`
+
+	// 积分抵扣：每个积分抵扣 PointTradeDeductUnitPrice 分，最多抵扣商品金额的 PointTradeDeductMaxPercent%
+	PointTradeDeductEnabled    model.BitBool `gorm:"column:point_trade_deduct_enabled;default:0;comment:是否开启积分抵扣" json:"pointTradeDeductEnabled"`
+	PointTradeDeductUnitPrice  int           `gorm:"column:point_trade_deduct_unit_price;default:0;comment:积分抵扣单价(分)" json:"pointTradeDeductUnitPrice"`
+	PointTradeDeductMaxPercent int           `gorm:"column:point_trade_deduct_max_percent;default:0;comment:积分最多抵扣百分比" json:"pointTradeDeductMaxPercent"`
+	// 积分赠送：订单支付后，每支付 1 元赠送 PointTradeGivePoint 个积分，另加商品设置的赠送积分
+	PointTradeGivePoint int `gorm:"column:point_trade_give_point;default:0;comment:1 元赠送多少积分" json:"pointTradeGivePoint"`
 }
 
 func (TradeConfig) TableName() string {
 	return "trade_config"
 }
+
+// 交易消息事件
+const (
+	TradeMessageOrderCreate       = "order_create"        // 订单创建
+	TradeMessageOrderPaySuccess   = "order_pay_success"   // 订单支付成功
+	TradeMessageOrderDelivery     = "order_delivery"      // 订单发货
+	TradeMessageOrderPickUpReady  = "order_pick_up_ready" // 自提订单待提货
+	TradeMessageOrderRefund       = "order_refund"        // 订单退款成功
+	TradeMessageAfterSaleAgree    = "after_sale_agree"    // 售后审核通过
+	TradeMessageAfterSaleDisagree = "after_sale_disagree" // 售后审核不通过
+	TradeMessageAfterSaleRefund   = "after_sale_refund"   // 售后退款成功
+)
+
+// TradeMessageTemplate 交易消息事件的模板编码，为空的渠道不发送
+type TradeMessageTemplate struct {
+	NotifyTemplateCode string   `json:"notifyTemplateCode"` // 站内信模板编码，发送给会员
+	SmsTemplateCode    string   `json:"smsTemplateCode"`    // 短信模板编码，发送到会员手机号
+	MailTemplateCode   string   `json:"mailTemplateCode"`   // 邮件模板编码，发送到 MailTo（会员没有邮箱，通常为商家客服邮箱）
+	MailTo             []string `json:"mailTo"`             // 邮件收件人，商家员工邮箱
+}
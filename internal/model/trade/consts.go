@@ -13,6 +13,19 @@ const (
 	TradeOrderStatusCanceled = 40
 )
 
+const (
+	// TradeOrderTypeNormal 普通订单。旧版本普通订单存储为 1，升级需执行 sql/migration/20261018_trade_order_type.sql
+	TradeOrderTypeNormal = 0
+	// TradeOrderTypeSeckill 秒杀订单
+	TradeOrderTypeSeckill = 1
+	// TradeOrderTypeBargain 砍价订单
+	TradeOrderTypeBargain = 2
+	// TradeOrderTypeCombination 拼团订单
+	TradeOrderTypeCombination = 3
+	// TradeOrderTypePoint 积分商城订单
+	TradeOrderTypePoint = 4
+)
+
 const (
 	// DeliveryTypeExpress 快递发货
 	DeliveryTypeExpress = 1
@@ -29,3 +42,22 @@ const (
 	// BrokerageWithdrawStatusAuditing 审核中
 	BrokerageWithdrawStatusAuditing = 1
 )
+
+const (
+	// PromotionTypeSeckillActivity 秒杀活动
+	PromotionTypeSeckillActivity = 1
+	// PromotionTypeBargainActivity 砍价活动
+	PromotionTypeBargainActivity = 2
+	// PromotionTypeCombinationActivity 拼团活动
+	PromotionTypeCombinationActivity = 3
+	// PromotionTypeDiscountActivity 限时折扣
+	PromotionTypeDiscountActivity = 4
+	// PromotionTypeRewardActivity 满减送
+	PromotionTypeRewardActivity = 5
+	// PromotionTypeMemberLevel 会员折扣
+	PromotionTypeMemberLevel = 6
+	// PromotionTypeCoupon 优惠券
+	PromotionTypeCoupon = 7
+	// PromotionTypePoint 积分抵扣
+	PromotionTypePoint = 8
+)
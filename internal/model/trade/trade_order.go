@@ -61,6 +61,7 @@ type TradeOrder struct {
 	CombinationHeadID        int64          `gorm:"column:combination_head_id;type:bigint;comment:拼团团长编号"`
 	CombinationRecordID      int64          `gorm:"column:combination_record_id;type:bigint;comment:拼团记录编号"`
 	PointActivityID          int64          `gorm:"column:point_activity_id;type:bigint;comment:积分商城活动的编号"`
+	TenantID                 int64          `gorm:"column:tenant_id;not null;default:0;comment:租户编号"`
 	Creator                  string         `gorm:"column:creator;size:64;default:'';comment:创建者"`
 	Updater                  string         `gorm:"column:updater;size:64;default:'';comment:更新者"`
 	CreatedAt                time.Time      `gorm:"column:create_time;autoCreateTime;comment:创建时间"`
@@ -0,0 +1,49 @@
+package captcha
+
+import (
+	"bytes"
+	"crypto/aes"
+	"encoding/base64"
+	"errors"
+)
+
+// AesEncrypt AES/ECB/PKCS7Padding 加密并输出 base64，与 AJ-Captcha 前端的 aesEncrypt 一致
+// 对应 Java: AESUtil.aesEncrypt
+func AesEncrypt(plainText, key string) (string, error) {
+	block, err := aes.NewCipher([]byte(key))
+	if err != nil {
+		return "", err
+	}
+	size := block.BlockSize()
+	padding := size - len(plainText)%size
+	data := append([]byte(plainText), bytes.Repeat([]byte{byte(padding)}, padding)...)
+	for i := 0; i < len(data); i += size {
+		block.Encrypt(data[i:i+size], data[i:i+size])
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// AesDecrypt 解密 AesEncrypt 加密的 base64 密文
+// 对应 Java: AESUtil.aesDecrypt
+func AesDecrypt(cipherText, key string) (string, error) {
+	block, err := aes.NewCipher([]byte(key))
+	if err != nil {
+		return "", err
+	}
+	data, err := base64.StdEncoding.DecodeString(cipherText)
+	if err != nil {
+		return "", err
+	}
+	size := block.BlockSize()
+	if len(data) == 0 || len(data)%size != 0 {
+		return "", errors.New("invalid cipher text")
+	}
+	for i := 0; i < len(data); i += size {
+		block.Decrypt(data[i:i+size], data[i:i+size])
+	}
+	padding := int(data[len(data)-1])
+	if padding == 0 || padding > size || padding > len(data) {
+		return "", errors.New("invalid padding")
+	}
+	return string(data[:len(data)-padding]), nil
+}
@@ -0,0 +1,145 @@
+package captcha
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"math/rand"
+)
+
+const (
+	// ImageWidth 底图宽度，与 AJ-Captcha 前端组件一致
+	ImageWidth = 310
+	// ImageHeight 底图高度
+	ImageHeight = 155
+	// JigsawSize 滑块宽度（滑块图为 JigsawSize x ImageHeight 的竖条）
+	JigsawSize = 47
+
+	// 滑块形状：主体为正方形，顶部、右侧各带一个半圆凸起
+	jigsawBodyTop  = 9
+	jigsawBodySize = 38
+	jigsawTabR     = 7
+	// jigsawBorder 滑块描边宽度
+	jigsawBorder = 1.5
+)
+
+// BlockPuzzle 滑块拼图验证码
+type BlockPuzzle struct {
+	X int // 滑块在底图中的横坐标，即正确答案
+	Y int // 滑块在底图中的纵坐标
+
+	OriginalImageBase64 string // 挖去滑块后的底图
+	JigsawImageBase64   string // 滑块图
+}
+
+// NewBlockPuzzle 生成滑块拼图：随机绘制底图，在随机位置抠出滑块
+// 对应 Java: BlockPuzzleCaptchaServiceImpl#pictureTemplatesCut
+func NewBlockPuzzle() (*BlockPuzzle, error) {
+	background := randomBackground()
+	x := JigsawSize + rand.Intn(ImageWidth-2*JigsawSize-10) + 5
+	y := rand.Intn(ImageHeight - JigsawSize)
+
+	jigsaw := image.NewNRGBA(image.Rect(0, 0, JigsawSize, ImageHeight))
+	for py := 0; py < JigsawSize; py++ {
+		for px := 0; px < JigsawSize; px++ {
+			inside, edge := jigsawMask(float64(px)+0.5, float64(py)+0.5)
+			if !inside {
+				continue
+			}
+			bx, by := x+px, y+py
+			src := background.NRGBAAt(bx, by)
+			if edge {
+				jigsaw.SetNRGBA(px, y+py, color.NRGBA{R: 255, G: 255, B: 255, A: 255})
+				background.SetNRGBA(bx, by, blend(src, color.NRGBA{R: 255, G: 255, B: 255, A: 255}, 0.6))
+				continue
+			}
+			jigsaw.SetNRGBA(px, y+py, src)
+			background.SetNRGBA(bx, by, blend(src, color.NRGBA{A: 255}, 0.5))
+		}
+	}
+
+	originalImage, err := encodePNG(background)
+	if err != nil {
+		return nil, err
+	}
+	jigsawImage, err := encodePNG(jigsaw)
+	if err != nil {
+		return nil, err
+	}
+	return &BlockPuzzle{
+		X:                   x,
+		Y:                   y,
+		OriginalImageBase64: originalImage,
+		JigsawImageBase64:   jigsawImage,
+	}, nil
+}
+
+// jigsawMask 判断滑块内的点是否属于滑块，以及是否处于描边上
+func jigsawMask(x, y float64) (inside bool, edge bool) {
+	d := jigsawDistance(x, y)
+	return d <= 0, d > -jigsawBorder && d <= 0
+}
+
+// jigsawDistance 点到滑块轮廓的近似有向距离，负数表示在滑块内部
+func jigsawDistance(x, y float64) float64 {
+	// 主体正方形
+	left, top := 0.0, float64(jigsawBodyTop)
+	right, bottom := float64(jigsawBodySize), float64(jigsawBodyTop+jigsawBodySize)
+	body := math.Max(math.Max(left-x, x-right), math.Max(top-y, y-bottom))
+	// 顶部、右侧凸起
+	topTab := math.Hypot(x-float64(jigsawBodySize)/2, y-top) - jigsawTabR
+	rightTab := math.Hypot(x-right, y-(top+bottom)/2) - jigsawTabR
+	return math.Min(body, math.Min(topTab, rightTab))
+}
+
+// randomBackground 随机生成底图：渐变底色叠加若干半透明圆形色块
+func randomBackground() *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, ImageWidth, ImageHeight))
+	from, to := randomColor(), randomColor()
+	for y := 0; y < ImageHeight; y++ {
+		for x := 0; x < ImageWidth; x++ {
+			img.SetNRGBA(x, y, blend(from, to, float64(x+y)/float64(ImageWidth+ImageHeight)))
+		}
+	}
+	for i := 0; i < 12; i++ {
+		cx, cy := rand.Intn(ImageWidth), rand.Intn(ImageHeight)
+		r := 10 + rand.Intn(40)
+		c := randomColor()
+		for y := max(cy-r, 0); y < min(cy+r, ImageHeight); y++ {
+			for x := max(cx-r, 0); x < min(cx+r, ImageWidth); x++ {
+				if (x-cx)*(x-cx)+(y-cy)*(y-cy) <= r*r {
+					img.SetNRGBA(x, y, blend(img.NRGBAAt(x, y), c, 0.45))
+				}
+			}
+		}
+	}
+	// 噪点，增加识别难度
+	for i := 0; i < ImageWidth*ImageHeight/20; i++ {
+		x, y := rand.Intn(ImageWidth), rand.Intn(ImageHeight)
+		img.SetNRGBA(x, y, blend(img.NRGBAAt(x, y), randomColor(), 0.5))
+	}
+	return img
+}
+
+func randomColor() color.NRGBA {
+	return color.NRGBA{R: uint8(40 + rand.Intn(200)), G: uint8(40 + rand.Intn(200)), B: uint8(40 + rand.Intn(200)), A: 255}
+}
+
+// blend 按比例 ratio 将 c2 混合到 c1
+func blend(c1, c2 color.NRGBA, ratio float64) color.NRGBA {
+	mix := func(a, b uint8) uint8 {
+		return uint8(float64(a)*(1-ratio) + float64(b)*ratio)
+	}
+	return color.NRGBA{R: mix(c1.R, c2.R), G: mix(c1.G, c2.G), B: mix(c1.B, c2.B), A: 255}
+}
+
+func encodePNG(img image.Image) (string, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
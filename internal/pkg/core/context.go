@@ -1,16 +1,26 @@
 package core
 
-import "github.com/gin-gonic/gin"
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
 
 const (
 	CtxUserIDKey    = "userID"
 	CtxLoginUserKey = "loginUser"
 )
 
+// 用户类型，与 Java UserTypeEnum 保持一致
+const (
+	UserTypeMember = 1 // 会员
+	UserTypeAdmin  = 2 // 管理员
+)
+
 // LoginUser 登录用户信息，与 Java 的 LoginUser 对齐
 type LoginUser struct {
 	UserID   int64  `json:"userId"`
-	UserType int    `json:"userType"` // 0: Member, 1: Admin
+	UserType int    `json:"userType"` // 1: Member, 2: Admin
 	TenantID int64  `json:"tenantId"`
 	Nickname string `json:"nickname"`
 }
@@ -42,14 +52,32 @@ func GetLoginUser(c *gin.Context) *LoginUser {
 	return nil
 }
 
+type loginUserCtxKey struct{}
+
 // SetLoginUser 设置登录用户信息到上下文
+// 同时写入 c.Request.Context()，供 Service 层通过 GetLoginUserFromContext 获取
 func SetLoginUser(c *gin.Context, user *LoginUser) {
 	if user != nil {
 		c.Set(CtxUserIDKey, user.UserID)
 		c.Set(CtxLoginUserKey, user)
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), loginUserCtxKey{}, user))
 	}
 }
 
+// GetLoginUserFromContext 从 context.Context 获取登录用户信息，支持 gin.Context 与 c.Request.Context()
+func GetLoginUserFromContext(ctx context.Context) *LoginUser {
+	if ctx == nil {
+		return nil
+	}
+	if user, ok := ctx.Value(loginUserCtxKey{}).(*LoginUser); ok {
+		return user
+	}
+	if user, ok := ctx.Value(CtxLoginUserKey).(*LoginUser); ok {
+		return user
+	}
+	return nil
+}
+
 // GetTenantId 获得租户编号
 func GetTenantId(c *gin.Context) int64 {
 	user := GetLoginUser(c)
@@ -36,6 +36,11 @@ func InitDB() *gorm.DB {
 		logger.Log.Fatal("failed to connect database", zap.Error(err))
 	}
 
+	// 多租户插件
+	if err := db.Use(&TenantPlugin{}); err != nil {
+		logger.Log.Fatal("failed to register tenant plugin", zap.Error(err))
+	}
+
 	sqlDB, _ := db.DB()
 	sqlDB.SetMaxIdleConns(cfg.MaxIdle)
 	sqlDB.SetMaxOpenConns(cfg.MaxOpen)
@@ -0,0 +1,87 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+const CtxOperateLogKey = "operateLog"
+
+type operateLogCtxKey struct{}
+
+// OperateLogContext 当前请求的操作日志上下文，由 Service 补充业务层面的操作内容
+// 对齐 Java: LogRecordContext
+type OperateLogContext struct {
+	mu     sync.Mutex
+	action string
+	extra  map[string]any
+}
+
+// StartOperateLog 为当前请求开启操作日志上下文，由 middleware.OperateLogRecorder 调用
+// 同时写入 c.Request.Context()，兼容向 Service 传递 c.Request.Context() 的 Handler
+func StartOperateLog(c *gin.Context) *OperateLogContext {
+	logCtx := &OperateLogContext{}
+	c.Set(CtxOperateLogKey, logCtx)
+	c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), operateLogCtxKey{}, logCtx))
+	return logCtx
+}
+
+// SetOperateLogAction 设置操作内容，如 "订单支付金额从【10.00】元修改为【8.00】元"
+// 未开启操作日志的请求（如 App 接口、定时任务）调用时不做处理
+func SetOperateLogAction(ctx context.Context, format string, args ...any) {
+	logCtx := getOperateLogContext(ctx)
+	if logCtx == nil {
+		return
+	}
+	logCtx.mu.Lock()
+	defer logCtx.mu.Unlock()
+	logCtx.action = fmt.Sprintf(format, args...)
+}
+
+// PutOperateLogExtra 设置操作日志的拓展字段
+func PutOperateLogExtra(ctx context.Context, key string, value any) {
+	logCtx := getOperateLogContext(ctx)
+	if logCtx == nil {
+		return
+	}
+	logCtx.mu.Lock()
+	defer logCtx.mu.Unlock()
+	if logCtx.extra == nil {
+		logCtx.extra = map[string]any{}
+	}
+	logCtx.extra[key] = value
+}
+
+// Action 获得 Service 设置的操作内容
+func (o *OperateLogContext) Action() string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.action
+}
+
+// Extra 获得 Service 设置的拓展字段
+func (o *OperateLogContext) Extra() map[string]any {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	extra := make(map[string]any, len(o.extra))
+	for k, v := range o.extra {
+		extra[k] = v
+	}
+	return extra
+}
+
+func getOperateLogContext(ctx context.Context) *OperateLogContext {
+	if ctx == nil {
+		return nil
+	}
+	if v, ok := ctx.Value(operateLogCtxKey{}).(*OperateLogContext); ok {
+		return v
+	}
+	if v, ok := ctx.Value(CtxOperateLogKey).(*OperateLogContext); ok {
+		return v
+	}
+	return nil
+}
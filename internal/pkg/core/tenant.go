@@ -0,0 +1,182 @@
+package core
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	CtxTenantIDKey     = "tenantId"
+	CtxIgnoreTenantKey = "ignoreTenant"
+
+	// TenantColumn 租户字段
+	TenantColumn = "tenant_id"
+)
+
+type tenantIDCtxKey struct{}
+type ignoreTenantCtxKey struct{}
+
+// SetTenantID 设置当前请求的租户编号
+// 同时写入 c.Request.Context()，兼容向 Service 传递 c.Request.Context() 的 Handler
+func SetTenantID(c *gin.Context, tenantId int64) {
+	c.Set(CtxTenantIDKey, tenantId)
+	c.Request = c.Request.WithContext(WithTenantID(c.Request.Context(), tenantId))
+}
+
+// SetIgnoreTenant 设置当前请求忽略租户
+func SetIgnoreTenant(c *gin.Context) {
+	c.Set(CtxIgnoreTenantKey, true)
+	c.Request = c.Request.WithContext(WithIgnoreTenant(c.Request.Context()))
+}
+
+// WithTenantID 返回携带租户编号的 Context，用于非 HTTP 场景（如定时任务）
+func WithTenantID(ctx context.Context, tenantId int64) context.Context {
+	return context.WithValue(ctx, tenantIDCtxKey{}, tenantId)
+}
+
+// WithTenant 返回在指定租户下执行的 Context，会取消上层 Context 的忽略租户，用于定时任务按租户执行
+// 对齐 Java: TenantUtils.execute
+func WithTenant(ctx context.Context, tenantId int64) context.Context {
+	return WithTenantID(context.WithValue(ctx, ignoreTenantCtxKey{}, false), tenantId)
+}
+
+// WithIgnoreTenant 返回忽略租户的 Context，用于平台管理员、系统任务等跨租户场景
+// 对齐 Java: TenantUtils.executeIgnore
+func WithIgnoreTenant(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ignoreTenantCtxKey{}, true)
+}
+
+// GetTenantIDFromContext 获得 Context 中的租户编号
+// 同时支持 WithTenantID 设置的值，以及 gin.Context（及其派生 Context）中 SetTenantID 设置的值
+func GetTenantIDFromContext(ctx context.Context) (int64, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	if v, ok := ctx.Value(tenantIDCtxKey{}).(int64); ok {
+		return v, v > 0
+	}
+	if v, ok := ctx.Value(CtxTenantIDKey).(int64); ok {
+		return v, v > 0
+	}
+	return 0, false
+}
+
+// IsIgnoreTenant 判断 Context 是否忽略租户
+func IsIgnoreTenant(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	if v, ok := ctx.Value(ignoreTenantCtxKey{}).(bool); ok {
+		return v
+	}
+	v, ok := ctx.Value(CtxIgnoreTenantKey).(bool)
+	return ok && v
+}
+
+// TenantPlugin 多租户 GORM 插件：对带有 tenant_id 字段的模型，查询、更新、删除自动追加 tenant_id 条件，创建时自动填充
+// Context 中没有租户编号，或设置了忽略租户时，不做处理
+// 对齐 Java: TenantDatabaseInterceptor
+type TenantPlugin struct{}
+
+func (p *TenantPlugin) Name() string {
+	return "tenant"
+}
+
+func (p *TenantPlugin) Initialize(db *gorm.DB) error {
+	if err := db.Callback().Create().Before("gorm:create").Register("tenant:create", p.fillTenant); err != nil {
+		return err
+	}
+	if err := db.Callback().Query().Before("gorm:query").Register("tenant:query", p.addTenantCondition); err != nil {
+		return err
+	}
+	if err := db.Callback().Row().Before("gorm:row").Register("tenant:row", p.addTenantCondition); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().Before("gorm:update").Register("tenant:update", p.addTenantConditionIfWhere); err != nil {
+		return err
+	}
+	return db.Callback().Delete().Before("gorm:delete").Register("tenant:delete", p.addTenantConditionIfWhere)
+}
+
+// tenantID 获得需要处理的租户编号，模型没有租户字段时返回 false
+func (p *TenantPlugin) tenantID(db *gorm.DB) (int64, bool) {
+	stmt := db.Statement
+	if stmt.Schema == nil || stmt.Schema.LookUpField(TenantColumn) == nil {
+		return 0, false
+	}
+	if IsIgnoreTenant(stmt.Context) {
+		return 0, false
+	}
+	return GetTenantIDFromContext(stmt.Context)
+}
+
+func (p *TenantPlugin) addTenantCondition(db *gorm.DB) {
+	tenantId, ok := p.tenantID(db)
+	if !ok {
+		return
+	}
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: db.Statement.Table, Name: TenantColumn}, Value: tenantId},
+	}})
+}
+
+// addTenantConditionIfWhere 更新、删除总是追加租户条件（包括按主键更新、删除）
+// 既没有条件也没有主键时，追加的租户条件会绕过 GORM 的全表操作保护，因此直接返回 ErrMissingWhereClause
+func (p *TenantPlugin) addTenantConditionIfWhere(db *gorm.DB) {
+	if _, ok := p.tenantID(db); !ok {
+		return
+	}
+	if _, ok := db.Statement.Clauses["WHERE"]; !ok && !db.AllowGlobalUpdate && !p.hasPrimaryKey(db) {
+		_ = db.AddError(gorm.ErrMissingWhereClause)
+		return
+	}
+	p.addTenantCondition(db)
+}
+
+// hasPrimaryKey 判断操作的模型是否带有主键值，GORM 会据此生成主键条件
+func (p *TenantPlugin) hasPrimaryKey(db *gorm.DB) bool {
+	stmt := db.Statement
+	if stmt.Schema == nil || stmt.Schema.PrioritizedPrimaryField == nil {
+		return false
+	}
+	field := stmt.Schema.PrioritizedPrimaryField
+	rv := reflect.Indirect(stmt.ReflectValue)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if _, zero := field.ValueOf(stmt.Context, reflect.Indirect(rv.Index(i))); !zero {
+				return true
+			}
+		}
+	case reflect.Struct:
+		_, zero := field.ValueOf(stmt.Context, rv)
+		return !zero
+	}
+	return false
+}
+
+func (p *TenantPlugin) fillTenant(db *gorm.DB) {
+	tenantId, ok := p.tenantID(db)
+	if !ok {
+		return
+	}
+	field := db.Statement.Schema.LookUpField(TenantColumn)
+	ctx := db.Statement.Context
+	rv := db.Statement.ReflectValue
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if _, zero := field.ValueOf(ctx, rv.Index(i)); zero {
+				_ = field.Set(ctx, rv.Index(i), tenantId)
+			}
+		}
+	case reflect.Struct:
+		if _, zero := field.ValueOf(ctx, rv); zero {
+			_ = field.Set(ctx, rv, tenantId)
+		}
+	}
+}
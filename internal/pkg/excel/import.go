@@ -0,0 +1,47 @@
+package excel
+
+import "fmt"
+
+// ImportResult 导入结果，以每行数据的业务标识（如用户名）汇总
+// 对应 Java: UserImportRespVO 等各模块的导入结果
+type ImportResult struct {
+	Creates  []string
+	Updates  []string
+	Failures map[string]string // 业务标识 -> 失败原因
+}
+
+// ImportAction 单行的保存结果
+type ImportAction int
+
+const (
+	ImportCreated ImportAction = iota + 1
+	ImportUpdated
+)
+
+// Import 逐行导入：读取阶段失败的行直接计入失败，其余行调用 save 保存
+// key 返回行的业务标识，为空时使用 "第 N 行"
+func Import[T any](result *ReadResult[T], key func(row *T) string, save func(row *T) (ImportAction, error)) *ImportResult {
+	res := &ImportResult{Creates: []string{}, Updates: []string{}, Failures: map[string]string{}}
+	for _, row := range result.Rows {
+		k := key(row.Data)
+		if k == "" {
+			k = fmt.Sprintf("第 %d 行", row.Num)
+		}
+		if row.Err != "" {
+			res.Failures[k] = row.Err
+			continue
+		}
+		action, err := save(row.Data)
+		if err != nil {
+			res.Failures[k] = err.Error()
+			continue
+		}
+		switch action {
+		case ImportCreated:
+			res.Creates = append(res.Creates, k)
+		case ImportUpdated:
+			res.Updates = append(res.Updates, k)
+		}
+	}
+	return res
+}
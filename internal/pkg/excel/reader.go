@@ -0,0 +1,357 @@
+package excel
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/xuri/excelize/v2"
+)
+
+// 导入结构体支持的标签：
+//
+//	excel:"登录名称|用户名|账号"  表头名称，"|" 之后为别名，任一匹配即可
+//	dict:"system_user_sex"      字典类型，将字典标签（如 "男"）转换为字典值
+//	format:"2006-01-02"         时间格式，未指定时兼容常见格式与 Excel 日期序列号
+//	validate:"required,email"   行校验规则，参见 go-playground/validator
+//
+// 对应 Java: ExcelUtils#read + DictConvert
+
+// DictResolver 将字典标签转换为字典值
+type DictResolver func(dictType, label string) (string, bool)
+
+// Row 读取到的一行数据
+type Row[T any] struct {
+	Num  int    // Excel 行号，从 1 开始
+	Data *T     // 转换后的数据，转换失败的字段保持零值
+	Err  string // 转换或校验失败的原因，为空表示通过
+}
+
+// ReadResult 读取结果，包含所有数据行
+type ReadResult[T any] struct {
+	Rows []*Row[T]
+}
+
+// Valid 转换与校验均通过的行
+func (r *ReadResult[T]) Valid() []*Row[T] {
+	var rows []*Row[T]
+	for _, row := range r.Rows {
+		if row.Err == "" {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// Errors 失败行的错误报告，key 为 Excel 行号
+func (r *ReadResult[T]) Errors() map[int]string {
+	errs := make(map[int]string)
+	for _, row := range r.Rows {
+		if row.Err != "" {
+			errs[row.Num] = row.Err
+		}
+	}
+	return errs
+}
+
+type readOptions struct {
+	sheet     string
+	headerRow int
+	dict      DictResolver
+}
+
+// ReadOption 读取选项
+type ReadOption func(*readOptions)
+
+// WithSheet 指定读取的 Sheet，默认第一个
+func WithSheet(sheet string) ReadOption {
+	return func(o *readOptions) { o.sheet = sheet }
+}
+
+// WithHeaderRow 指定表头所在行，从 1 开始，默认第 1 行
+func WithHeaderRow(row int) ReadOption {
+	return func(o *readOptions) { o.headerRow = row }
+}
+
+// WithDictResolver 指定字典转换，dict 标签的字段需要
+func WithDictResolver(resolver DictResolver) ReadOption {
+	return func(o *readOptions) { o.dict = resolver }
+}
+
+var rowValidator = validator.New()
+
+// column 结构体字段与表头的映射
+type column struct {
+	index  int // 字段下标
+	header string
+	alias  []string
+	dict   string
+	format string
+}
+
+// Read 读取 Excel，将表头之后的每一行映射为 T，并逐行转换与校验
+func Read[T any](r io.Reader, opts ...ReadOption) (*ReadResult[T], error) {
+	o := &readOptions{headerRow: 1}
+	for _, opt := range opts {
+		opt(o)
+	}
+	elemType := reflect.TypeOf((*T)(nil)).Elem()
+	if elemType.Kind() != reflect.Struct {
+		return nil, errors.New("excel: T must be a struct")
+	}
+
+	f, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("读取 Excel 失败: %w", err)
+	}
+	defer f.Close()
+	sheet := o.sheet
+	if sheet == "" {
+		sheet = f.GetSheetName(0)
+	}
+	// 使用原始值，避免日期、长数字被单元格格式化
+	rows, err := f.GetRows(sheet, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, fmt.Errorf("读取 Excel 失败: %w", err)
+	}
+	if len(rows) < o.headerRow {
+		return &ReadResult[T]{}, nil
+	}
+
+	columns := parseColumns(elemType)
+	positions := matchHeaders(rows[o.headerRow-1], columns)
+	for _, col := range columns {
+		if col.dict != "" && o.dict == nil {
+			return nil, fmt.Errorf("excel: 字段 %s 需要字典转换，请指定 WithDictResolver", col.header)
+		}
+	}
+
+	result := &ReadResult[T]{}
+	for i := o.headerRow; i < len(rows); i++ {
+		cells := rows[i]
+		if isBlankRow(cells) {
+			continue
+		}
+		row := &Row[T]{Num: i + 1, Data: new(T)}
+		row.Err = readRow(reflect.ValueOf(row.Data).Elem(), cells, columns, positions, o)
+		result.Rows = append(result.Rows, row)
+	}
+	return result, nil
+}
+
+// parseColumns 解析带 excel 标签的字段
+func parseColumns(t reflect.Type) []*column {
+	var columns []*column
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("excel")
+		if tag == "" || tag == "-" || !field.IsExported() {
+			continue
+		}
+		names := strings.Split(tag, "|")
+		columns = append(columns, &column{
+			index:  i,
+			header: strings.TrimSpace(names[0]),
+			alias:  names[1:],
+			dict:   field.Tag.Get("dict"),
+			format: field.Tag.Get("format"),
+		})
+	}
+	return columns
+}
+
+// matchHeaders 按表头名称或别名匹配列，返回字段对应的列下标，未匹配的字段为 -1
+func matchHeaders(headers []string, columns []*column) []int {
+	positions := make([]int, len(columns))
+	for i, col := range columns {
+		positions[i] = -1
+		names := append([]string{col.header}, col.alias...)
+	match:
+		for j, header := range headers {
+			header = strings.TrimSpace(header)
+			for _, name := range names {
+				if strings.EqualFold(header, strings.TrimSpace(name)) {
+					positions[i] = j
+					break match
+				}
+			}
+		}
+	}
+	return positions
+}
+
+// readRow 转换并校验一行，返回错误原因
+func readRow(v reflect.Value, cells []string, columns []*column, positions []int, o *readOptions) string {
+	var errs []string
+	for i, col := range columns {
+		if positions[i] < 0 || positions[i] >= len(cells) {
+			continue
+		}
+		raw := strings.TrimSpace(cells[positions[i]])
+		if raw == "" {
+			continue
+		}
+		if col.dict != "" {
+			value, ok := o.dict(col.dict, raw)
+			if !ok {
+				errs = append(errs, fmt.Sprintf("%s「%s」不存在", col.header, raw))
+				continue
+			}
+			raw = value
+		}
+		if err := setValue(v.Field(col.index), raw, col.format); err != nil {
+			errs = append(errs, fmt.Sprintf("%s「%s」格式不正确", col.header, raw))
+		}
+	}
+	if len(errs) > 0 {
+		return strings.Join(errs, "；")
+	}
+	return validateRow(v, columns)
+}
+
+// validateRow 按 validate 标签校验，错误信息使用表头名称
+func validateRow(v reflect.Value, columns []*column) string {
+	err := rowValidator.Struct(v.Interface())
+	var validationErrs validator.ValidationErrors
+	if err == nil || !errors.As(err, &validationErrs) {
+		if err != nil {
+			return err.Error()
+		}
+		return ""
+	}
+	headers := make(map[string]string, len(columns))
+	for _, col := range columns {
+		headers[v.Type().Field(col.index).Name] = col.header
+	}
+	errs := make([]string, 0, len(validationErrs))
+	for _, fe := range validationErrs {
+		name := headers[fe.StructField()]
+		if name == "" {
+			name = fe.StructField()
+		}
+		errs = append(errs, name+validationMessage(fe))
+	}
+	return strings.Join(errs, "；")
+}
+
+func validationMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "不能为空"
+	case "email":
+		return "格式不正确"
+	case "max":
+		return "不能超过 " + fe.Param()
+	case "min":
+		return "不能小于 " + fe.Param()
+	case "len":
+		return "长度必须为 " + fe.Param()
+	case "oneof":
+		return "必须为 " + fe.Param() + " 之一"
+	default:
+		return "校验失败（" + fe.Tag() + "）"
+	}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// dateLayouts 未指定 format 时尝试的时间格式
+var dateLayouts = []string{
+	time.DateTime, time.DateOnly, "2006/01/02 15:04:05", "2006/01/02", "2006/1/2 15:04:05", "2006/1/2", time.RFC3339,
+}
+
+// setValue 将单元格文本转换为字段类型
+func setValue(field reflect.Value, raw, format string) error {
+	if field.Kind() == reflect.Ptr {
+		elem := reflect.New(field.Type().Elem())
+		if err := setValue(elem.Elem(), raw, format); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+	}
+	if field.Type() == timeType {
+		t, err := parseTime(raw, format)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// 数字单元格可能带有 ".0"
+		n, err := strconv.ParseInt(strings.TrimSuffix(raw, ".0"), 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(strings.TrimSuffix(raw, ".0"), 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(raw, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(n)
+	case reflect.Bool:
+		switch raw {
+		case "是", "Y", "y":
+			field.SetBool(true)
+		case "否", "N", "n":
+			field.SetBool(false)
+		default:
+			b, err := strconv.ParseBool(raw)
+			if err != nil {
+				return err
+			}
+			field.SetBool(b)
+		}
+	default:
+		return fmt.Errorf("excel: unsupported field type %s", field.Type())
+	}
+	return nil
+}
+
+// parseTime 解析时间，兼容 Excel 日期序列号（如 45292 表示 2024-01-01）
+func parseTime(raw, format string) (time.Time, error) {
+	if serial, err := strconv.ParseFloat(raw, 64); err == nil {
+		t, err := excelize.ExcelDateToTime(serial, false)
+		if err != nil {
+			return time.Time{}, err
+		}
+		// 序列号不含时区，按本地时间解释
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.Local), nil
+	}
+	layouts := dateLayouts
+	if format != "" {
+		layouts = []string{format}
+	}
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, raw, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q", raw)
+}
+
+func isBlankRow(cells []string) bool {
+	for _, cell := range cells {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,257 @@
+package excel
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// 导出结构体支持的标签：
+//
+//	label:"用户性别"            表头名称，未设置的字段不导出
+//	dict:"system_user_sex"     字典类型，将字典值渲染为字典标签（如 1 -> "男"）
+//	width:"20"                 列宽
+//	format:"2006-01-02"        时间格式，默认 "2006-01-02 15:04:05"
+//
+// 对应 Java: ExcelUtils#write + DictConvert
+
+// DefaultBatchSize 分批导出时每批查询的数量
+const DefaultBatchSize = 1000
+
+// DictFormatter 将字典值转换为字典标签，不存在时返回原值
+type DictFormatter func(dictType, value string) string
+
+type writeOptions struct {
+	sheet     string
+	dict      DictFormatter
+	batchSize int
+}
+
+// WriteOption 导出选项
+type WriteOption func(*writeOptions)
+
+// WithSheetName 指定 Sheet 名称，默认 "数据"
+func WithSheetName(sheet string) WriteOption {
+	return func(o *writeOptions) { o.sheet = sheet }
+}
+
+// WithDictFormatter 指定字典转换，dict 标签的字段需要
+func WithDictFormatter(formatter DictFormatter) WriteOption {
+	return func(o *writeOptions) { o.dict = formatter }
+}
+
+// WithBatchSize 指定分批导出时每批查询的数量
+func WithBatchSize(size int) WriteOption {
+	return func(o *writeOptions) { o.batchSize = size }
+}
+
+// exportColumn 导出列
+type exportColumn struct {
+	index  int
+	header string
+	dict   string
+	width  float64
+	format string
+}
+
+// StreamWriter 基于 excelize StreamWriter 的流式写入，数据逐行写入临时文件，不在内存中保留整个工作簿
+type StreamWriter[T any] struct {
+	w *streamWriter
+}
+
+// NewStreamWriter 创建流式写入，并写入表头
+func NewStreamWriter[T any](opts ...WriteOption) (*StreamWriter[T], error) {
+	w, err := newStreamWriter(reflect.TypeOf((*T)(nil)).Elem(), opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &StreamWriter[T]{w: w}, nil
+}
+
+// Write 追加数据行
+func (w *StreamWriter[T]) Write(rows ...*T) error {
+	for _, row := range rows {
+		if row == nil {
+			continue
+		}
+		if err := w.w.writeRow(reflect.ValueOf(row).Elem()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteTo 结束写入，并将工作簿输出到 out
+func (w *StreamWriter[T]) WriteTo(out io.Writer) (int64, error) {
+	return w.w.writeTo(out)
+}
+
+// Close 释放临时文件
+func (w *StreamWriter[T]) Close() error {
+	return w.w.file.Close()
+}
+
+// BatchFetcher 按游标分批查询：cursor 为上一批返回的游标（首批为 0），返回本批数据与下一批的游标
+// 通常以主键为游标（WHERE id > cursor ORDER BY id LIMIT limit），避免深分页
+type BatchFetcher[T any] func(cursor int64, limit int) ([]*T, int64, error)
+
+// Export 按游标分批查询并流式写入 out，适用于大数据量导出
+func Export[T any](out io.Writer, fetch BatchFetcher[T], opts ...WriteOption) error {
+	o := newWriteOptions(opts)
+	w, err := NewStreamWriter[T](opts...)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	var cursor int64
+	for {
+		rows, next, err := fetch(cursor, o.batchSize)
+		if err != nil {
+			return err
+		}
+		if err := w.Write(rows...); err != nil {
+			return err
+		}
+		if len(rows) < o.batchSize {
+			break
+		}
+		cursor = next
+	}
+	_, err = w.WriteTo(out)
+	return err
+}
+
+func newWriteOptions(opts []WriteOption) *writeOptions {
+	o := &writeOptions{sheet: "数据", batchSize: DefaultBatchSize}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// streamWriter 非泛型实现，供 WriteExcel 等按反射类型写入的场景复用
+type streamWriter struct {
+	file    *excelize.File
+	sw      *excelize.StreamWriter
+	columns []*exportColumn
+	dict    DictFormatter
+	row     int
+}
+
+func newStreamWriter(elemType reflect.Type, opts ...WriteOption) (*streamWriter, error) {
+	o := newWriteOptions(opts)
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return nil, errors.New("excel: row type must be a struct")
+	}
+	columns := parseExportColumns(elemType)
+	for _, col := range columns {
+		if col.dict != "" && o.dict == nil {
+			return nil, fmt.Errorf("excel: 字段 %s 需要字典转换，请指定 WithDictFormatter", col.header)
+		}
+	}
+
+	f := excelize.NewFile()
+	if err := f.SetSheetName(f.GetSheetName(0), o.sheet); err != nil {
+		f.Close()
+		return nil, err
+	}
+	sw, err := f.NewStreamWriter(o.sheet)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	// 列宽需要在写入数据前设置
+	for i, col := range columns {
+		if col.width <= 0 {
+			continue
+		}
+		if err := sw.SetColWidth(i+1, i+1, col.width); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+	headers := make([]interface{}, len(columns))
+	for i, col := range columns {
+		headers[i] = col.header
+	}
+	if err := sw.SetRow("A1", headers); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &streamWriter{file: f, sw: sw, columns: columns, dict: o.dict, row: 1}, nil
+}
+
+// parseExportColumns 解析带 label 标签的字段
+func parseExportColumns(t reflect.Type) []*exportColumn {
+	var columns []*exportColumn
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		header := field.Tag.Get("label")
+		if header == "" || !field.IsExported() {
+			continue
+		}
+		width, _ := strconv.ParseFloat(field.Tag.Get("width"), 64)
+		columns = append(columns, &exportColumn{
+			index:  i,
+			header: header,
+			dict:   field.Tag.Get("dict"),
+			width:  width,
+			format: field.Tag.Get("format"),
+		})
+	}
+	return columns
+}
+
+func (w *streamWriter) writeRow(v reflect.Value) error {
+	values := make([]interface{}, len(w.columns))
+	for i, col := range w.columns {
+		values[i] = w.cellValue(v.Field(col.index), col)
+	}
+	w.row++
+	cell, err := excelize.CoordinatesToCellName(1, w.row)
+	if err != nil {
+		return err
+	}
+	return w.sw.SetRow(cell, values)
+}
+
+// cellValue 转换字段值：指针解引用，时间格式化，字典值转换为标签
+func (w *streamWriter) cellValue(field reflect.Value, col *exportColumn) interface{} {
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return nil
+		}
+		field = field.Elem()
+	}
+	if field.Type() == timeType {
+		t := field.Interface().(time.Time)
+		if t.IsZero() {
+			return nil
+		}
+		format := col.format
+		if format == "" {
+			format = time.DateTime
+		}
+		return t.Format(format)
+	}
+	if col.dict != "" {
+		return w.dict(col.dict, fmt.Sprint(field.Interface()))
+	}
+	return field.Interface()
+}
+
+func (w *streamWriter) writeTo(out io.Writer) (int64, error) {
+	if err := w.sw.Flush(); err != nil {
+		return 0, err
+	}
+	return w.file.WriteTo(out)
+}
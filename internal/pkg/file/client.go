@@ -1,29 +1,97 @@
 package file
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
+	"time"
 )
 
+// ErrPresignNotSupported 存储不支持预签名直传，需由服务端代理上传
+var ErrPresignNotSupported = errors.New("presigned upload not supported")
+
+// ErrInvalidPath 文件路径不合法，如包含 ".." 跳出存储目录
+var ErrInvalidPath = errors.New("invalid file path")
+
 // FileClient 文件客户端接口
 type FileClient interface {
 	Upload(content []byte, path string) (string, error)
 	Delete(path string) error
 	GetContent(path string) ([]byte, error)
+	// PresignPutObject 获得直传文件的预签名地址，签名限定文件类型与大小，不支持时返回 ErrPresignNotSupported
+	PresignPutObject(path, contentType string, size int64, expires time.Duration) (*PresignedUrl, error)
+}
+
+// PresignedUrl 文件预签名地址
+// 对应 Java: FilePresignedUrlRespDTO
+type PresignedUrl struct {
+	UploadUrl string // 直传地址
+	Url       string // 上传完成后的访问地址
+}
+
+// ObjectInfo 存储中文件的元信息
+type ObjectInfo struct {
+	Size        int64  // 文件大小，单位：字节
+	ContentType string // 文件类型
+	Url         string // 访问地址
+}
+
+// HeadFileClient 支持查询文件元信息的文件客户端，用于直传完成后以存储侧的实际结果登记文件
+type HeadFileClient interface {
+	FileClient
+	HeadObject(ctx context.Context, path string) (*ObjectInfo, error)
+}
+
+// StreamFileClient 支持流式上传的文件客户端，大文件无需整体读入内存
+type StreamFileClient interface {
+	FileClient
+	UploadStream(ctx context.Context, r io.Reader, path, contentType string) (string, error)
+}
+
+// 文件存储器
+// 对应 Java: FileStorageEnum
+const (
+	StorageDB    int32 = 1
+	StorageLocal int32 = 10
+	StorageFTP   int32 = 11
+	StorageSFTP  int32 = 12
+	StorageS3    int32 = 20
+)
+
+// FileContentStore 文件内容的存储，DB 存储器使用，由 service 层基于 infra_file_content 表实现
+type FileContentStore interface {
+	SaveContent(configId int64, path string, content []byte) error
+	DeleteContent(configId int64, path string) error
+	GetContent(configId int64, path string) ([]byte, error)
 }
 
 // ClientConfig 客户端配置通用结构 (用于解析 JSON)
 type ClientConfig struct {
 	Domain   string `json:"domain"`
 	BasePath string `json:"basePath"` // Local 使用
-	// S3 相关字段可按需添加
-	Endpoint  string `json:"endpoint"`
-	AccessKey string `json:"accessKey"`
-	SecretKey string `json:"secretKey"`
-	Bucket    string `json:"bucket"`
+	// S3 使用
+	Endpoint              string `json:"endpoint"`
+	AccessKey             string `json:"accessKey"`
+	SecretKey             string `json:"secretKey"`
+	AccessSecret          string `json:"accessSecret"` // 同 SecretKey，兼容 Java 的配置
+	Bucket                string `json:"bucket"`
+	EnablePathStyleAccess bool   `json:"enablePathStyleAccess"` // 是否使用 Path Style 访问，MinIO 需开启
+	// FTP、SFTP 使用，BasePath 为远程目录
+	Host                 string `json:"host"`
+	Port                 int    `json:"port"`
+	Username             string `json:"username"`
+	Password             string `json:"password"`
+	Mode                 string `json:"mode"`                 // FTP 连接模式，仅支持 Passive
+	PrivateKey           string `json:"privateKey"`           // SFTP 私钥（PEM），配置后优先使用密钥认证
+	PrivateKeyPassphrase string `json:"privateKeyPassphrase"` // SFTP 私钥密码
+	HostKey              string `json:"hostKey"`              // SFTP 服务端公钥（authorized_keys 格式），必填
 }
 
 // LocalFileClient 本地文件客户端
@@ -62,13 +130,54 @@ func (c *LocalFileClient) GetContent(path string) ([]byte, error) {
 	return ioutil.ReadFile(fullPath)
 }
 
+// PresignPutObject 本地存储不支持直传，由服务端代理上传
+func (c *LocalFileClient) PresignPutObject(path, contentType string, size int64, expires time.Duration) (*PresignedUrl, error) {
+	return nil, ErrPresignNotSupported
+}
+
+// CleanPath 规范化相对于存储目录的文件路径，路径为空或跳出存储目录时返回 ErrInvalidPath
+func CleanPath(filePath string) (string, error) {
+	cleaned := path.Clean(strings.TrimPrefix(filePath, "/"))
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") || path.IsAbs(cleaned) {
+		return "", ErrInvalidPath
+	}
+	return cleaned, nil
+}
+
+// resolvePath 获得文件在存储目录 basePath 下的完整路径，结果不在 basePath 下时返回 ErrInvalidPath
+func resolvePath(basePath, filePath string) (string, error) {
+	cleaned, err := CleanPath(filePath)
+	if err != nil {
+		return "", err
+	}
+	base := path.Clean(basePath)
+	fullPath := path.Join(base, cleaned)
+	if base != "/" && fullPath != base && !strings.HasPrefix(fullPath, base+"/") {
+		return "", ErrInvalidPath
+	}
+	return fullPath, nil
+}
+
+// buildFileUrl 通过 FileService.GetFileContent 访问的文件地址，DB、FTP、SFTP 存储使用
+// 对应 Java: AbstractFileClient#formatFileUrl
+func buildFileUrl(domain string, configId int64, path string) string {
+	return fmt.Sprintf("%s/admin-api/infra/file/%d/get/%s", strings.TrimRight(domain, "/"), configId, path)
+}
+
 // FileClientFactory 简单工厂
-func NewFileClient(storage int32, config json.RawMessage) (FileClient, error) {
+// configId 用于生成 DB、FTP、SFTP 文件的访问地址；store 仅 DB 存储器使用
+func NewFileClient(configId int64, storage int32, config json.RawMessage, store FileContentStore) (FileClient, error) {
 	switch storage {
-	case 10: // Local
+	case StorageDB:
+		return NewDBFileClient(configId, config, store)
+	case StorageLocal:
 		return NewLocalFileClient(config)
-	case 20: // S3
-		return nil, errors.New("S3 storage not implemented yet")
+	case StorageFTP:
+		return NewFtpFileClient(configId, config)
+	case StorageSFTP:
+		return NewSftpFileClient(configId, config)
+	case StorageS3:
+		return NewS3FileClient(config)
 	default:
 		return nil, errors.New("unknown storage type")
 	}
@@ -0,0 +1,46 @@
+package file
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// DBFileClient 数据库文件客户端，文件内容存储在 infra_file_content 表
+// 对应 Java: DBFileClient
+type DBFileClient struct {
+	Config   ClientConfig
+	configId int64
+	store    FileContentStore
+}
+
+func NewDBFileClient(configId int64, config json.RawMessage, store FileContentStore) (*DBFileClient, error) {
+	var cfg ClientConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return nil, err
+	}
+	if store == nil {
+		return nil, errors.New("DB 存储器未配置文件内容存储")
+	}
+	return &DBFileClient{Config: cfg, configId: configId, store: store}, nil
+}
+
+func (c *DBFileClient) Upload(content []byte, path string) (string, error) {
+	if err := c.store.SaveContent(c.configId, path, content); err != nil {
+		return "", err
+	}
+	return buildFileUrl(c.Config.Domain, c.configId, path), nil
+}
+
+func (c *DBFileClient) Delete(path string) error {
+	return c.store.DeleteContent(c.configId, path)
+}
+
+func (c *DBFileClient) GetContent(path string) ([]byte, error) {
+	return c.store.GetContent(c.configId, path)
+}
+
+// PresignPutObject DB 存储不支持直传，由服务端代理上传
+func (c *DBFileClient) PresignPutObject(path, contentType string, size int64, expires time.Duration) (*PresignedUrl, error) {
+	return nil, ErrPresignNotSupported
+}
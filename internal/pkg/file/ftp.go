@@ -0,0 +1,122 @@
+package file
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jlaffaye/ftp"
+)
+
+const (
+	ftpDefaultPort = 21
+	ftpModePassive = "Passive"
+	ftpTimeout     = 30 * time.Second
+)
+
+// FtpFileClient FTP 文件客户端，每次操作建立一个连接
+// 对应 Java: FtpFileClient
+type FtpFileClient struct {
+	Config   ClientConfig
+	configId int64
+}
+
+func NewFtpFileClient(configId int64, config json.RawMessage) (*FtpFileClient, error) {
+	var cfg ClientConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return nil, err
+	}
+	if cfg.Host == "" || cfg.Username == "" {
+		return nil, errors.New("FTP 配置的 host、username 不能为空")
+	}
+	if cfg.Mode != "" && !strings.EqualFold(cfg.Mode, ftpModePassive) {
+		return nil, errors.New("FTP 仅支持被动模式（Passive）")
+	}
+	if cfg.Port == 0 {
+		cfg.Port = ftpDefaultPort
+	}
+	return &FtpFileClient{Config: cfg, configId: configId}, nil
+}
+
+func (c *FtpFileClient) Upload(content []byte, filePath string) (string, error) {
+	fullPath, err := resolvePath(c.Config.BasePath, filePath)
+	if err != nil {
+		return "", err
+	}
+	err = c.withConn(func(conn *ftp.ServerConn) error {
+		c.mkdirAll(conn, path.Dir(fullPath))
+		return conn.Stor(fullPath, bytes.NewReader(content))
+	})
+	if err != nil {
+		return "", err
+	}
+	return buildFileUrl(c.Config.Domain, c.configId, filePath), nil
+}
+
+func (c *FtpFileClient) Delete(filePath string) error {
+	fullPath, err := resolvePath(c.Config.BasePath, filePath)
+	if err != nil {
+		return err
+	}
+	return c.withConn(func(conn *ftp.ServerConn) error {
+		return conn.Delete(fullPath)
+	})
+}
+
+func (c *FtpFileClient) GetContent(filePath string) ([]byte, error) {
+	fullPath, err := resolvePath(c.Config.BasePath, filePath)
+	if err != nil {
+		return nil, err
+	}
+	var content []byte
+	err = c.withConn(func(conn *ftp.ServerConn) error {
+		r, err := conn.Retr(fullPath)
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+		content, err = io.ReadAll(r)
+		return err
+	})
+	return content, err
+}
+
+// PresignPutObject FTP 不支持直传，由服务端代理上传
+func (c *FtpFileClient) PresignPutObject(path, contentType string, size int64, expires time.Duration) (*PresignedUrl, error) {
+	return nil, ErrPresignNotSupported
+}
+
+// withConn 建立连接并登录，执行完成后断开
+func (c *FtpFileClient) withConn(fn func(conn *ftp.ServerConn) error) error {
+	addr := net.JoinHostPort(c.Config.Host, strconv.Itoa(c.Config.Port))
+	conn, err := ftp.Dial(addr, ftp.DialWithTimeout(ftpTimeout))
+	if err != nil {
+		return err
+	}
+	defer conn.Quit()
+	if err := conn.Login(c.Config.Username, c.Config.Password); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+// mkdirAll 逐级创建目录，目录已存在时忽略错误
+func (c *FtpFileClient) mkdirAll(conn *ftp.ServerConn, dir string) {
+	if dir == "" || dir == "." || dir == "/" {
+		return
+	}
+	current := ""
+	if strings.HasPrefix(dir, "/") {
+		current = "/"
+	}
+	for _, segment := range strings.Split(strings.Trim(dir, "/"), "/") {
+		current = path.Join(current, segment)
+		_ = conn.MakeDir(current)
+	}
+}
@@ -0,0 +1,449 @@
+package file
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// s3PartSize 分片上传的分片大小，S3 要求除最后一片外不小于 5MB
+	s3PartSize = 8 << 20
+	// s3DefaultRegion MinIO 等未区分区域的服务使用的默认区域
+	s3DefaultRegion = "us-east-1"
+
+	s3EndpointAliyun  = "aliyuncs.com"
+	s3EndpointTencent = "myqcloud.com"
+	s3EndpointQiniu   = "qiniucs.com"
+)
+
+// S3FileClient 基于 S3 协议的文件客户端，兼容 MinIO、阿里云 OSS、腾讯云 COS、七牛云等
+// 对应 Java: S3FileClient
+type S3FileClient struct {
+	Config     ClientConfig
+	endpoint   *url.URL
+	region     string
+	secretKey  string
+	httpClient *http.Client
+}
+
+func NewS3FileClient(config json.RawMessage) (*S3FileClient, error) {
+	var cfg ClientConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return nil, err
+	}
+	if cfg.Endpoint == "" || cfg.Bucket == "" || cfg.AccessKey == "" {
+		return nil, errors.New("S3 配置的 endpoint、bucket、accessKey 不能为空")
+	}
+	// 兼容 Java 配置中的 accessSecret 字段
+	secretKey := cfg.SecretKey
+	if secretKey == "" {
+		secretKey = cfg.AccessSecret
+	}
+	rawEndpoint := cfg.Endpoint
+	if !strings.HasPrefix(rawEndpoint, "http://") && !strings.HasPrefix(rawEndpoint, "https://") {
+		rawEndpoint = "https://" + rawEndpoint
+	}
+	endpoint, err := url.Parse(strings.TrimRight(rawEndpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("S3 endpoint 格式不正确: %w", err)
+	}
+	c := &S3FileClient{
+		Config:     cfg,
+		endpoint:   endpoint,
+		region:     buildS3Region(endpoint.Hostname()),
+		secretKey:  secretKey,
+		httpClient: &http.Client{Timeout: 5 * time.Minute},
+	}
+	if c.Config.Domain == "" {
+		c.Config.Domain = c.buildDomain()
+	}
+	c.Config.Domain = strings.TrimRight(c.Config.Domain, "/")
+	return c, nil
+}
+
+// buildS3Region 根据 endpoint 推断区域，阿里云、腾讯云、七牛云必须指定区域，否则签名校验失败
+func buildS3Region(host string) string {
+	switch {
+	case strings.HasSuffix(host, s3EndpointAliyun):
+		// oss-cn-beijing.aliyuncs.com、oss-cn-beijing-internal.aliyuncs.com
+		region, _, _ := strings.Cut(host, ".")
+		return strings.TrimPrefix(strings.TrimSuffix(region, "-internal"), "oss-")
+	case strings.HasSuffix(host, s3EndpointTencent):
+		// cos.ap-guangzhou.myqcloud.com
+		return strings.TrimSuffix(strings.TrimPrefix(host, "cos."), "."+s3EndpointTencent)
+	case strings.HasSuffix(host, s3EndpointQiniu):
+		// s3.cn-south-1.qiniucs.com、s3-cn-south-1.qiniucs.com
+		region := strings.TrimSuffix(host, "."+s3EndpointQiniu)
+		return strings.TrimPrefix(strings.TrimPrefix(region, "s3."), "s3-")
+	default:
+		return s3DefaultRegion
+	}
+}
+
+// buildDomain 未配置自定义域名时，使用 endpoint + bucket 作为访问域名
+func (c *S3FileClient) buildDomain() string {
+	if c.Config.EnablePathStyleAccess {
+		return c.endpoint.String() + "/" + c.Config.Bucket
+	}
+	return c.endpoint.Scheme + "://" + c.Config.Bucket + "." + c.endpoint.Host
+}
+
+func (c *S3FileClient) Upload(content []byte, path string) (string, error) {
+	return c.UploadStream(context.Background(), bytes.NewReader(content), path, detectContentType(path, content))
+}
+
+// UploadStream 流式上传。小于一个分片时直接上传，否则使用分片上传，内存中最多只保留一个分片
+func (c *S3FileClient) UploadStream(ctx context.Context, r io.Reader, path, contentType string) (string, error) {
+	buf := make([]byte, s3PartSize)
+	n, err := io.ReadFull(r, buf)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		if err := c.putObject(ctx, path, buf[:n], contentType); err != nil {
+			return "", err
+		}
+		return c.Config.Domain + "/" + path, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	uploadId, err := c.createMultipartUpload(ctx, path, contentType)
+	if err != nil {
+		return "", err
+	}
+	if err := c.uploadParts(ctx, path, uploadId, buf, r); err != nil {
+		// 上传失败时取消分片上传，释放已上传的分片
+		if abortErr := c.abortMultipartUpload(context.Background(), path, uploadId); abortErr != nil {
+			return "", fmt.Errorf("%w (abort failed: %v)", err, abortErr)
+		}
+		return "", err
+	}
+	return c.Config.Domain + "/" + path, nil
+}
+
+func (c *S3FileClient) Delete(path string) error {
+	_, err := c.do(context.Background(), http.MethodDelete, path, nil, nil, nil)
+	return err
+}
+
+func (c *S3FileClient) GetContent(path string) ([]byte, error) {
+	return c.do(context.Background(), http.MethodGet, path, nil, nil, nil)
+}
+
+// HeadObject 查询文件的实际大小与类型
+func (c *S3FileClient) HeadObject(ctx context.Context, path string) (*ObjectInfo, error) {
+	req, err := c.newRequest(ctx, http.MethodHead, path, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return &ObjectInfo{
+		Size:        resp.ContentLength,
+		ContentType: resp.Header.Get("Content-Type"),
+		Url:         c.Config.Domain + "/" + path,
+	}, nil
+}
+
+func (c *S3FileClient) putObject(ctx context.Context, path string, content []byte, contentType string) error {
+	_, err := c.do(ctx, http.MethodPut, path, nil, map[string]string{"Content-Type": contentType}, content)
+	return err
+}
+
+// uploadParts 上传所有分片并完成分片上传，first 为已读取的第一个分片
+func (c *S3FileClient) uploadParts(ctx context.Context, path, uploadId string, first []byte, r io.Reader) error {
+	var parts []s3CompletedPart
+	part := first
+	for partNumber := 1; ; partNumber++ {
+		etag, err := c.uploadPart(ctx, path, uploadId, partNumber, part)
+		if err != nil {
+			return err
+		}
+		parts = append(parts, s3CompletedPart{PartNumber: partNumber, ETag: etag})
+
+		n, err := io.ReadFull(r, first)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return err
+		}
+		part = first[:n]
+	}
+	return c.completeMultipartUpload(ctx, path, uploadId, parts)
+}
+
+func (c *S3FileClient) createMultipartUpload(ctx context.Context, path, contentType string) (string, error) {
+	body, err := c.do(ctx, http.MethodPost, path, url.Values{"uploads": {""}}, map[string]string{"Content-Type": contentType}, nil)
+	if err != nil {
+		return "", err
+	}
+	var result struct {
+		UploadId string `xml:"UploadId"`
+	}
+	if err := xml.Unmarshal(body, &result); err != nil {
+		return "", err
+	}
+	if result.UploadId == "" {
+		return "", errors.New("S3 创建分片上传失败: 未返回 UploadId")
+	}
+	return result.UploadId, nil
+}
+
+func (c *S3FileClient) uploadPart(ctx context.Context, path, uploadId string, partNumber int, content []byte) (string, error) {
+	query := url.Values{"partNumber": {strconv.Itoa(partNumber)}, "uploadId": {uploadId}}
+	req, err := c.newRequest(ctx, http.MethodPut, path, query, nil, content)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.send(req)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	return resp.Header.Get("ETag"), nil
+}
+
+type s3CompletedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+func (c *S3FileClient) completeMultipartUpload(ctx context.Context, path, uploadId string, parts []s3CompletedPart) error {
+	payload, err := xml.Marshal(struct {
+		XMLName xml.Name          `xml:"CompleteMultipartUpload"`
+		Parts   []s3CompletedPart `xml:"Part"`
+	}{Parts: parts})
+	if err != nil {
+		return err
+	}
+	body, err := c.do(ctx, http.MethodPost, path, url.Values{"uploadId": {uploadId}}, map[string]string{"Content-Type": "application/xml"}, payload)
+	if err != nil {
+		return err
+	}
+	// 完成分片上传可能返回 200 但响应体为错误信息
+	if s3Err := parseS3Error(body); s3Err != nil {
+		return s3Err
+	}
+	return nil
+}
+
+func (c *S3FileClient) abortMultipartUpload(ctx context.Context, path, uploadId string) error {
+	_, err := c.do(ctx, http.MethodDelete, path, url.Values{"uploadId": {uploadId}}, nil, nil)
+	return err
+}
+
+// do 发起签名请求并读取响应体
+func (c *S3FileClient) do(ctx context.Context, method, path string, query url.Values, headers map[string]string, payload []byte) ([]byte, error) {
+	req, err := c.newRequest(ctx, method, path, query, headers, payload)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+func (c *S3FileClient) send(req *http.Request) (*http.Response, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if s3Err := parseS3Error(body); s3Err != nil {
+		return nil, fmt.Errorf("S3 %s %s 失败: %w", req.Method, req.URL.Path, s3Err)
+	}
+	return nil, fmt.Errorf("S3 %s %s 失败: http %d", req.Method, req.URL.Path, resp.StatusCode)
+}
+
+type s3Error struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+func (e *s3Error) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+func parseS3Error(body []byte) error {
+	var e struct {
+		XMLName xml.Name
+		s3Error
+	}
+	if err := xml.Unmarshal(body, &e); err != nil || e.XMLName.Local != "Error" {
+		return nil
+	}
+	return &e.s3Error
+}
+
+// newRequest 构建请求并使用 AWS Signature Version 4 签名
+// 参考 https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html
+func (c *S3FileClient) newRequest(ctx context.Context, method, path string, query url.Values, headers map[string]string, payload []byte) (*http.Request, error) {
+	host, uri := c.objectLocation(path)
+	rawQuery := s3CanonicalQuery(query)
+	reqURL := c.endpoint.Scheme + "://" + host + uri
+	if rawQuery != "" {
+		reqURL += "?" + rawQuery
+	}
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := s3SHA256Hex(payload)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	// 参与签名的请求头：host 与所有 x-amz-* 头，以及 content-type
+	signHeaders := map[string]string{"host": host}
+	for k := range req.Header {
+		lk := strings.ToLower(k)
+		if strings.HasPrefix(lk, "x-amz-") || lk == "content-type" {
+			signHeaders[lk] = strings.TrimSpace(req.Header.Get(k))
+		}
+	}
+	names := make([]string, 0, len(signHeaders))
+	for k := range signHeaders {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, k := range names {
+		canonicalHeaders.WriteString(k + ":" + signHeaders[k] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{method, uri, rawQuery, canonicalHeaders.String(), signedHeaders, payloadHash}, "\n")
+	scope := date + "/" + c.region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, s3SHA256Hex([]byte(canonicalRequest))}, "\n")
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.Config.AccessKey, scope, signedHeaders, c.sign(date, stringToSign)))
+	return req, nil
+}
+
+// PresignPutObject 生成预签名上传地址。客户端需使用 PUT 方法，并携带相同的 Content-Type、Content-Length 直传
+// 参考 https://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-query-string-auth.html
+func (c *S3FileClient) PresignPutObject(path, contentType string, size int64, expires time.Duration) (*PresignedUrl, error) {
+	host, uri := c.objectLocation(path)
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	scope := date + "/" + c.region + "/s3/aws4_request"
+
+	// 签名包含 content-length、content-type，限制客户端只能上传申请时声明的文件大小与类型
+	signedHeaders := "content-length;content-type;host"
+	query := url.Values{
+		"X-Amz-Algorithm":     {"AWS4-HMAC-SHA256"},
+		"X-Amz-Credential":    {c.Config.AccessKey + "/" + scope},
+		"X-Amz-Date":          {amzDate},
+		"X-Amz-Expires":       {strconv.Itoa(int(expires.Seconds()))},
+		"X-Amz-SignedHeaders": {signedHeaders},
+	}
+	rawQuery := s3CanonicalQuery(query)
+	canonicalHeaders := "content-length:" + strconv.FormatInt(size, 10) + "\n" + "content-type:" + contentType + "\n" + "host:" + host + "\n"
+	canonicalRequest := strings.Join([]string{http.MethodPut, uri, rawQuery, canonicalHeaders, signedHeaders, "UNSIGNED-PAYLOAD"}, "\n")
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, s3SHA256Hex([]byte(canonicalRequest))}, "\n")
+	return &PresignedUrl{
+		UploadUrl: c.endpoint.Scheme + "://" + host + uri + "?" + rawQuery + "&X-Amz-Signature=" + c.sign(date, stringToSign),
+		Url:       c.Config.Domain + "/" + path,
+	}, nil
+}
+
+// objectLocation 对象的请求域名与路径，Path Style 为 endpoint/bucket/path，否则为 bucket.endpoint/path
+func (c *S3FileClient) objectLocation(path string) (host, uri string) {
+	if c.Config.EnablePathStyleAccess {
+		return c.endpoint.Host, c.endpoint.EscapedPath() + "/" + c.Config.Bucket + "/" + s3EscapePath(path)
+	}
+	return c.Config.Bucket + "." + c.endpoint.Host, c.endpoint.EscapedPath() + "/" + s3EscapePath(path)
+}
+
+// sign 使用派生密钥计算签名
+func (c *S3FileClient) sign(date, stringToSign string) string {
+	signingKey := s3HMAC([]byte("AWS4"+c.secretKey), date)
+	signingKey = s3HMAC(signingKey, c.region)
+	signingKey = s3HMAC(signingKey, "s3")
+	signingKey = s3HMAC(signingKey, "aws4_request")
+	return hex.EncodeToString(s3HMAC(signingKey, stringToSign))
+}
+
+// s3EscapePath 按 SigV4 规则编码对象路径，保留 "/"
+func s3EscapePath(path string) string {
+	segments := strings.Split(strings.TrimLeft(path, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = s3Escape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// s3CanonicalQuery 按参数名排序并编码查询参数
+func s3CanonicalQuery(query url.Values) string {
+	if len(query) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, s3Escape(k)+"="+s3Escape(query.Get(k)))
+	}
+	return strings.Join(pairs, "&")
+}
+
+// s3Escape 除 A-Z a-z 0-9 - _ . ~ 外均需编码
+func s3Escape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+func s3SHA256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func s3HMAC(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// detectContentType 根据文件扩展名推断类型，无法推断时根据内容推断
+func detectContentType(path string, content []byte) string {
+	if contentType := mime.TypeByExtension(filepath.Ext(path)); contentType != "" {
+		return contentType
+	}
+	return http.DetectContentType(content)
+}
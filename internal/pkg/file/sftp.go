@@ -0,0 +1,157 @@
+package file
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	sftpDefaultPort = 22
+	sftpTimeout     = 30 * time.Second
+)
+
+// SftpFileClient SFTP 文件客户端，支持密码与密钥认证，每次操作建立一个连接
+// 对应 Java: SftpFileClient
+type SftpFileClient struct {
+	Config    ClientConfig
+	configId  int64
+	sshConfig *ssh.ClientConfig
+}
+
+func NewSftpFileClient(configId int64, config json.RawMessage) (*SftpFileClient, error) {
+	var cfg ClientConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return nil, err
+	}
+	if cfg.Host == "" || cfg.Username == "" {
+		return nil, errors.New("SFTP 配置的 host、username 不能为空")
+	}
+	if cfg.Port == 0 {
+		cfg.Port = sftpDefaultPort
+	}
+
+	// 认证方式：配置私钥时优先使用密钥认证，同时配置密码时作为备选
+	var auth []ssh.AuthMethod
+	if cfg.PrivateKey != "" {
+		signer, err := parsePrivateKey(cfg.PrivateKey, cfg.PrivateKeyPassphrase)
+		if err != nil {
+			return nil, fmt.Errorf("SFTP 私钥格式不正确: %w", err)
+		}
+		auth = append(auth, ssh.PublicKeys(signer))
+	}
+	if cfg.Password != "" {
+		auth = append(auth, ssh.Password(cfg.Password))
+	}
+	if len(auth) == 0 {
+		return nil, errors.New("SFTP 配置的 password、privateKey 不能同时为空")
+	}
+
+	// 必须校验服务端公钥，避免中间人攻击
+	if cfg.HostKey == "" {
+		return nil, errors.New("SFTP 配置的 hostKey 不能为空")
+	}
+	hostKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(cfg.HostKey))
+	if err != nil {
+		return nil, fmt.Errorf("SFTP 服务端公钥格式不正确: %w", err)
+	}
+	return &SftpFileClient{
+		Config:   cfg,
+		configId: configId,
+		sshConfig: &ssh.ClientConfig{
+			User:            cfg.Username,
+			Auth:            auth,
+			HostKeyCallback: ssh.FixedHostKey(hostKey),
+			Timeout:         sftpTimeout,
+		},
+	}, nil
+}
+
+func parsePrivateKey(privateKey, passphrase string) (ssh.Signer, error) {
+	if passphrase != "" {
+		return ssh.ParsePrivateKeyWithPassphrase([]byte(privateKey), []byte(passphrase))
+	}
+	return ssh.ParsePrivateKey([]byte(privateKey))
+}
+
+func (c *SftpFileClient) Upload(content []byte, filePath string) (string, error) {
+	fullPath, err := resolvePath(c.Config.BasePath, filePath)
+	if err != nil {
+		return "", err
+	}
+	err = c.withClient(func(client *sftp.Client) error {
+		if err := client.MkdirAll(path.Dir(fullPath)); err != nil {
+			return err
+		}
+		f, err := client.Create(fullPath)
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(content); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	})
+	if err != nil {
+		return "", err
+	}
+	return buildFileUrl(c.Config.Domain, c.configId, filePath), nil
+}
+
+func (c *SftpFileClient) Delete(filePath string) error {
+	fullPath, err := resolvePath(c.Config.BasePath, filePath)
+	if err != nil {
+		return err
+	}
+	return c.withClient(func(client *sftp.Client) error {
+		return client.Remove(fullPath)
+	})
+}
+
+func (c *SftpFileClient) GetContent(filePath string) ([]byte, error) {
+	fullPath, err := resolvePath(c.Config.BasePath, filePath)
+	if err != nil {
+		return nil, err
+	}
+	var content []byte
+	err = c.withClient(func(client *sftp.Client) error {
+		f, err := client.Open(fullPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		content, err = io.ReadAll(f)
+		return err
+	})
+	return content, err
+}
+
+// PresignPutObject SFTP 不支持直传，由服务端代理上传
+func (c *SftpFileClient) PresignPutObject(path, contentType string, size int64, expires time.Duration) (*PresignedUrl, error) {
+	return nil, ErrPresignNotSupported
+}
+
+// withClient 建立 SSH 连接与 SFTP 会话，执行完成后断开
+func (c *SftpFileClient) withClient(fn func(client *sftp.Client) error) error {
+	addr := net.JoinHostPort(c.Config.Host, strconv.Itoa(c.Config.Port))
+	conn, err := ssh.Dial("tcp", addr, c.sshConfig)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client, err := sftp.NewClient(conn)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return fn(client)
+}
@@ -0,0 +1,10 @@
+package utils
+
+// MaskMobile 手机号脱敏，保留前 3 位与后 4 位，如 138****1234；长度不足 7 位时原样返回
+// 对应 Java: DesensitizedUtil.mobilePhone
+func MaskMobile(mobile string) string {
+	if len(mobile) < 7 {
+		return mobile
+	}
+	return mobile[:3] + "****" + mobile[len(mobile)-4:]
+}
@@ -22,6 +22,7 @@ type AuthService struct {
 	loginLogSvc   *LoginLogService
 	userSvc       *UserService
 	socialUserSvc *SocialUserService
+	captchaSvc    *CaptchaService
 }
 
 func NewAuthService(
@@ -34,6 +35,7 @@ func NewAuthService(
 	loginLogSvc *LoginLogService,
 	userSvc *UserService,
 	socialUserSvc *SocialUserService,
+	captchaSvc *CaptchaService,
 ) *AuthService {
 	return &AuthService{
 		repo:          repo,
@@ -45,6 +47,7 @@ func NewAuthService(
 		loginLogSvc:   loginLogSvc,
 		userSvc:       userSvc,
 		socialUserSvc: socialUserSvc,
+		captchaSvc:    captchaSvc,
 	}
 }
 
@@ -194,6 +197,15 @@ func (s *AuthService) GetPermissionInfo(ctx context.Context) (*resp.AuthPermissi
 
 // Login 登录业务
 func (s *AuthService) Login(ctx context.Context, req *req.AuthLoginReq) (*resp.AuthLoginResp, error) {
+	// 校验验证码
+	if err := s.captchaSvc.ValidateCaptcha(ctx, req.CaptchaVerification); err != nil {
+		return nil, err
+	}
+	return s.login(ctx, req)
+}
+
+// login 账号密码登录，不校验验证码
+func (s *AuthService) login(ctx context.Context, req *req.AuthLoginReq) (*resp.AuthLoginResp, error) {
 	// 0. 解析租户
 	var tenantId int64 = 1 // 默认租户ID
 	if req.TenantName != "" {
@@ -315,8 +327,8 @@ func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (*r
 
 // SmsLogin 短信登录
 func (s *AuthService) SmsLogin(ctx context.Context, req *req.AuthSmsLoginReq) (*resp.AuthLoginResp, error) {
-	// 1. 验证短信验证码 (场景: 1-登录)
-	if err := s.smsCodeSvc.ValidateSmsCode(ctx, req.Mobile, 1, req.Code); err != nil {
+	// 1. 验证短信验证码 (场景: 21-后台用户手机号登录)
+	if err := s.smsCodeSvc.ValidateSmsCode(ctx, req.Mobile, SmsSceneAdminLogin, req.Code); err != nil {
 		return nil, err
 	}
 
@@ -356,6 +368,9 @@ func (s *AuthService) SmsLogin(ctx context.Context, req *req.AuthSmsLoginReq) (*
 
 // SendSmsCode 发送短信验证码
 func (s *AuthService) SendSmsCode(ctx context.Context, req *req.AuthSmsSendReq) error {
+	if err := s.captchaSvc.ValidateCaptcha(ctx, req.CaptchaVerification); err != nil {
+		return err
+	}
 	return s.smsCodeSvc.SendSmsCode(ctx, req.Mobile, req.Scene)
 }
 
@@ -363,6 +378,9 @@ func (s *AuthService) SendSmsCode(ctx context.Context, req *req.AuthSmsSendReq)
 func (s *AuthService) Register(ctx context.Context, r *req.AuthRegisterReq) (*resp.AuthLoginResp, error) {
 	// 0. 参数校验
 	// TODO: 校验密码强度等 (Java: Validator)
+	if err := s.captchaSvc.ValidateCaptcha(ctx, r.CaptchaVerification); err != nil {
+		return nil, err
+	}
 
 	// 1. 创建用户
 	createReq := &req.UserSaveReq{
@@ -379,19 +397,24 @@ func (s *AuthService) Register(ctx context.Context, r *req.AuthRegisterReq) (*re
 		return nil, err
 	}
 
-	// 2. 自动登录
+	// 2. 自动登录（验证码已在注册时校验）
 	// 构造登录请求 Mock
 	loginReq := &req.AuthLoginReq{
 		Username: r.Username,
 		Password: r.Password,
 	}
-	return s.Login(ctx, loginReq)
+	return s.login(ctx, loginReq)
 }
 
 // ResetPassword 重置密码
 func (s *AuthService) ResetPassword(ctx context.Context, req *req.AuthResetPasswordReq) error {
-	// 1. 验证短信验证码 (场景: 3-重置密码)
-	if err := s.smsCodeSvc.ValidateSmsCode(ctx, req.Mobile, 3, req.Code); err != nil {
+	// 0. 校验验证码
+	if err := s.captchaSvc.ValidateCaptcha(ctx, req.CaptchaVerification); err != nil {
+		return err
+	}
+
+	// 1. 验证短信验证码 (场景: 23-后台用户忘记密码)
+	if err := s.smsCodeSvc.ValidateSmsCode(ctx, req.Mobile, SmsSceneAdminResetPassword, req.Code); err != nil {
 		return err
 	}
 
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"backend-go/internal/api/req"
+	"backend-go/internal/api/resp"
+	"backend-go/internal/pkg/captcha"
+	"backend-go/internal/pkg/core"
+	"backend-go/pkg/config"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// CaptchaTypeBlockPuzzle 滑块拼图验证码
+	CaptchaTypeBlockPuzzle = "blockPuzzle"
+
+	// CaptchaEnableConfigKey 是否开启验证码的参数配置键，值为 true / false
+	// 可通过 CaptchaEnableConfigKey + "." + 租户编号 为单个租户单独配置，优先级高于全局配置
+	CaptchaEnableConfigKey = "system.captcha.enable"
+
+	captchaCacheKey       = "RUNNING:CAPTCHA:%s"
+	captchaSecondCacheKey = "RUNNING:CAPTCHA:second-%s"
+	// captchaExpire 验证码有效期
+	captchaExpire = 2 * time.Minute
+	// captchaSecondExpire 校验通过后，二次校验凭证的有效期
+	captchaSecondExpire = 3 * time.Minute
+	// captchaSlipOffset 滑块横坐标允许的误差
+	captchaSlipOffset = 5
+)
+
+// AJ-Captcha 响应码
+// 对应 Java: RepCodeEnum
+const (
+	CaptchaRepCodeSuccess    = "0000"
+	CaptchaRepCodeNullError  = "0011"
+	CaptchaRepCodeInvalid    = "6110"
+	CaptchaRepCodeCoordError = "6111"
+	CaptchaRepCodeError      = "6112"
+)
+
+// captchaPoint 缓存的验证码答案
+type captchaPoint struct {
+	X         int    `json:"x"`
+	Y         int    `json:"y"`
+	SecretKey string `json:"secretKey"`
+}
+
+// CaptchaService 行为验证码，兼容 AJ-Captcha 前端组件
+// 对应 Java: CaptchaService（AJ-Captcha）+ CaptchaController
+type CaptchaService struct {
+	rdb       *redis.Client
+	configSvc *ConfigService
+}
+
+func NewCaptchaService(rdb *redis.Client, configSvc *ConfigService) *CaptchaService {
+	return &CaptchaService{rdb: rdb, configSvc: configSvc}
+}
+
+// GetCaptcha 获取验证码
+func (s *CaptchaService) GetCaptcha(ctx context.Context, r *req.CaptchaReq) *resp.CaptchaResp {
+	if r.CaptchaType == "" {
+		return CaptchaFailResp(CaptchaRepCodeNullError, "captchaType不能为空")
+	}
+	if r.CaptchaType != CaptchaTypeBlockPuzzle {
+		return CaptchaFailResp(CaptchaRepCodeError, "不支持的验证码类型："+r.CaptchaType)
+	}
+
+	puzzle, err := captcha.NewBlockPuzzle()
+	if err != nil {
+		zap.L().Error("generate block puzzle failed", zap.Error(err))
+		return CaptchaFailResp(CaptchaRepCodeError, "获取验证码失败,请联系管理员")
+	}
+	token, err := randomHex(16)
+	if err != nil {
+		return CaptchaFailResp(CaptchaRepCodeError, "获取验证码失败,请联系管理员")
+	}
+	secretKey, err := randomHex(8)
+	if err != nil {
+		return CaptchaFailResp(CaptchaRepCodeError, "获取验证码失败,请联系管理员")
+	}
+
+	point, _ := json.Marshal(captchaPoint{X: puzzle.X, Y: puzzle.Y, SecretKey: secretKey})
+	if err := s.rdb.Set(ctx, fmt.Sprintf(captchaCacheKey, token), point, captchaExpire).Err(); err != nil {
+		zap.L().Error("save captcha failed", zap.Error(err))
+		return CaptchaFailResp(CaptchaRepCodeError, "获取验证码失败,请联系管理员")
+	}
+	return captchaSuccess(&resp.CaptchaDataResp{
+		CaptchaType:         CaptchaTypeBlockPuzzle,
+		Token:               token,
+		OriginalImageBase64: puzzle.OriginalImageBase64,
+		JigsawImageBase64:   puzzle.JigsawImageBase64,
+		SecretKey:           secretKey,
+	})
+}
+
+// CheckCaptcha 校验滑块坐标；通过后生成二次校验凭证，供登录等接口通过 VerifyCaptcha 校验
+// 二次校验凭证 captchaVerification = AES(token + "---" + pointJson, secretKey)，由前端自行计算
+func (s *CaptchaService) CheckCaptcha(ctx context.Context, r *req.CaptchaReq) *resp.CaptchaResp {
+	if r.Token == "" || r.PointJSON == "" {
+		return CaptchaFailResp(CaptchaRepCodeNullError, "token或pointJson不能为空")
+	}
+
+	// 验证码只能校验一次，无论成功与否都失效
+	value, err := s.rdb.GetDel(ctx, fmt.Sprintf(captchaCacheKey, r.Token)).Result()
+	if errors.Is(err, redis.Nil) {
+		return CaptchaFailResp(CaptchaRepCodeInvalid, "验证码已失效，请重新获取")
+	}
+	if err != nil {
+		zap.L().Error("get captcha failed", zap.Error(err))
+		return CaptchaFailResp(CaptchaRepCodeError, "获取验证码失败,请联系管理员")
+	}
+	var point captchaPoint
+	if err := json.Unmarshal([]byte(value), &point); err != nil {
+		return CaptchaFailResp(CaptchaRepCodeInvalid, "验证码已失效，请重新获取")
+	}
+
+	pointJSON, err := captcha.AesDecrypt(r.PointJSON, point.SecretKey)
+	if err != nil {
+		return CaptchaFailResp(CaptchaRepCodeCoordError, "验证失败")
+	}
+	var userPoint struct {
+		X float64 `json:"x"`
+		Y float64 `json:"y"`
+	}
+	if err := json.Unmarshal([]byte(pointJSON), &userPoint); err != nil {
+		return CaptchaFailResp(CaptchaRepCodeCoordError, "验证失败")
+	}
+	// 滑块图为整列竖条，前端只横向拖动且 y 固定上报 5.0，因此只校验横坐标
+	if math.Abs(userPoint.X-float64(point.X)) > captchaSlipOffset {
+		return CaptchaFailResp(CaptchaRepCodeCoordError, "验证失败")
+	}
+
+	verification, err := captcha.AesEncrypt(r.Token+"---"+pointJSON, point.SecretKey)
+	if err != nil {
+		return CaptchaFailResp(CaptchaRepCodeError, "获取验证码失败,请联系管理员")
+	}
+	if err := s.rdb.Set(ctx, fmt.Sprintf(captchaSecondCacheKey, verification), r.Token, captchaSecondExpire).Err(); err != nil {
+		zap.L().Error("save captcha verification failed", zap.Error(err))
+		return CaptchaFailResp(CaptchaRepCodeError, "获取验证码失败,请联系管理员")
+	}
+	return captchaSuccess(&resp.CaptchaDataResp{
+		CaptchaType: CaptchaTypeBlockPuzzle,
+		Token:       r.Token,
+		Result:      true,
+	})
+}
+
+// VerifyCaptcha 二次校验：校验 CheckCaptcha 通过后的凭证，凭证只能使用一次
+// 对应 Java: CaptchaService#verification
+func (s *CaptchaService) VerifyCaptcha(ctx context.Context, captchaVerification string) error {
+	if captchaVerification == "" {
+		return errors.New("captchaVerification不能为空")
+	}
+	n, err := s.rdb.Del(ctx, fmt.Sprintf(captchaSecondCacheKey, captchaVerification)).Result()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.New("验证码已失效，请重新获取")
+	}
+	return nil
+}
+
+// ValidateCaptcha 校验验证码，未开启验证码时直接通过；用于登录、发送短信验证码、重置密码
+// 对应 Java: AdminAuthServiceImpl#validateCaptcha
+func (s *CaptchaService) ValidateCaptcha(ctx context.Context, captchaVerification string) error {
+	if !s.IsCaptchaEnabled(ctx) {
+		return nil
+	}
+	if captchaVerification == "" {
+		return core.NewBizError(1002000005, "验证码不能为空") // AUTH_LOGIN_CAPTCHA_NOT_EMPTY
+	}
+	if err := s.VerifyCaptcha(ctx, captchaVerification); err != nil {
+		return core.NewBizError(1002000004, "验证码不正确，原因："+err.Error()) // AUTH_LOGIN_CAPTCHA_CODE_ERROR
+	}
+	return nil
+}
+
+// IsCaptchaEnabled 是否开启验证码：当前租户的参数配置 > 全局参数配置 > 配置文件 captcha.enable
+func (s *CaptchaService) IsCaptchaEnabled(ctx context.Context) bool {
+	keys := []string{CaptchaEnableConfigKey}
+	if tenantId, ok := core.GetTenantIDFromContext(ctx); ok {
+		keys = []string{CaptchaEnableConfigKey + "." + strconv.FormatInt(tenantId, 10), CaptchaEnableConfigKey}
+	}
+	for _, key := range keys {
+		cfg, err := s.configSvc.GetConfigByKey(ctx, key)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			zap.L().Error("get captcha config failed", zap.String("key", key), zap.Error(err))
+			break
+		}
+		if enabled, err := strconv.ParseBool(strings.TrimSpace(cfg.Value)); err == nil {
+			return enabled
+		}
+	}
+	return config.C.Captcha.Enable
+}
+
+func captchaSuccess(data *resp.CaptchaDataResp) *resp.CaptchaResp {
+	return &resp.CaptchaResp{RepCode: CaptchaRepCodeSuccess, RepData: data, Success: true}
+}
+
+// CaptchaFailResp 构建失败响应
+func CaptchaFailResp(code, msg string) *resp.CaptchaResp {
+	return &resp.CaptchaResp{RepCode: code, RepMsg: msg}
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+
+	"github.com/samber/lo"
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+	"gorm.io/gorm/clause"
+
+	"backend-go/internal/model"
+	"backend-go/internal/pkg/core"
+)
+
+// DataScope 数据范围，与 Java DataScopeEnum 保持一致
+const (
+	DataScopeAll          = 1 // 全部数据权限
+	DataScopeDeptCustom   = 2 // 指定部门数据权限
+	DataScopeDeptOnly     = 3 // 部门数据权限
+	DataScopeDeptAndChild = 4 // 部门及以下数据权限
+	DataScopeSelf         = 5 // 仅本人数据权限
+)
+
+// DeptDataPermission 部门的数据权限
+// 对应 Java: DeptDataPermissionRespDTO
+type DeptDataPermission struct {
+	All     bool    // 是否可查看全部数据
+	Self    bool    // 是否可查看自己的数据
+	DeptIds []int64 // 可查看的部门编号数组
+}
+
+// GetDeptDataPermission 获得登录用户的部门数据权限
+// 对应 Java: PermissionServiceImpl.getDeptDataPermission
+func (s *PermissionService) GetDeptDataPermission(ctx context.Context, userId int64) (*DeptDataPermission, error) {
+	result := &DeptDataPermission{DeptIds: []int64{}}
+
+	// 获得用户开启的角色
+	roleIds, err := s.GetUserRoleIdListByUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	var roles []*model.SystemRole
+	if len(roleIds) > 0 {
+		r := s.q.SystemRole
+		if roles, err = r.WithContext(ctx).Where(r.ID.In(roleIds...), r.Status.Eq(0)).Find(); err != nil {
+			return nil, err
+		}
+	}
+	// 如果角色为空，则只能查看自己
+	if len(roles) == 0 {
+		result.Self = true
+		return result, nil
+	}
+
+	// 用户所在部门，按需查询
+	var userDeptId *int64
+	getUserDeptId := func() (int64, error) {
+		if userDeptId == nil {
+			u := s.q.SystemUser
+			user, err := u.WithContext(ctx).Where(u.ID.Eq(userId)).First()
+			if err != nil {
+				return 0, err
+			}
+			userDeptId = &user.DeptID
+		}
+		return *userDeptId, nil
+	}
+
+	for _, role := range roles {
+		switch role.DataScope {
+		case DataScopeAll:
+			result.All = true
+			return result, nil
+		case DataScopeDeptCustom:
+			result.DeptIds = append(result.DeptIds, role.DataScopeDeptIds...)
+			// 自定义可见部门时，保证可以看到自己所在的部门
+			deptId, err := getUserDeptId()
+			if err != nil {
+				return nil, err
+			}
+			result.DeptIds = append(result.DeptIds, deptId)
+		case DataScopeDeptOnly:
+			deptId, err := getUserDeptId()
+			if err != nil {
+				return nil, err
+			}
+			result.DeptIds = append(result.DeptIds, deptId)
+		case DataScopeDeptAndChild:
+			deptId, err := getUserDeptId()
+			if err != nil {
+				return nil, err
+			}
+			childIds, err := s.deptSvc.GetChildDeptIdList(ctx, deptId)
+			if err != nil {
+				return nil, err
+			}
+			result.DeptIds = append(result.DeptIds, deptId)
+			result.DeptIds = append(result.DeptIds, childIds...)
+		case DataScopeSelf:
+			result.Self = true
+		}
+	}
+	result.DeptIds = lo.Uniq(lo.Filter(result.DeptIds, func(id int64, _ int) bool {
+		return id > 0
+	}))
+	return result, nil
+}
+
+// DeptDataScope 获得部门数据权限的查询条件，由各查询按需调用（opt-in）
+// deptColumn 为部门字段，userColumn 为用户字段，表中没有对应字段时传 nil
+// 未登录（如定时任务）、非管理员或拥有全部数据权限时返回 nil，表示不过滤
+// 对应 Java: DeptDataPermissionRule.getExpression
+func (s *PermissionService) DeptDataScope(ctx context.Context, deptColumn, userColumn *field.Int64) ([]gen.Condition, error) {
+	loginUser := core.GetLoginUserFromContext(ctx)
+	if loginUser == nil || loginUser.UserType != core.UserTypeAdmin {
+		return nil, nil
+	}
+	perm, err := s.GetDeptDataPermission(ctx, loginUser.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if perm.All {
+		return nil, nil
+	}
+
+	var exprs []field.Expr
+	if deptColumn != nil && len(perm.DeptIds) > 0 {
+		exprs = append(exprs, deptColumn.In(perm.DeptIds...))
+	}
+	if userColumn != nil && perm.Self {
+		exprs = append(exprs, userColumn.Eq(loginUser.UserID))
+	}
+	// 既不能查看任何部门，又不能查看自己，则不可查看任何数据
+	if len(exprs) == 0 {
+		return gen.Cond(clause.Expr{SQL: "1 = 0"}), nil
+	}
+	return []gen.Condition{field.Or(exprs...)}, nil
+}
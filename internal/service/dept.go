@@ -163,3 +163,23 @@ func (s *DeptService) GetSimpleDeptList(ctx context.Context) ([]*resp.DeptSimple
 	}
 	return res, nil
 }
+
+// GetChildDeptIdList 获得指定部门的所有子部门编号（递归，不包含自身）
+// 对应 Java: DeptServiceImpl.getChildDeptIdListFromCache
+func (s *DeptService) GetChildDeptIdList(ctx context.Context, id int64) ([]int64, error) {
+	d := s.q.SystemDept
+	var children []int64
+	parentIds := []int64{id}
+	for len(parentIds) > 0 {
+		list, err := d.WithContext(ctx).Where(d.ParentID.In(parentIds...)).Find()
+		if err != nil {
+			return nil, err
+		}
+		parentIds = parentIds[:0]
+		for _, item := range list {
+			children = append(children, item.ID)
+			parentIds = append(parentIds, item.ID)
+		}
+	}
+	return children, nil
+}
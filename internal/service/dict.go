@@ -3,12 +3,16 @@ package service
 import (
 	"context"
 	"errors"
+	"sync"
 
 	"backend-go/internal/api/req"
 	"backend-go/internal/api/resp"
 	"backend-go/internal/model"
 	"backend-go/internal/pkg/core"
+	"backend-go/internal/pkg/excel"
 	"backend-go/internal/repo/query"
+
+	"go.uber.org/zap"
 )
 
 type DictService struct {
@@ -275,3 +279,62 @@ func (s *DictService) GetSimpleDictDataList(ctx context.Context) ([]*resp.DictDa
 	}
 	return res, nil
 }
+
+// LoadDictResolver 加载指定字典类型的数据，用于 Excel 导入时将字典标签转换为字典值
+// 对应 Java: DictFrameworkUtils#parseDictDataValue
+func LoadDictResolver(ctx context.Context, q *query.Query, dictTypes ...string) (excel.DictResolver, error) {
+	d := q.SystemDictData
+	list, err := d.WithContext(ctx).Where(d.DictType.In(dictTypes...)).Find()
+	if err != nil {
+		return nil, err
+	}
+	labels := make(map[string]map[string]string, len(dictTypes))
+	values := make(map[string]map[string]bool, len(dictTypes))
+	for _, item := range list {
+		if labels[item.DictType] == nil {
+			labels[item.DictType] = make(map[string]string)
+			values[item.DictType] = make(map[string]bool)
+		}
+		labels[item.DictType][item.Label] = item.Value
+		values[item.DictType][item.Value] = true
+	}
+	return func(dictType, label string) (string, bool) {
+		if value, ok := labels[dictType][label]; ok {
+			return value, true
+		}
+		// 兼容直接填写字典值的情况，如导入模板中的 "1"
+		if values[dictType][label] {
+			return label, true
+		}
+		return "", false
+	}, nil
+}
+
+// NewDictFormatter 创建字典值到字典标签的转换，用于 Excel 导出
+// 字典数据按类型在首次使用时加载，同一次导出内复用；加载失败或值不存在时返回原值
+// 对应 Java: DictFrameworkUtils#parseDictDataLabel
+func (s *DictService) NewDictFormatter(ctx context.Context) excel.DictFormatter {
+	var mu sync.Mutex
+	labels := make(map[string]map[string]string)
+	return func(dictType, value string) string {
+		mu.Lock()
+		defer mu.Unlock()
+		typeLabels, ok := labels[dictType]
+		if !ok {
+			d := s.q.SystemDictData
+			list, err := d.WithContext(ctx).Where(d.DictType.Eq(dictType)).Find()
+			if err != nil {
+				zap.L().Warn("load dict data failed", zap.String("dictType", dictType), zap.Error(err))
+			}
+			typeLabels = make(map[string]string, len(list))
+			for _, item := range list {
+				typeLabels[item.Value] = item.Label
+			}
+			labels[dictType] = typeLabels
+		}
+		if label, ok := typeLabels[value]; ok {
+			return label
+		}
+		return value
+	}
+}
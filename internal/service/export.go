@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"backend-go/internal/pkg/core"
+	"backend-go/internal/pkg/excel"
+
+	"go.uber.org/zap"
+)
+
+// ExportNotifyTemplateCode 异步导出完成的站内信模板编码，模板参数：fileName、url
+const ExportNotifyTemplateCode = "excel_export"
+
+// exportAsyncConcurrency 同时执行的异步导出任务数上限
+const exportAsyncConcurrency = 4
+
+// ExportFunc 写入导出内容，dict 用于将字典值渲染为字典标签
+type ExportFunc func(ctx context.Context, w io.Writer, dict excel.DictFormatter) error
+
+// ExportService Excel 导出：同步导出直接写入响应；异步导出在后台生成文件，上传后通过站内信通知下载地址
+type ExportService struct {
+	dictSvc   *DictService
+	fileSvc   *FileService
+	notifySvc *NotifyService
+	sem       chan struct{} // 异步导出的并发令牌
+}
+
+func NewExportService(dictSvc *DictService, fileSvc *FileService, notifySvc *NotifyService) *ExportService {
+	return &ExportService{
+		dictSvc:   dictSvc,
+		fileSvc:   fileSvc,
+		notifySvc: notifySvc,
+		sem:       make(chan struct{}, exportAsyncConcurrency),
+	}
+}
+
+// Export 同步导出，写入 w
+func (s *ExportService) Export(ctx context.Context, w io.Writer, export ExportFunc) error {
+	return export(ctx, w, s.dictSvc.NewDictFormatter(ctx))
+}
+
+// ExportAsync 异步导出：后台写入临时文件并上传到文件存储，完成后给 userId 发送站内信
+// ctx 中的租户等信息会保留，但不随请求结束而取消；并发任务数达到上限时直接拒绝
+func (s *ExportService) ExportAsync(ctx context.Context, userId int64, fileName string, export ExportFunc) error {
+	if userId <= 0 {
+		return core.ErrUnauthorized
+	}
+	select {
+	case s.sem <- struct{}{}:
+	default:
+		return core.NewBizError(429, "导出任务过多，请稍后重试") // TOO_MANY_REQUESTS
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		defer func() { <-s.sem }()
+		defer func() {
+			if r := recover(); r != nil {
+				zap.L().Error("async export panic", zap.String("fileName", fileName), zap.Any("panic", r))
+			}
+		}()
+		url, err := s.exportToFile(ctx, fileName, export)
+		if err != nil {
+			zap.L().Error("async export failed", zap.Int64("userId", userId), zap.String("fileName", fileName), zap.Error(err))
+			return
+		}
+		if _, err := s.notifySvc.SendNotify(ctx, userId, UserTypeAdmin, ExportNotifyTemplateCode, map[string]interface{}{
+			"fileName": fileName,
+			"url":      url,
+		}); err != nil {
+			zap.L().Error("send export notify failed", zap.Int64("userId", userId), zap.String("url", url), zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+// exportToFile 导出到临时文件后上传，返回文件访问地址
+func (s *ExportService) exportToFile(ctx context.Context, fileName string, export ExportFunc) (string, error) {
+	tmp, err := os.CreateTemp("", "export-*.xlsx")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		tmp.Close()
+		os.Remove(tmp.Name())
+	}()
+
+	if err := s.Export(ctx, tmp, export); err != nil {
+		return "", err
+	}
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return "", err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	// 按日期归档，避免同名导出文件相互覆盖
+	now := time.Now()
+	path := fmt.Sprintf("export/%s/%d_%s", now.Format("2006/01/02"), now.UnixMilli(), fileName)
+	return s.fileSvc.CreateFileFromReader(ctx, fileName, path, tmp, size)
+}
@@ -7,28 +7,53 @@ import (
 	"backend-go/internal/pkg/core"
 	"backend-go/internal/pkg/file"
 	"backend-go/internal/repo/query"
+	"backend-go/pkg/config"
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"mime"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/samber/lo"
 )
 
+const (
+	defaultFileMaxSize       = 100 << 20 // 100MB
+	defaultFilePresignExpire = 600       // 10 分钟
+)
+
+// defaultFileAllowedTypes 默认允许直传的文件类型
+var defaultFileAllowedTypes = []string{
+	"image/*", "video/*", "audio/*",
+	"application/pdf", "application/zip", "application/msword", "application/vnd.*",
+	"text/plain", "text/csv",
+}
+
 type FileService struct {
 	q                 *query.Query
 	fileConfigService *FileConfigService
+	contentStore      file.FileContentStore
 }
 
 func NewFileService(q *query.Query, fileConfigService *FileConfigService) *FileService {
 	return &FileService{
 		q:                 q,
 		fileConfigService: fileConfigService,
+		contentStore:      &fileContentStore{q: q},
 	}
 }
 
 // CreateFile 上传/创建文件
 func (s *FileService) CreateFile(ctx context.Context, name string, path string, content []byte) (string, error) {
+	return s.CreateFileFromReader(ctx, name, path, bytes.NewReader(content), int64(len(content)))
+}
+
+// CreateFileFromReader 上传/创建文件，存储支持流式上传时（如 S3）不会将文件整体读入内存
+func (s *FileService) CreateFileFromReader(ctx context.Context, name string, path string, r io.Reader, size int64) (string, error) {
 	// 1. 获取 Master 配置
 	config, err := s.fileConfigService.GetMasterFileConfig(ctx)
 	if err != nil {
@@ -36,19 +61,34 @@ func (s *FileService) CreateFile(ctx context.Context, name string, path string,
 	}
 
 	// 2. 初始化客户端
-	client, err := file.NewFileClient(config.Storage, config.Config)
+	client, err := file.NewFileClient(config.ID, config.Storage, config.Config, s.contentStore)
 	if err != nil {
 		return "", fmt.Errorf("初始化文件客户端失败: %v", err)
 	}
 
-	// 3. 处理路径 (如果未提供 path，则使用 name)
-	// 这里可以添加日期分目录的逻辑，如 2023/10/01/uuid.jpg
-	if path == "" {
-		path = fmt.Sprintf("%s/%s", time.Now().Format("2006/01/02"), name)
+	// 3. 处理路径 (如果未提供 path，则使用 name)，并校验文件类型与大小
+	if path, err = file.CleanPath(buildFilePath(name, path)); err != nil {
+		return "", errors.New("文件路径不合法")
+	}
+	contentType := mime.TypeByExtension(filepath.Ext(name))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	if err := validateFile(contentType, size); err != nil {
+		return "", err
 	}
 
 	// 4. 上传
-	url, err := client.Upload(content, path)
+	var url string
+	if streamClient, ok := client.(file.StreamFileClient); ok {
+		url, err = streamClient.UploadStream(ctx, r, path, contentType)
+	} else {
+		var content []byte
+		if content, err = io.ReadAll(r); err != nil {
+			return "", err
+		}
+		url, err = client.Upload(content, path)
+	}
 	if err != nil {
 		return "", err
 	}
@@ -59,8 +99,8 @@ func (s *FileService) CreateFile(ctx context.Context, name string, path string,
 		Name:     name,
 		Path:     path,
 		Url:      url,
-		Type:     "", // 可以通过 http.DetectContentType(content) 获取
-		Size:     len(content),
+		Type:     contentType,
+		Size:     int(size),
 	}
 	err = s.q.InfraFile.WithContext(ctx).Create(fileRecord)
 	if err != nil {
@@ -70,6 +110,140 @@ func (s *FileService) CreateFile(ctx context.Context, name string, path string,
 	return url, nil
 }
 
+// GetFilePresignedUrl 获取文件预签名上传地址，客户端直传存储后调用 CreateFileRecord 登记文件
+// 主配置不支持直传时（如本地存储），返回 presigned = false，由客户端改为服务端代理上传
+// 对应 Java: FileServiceImpl#getFilePresignedUrl
+func (s *FileService) GetFilePresignedUrl(ctx context.Context, r *req.FilePresignedUrlReq) (*resp.FilePresignedUrlRespVO, error) {
+	if err := validateFile(r.ContentType, r.Size); err != nil {
+		return nil, err
+	}
+	// 与 CreateFileRecord 使用同一规则清理路径，避免签发的地址与登记时 HEAD 的对象不一致
+	path, err := file.CleanPath(buildFilePath(r.Name, r.Path))
+	if err != nil {
+		return nil, errors.New("文件路径不合法")
+	}
+	fileConfig, err := s.fileConfigService.GetMasterFileConfig(ctx)
+	if err != nil {
+		return nil, errors.New("请先配置主文件存储")
+	}
+	client, err := file.NewFileClient(fileConfig.ID, fileConfig.Storage, fileConfig.Config, s.contentStore)
+	if err != nil {
+		return nil, fmt.Errorf("初始化文件客户端失败: %v", err)
+	}
+
+	presigned, err := client.PresignPutObject(path, r.ContentType, r.Size, filePresignExpire())
+	if errors.Is(err, file.ErrPresignNotSupported) {
+		return &resp.FilePresignedUrlRespVO{ConfigId: fileConfig.ID, Presigned: false, Path: path}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &resp.FilePresignedUrlRespVO{
+		ConfigId:  fileConfig.ID,
+		Presigned: true,
+		UploadUrl: presigned.UploadUrl,
+		Url:       presigned.Url,
+		Path:      path,
+	}, nil
+}
+
+// CreateFileRecord 直传完成后登记文件
+// 文件的大小、类型与访问地址以存储侧 HEAD 的实际结果为准，不信任客户端的上报
+// 对应 Java: FileServiceImpl#createFile(FileCreateReqVO)
+func (s *FileService) CreateFileRecord(ctx context.Context, r *req.FileCreateReq) (int64, error) {
+	path, err := file.CleanPath(r.Path)
+	if err != nil {
+		return 0, errors.New("文件路径不合法")
+	}
+	fileConfig, err := s.fileConfigService.GetFileConfig(ctx, r.ConfigId)
+	if err != nil {
+		return 0, errors.New("配置不存在")
+	}
+	if fileConfig.Config == nil {
+		return 0, errors.New("配置内容为空")
+	}
+	client, err := file.NewFileClient(fileConfig.ID, fileConfig.Storage, *fileConfig.Config, s.contentStore)
+	if err != nil {
+		return 0, fmt.Errorf("初始化文件客户端失败: %v", err)
+	}
+	headClient, ok := client.(file.HeadFileClient)
+	if !ok {
+		return 0, errors.New("该文件配置不支持直传")
+	}
+	info, err := headClient.HeadObject(ctx, path)
+	if err != nil {
+		return 0, fmt.Errorf("文件不存在: %v", err)
+	}
+	if err := validateFile(info.ContentType, info.Size); err != nil {
+		// 不符合要求的文件直接删除，避免占用存储
+		if delErr := client.Delete(path); delErr != nil {
+			return 0, fmt.Errorf("%w (删除文件失败: %v)", err, delErr)
+		}
+		return 0, err
+	}
+
+	fileRecord := &model.InfraFile{
+		ConfigId: fileConfig.ID,
+		Name:     r.Name,
+		Path:     path,
+		Url:      info.Url,
+		Type:     info.ContentType,
+		Size:     int(info.Size),
+	}
+	if err := s.q.InfraFile.WithContext(ctx).Create(fileRecord); err != nil {
+		return 0, err
+	}
+	return fileRecord.ID, nil
+}
+
+// validateFile 校验文件的类型与大小
+func validateFile(contentType string, size int64) error {
+	maxSize := config.C.Infra.File.MaxSize
+	if maxSize <= 0 {
+		maxSize = defaultFileMaxSize
+	}
+	if size <= 0 {
+		return core.NewBizError(1001003002, "文件为空") // FILE_IS_EMPTY
+	}
+	if size > maxSize {
+		return core.NewBizError(1001003003, fmt.Sprintf("文件大小不能超过 %dMB", maxSize>>20)) // FILE_SIZE_EXCEEDED
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return core.NewBizError(1001003004, "文件类型不支持："+contentType) // FILE_TYPE_NOT_ALLOWED
+	}
+	allowedTypes := config.C.Infra.File.AllowedTypes
+	if len(allowedTypes) == 0 {
+		allowedTypes = defaultFileAllowedTypes
+	}
+	for _, allowed := range allowedTypes {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if prefix, ok := strings.CutSuffix(allowed, "*"); ok && strings.HasPrefix(mediaType, prefix) {
+			return nil
+		}
+		if mediaType == allowed {
+			return nil
+		}
+	}
+	return core.NewBizError(1001003004, "文件类型不支持："+contentType) // FILE_TYPE_NOT_ALLOWED
+}
+
+func filePresignExpire() time.Duration {
+	if expire := config.C.Infra.File.PresignExpire; expire > 0 {
+		return time.Duration(expire) * time.Second
+	}
+	return defaultFilePresignExpire * time.Second
+}
+
+// buildFilePath 生成文件路径，未指定时按日期分目录，如 2023/10/01/a.jpg
+func buildFilePath(name, path string) string {
+	if path != "" {
+		return path
+	}
+	return fmt.Sprintf("%s/%s", time.Now().Format("2006/01/02"), name)
+}
+
 // DeleteFile 删除文件
 func (s *FileService) DeleteFile(ctx context.Context, id int64) error {
 	f := s.q.InfraFile
@@ -88,7 +262,7 @@ func (s *FileService) DeleteFile(ctx context.Context, id int64) error {
 
 	// 初始化客户端并删除物理文件
 	if config.Config != nil {
-		client, err := file.NewFileClient(config.Storage, *config.Config)
+		client, err := file.NewFileClient(config.ID, config.Storage, *config.Config, s.contentStore)
 		if err == nil {
 			_ = client.Delete(fileRecord.Path)
 		}
@@ -108,7 +282,7 @@ func (s *FileService) GetFileContent(ctx context.Context, configId int64, path s
 		return nil, errors.New("配置内容为空")
 	}
 
-	client, err := file.NewFileClient(config.Storage, *config.Config)
+	client, err := file.NewFileClient(config.ID, config.Storage, *config.Config, s.contentStore)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"backend-go/internal/model"
+	"backend-go/internal/repo/query"
+	"context"
+	"errors"
+)
+
+// fileContentStore 基于 infra_file_content 表的文件内容存储，供 DB 存储器使用
+// 对应 Java: FileContentMapper
+type fileContentStore struct {
+	q *query.Query
+}
+
+// SaveContent 保存文件内容，相同路径时原地更新
+func (s *fileContentStore) SaveContent(configId int64, path string, content []byte) error {
+	c := s.q.InfraFileContent
+	info, err := c.WithContext(context.Background()).Where(c.ConfigId.Eq(configId), c.Path.Eq(path)).Update(c.Content, content)
+	if err != nil {
+		return err
+	}
+	if info.RowsAffected > 0 {
+		return nil
+	}
+	return c.WithContext(context.Background()).Create(&model.InfraFileContent{ConfigId: configId, Path: path, Content: content})
+}
+
+func (s *fileContentStore) DeleteContent(configId int64, path string) error {
+	c := s.q.InfraFileContent
+	_, err := c.WithContext(context.Background()).Where(c.ConfigId.Eq(configId), c.Path.Eq(path)).Delete()
+	return err
+}
+
+// GetContent 获得文件内容，同一路径存在多条时取最新的一条
+func (s *fileContentStore) GetContent(configId int64, path string) ([]byte, error) {
+	c := s.q.InfraFileContent
+	list, err := c.WithContext(context.Background()).Where(c.ConfigId.Eq(configId), c.Path.Eq(path)).
+		Order(c.ID.Desc()).Limit(1).Find()
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, errors.New("文件不存在")
+	}
+	return list[0].Content, nil
+}
@@ -2,19 +2,124 @@ package service
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"backend-go/internal/api/req"
 	"backend-go/internal/model"
 	"backend-go/internal/pkg/core"
 	"backend-go/internal/repo/query"
+
+	"go.uber.org/zap"
+)
+
+const (
+	apiAccessLogQueueSize     = 4096            // 待写入队列容量，满时丢弃
+	apiAccessLogBatchSize     = 100             // 单批写入条数
+	apiAccessLogFlushInterval = 1 * time.Second // 最长写入间隔
 )
 
 type ApiAccessLogService struct {
-	q *query.Query
+	q        *query.Query
+	logger   *zap.Logger
+	queue    chan *model.InfraApiAccessLog
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+func NewApiAccessLogService(q *query.Query, logger *zap.Logger) *ApiAccessLogService {
+	s := &ApiAccessLogService{
+		q:      q,
+		logger: logger,
+		queue:  make(chan *model.InfraApiAccessLog, apiAccessLogQueueSize),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go s.runBatchWriter()
+	return s
 }
 
-func NewApiAccessLogService(q *query.Query) *ApiAccessLogService {
-	return &ApiAccessLogService{q: q}
+// Stop 停止批量写入，写入队列中剩余的访问日志后返回；ctx 超时则放弃等待
+// 需在 HTTP 服务停止接收请求后调用
+func (s *ApiAccessLogService) Stop(ctx context.Context) error {
+	s.stopOnce.Do(func() { close(s.stop) })
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// CreateApiAccessLog 异步记录 API 访问日志，队列满时丢弃，不阻塞请求
+// 对应 Java: ApiAccessLogServiceImpl.createApiAccessLog (@Async)
+func (s *ApiAccessLogService) CreateApiAccessLog(log *model.InfraApiAccessLog) {
+	select {
+	case s.queue <- log:
+	default:
+		s.logger.Warn("api access log queue is full, dropped", zap.String("url", log.RequestURL))
+	}
+}
+
+// runBatchWriter 按批量或时间间隔写入访问日志，Stop 时写入剩余日志后退出
+func (s *ApiAccessLogService) runBatchWriter() {
+	defer close(s.done)
+	ticker := time.NewTicker(apiAccessLogFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*model.InfraApiAccessLog, 0, apiAccessLogBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		// 每条日志已携带各自的租户编号，批量写入时忽略租户，避免被覆盖
+		if err := s.q.InfraApiAccessLog.WithContext(core.WithIgnoreTenant(context.Background())).CreateInBatches(batch, apiAccessLogBatchSize); err != nil {
+			s.logger.Error("failed to write api access log", zap.Int("count", len(batch)), zap.Error(err))
+		}
+		batch = make([]*model.InfraApiAccessLog, 0, apiAccessLogBatchSize)
+	}
+
+	for {
+		select {
+		case log := <-s.queue:
+			batch = append(batch, log)
+			if len(batch) >= apiAccessLogBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-s.stop:
+			for {
+				select {
+				case log := <-s.queue:
+					batch = append(batch, log)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// CleanApiAccessLog 物理删除 exceedDay 天前的访问日志，每次删除 deleteLimit 条，返回删除总数
+// 对应 Java: ApiAccessLogServiceImpl.cleanAccessLog
+func (s *ApiAccessLogService) CleanApiAccessLog(ctx context.Context, exceedDay int, deleteLimit int) (int, error) {
+	l := s.q.InfraApiAccessLog
+	expireTime := time.Now().AddDate(0, 0, -exceedDay)
+	count := 0
+	for {
+		info, err := l.WithContext(ctx).Unscoped().Where(l.CreatedAt.Lt(expireTime)).Limit(deleteLimit).Delete()
+		if err != nil {
+			return count, err
+		}
+		count += int(info.RowsAffected)
+		// 达到删除预期条数，说明到底了
+		if info.RowsAffected < int64(deleteLimit) {
+			return count, nil
+		}
+	}
 }
 
 // GetApiAccessLogPage 获取API访问日志分页
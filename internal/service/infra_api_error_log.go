@@ -77,3 +77,27 @@ func (s *ApiErrorLogService) UpdateApiErrorLogProcess(ctx context.Context, id in
 	})
 	return err
 }
+
+// CreateApiErrorLog 创建API错误日志
+func (s *ApiErrorLogService) CreateApiErrorLog(ctx context.Context, log *model.InfraApiErrorLog) error {
+	return s.q.InfraApiErrorLog.WithContext(ctx).Create(log)
+}
+
+// CleanApiErrorLog 物理删除 exceedDay 天前的错误日志，每次删除 deleteLimit 条，返回删除总数
+// 对应 Java: ApiErrorLogServiceImpl.cleanErrorLog
+func (s *ApiErrorLogService) CleanApiErrorLog(ctx context.Context, exceedDay int, deleteLimit int) (int, error) {
+	l := s.q.InfraApiErrorLog
+	expireTime := time.Now().AddDate(0, 0, -exceedDay)
+	count := 0
+	for {
+		info, err := l.WithContext(ctx).Unscoped().Where(l.CreatedAt.Lt(expireTime)).Limit(deleteLimit).Delete()
+		if err != nil {
+			return count, err
+		}
+		count += int(info.RowsAffected)
+		// 达到删除预期条数，说明到底了
+		if info.RowsAffected < int64(deleteLimit) {
+			return count, nil
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+
+	"backend-go/pkg/config"
+
+	"go.uber.org/zap"
+)
+
+const (
+	AccessLogCleanJobHandlerName = "accessLogCleanJob"
+	ErrorLogCleanJobHandlerName  = "errorLogCleanJob"
+
+	// apiLogCleanDefaultRetainDays 默认保留天数
+	apiLogCleanDefaultRetainDays = 14
+	// apiLogCleanDeleteLimit 每次删除间隔的条数，如果值太高可能会造成数据库的压力过大
+	apiLogCleanDeleteLimit = 100
+)
+
+// AccessLogCleanJob 物理删除 N 天前的 API 访问日志
+// 对齐 Java: AccessLogCleanJob
+type AccessLogCleanJob struct {
+	svc    *ApiAccessLogService
+	logger *zap.Logger
+}
+
+func NewAccessLogCleanJob(svc *ApiAccessLogService, logger *zap.Logger) *AccessLogCleanJob {
+	return &AccessLogCleanJob{svc: svc, logger: logger}
+}
+
+func (j *AccessLogCleanJob) Execute(ctx context.Context, param string) error {
+	count, err := j.svc.CleanApiAccessLog(ctx, apiLogRetainDays(config.C.Infra.ApiLog.AccessLogRetainDays), apiLogCleanDeleteLimit)
+	j.logger.Info("[AccessLogCleanJob] 清理访问日志", zap.Int("count", count))
+	return err
+}
+
+// ErrorLogCleanJob 物理删除 N 天前的 API 错误日志
+// 对齐 Java: ErrorLogCleanJob
+type ErrorLogCleanJob struct {
+	svc    *ApiErrorLogService
+	logger *zap.Logger
+}
+
+func NewErrorLogCleanJob(svc *ApiErrorLogService, logger *zap.Logger) *ErrorLogCleanJob {
+	return &ErrorLogCleanJob{svc: svc, logger: logger}
+}
+
+func (j *ErrorLogCleanJob) Execute(ctx context.Context, param string) error {
+	count, err := j.svc.CleanApiErrorLog(ctx, apiLogRetainDays(config.C.Infra.ApiLog.ErrorLogRetainDays), apiLogCleanDeleteLimit)
+	j.logger.Info("[ErrorLogCleanJob] 清理错误日志", zap.Int("count", count))
+	return err
+}
+
+func apiLogRetainDays(days int) int {
+	if days <= 0 {
+		return apiLogCleanDefaultRetainDays
+	}
+	return days
+}
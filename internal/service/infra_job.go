@@ -61,21 +61,24 @@ func (s *JobService) UpdateJob(ctx context.Context, r *req.JobSaveReq) error {
 	if err != nil {
 		return err
 	}
-	// Reschedule if scheduler exists
+	// Reschedule on all instances if scheduler exists
 	if s.scheduler != nil {
-		_ = s.scheduler.RemoveJob(*r.ID)
-		_ = s.scheduler.AddJob(ctx, *r.ID)
+		_ = s.scheduler.PublishRefresh(ctx, *r.ID)
 	}
 	return nil
 }
 
 // DeleteJob 删除定时任务
 func (s *JobService) DeleteJob(ctx context.Context, id int64) error {
+	_, err := s.q.InfraJob.WithContext(ctx).Where(s.q.InfraJob.ID.Eq(id)).Delete()
+	if err != nil {
+		return err
+	}
+	// Unschedule on all instances if scheduler exists
 	if s.scheduler != nil {
-		_ = s.scheduler.RemoveJob(id)
+		_ = s.scheduler.PublishRefresh(ctx, id)
 	}
-	_, err := s.q.InfraJob.WithContext(ctx).Where(s.q.InfraJob.ID.Eq(id)).Delete()
-	return err
+	return nil
 }
 
 // GetJob 获取定时任务
@@ -22,15 +22,17 @@ type MemberAuthService struct {
 	userSvc    *MemberUserService
 	socialSvc  *service.SocialUserService
 	tokenSvc   *service.OAuth2TokenService
+	captchaSvc *service.CaptchaService
 }
 
-func NewMemberAuthService(repo *query.Query, smsCodeSvc *service.SmsCodeService, userSvc *MemberUserService, socialSvc *service.SocialUserService, tokenSvc *service.OAuth2TokenService) *MemberAuthService {
+func NewMemberAuthService(repo *query.Query, smsCodeSvc *service.SmsCodeService, userSvc *MemberUserService, socialSvc *service.SocialUserService, tokenSvc *service.OAuth2TokenService, captchaSvc *service.CaptchaService) *MemberAuthService {
 	return &MemberAuthService{
 		repo:       repo,
 		smsCodeSvc: smsCodeSvc,
 		userSvc:    userSvc,
 		socialSvc:  socialSvc,
 		tokenSvc:   tokenSvc,
+		captchaSvc: captchaSvc,
 	}
 }
 
@@ -155,6 +157,9 @@ func (s *MemberAuthService) SocialLogin(ctx context.Context, r *req.AppAuthSocia
 
 // SendSmsCode 发送验证码
 func (s *MemberAuthService) SendSmsCode(ctx context.Context, r *req.AppAuthSmsSendReq) error {
+	if err := s.captchaSvc.ValidateCaptcha(ctx, r.CaptchaVerification); err != nil {
+		return err
+	}
 	return s.smsCodeSvc.SendSmsCode(ctx, r.Mobile, r.Scene)
 }
 
@@ -209,8 +214,8 @@ func (s *MemberAuthService) createToken(ctx context.Context, user *member.Member
 		"nickname": user.Nickname,
 	}
 
-	// 创建访问令牌（UserType=1 表示会员，TenantID=0 表示默认租户）
-	tokenDO, err := s.tokenSvc.CreateAccessToken(ctx, user.ID, service.UserTypeMember, 0, userInfo)
+	// 创建访问令牌（UserType=1 表示会员），令牌携带会员所属租户，请求头中的租户必须与之一致
+	tokenDO, err := s.tokenSvc.CreateAccessToken(ctx, user.ID, service.UserTypeMember, user.TenantID, userInfo)
 	if err != nil {
 		return nil, core.ErrUnknown
 	}
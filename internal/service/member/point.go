@@ -7,6 +7,8 @@ import (
 	"backend-go/internal/repo/query"
 	"context"
 	"errors"
+
+	"gorm.io/gen"
 )
 
 type MemberPointRecordService struct {
@@ -86,60 +88,48 @@ func (s *MemberPointRecordService) CreatePointRecord(ctx context.Context, userId
 	if point == 0 {
 		return nil
 	}
-
 	return s.q.Transaction(func(tx *query.Query) error {
-		// 1. 校验用户积分余额
-		user, err := s.memberUserSvc.GetUser(ctx, userId)
-		if err != nil {
-			return err
-		}
-		if user == nil {
-			return errors.New("user not found")
-		}
+		return s.CreatePointRecordTx(ctx, tx, userId, point, bizType, bizId, title, description)
+	})
+}
 
-		userPoint := user.Point
-		totalPoint := int(userPoint) + point
-		if totalPoint < 0 {
-			return core.NewBizError(1004014003, "用户积分余额不足") // Assuming error code
-		}
+// CreatePointRecordTx 在调用方的事务 tx 中创建积分记录，用于与订单等业务数据一起提交或回滚
+// point 为负数时扣减积分，余额不足返回错误
+func (s *MemberPointRecordService) CreatePointRecordTx(ctx context.Context, tx *query.Query, userId int64, point int, bizType int, bizId string, title string, description string) error {
+	if point == 0 {
+		return nil
+	}
 
-		// 2. 更新用户积分
-		// Note: UpdateUserPoint in MemberUserService uses non-transactional DB instance by default if not passed TX.
-		// BUT `s.q.Transaction` passes `tx *query.Query`. We should use `tx` to perform updates.
-		// However, MemberUserService methods mostly use `s.q`. To support transaction properly across services,
-		// ideally methods should accept `*query.Query` or we use the `tx` here to update directly or
-		// MemberUserService needs to support transactional context propagation (which WithContext does IF the DB attached to context is tx).
-		// GORM `WithContext` propagates context, but `s.q` in MemberUserService is the global one.
-		// Standard way in this project: Pass `tx` to service? Or `MemberUserService` methods use `s.q.WithContext(ctx)`.
-		// If we use `s.q.Transaction`, the `tx` has the transaction.
-		// We can't easily inject `tx` into `MemberUserService` without changing method signature.
-
-		// Workaround: We will manually update User Point here using `tx` to ensure transaction safety.
-		// OR, if `MemberUserService.UpdateUserPoint` uses `WithContext(ctx)`, GORM DOES NOT automatically pick up transaction from context unless using a specific middleware or logic.
-		// In `go-zero`/standard GORM, usually we pass `db` instance.
-		// Here using `gorm gen`, `tx` IS the query instance for transaction.
-
-		// Let's implement logic here for safety using `tx`.
-
-		u := tx.MemberUser
-		info, err := u.WithContext(ctx).Where(u.ID.Eq(userId)).Update(u.Point, u.Point.Add(int32(point)))
-		if err != nil {
-			return err
-		}
-		if info.RowsAffected == 0 {
-			return errors.New("update user point failed")
+	// 1. 更新用户积分，扣减时以余额充足为条件，避免并发扣成负数
+	u := tx.MemberUser
+	conds := []gen.Condition{u.ID.Eq(userId)}
+	if point < 0 {
+		conds = append(conds, u.Point.Gte(int32(-point)))
+	}
+	info, err := u.WithContext(ctx).Where(conds...).Update(u.Point, u.Point.Add(int32(point)))
+	if err != nil {
+		return err
+	}
+	if info.RowsAffected == 0 {
+		if point < 0 {
+			return core.NewBizError(1004014003, "用户积分余额不足") // USER_POINT_NOT_ENOUGH
 		}
+		return errors.New("user not found")
+	}
+	user, err := u.WithContext(ctx).Where(u.ID.Eq(userId)).First()
+	if err != nil {
+		return err
+	}
 
-		// 3. 增加积分记录
-		record := &member.MemberPointRecord{
-			UserID:      userId,
-			BizID:       bizId,
-			BizType:     bizType,
-			Title:       title,
-			Description: description, // Format description if needed outside
-			Point:       point,
-			TotalPoint:  totalPoint,
-		}
-		return tx.MemberPointRecord.WithContext(ctx).Create(record)
-	})
+	// 2. 增加积分记录
+	record := &member.MemberPointRecord{
+		UserID:      userId,
+		BizID:       bizId,
+		BizType:     bizType,
+		Title:       title,
+		Description: description,
+		Point:       point,
+		TotalPoint:  int(user.Point),
+	}
+	return tx.MemberPointRecord.WithContext(ctx).Create(record)
 }
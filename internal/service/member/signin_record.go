@@ -170,7 +170,7 @@ func (s *MemberSignInRecordService) CreateSignInRecord(ctx context.Context, user
 			// Using "1" as BizType for SIGN_IN as defined in MemberPointBizTypeEnum.SIGN
 			// Assuming Enum value 1 matches.
 			// BizId is record ID.
-			if err := s.pointRecordSvc.CreatePointRecord(ctx, userId, rewardPoint, 1, utils.ToString(record.ID), "签到", "签到奖励"); err != nil {
+			if err := s.pointRecordSvc.CreatePointRecord(ctx, userId, rewardPoint, member.PointBizTypeSign, utils.ToString(record.ID), "签到", "签到奖励"); err != nil {
 				return err
 			}
 		}
@@ -16,13 +16,15 @@ type MemberUserService struct {
 	q          *query.Query
 	smsCodeSvc *service.SmsCodeService
 	levelSvc   *MemberLevelService // Injection
+	captchaSvc *service.CaptchaService
 }
 
-func NewMemberUserService(q *query.Query, smsCodeSvc *service.SmsCodeService, levelSvc *MemberLevelService) *MemberUserService {
+func NewMemberUserService(q *query.Query, smsCodeSvc *service.SmsCodeService, levelSvc *MemberLevelService, captchaSvc *service.CaptchaService) *MemberUserService {
 	return &MemberUserService{
 		q:          q,
 		smsCodeSvc: smsCodeSvc,
 		levelSvc:   levelSvc,
+		captchaSvc: captchaSvc,
 	}
 }
 
@@ -141,9 +143,14 @@ func (s *MemberUserService) UpdateUserMobile(ctx context.Context, id int64, req
 
 // ResetUserPassword 重置用户密码 (忘记密码)
 func (s *MemberUserService) ResetUserPassword(ctx context.Context, req *req.AppMemberUserResetPasswordReq) error {
+	// 0. 校验图形验证码
+	if err := s.captchaSvc.ValidateCaptcha(ctx, req.CaptchaVerification); err != nil {
+		return err
+	}
+
 	// 1. 校验验证码 (场景: 重置密码)
 	// TODO: Replace magic number with Enum. MEMBER_RESET_PASSWORD = 4
-	if err := s.smsCodeSvc.ValidateSmsCode(ctx, req.Mobile, 4, req.Code); err != nil {
+	if err := s.smsCodeSvc.ValidateSmsCode(ctx, req.Mobile, service.SmsSceneMemberResetPassword, req.Code); err != nil {
 		return err
 	}
 
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 
+	"github.com/redis/go-redis/v9"
+
 	"backend-go/internal/api/req"
 	"backend-go/internal/api/resp"
 	"backend-go/internal/model"
@@ -11,12 +13,14 @@ import (
 )
 
 type MenuService struct {
-	q *query.Query
+	q   *query.Query
+	rdb *redis.Client
 }
 
-func NewMenuService(q *query.Query) *MenuService {
+func NewMenuService(q *query.Query, rdb *redis.Client) *MenuService {
 	return &MenuService{
-		q: q,
+		q:   q,
+		rdb: rdb,
 	}
 }
 
@@ -89,7 +93,12 @@ func (s *MenuService) UpdateMenu(ctx context.Context, req *req.MenuUpdateReq) er
 		KeepAlive:     model.BitBool(req.KeepAlive),
 		AlwaysShow:    model.BitBool(req.AlwaysShow),
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	// 6. 权限标识、状态可能变更，清除拥有该菜单的用户的权限缓存
+	evictMenuUserPermissionCache(ctx, s.q, s.rdb, req.ID)
+	return nil
 }
 
 // DeleteMenu 删除菜单
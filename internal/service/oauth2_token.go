@@ -15,8 +15,8 @@ const (
 	RedisKeyOAuth2AccessToken = "oauth2_access_token:%s"
 
 	// 用户类型常量，与 Java UserTypeEnum 保持一致
-	UserTypeMember = 1 // 会员
-	UserTypeAdmin  = 2 // 管理员
+	UserTypeMember = core.UserTypeMember // 会员
+	UserTypeAdmin  = core.UserTypeAdmin  // 管理员
 
 	// 默认过期时间
 	DefaultAccessTokenExpireSeconds  = 30 * 24 * 3600 // 30 天
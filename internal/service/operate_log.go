@@ -17,6 +17,11 @@ func NewOperateLogService(q *query.Query) *OperateLogService {
 	return &OperateLogService{q: q}
 }
 
+// CreateOperateLog 创建操作日志
+func (s *OperateLogService) CreateOperateLog(ctx context.Context, log *model.SystemOperateLog) error {
+	return s.q.SystemOperateLog.WithContext(ctx).Create(log)
+}
+
 // GetOperateLogPage 获取操作日志分页
 func (s *OperateLogService) GetOperateLogPage(ctx context.Context, r *req.OperateLogPageReq) (*core.PageResult[*model.SystemOperateLog], error) {
 	q := s.q.SystemOperateLog.WithContext(ctx)
@@ -36,18 +36,3 @@ type PayTransferRespDTO struct {
 	ChannelErrorMsg    string            `json:"channelErrorMsg"`
 	ChannelExtras      map[string]string `json:"channelExtras"`
 }
-
-type PayWalletRespDTO struct {
-	ID int64 `json:"id"`
-}
-
-// PayWalletService (Placeholder)
-type PayWalletService struct{}
-
-func NewPayWalletService() *PayWalletService {
-	return &PayWalletService{}
-}
-
-func (s *PayWalletService) GetOrCreateWallet(userId int64, userType int) (*PayWalletRespDTO, error) {
-	return &PayWalletRespDTO{ID: 0}, nil // Mock
-}
@@ -102,6 +102,18 @@ func (s *PayAppService) GetApp(ctx context.Context, id int64) (*pay.PayApp, erro
 	return s.q.PayApp.WithContext(ctx).Where(s.q.PayApp.ID.Eq(id)).First()
 }
 
+// GetAppByAppKey 根据应用标识获得支付应用
+func (s *PayAppService) GetAppByAppKey(ctx context.Context, appKey string) (*pay.PayApp, error) {
+	app, err := s.q.PayApp.WithContext(ctx).Where(s.q.PayApp.AppKey.Eq(appKey)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, core.NewBizError(1006000000, "支付应用不存在") // PAY_APP_NOT_FOUND
+		}
+		return nil, err
+	}
+	return app, nil
+}
+
 // GetAppMap 获得支付应用 Map
 func (s *PayAppService) GetAppMap(ctx context.Context, ids []int64) (map[int64]*pay.PayApp, error) {
 	if len(ids) == 0 {
@@ -5,6 +5,7 @@ import (
 	"backend-go/internal/model/pay"
 	"backend-go/internal/pkg/core"
 	"backend-go/internal/repo/query"
+	"backend-go/internal/service/pay/client"
 	"context"
 	"errors"
 
@@ -12,11 +13,12 @@ import (
 )
 
 type PayChannelService struct {
-	q *query.Query
+	q         *query.Query
+	clientFac *client.PayClientFactory
 }
 
-func NewPayChannelService(q *query.Query) *PayChannelService {
-	return &PayChannelService{q: q}
+func NewPayChannelService(q *query.Query, clientFac *client.PayClientFactory) *PayChannelService {
+	return &PayChannelService{q: q, clientFac: clientFac}
 }
 
 // CreateChannel 创建支付渠道
@@ -123,3 +125,16 @@ func (s *PayChannelService) ValidPayChannel(ctx context.Context, id int64) (*pay
 	}
 	return channel, nil
 }
+
+// GetPayClient 获得指定编号的支付客户端
+// 对齐 Java: PayChannelServiceImpl.getPayClient，客户端不存在时按渠道配置懒加载创建
+func (s *PayChannelService) GetPayClient(ctx context.Context, id int64) (client.PayClient, error) {
+	if payClient := s.clientFac.GetPayClient(id); payClient != nil {
+		return payClient, nil
+	}
+	channel, err := s.validateChannelExists(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.clientFac.CreateOrUpdatePayClient(channel.ID, channel.Code, channel.Config.ToJSON())
+}
@@ -3,8 +3,17 @@ package alipay
 import (
 	"backend-go/internal/service/pay/client"
 	"context"
+	"crypto/rsa"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 )
 
 func init() {
@@ -15,63 +24,728 @@ func init() {
 	client.RegisterCreator("alipay_bar", NewAlipayPayClientAsClient)
 }
 
-func NewAlipayPayClientAsClient(channelID int64, config string) (client.PayClient, error) {
-	// We pass empty channelCode because it's determined by the map key in factory if we want precise control.
-	// Or we can parse it from somewhere. For now, let's just make it work.
-	return NewAlipayPayClient(channelID, "alipay_unknown", config)
+func NewAlipayPayClientAsClient(channelID int64, channelCode string, config string) (client.PayClient, error) {
+	return NewAlipayPayClient(channelID, channelCode, config)
 }
 
+// 与 PayOrderStatusEnum / PayRefundStatusEnum / PayTransferStatusEnum 对齐
+const (
+	statusWaiting = 0  // 待支付 / 未退款 / 等待转账
+	statusSuccess = 10 // 支付成功 / 退款成功 / 转账成功
+	statusClosed  = 20 // 支付关闭 / 退款失败 / 转账关闭
+	statusRefund  = 30 // 已退款
+)
+
+// 展示模式，与 PayOrderDisplayModeEnum 对齐
+const (
+	displayModeURL     = "url"
+	displayModeForm    = "form"
+	displayModeQrCode  = "qr_code"
+	displayModeBarCode = "bar_code"
+	displayModeApp     = "app"
+)
+
+// 支付宝网关的时间格式，统一为东八区
+const timeLayout = "2006-01-02 15:04:05"
+
+var timeZone = time.FixedZone("CST", 8*3600)
+
 type AlipayPayClient struct {
 	*client.BaseClient
+	config     *AlipayPayClientConfig
+	httpClient *http.Client
+	privateKey *rsa.PrivateKey
+	publicKey  *rsa.PublicKey
+	// 证书模式下，请求需携带的证书序列号
+	appCertSN  string
+	rootCertSN string
 }
 
 func NewAlipayPayClient(channelID int64, channelCode string, config string) (*AlipayPayClient, error) {
 	return &AlipayPayClient{
 		BaseClient: client.NewBaseClient(channelID, channelCode, config),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
 	}, nil
 }
 
 func (c *AlipayPayClient) Init() error {
-	fmt.Printf("Initializing Alipay Client for Channel %d\n", c.ChannelID)
-	// Parse config JSON to Alipay Config struct
+	// 1. 解析配置
+	var cfg AlipayPayClientConfig
+	if err := json.Unmarshal([]byte(c.Config), &cfg); err != nil {
+		return fmt.Errorf("解析支付宝支付配置失败: %w", err)
+	}
+	if cfg.ServerURL == "" {
+		cfg.ServerURL = ServerURLProd
+	}
+	if cfg.SignType == "" {
+		cfg.SignType = SignTypeRSA2
+	}
+	if cfg.SignType != SignTypeRSA2 {
+		return fmt.Errorf("暂不支持的支付宝签名算法: %s", cfg.SignType)
+	}
+	if cfg.EncryptType != "" {
+		return fmt.Errorf("暂不支持支付宝接口内容加密: %s", cfg.EncryptType)
+	}
+	c.config = &cfg
+
+	// 2. 加载商户私钥
+	privateKey, err := parsePrivateKey(cfg.PrivateKey)
+	if err != nil {
+		return err
+	}
+	c.privateKey = privateKey
+
+	// 3. 加载支付宝公钥
+	switch cfg.Mode {
+	case ModePublicKey:
+		publicKey, err := parsePublicKey(cfg.AlipayPublicKey)
+		if err != nil {
+			return err
+		}
+		c.publicKey = publicKey
+	case ModeCertificate:
+		return c.initCertificate()
+	default:
+		return fmt.Errorf("未知的支付宝公钥类型: %d", cfg.Mode)
+	}
+	return nil
+}
+
+// initCertificate 证书模式：加载支付宝公钥证书，并计算应用证书、根证书序列号
+func (c *AlipayPayClient) initCertificate() error {
+	appCerts, err := parseCertificates(c.config.AppCertContent)
+	if err != nil {
+		return fmt.Errorf("加载商户应用公钥证书失败: %w", err)
+	}
+	c.appCertSN = getCertSN(appCerts[0])
+
+	alipayCerts, err := parseCertificates(c.config.AlipayPublicCertContent)
+	if err != nil {
+		return fmt.Errorf("加载支付宝公钥证书失败: %w", err)
+	}
+	publicKey, ok := alipayCerts[0].PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return errors.New("支付宝公钥证书不是 RSA 证书")
+	}
+	c.publicKey = publicKey
+
+	rootCertSN, err := getRootCertSN(c.config.RootCertContent)
+	if err != nil {
+		return fmt.Errorf("加载支付宝根证书失败: %w", err)
+	}
+	c.rootCertSN = rootCertSN
 	return nil
 }
 
+// ========== 支付 ==========
+
+// UnifiedOrder 统一下单
 func (c *AlipayPayClient) UnifiedOrder(ctx context.Context, req *client.UnifiedOrderReq) (*client.OrderResp, error) {
-	fmt.Printf("Alipay UnifiedOrder: %s\n", req.OutTradeNo)
-	// Implement real Alipay SDK call here
+	switch c.ChannelCode {
+	case "alipay_pc":
+		return c.pagePayOrder(req, "alipay.trade.page.pay", "FAST_INSTANT_TRADE_PAY")
+	case "alipay_wap":
+		return c.pagePayOrder(req, "alipay.trade.wap.pay", "QUICK_WAP_WAY")
+	case "alipay_app":
+		return c.appOrder(req)
+	case "alipay_qr":
+		return c.qrOrder(ctx, req)
+	case "alipay_bar":
+		return c.barOrder(ctx, req)
+	default:
+		return nil, fmt.Errorf("暂不支持的支付宝支付渠道: %s", c.ChannelCode)
+	}
+}
+
+// pagePayOrder PC 网站支付 / 手机网站支付：生成跳转链接或自动提交的表单，由用户浏览器发起请求
+func (c *AlipayPayClient) pagePayOrder(req *client.UnifiedOrderReq, method string, productCode string) (*client.OrderResp, error) {
+	bizContent := c.buildOrderBizContent(req)
+	bizContent["product_code"] = productCode
+	if method == "alipay.trade.wap.pay" {
+		// 用户付款中途退出返回商户网站的地址
+		bizContent["quit_url"] = req.ReturnURL
+	}
+
+	params, err := c.buildRequestParams(method, bizContent, req.NotifyURL, req.ReturnURL)
+	if err != nil {
+		return nil, err
+	}
+
+	displayMode := req.DisplayMode
+	if displayMode == "" {
+		displayMode = displayModeURL
+	}
+	var displayContent string
+	if displayMode == displayModeForm {
+		displayContent = c.buildFormHTML(params)
+	} else {
+		displayMode = displayModeURL
+		displayContent = c.config.ServerURL + "?" + encodeParams(params)
+	}
 	return &client.OrderResp{
-		Status:         0, // WAITING
+		Status:         statusWaiting,
 		OutTradeNo:     req.OutTradeNo,
-		DisplayMode:    req.DisplayMode,
-		DisplayContent: "https://openapi.alipay.com/gateway.do?mock=" + req.OutTradeNo,
+		DisplayMode:    displayMode,
+		DisplayContent: displayContent,
 	}, nil
 }
 
-func (c *AlipayPayClient) UnifiedRefund(ctx context.Context, req *client.UnifiedRefundReq) (*client.RefundResp, error) {
-	return nil, errors.New("not implemented")
+// appOrder APP 支付：返回签名后的订单字符串，由客户端 SDK 拉起支付宝
+func (c *AlipayPayClient) appOrder(req *client.UnifiedOrderReq) (*client.OrderResp, error) {
+	bizContent := c.buildOrderBizContent(req)
+	bizContent["product_code"] = "QUICK_MSECURITY_PAY"
+
+	params, err := c.buildRequestParams("alipay.trade.app.pay", bizContent, req.NotifyURL, "")
+	if err != nil {
+		return nil, err
+	}
+	return &client.OrderResp{
+		Status:         statusWaiting,
+		OutTradeNo:     req.OutTradeNo,
+		DisplayMode:    displayModeApp,
+		DisplayContent: encodeParams(params),
+	}, nil
 }
 
-func (c *AlipayPayClient) GetOrder(ctx context.Context, outTradeNo string) (*client.OrderResp, error) {
-	return nil, errors.New("not implemented")
+// qrOrder 扫码支付：预下单获得二维码内容
+func (c *AlipayPayClient) qrOrder(ctx context.Context, req *client.UnifiedOrderReq) (*client.OrderResp, error) {
+	bizContent := c.buildOrderBizContent(req)
+
+	var resp struct {
+		alipayResponse
+		OutTradeNo string `json:"out_trade_no"`
+		QrCode     string `json:"qr_code"`
+	}
+	raw, err := c.execute(ctx, "alipay.trade.precreate", bizContent, req.NotifyURL, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if !resp.isSuccess() {
+		return c.closedOrderResp(req.OutTradeNo, &resp.alipayResponse, raw), nil
+	}
+	return &client.OrderResp{
+		Status:         statusWaiting,
+		OutTradeNo:     req.OutTradeNo,
+		DisplayMode:    displayModeQrCode,
+		DisplayContent: resp.QrCode,
+		RawData:        raw,
+	}, nil
 }
 
-func (c *AlipayPayClient) GetRefund(ctx context.Context, outTradeNo, outRefundNo string) (*client.RefundResp, error) {
-	return nil, errors.New("not implemented")
+// barOrder 条码支付：商户扫描用户付款码，小额免密时同步返回成功
+func (c *AlipayPayClient) barOrder(ctx context.Context, req *client.UnifiedOrderReq) (*client.OrderResp, error) {
+	authCode := req.ChannelExtras["auth_code"]
+	if authCode == "" {
+		return nil, errors.New("条码支付需要 auth_code")
+	}
+	bizContent := c.buildOrderBizContent(req)
+	bizContent["scene"] = "bar_code"
+	bizContent["auth_code"] = authCode
+	bizContent["product_code"] = "FACE_TO_FACE_PAYMENT"
+
+	var resp struct {
+		alipayResponse
+		TradeNo     string `json:"trade_no"`
+		OutTradeNo  string `json:"out_trade_no"`
+		BuyerUserID string `json:"buyer_user_id"`
+		GmtPayment  string `json:"gmt_payment"`
+	}
+	raw, err := c.execute(ctx, "alipay.trade.pay", bizContent, req.NotifyURL, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if !resp.isSuccess() {
+		return c.closedOrderResp(req.OutTradeNo, &resp.alipayResponse, raw), nil
+	}
+	// 10000 表示免密支付成功；10003 表示等待用户输入密码，前端需轮询订单状态
+	if resp.Code == "10000" {
+		return &client.OrderResp{
+			Status:         statusSuccess,
+			OutTradeNo:     req.OutTradeNo,
+			ChannelOrderNo: resp.TradeNo,
+			ChannelUserID:  resp.BuyerUserID,
+			SuccessTime:    parseTime(resp.GmtPayment),
+			DisplayMode:    displayModeBarCode,
+			RawData:        raw,
+		}, nil
+	}
+	return &client.OrderResp{
+		Status:      statusWaiting,
+		OutTradeNo:  req.OutTradeNo,
+		DisplayMode: displayModeBarCode,
+		RawData:     raw,
+	}, nil
+}
+
+// buildOrderBizContent 构建下单的公共业务参数
+func (c *AlipayPayClient) buildOrderBizContent(req *client.UnifiedOrderReq) map[string]interface{} {
+	bizContent := map[string]interface{}{
+		"out_trade_no": req.OutTradeNo,
+		"subject":      req.Subject,
+		"total_amount": formatAmount(req.Price),
+	}
+	if req.Body != "" {
+		bizContent["body"] = req.Body
+	}
+	if !req.ExpireTime.IsZero() {
+		bizContent["time_expire"] = req.ExpireTime.In(timeZone).Format(timeLayout)
+	}
+	return bizContent
 }
 
+func (c *AlipayPayClient) closedOrderResp(outTradeNo string, resp *alipayResponse, raw string) *client.OrderResp {
+	return &client.OrderResp{
+		Status:           statusClosed,
+		OutTradeNo:       outTradeNo,
+		ChannelErrorCode: resp.errorCode(),
+		ChannelErrorMsg:  resp.errorMsg(),
+		RawData:          raw,
+	}
+}
+
+// GetOrder 查询订单
+func (c *AlipayPayClient) GetOrder(ctx context.Context, outTradeNo string) (*client.OrderResp, error) {
+	var resp struct {
+		alipayResponse
+		TradeNo     string `json:"trade_no"`
+		OutTradeNo  string `json:"out_trade_no"`
+		BuyerUserID string `json:"buyer_user_id"`
+		TradeStatus string `json:"trade_status"`
+		SendPayDate string `json:"send_pay_date"`
+	}
+	raw, err := c.execute(ctx, "alipay.trade.query", map[string]interface{}{"out_trade_no": outTradeNo}, "", &resp)
+	if err != nil {
+		return nil, err
+	}
+	if !resp.isSuccess() {
+		// 交易不存在：用户尚未扫码或下单后未拉起，视为关闭
+		if resp.SubCode == "ACQ.TRADE_NOT_EXIST" {
+			return &client.OrderResp{
+				Status:           statusClosed,
+				OutTradeNo:       outTradeNo,
+				ChannelErrorCode: resp.errorCode(),
+				ChannelErrorMsg:  resp.errorMsg(),
+				RawData:          raw,
+			}, nil
+		}
+		return nil, fmt.Errorf("查询支付宝订单失败: %s", resp.errorMsg())
+	}
+
+	return &client.OrderResp{
+		Status:         parseTradeStatus(resp.TradeStatus),
+		OutTradeNo:     outTradeNo,
+		ChannelOrderNo: resp.TradeNo,
+		ChannelUserID:  resp.BuyerUserID,
+		SuccessTime:    parseTime(resp.SendPayDate),
+		RawData:        raw,
+	}, nil
+}
+
+// ParseOrderNotify 解析支付回调
+// 支付宝在支付成功、交易关闭、退款等交易数据变化时均会回调，因此需综合判断状态
 func (c *AlipayPayClient) ParseOrderNotify(req *client.NotifyData) (*client.OrderResp, error) {
-	return nil, errors.New("not implemented")
+	// 1. 校验签名
+	params := req.Params
+	if err := c.verifyNotify(params); err != nil {
+		return nil, err
+	}
+
+	// 2. 解析订单状态
+	status := parseTradeStatus(params["trade_status"])
+	// 特殊逻辑：支付宝没有退款成功的状态，所以如果有退款金额，认为是退款
+	if refundFee, _ := strconv.ParseFloat(params["refund_fee"], 64); refundFee > 0 {
+		status = statusRefund
+	}
+	return &client.OrderResp{
+		Status:         status,
+		OutTradeNo:     params["out_trade_no"],
+		ChannelOrderNo: params["trade_no"],
+		ChannelUserID:  params["buyer_id"],
+		SuccessTime:    parseTime(params["gmt_payment"]),
+		RawData:        req.Body,
+	}, nil
 }
 
+// ========== 退款 ==========
+
+// UnifiedRefund 统一退款
+func (c *AlipayPayClient) UnifiedRefund(ctx context.Context, req *client.UnifiedRefundReq) (*client.RefundResp, error) {
+	bizContent := map[string]interface{}{
+		"out_trade_no":   req.OutTradeNo,
+		"out_request_no": req.OutRefundNo,
+		"refund_amount":  formatAmount(req.RefundPrice),
+		"refund_reason":  req.Reason,
+	}
+
+	var resp struct {
+		alipayResponse
+		TradeNo      string `json:"trade_no"`
+		FundChange   string `json:"fund_change"`
+		GmtRefundPay string `json:"gmt_refund_pay"`
+	}
+	raw, err := c.execute(ctx, "alipay.trade.refund", bizContent, "", &resp)
+	if err != nil {
+		return nil, err
+	}
+	if !resp.isSuccess() {
+		// 系统繁忙时退款结果未知，返回待退款，由退款同步任务兜底查询
+		if resp.SubCode == "ACQ.SYSTEM_ERROR" || resp.SubCode == "SYSTEM_ERROR" {
+			return &client.RefundResp{
+				Status:      statusWaiting,
+				OutTradeNo:  req.OutTradeNo,
+				OutRefundNo: req.OutRefundNo,
+				RawData:     raw,
+			}, nil
+		}
+		return &client.RefundResp{
+			Status:           statusClosed,
+			OutTradeNo:       req.OutTradeNo,
+			OutRefundNo:      req.OutRefundNo,
+			ChannelErrorCode: resp.errorCode(),
+			ChannelErrorMsg:  resp.errorMsg(),
+			RawData:          raw,
+		}, nil
+	}
+	// 支付宝退款为同步接口，调用成功即退款成功
+	successTime := parseTime(resp.GmtRefundPay)
+	if successTime.IsZero() {
+		successTime = time.Now()
+	}
+	return &client.RefundResp{
+		Status:          statusSuccess,
+		OutTradeNo:      req.OutTradeNo,
+		OutRefundNo:     req.OutRefundNo,
+		ChannelRefundNo: resp.TradeNo,
+		SuccessTime:     successTime,
+		RawData:         raw,
+	}, nil
+}
+
+// GetRefund 查询退款
+func (c *AlipayPayClient) GetRefund(ctx context.Context, outTradeNo, outRefundNo string) (*client.RefundResp, error) {
+	bizContent := map[string]interface{}{
+		"out_trade_no":   outTradeNo,
+		"out_request_no": outRefundNo,
+		"query_options":  []string{"gmt_refund_pay"},
+	}
+
+	var resp struct {
+		alipayResponse
+		TradeNo      string `json:"trade_no"`
+		RefundStatus string `json:"refund_status"`
+		GmtRefundPay string `json:"gmt_refund_pay"`
+	}
+	raw, err := c.execute(ctx, "alipay.trade.fastpay.refund.query", bizContent, "", &resp)
+	if err != nil {
+		return nil, err
+	}
+	if !resp.isSuccess() {
+		// 交易不存在，说明退款未发起成功
+		if resp.SubCode == "ACQ.TRADE_NOT_EXIST" {
+			return &client.RefundResp{
+				Status:           statusClosed,
+				OutTradeNo:       outTradeNo,
+				OutRefundNo:      outRefundNo,
+				ChannelErrorCode: resp.errorCode(),
+				ChannelErrorMsg:  resp.errorMsg(),
+				RawData:          raw,
+			}, nil
+		}
+		return nil, fmt.Errorf("查询支付宝退款失败: %s", resp.errorMsg())
+	}
+	// 退款成功时返回 REFUND_SUCCESS；未返回该字段说明退款尚未成功
+	if resp.RefundStatus == "REFUND_SUCCESS" {
+		return &client.RefundResp{
+			Status:          statusSuccess,
+			OutTradeNo:      outTradeNo,
+			OutRefundNo:     outRefundNo,
+			ChannelRefundNo: resp.TradeNo,
+			SuccessTime:     parseTime(resp.GmtRefundPay),
+			RawData:         raw,
+		}, nil
+	}
+	return &client.RefundResp{
+		Status:      statusWaiting,
+		OutTradeNo:  outTradeNo,
+		OutRefundNo: outRefundNo,
+		RawData:     raw,
+	}, nil
+}
+
+// ParseRefundNotify 解析退款回调
+// 支付宝退款为同步接口，没有独立的退款回调；退款引起的交易变化通过支付回调通知
 func (c *AlipayPayClient) ParseRefundNotify(req *client.NotifyData) (*client.RefundResp, error) {
-	return nil, errors.New("not implemented")
+	return nil, errors.New("支付宝无退款回调")
 }
 
+// ========== 转账 ==========
+
+// UnifiedTransfer 统一转账 (单笔转账到支付宝账户)
 func (c *AlipayPayClient) UnifiedTransfer(ctx context.Context, req *client.UnifiedTransferReq) (*client.TransferResp, error) {
-	fmt.Printf("Alipay UnifiedTransfer: %s -> %s\n", req.OutTradeNo, req.ChannelUserID)
-	return &client.TransferResp{
-		ChannelTransferNo: "MOCK_ALIPAY_TRANSFER_" + req.OutTradeNo,
-		Status:            10, // SUCCESS
-	}, nil
+	if req.UserAccount == "" {
+		return nil, errors.New("支付宝转账需要收款人账号")
+	}
+	bizContent := map[string]interface{}{
+		"out_biz_no":   req.OutTradeNo,
+		"trans_amount": formatAmount(req.Price),
+		"order_title":  req.Subject,
+		"product_code": "TRANS_ACCOUNT_NO_PWD",
+		"biz_scene":    "DIRECT_TRANSFER",
+		"payee_info": map[string]string{
+			"identity":      req.UserAccount,
+			"identity_type": "ALIPAY_LOGON_ID",
+			"name":          req.UserName,
+		},
+	}
+
+	var resp struct {
+		alipayResponse
+		OutBizNo       string `json:"out_biz_no"`
+		OrderID        string `json:"order_id"`
+		PayFundOrderID string `json:"pay_fund_order_id"`
+		Status         string `json:"status"`
+		TransDate      string `json:"trans_date"`
+	}
+	raw, err := c.execute(ctx, "alipay.fund.trans.uni.transfer", bizContent, "", &resp)
+	if err != nil {
+		return nil, err
+	}
+	if !resp.isSuccess() {
+		return &client.TransferResp{
+			Status:           statusClosed,
+			OutTradeNo:       req.OutTradeNo,
+			ChannelErrorCode: resp.errorCode(),
+			ChannelErrorMsg:  resp.errorMsg(),
+			RawData:          raw,
+		}, nil
+	}
+
+	switch resp.Status {
+	case "SUCCESS":
+		return &client.TransferResp{
+			Status:            statusSuccess,
+			OutTradeNo:        req.OutTradeNo,
+			ChannelTransferNo: resp.OrderID,
+			SuccessTime:       parseTime(resp.TransDate),
+			RawData:           raw,
+		}, nil
+	case "FAIL", "CLOSED":
+		return &client.TransferResp{
+			Status:            statusClosed,
+			OutTradeNo:        req.OutTradeNo,
+			ChannelTransferNo: resp.OrderID,
+			ChannelErrorCode:  resp.Status,
+			RawData:           raw,
+		}, nil
+	default:
+		// DEALING 等处理中状态
+		return &client.TransferResp{
+			Status:            statusWaiting,
+			OutTradeNo:        req.OutTradeNo,
+			ChannelTransferNo: resp.OrderID,
+			RawData:           raw,
+		}, nil
+	}
+}
+
+// ========== 网关调用 ==========
+
+// alipayResponse 支付宝网关的公共响应参数
+type alipayResponse struct {
+	Code    string `json:"code"`
+	Msg     string `json:"msg"`
+	SubCode string `json:"sub_code"`
+	SubMsg  string `json:"sub_msg"`
+}
+
+// isSuccess 对齐支付宝 SDK AlipayResponse.isSuccess：无业务错误码即为成功
+func (r *alipayResponse) isSuccess() bool {
+	return r.SubCode == "" && (r.Code == "10000" || r.Code == "10003")
+}
+
+func (r *alipayResponse) errorCode() string {
+	if r.SubCode != "" {
+		return r.SubCode
+	}
+	return r.Code
+}
+
+func (r *alipayResponse) errorMsg() string {
+	if r.SubMsg != "" {
+		return r.SubMsg
+	}
+	return r.Msg
+}
+
+// buildRequestParams 构建已签名的网关请求参数
+func (c *AlipayPayClient) buildRequestParams(method string, bizContent map[string]interface{}, notifyURL string, returnURL string) (map[string]string, error) {
+	bizContentJSON, err := json.Marshal(bizContent)
+	if err != nil {
+		return nil, err
+	}
+	params := map[string]string{
+		"app_id":      c.config.AppID,
+		"method":      method,
+		"format":      "JSON",
+		"charset":     "utf-8",
+		"sign_type":   c.config.SignType,
+		"timestamp":   time.Now().In(timeZone).Format(timeLayout),
+		"version":     "1.0",
+		"biz_content": string(bizContentJSON),
+	}
+	if notifyURL != "" {
+		params["notify_url"] = notifyURL
+	}
+	if returnURL != "" {
+		params["return_url"] = returnURL
+	}
+	if c.config.Mode == ModeCertificate {
+		params["app_cert_sn"] = c.appCertSN
+		params["alipay_root_cert_sn"] = c.rootCertSN
+	}
+
+	sign, err := signRSA2(buildSignContent(params, "sign"), c.privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("支付宝请求签名失败: %w", err)
+	}
+	params["sign"] = sign
+	return params, nil
+}
+
+// execute 调用支付宝网关，校验响应签名，并将 {method}_response 节点解析到 result
+// 返回响应节点的原始 JSON，便于记录渠道原始数据
+func (c *AlipayPayClient) execute(ctx context.Context, method string, bizContent map[string]interface{}, notifyURL string, result interface{}) (string, error) {
+	params, err := c.buildRequestParams(method, bizContent, notifyURL, "")
+	if err != nil {
+		return "", err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.ServerURL, strings.NewReader(encodeParams(params)))
+	if err != nil {
+		return "", err
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded;charset=utf-8")
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("请求支付宝网关失败: %w", err)
+	}
+	defer httpResp.Body.Close()
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return "", fmt.Errorf("读取支付宝网关响应失败: %w", err)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("支付宝网关响应异常: HTTP %d", httpResp.StatusCode)
+	}
+
+	// json.RawMessage 保留响应节点的原文，用于验签
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return "", fmt.Errorf("解析支付宝网关响应失败: %w", err)
+	}
+	nodeName := strings.ReplaceAll(method, ".", "_") + "_response"
+	node, ok := envelope[nodeName]
+	if !ok {
+		node, ok = envelope["error_response"]
+		if !ok {
+			return "", fmt.Errorf("支付宝网关响应缺少 %s 节点", nodeName)
+		}
+	}
+
+	// 校验签名：成功响应必须携带有效签名；网关级错误 (如签名错误) 可能不返回 sign，此时仅解析错误信息
+	var sign string
+	if rawSign, ok := envelope["sign"]; ok {
+		if err := json.Unmarshal(rawSign, &sign); err != nil {
+			return "", fmt.Errorf("解析支付宝响应签名失败: %w", err)
+		}
+	}
+	if sign == "" {
+		var status alipayResponse
+		if err := json.Unmarshal(node, &status); err != nil {
+			return "", fmt.Errorf("解析支付宝响应内容失败: %w", err)
+		}
+		if status.isSuccess() {
+			return "", errors.New("支付宝响应缺少签名")
+		}
+	} else if err := verifyRSA2(string(node), sign, c.publicKey); err != nil {
+		return "", fmt.Errorf("支付宝响应验签失败: %w", err)
+	}
+
+	if err := json.Unmarshal(node, result); err != nil {
+		return "", fmt.Errorf("解析支付宝响应内容失败: %w", err)
+	}
+	return string(node), nil
+}
+
+// verifyNotify 校验异步通知签名，sign 与 sign_type 不参与签名
+func (c *AlipayPayClient) verifyNotify(params map[string]string) error {
+	sign := params["sign"]
+	if sign == "" {
+		return errors.New("支付宝回调缺少签名")
+	}
+	if appID := params["app_id"]; appID != "" && appID != c.config.AppID {
+		return fmt.Errorf("支付宝回调的 app_id 不匹配: %s", appID)
+	}
+	if err := verifyRSA2(buildSignContent(params, "sign", "sign_type"), sign, c.publicKey); err != nil {
+		return fmt.Errorf("支付宝回调验签失败: %w", err)
+	}
+	return nil
+}
+
+// buildFormHTML 构建自动提交到支付宝网关的表单
+func (c *AlipayPayClient) buildFormHTML(params map[string]string) string {
+	var sb strings.Builder
+	sb.WriteString(`<form name="punchout_form" method="post" action="`)
+	sb.WriteString(html.EscapeString(c.config.ServerURL + "?charset=utf-8"))
+	sb.WriteString(`">`)
+	for k, v := range params {
+		sb.WriteString(`<input type="hidden" name="`)
+		sb.WriteString(html.EscapeString(k))
+		sb.WriteString(`" value="`)
+		sb.WriteString(html.EscapeString(v))
+		sb.WriteString(`">`)
+	}
+	sb.WriteString(`<input type="submit" value="立即支付" style="display:none"></form>`)
+	sb.WriteString(`<script>document.forms[0].submit();</script>`)
+	return sb.String()
+}
+
+// ========== 工具方法 ==========
+
+func encodeParams(params map[string]string) string {
+	values := make(url.Values, len(params))
+	for k, v := range params {
+		values.Set(k, v)
+	}
+	return values.Encode()
+}
+
+// formatAmount 将分转换为支付宝要求的元 (保留两位小数)
+func formatAmount(fen int) string {
+	return fmt.Sprintf("%d.%02d", fen/100, fen%100)
+}
+
+// parseTime 解析支付宝的时间字符串，解析失败返回零值
+func parseTime(value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	t, err := time.ParseInLocation(timeLayout, value, timeZone)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// parseTradeStatus 将支付宝交易状态转换为支付订单状态
+func parseTradeStatus(tradeStatus string) int {
+	switch tradeStatus {
+	case "TRADE_SUCCESS", "TRADE_FINISHED":
+		return statusSuccess
+	case "TRADE_CLOSED":
+		return statusClosed
+	default:
+		// WAIT_BUYER_PAY
+		return statusWaiting
+	}
 }
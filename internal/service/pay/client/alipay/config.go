@@ -0,0 +1,45 @@
+package alipay
+
+// AlipayPayClientConfig 支付宝支付客户端配置
+// 与 Java AlipayPayClientConfig 保持一致
+type AlipayPayClientConfig struct {
+	// ========== 通用参数 ==========
+	// 网关地址，例如 https://openapi.alipay.com/gateway.do
+	ServerURL string `json:"serverUrl"`
+	// 开放平台上创建的应用的 ID
+	AppID string `json:"appId"`
+	// 签名算法类型，推荐 RSA2
+	SignType string `json:"signType"`
+	// 公钥类型：1 = 公钥模式，2 = 证书模式
+	Mode int `json:"mode"`
+	// 商户私钥
+	PrivateKey string `json:"privateKey"`
+
+	// ========== 公钥模式的参数 ==========
+	// 支付宝公钥字符串
+	AlipayPublicKey string `json:"alipayPublicKey,omitempty"`
+
+	// ========== 证书模式的参数 ==========
+	// 商户公钥应用证书内容
+	AppCertContent string `json:"appCertContent,omitempty"`
+	// 支付宝公钥证书内容
+	AlipayPublicCertContent string `json:"alipayPublicCertContent,omitempty"`
+	// 根证书内容
+	RootCertContent string `json:"rootCertContent,omitempty"`
+
+	// ========== 接口内容加密 ==========
+	// 接口内容加密方式，例如 AES
+	EncryptType string `json:"encryptType,omitempty"`
+	// 接口内容加密的私钥
+	EncryptKey string `json:"encryptKey,omitempty"`
+}
+
+const (
+	ModePublicKey   = 1 // 公钥模式
+	ModeCertificate = 2 // 证书模式
+
+	SignTypeRSA2 = "RSA2"
+
+	ServerURLProd    = "https://openapi.alipay.com/gateway.do"
+	ServerURLSandbox = "https://openapi-sandbox.dl.alipaydev.com/gateway.do"
+)
@@ -0,0 +1,175 @@
+package alipay
+
+import (
+	"crypto"
+	"crypto/md5"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// buildSignContent 构建待签名字符串
+// 规则：剔除 excludes 中的参数及空值，按参数名 ASCII 升序排列，以 k=v&k=v 拼接 (值不做 URL 编码)
+func buildSignContent(params map[string]string, excludes ...string) string {
+	keys := make([]string, 0, len(params))
+	for k, v := range params {
+		if v == "" || containsString(excludes, k) {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	for i, k := range keys {
+		if i > 0 {
+			sb.WriteByte('&')
+		}
+		sb.WriteString(k)
+		sb.WriteByte('=')
+		sb.WriteString(params[k])
+	}
+	return sb.String()
+}
+
+// signRSA2 使用 SHA256WithRSA 签名，返回 Base64 编码结果
+func signRSA2(content string, privateKey *rsa.PrivateKey) (string, error) {
+	hashed := sha256.Sum256([]byte(content))
+	signature, err := rsa.SignPKCS1v15(nil, privateKey, crypto.SHA256, hashed[:])
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
+
+// verifyRSA2 校验 SHA256WithRSA 签名
+func verifyRSA2(content string, sign string, publicKey *rsa.PublicKey) error {
+	signature, err := base64.StdEncoding.DecodeString(sign)
+	if err != nil {
+		return fmt.Errorf("签名格式不正确: %w", err)
+	}
+	hashed := sha256.Sum256([]byte(content))
+	return rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hashed[:], signature)
+}
+
+// parsePrivateKey 解析商户私钥
+// 兼容支付宝开放平台工具生成的裸 Base64 格式，以及 PKCS#1 / PKCS#8 的 PEM 格式
+func parsePrivateKey(content string) (*rsa.PrivateKey, error) {
+	der, err := decodeKeyContent(content)
+	if err != nil {
+		return nil, err
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("解析商户私钥失败: %w", err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("商户私钥不是 RSA 私钥")
+	}
+	return rsaKey, nil
+}
+
+// parsePublicKey 解析支付宝公钥，兼容裸 Base64 与 PEM 格式
+func parsePublicKey(content string) (*rsa.PublicKey, error) {
+	der, err := decodeKeyContent(content)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		if rsaKey, err2 := x509.ParsePKCS1PublicKey(der); err2 == nil {
+			return rsaKey, nil
+		}
+		return nil, fmt.Errorf("解析支付宝公钥失败: %w", err)
+	}
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("支付宝公钥不是 RSA 公钥")
+	}
+	return rsaKey, nil
+}
+
+// decodeKeyContent 将密钥内容解码为 DER 字节
+func decodeKeyContent(content string) ([]byte, error) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return nil, errors.New("密钥内容为空")
+	}
+	if block, _ := pem.Decode([]byte(content)); block != nil {
+		return block.Bytes, nil
+	}
+	der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(content), ""))
+	if err != nil {
+		return nil, fmt.Errorf("密钥内容不是合法的 Base64: %w", err)
+	}
+	return der, nil
+}
+
+// parseCertificates 解析 PEM 格式的证书链
+func parseCertificates(content string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := []byte(strings.TrimSpace(content))
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("解析证书失败: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("证书内容为空")
+	}
+	return certs, nil
+}
+
+// getCertSN 计算证书序列号：MD5(颁发者 DN + 序列号十进制字符串)
+// 对齐支付宝 SDK AlipaySignature.getCertSN
+func getCertSN(cert *x509.Certificate) string {
+	sum := md5.Sum([]byte(cert.Issuer.String() + cert.SerialNumber.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// getRootCertSN 计算根证书序列号：仅取 RSA 签名算法的证书，多个以下划线拼接
+// 对齐支付宝 SDK AlipaySignature.getRootCertSN
+func getRootCertSN(content string) (string, error) {
+	certs, err := parseCertificates(content)
+	if err != nil {
+		return "", err
+	}
+	sns := make([]string, 0, len(certs))
+	for _, cert := range certs {
+		if cert.SignatureAlgorithm != x509.SHA1WithRSA && cert.SignatureAlgorithm != x509.SHA256WithRSA {
+			continue
+		}
+		sns = append(sns, getCertSN(cert))
+	}
+	return strings.Join(sns, "_"), nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -29,7 +29,7 @@ func (f *PayClientFactory) GetPayClient(channelID int64) PayClient {
 // Given Go's static nature, a registry or simple switch in a "provider" package implies circular deps if not careful.
 // Best approach: Define 'Creator' function type.
 
-type ClientCreator func(channelID int64, config string) (PayClient, error)
+type ClientCreator func(channelID int64, channelCode string, config string) (PayClient, error)
 
 var creators = make(map[string]ClientCreator)
 
@@ -63,7 +63,7 @@ func (f *PayClientFactory) CreateOrUpdatePayClient(channelID int64, channelCode
 		return nil, errors.New("channel not supported")
 	}
 
-	newClient, err := creator(channelID, config)
+	newClient, err := creator(channelID, channelCode, config)
 	if err != nil {
 		return nil, err
 	}
@@ -36,8 +36,8 @@ func init() {
 	client.RegisterCreator("wx_bar", NewWxPayClientAsClient)
 }
 
-func NewWxPayClientAsClient(channelID int64, config string) (client.PayClient, error) {
-	return NewWxPayClient(channelID, "wx_unknown", config)
+func NewWxPayClientAsClient(channelID int64, channelCode string, config string) (client.PayClient, error) {
+	return NewWxPayClient(channelID, channelCode, config)
 }
 
 type WxPayClient struct {
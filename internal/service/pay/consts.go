@@ -22,3 +22,44 @@ const (
 	PayNotifyStatusRequestSuccess = 21 // 请求成功，但是结果失败
 	PayNotifyStatusRequestFailure = 22 // 请求失败
 )
+
+// PayRefundStatusEnum 退款状态
+const (
+	PayRefundStatusWaiting = 0  // 未退款
+	PayRefundStatusSuccess = 10 // 退款成功
+	PayRefundStatusFailure = 20 // 退款失败
+)
+
+// PayChannelCodeWallet 钱包支付渠道编码
+const PayChannelCodeWallet = "wallet"
+
+// PayWalletBizTypeEnum 钱包交易业务分类
+const (
+	PayWalletBizTypeRecharge       = 1 // 充值
+	PayWalletBizTypeRechargeRefund = 2 // 充值退款
+	PayWalletBizTypePayment        = 3 // 支付
+	PayWalletBizTypePaymentRefund  = 4 // 支付退款
+	PayWalletBizTypeUpdateBalance  = 5 // 更新余额
+	PayWalletBizTypeTransfer       = 6 // 分佣提现
+)
+
+// PayWalletBizTypeTitles 钱包交易业务分类对应的流水说明
+var PayWalletBizTypeTitles = map[int]string{
+	PayWalletBizTypeRecharge:       "充值",
+	PayWalletBizTypeRechargeRefund: "充值退款",
+	PayWalletBizTypePayment:        "支付",
+	PayWalletBizTypePaymentRefund:  "支付退款",
+	PayWalletBizTypeUpdateBalance:  "更新余额",
+	PayWalletBizTypeTransfer:       "分佣提现",
+}
+
+// PayWalletRechargeAppKey 钱包充值使用的支付应用标识
+const PayWalletRechargeAppKey = "wallet"
+
+// PayTransferStatusEnum 转账状态
+const (
+	PayTransferStatusWaiting    = 0  // 等待转账
+	PayTransferStatusProcessing = 5  // 转账进行中
+	PayTransferStatusSuccess    = 10 // 转账成功
+	PayTransferStatusClosed     = 20 // 转账关闭
+)
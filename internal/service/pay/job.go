@@ -0,0 +1,99 @@
+package pay
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// 支付模块内置 Job 的处理器名 (infra_job.handler_name)
+const (
+	PayOrderExpireJobHandlerName = "payOrderExpireJob"
+	PayOrderSyncJobHandlerName   = "payOrderSyncJob"
+	PayRefundSyncJobHandlerName  = "payRefundSyncJob"
+	PayNotifyJobHandlerName      = "payNotifyJob"
+)
+
+// payOrderSyncDuration 支付订单同步的时间范围，只同步最近创建的待支付拓展单
+const payOrderSyncDuration = 10 * time.Minute
+
+// PayOrderExpireJob 支付订单的过期 Job
+type PayOrderExpireJob struct {
+	orderSvc *PayOrderService
+	logger   *zap.Logger
+}
+
+func NewPayOrderExpireJob(orderSvc *PayOrderService, logger *zap.Logger) *PayOrderExpireJob {
+	return &PayOrderExpireJob{orderSvc: orderSvc, logger: logger}
+}
+
+// Execute 实现 service.JobHandler
+func (j *PayOrderExpireJob) Execute(ctx context.Context, param string) error {
+	count, err := j.orderSvc.ExpireOrder(ctx)
+	if err != nil {
+		return err
+	}
+	j.logger.Info("[PayOrderExpireJob] 支付过期", zap.Int("count", count))
+	return nil
+}
+
+// PayOrderSyncJob 支付订单的同步 Job，兜底渠道回调丢失的场景
+type PayOrderSyncJob struct {
+	orderSvc *PayOrderService
+	logger   *zap.Logger
+}
+
+func NewPayOrderSyncJob(orderSvc *PayOrderService, logger *zap.Logger) *PayOrderSyncJob {
+	return &PayOrderSyncJob{orderSvc: orderSvc, logger: logger}
+}
+
+// Execute 实现 service.JobHandler
+func (j *PayOrderSyncJob) Execute(ctx context.Context, param string) error {
+	count, err := j.orderSvc.SyncOrder(ctx, time.Now().Add(-payOrderSyncDuration))
+	if err != nil {
+		return err
+	}
+	j.logger.Info("[PayOrderSyncJob] 同步支付订单", zap.Int("count", count))
+	return nil
+}
+
+// PayRefundSyncJob 退款订单的同步 Job，兜底渠道回调丢失或发起退款异常的场景
+type PayRefundSyncJob struct {
+	refundSvc *PayRefundService
+	logger    *zap.Logger
+}
+
+func NewPayRefundSyncJob(refundSvc *PayRefundService, logger *zap.Logger) *PayRefundSyncJob {
+	return &PayRefundSyncJob{refundSvc: refundSvc, logger: logger}
+}
+
+// Execute 实现 service.JobHandler
+func (j *PayRefundSyncJob) Execute(ctx context.Context, param string) error {
+	count, err := j.refundSvc.SyncRefund(ctx)
+	if err != nil {
+		return err
+	}
+	j.logger.Info("[PayRefundSyncJob] 同步退款订单", zap.Int("count", count))
+	return nil
+}
+
+// PayNotifyJob 支付通知的执行 Job，回调商户的支付、退款结果
+type PayNotifyJob struct {
+	notifySvc *PayNotifyService
+	logger    *zap.Logger
+}
+
+func NewPayNotifyJob(notifySvc *PayNotifyService, logger *zap.Logger) *PayNotifyJob {
+	return &PayNotifyJob{notifySvc: notifySvc, logger: logger}
+}
+
+// Execute 实现 service.JobHandler
+func (j *PayNotifyJob) Execute(ctx context.Context, param string) error {
+	count, err := j.notifySvc.ExecuteNotify(ctx)
+	if err != nil {
+		return err
+	}
+	j.logger.Info("[PayNotifyJob] 执行支付通知", zap.Int("count", count))
+	return nil
+}
@@ -7,9 +7,11 @@ import (
 	"backend-go/internal/repo/query"
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -35,11 +37,16 @@ func NewPayNotifyService(q *query.Query, logger *zap.Logger, rdb *redis.Client)
 
 // CreatePayNotifyTask 创建回调通知任务
 func (s *PayNotifyService) CreatePayNotifyTask(ctx context.Context, typeVal int, dataId int64) error {
+	return s.CreatePayNotifyTaskTx(ctx, s.q, typeVal, dataId)
+}
+
+// CreatePayNotifyTaskTx 在事务中创建回调通知任务
+func (s *PayNotifyService) CreatePayNotifyTaskTx(ctx context.Context, tx *query.Query, typeVal int, dataId int64) error {
 	var task *pay.PayNotifyTask
 
 	// 1. Get Data by Type
 	if typeVal == PayNotifyTypeOrder {
-		order, err := s.q.PayOrder.WithContext(ctx).Where(s.q.PayOrder.ID.Eq(dataId)).First()
+		order, err := tx.PayOrder.WithContext(ctx).Where(tx.PayOrder.ID.Eq(dataId)).First()
 		if err != nil {
 			return err
 		}
@@ -51,7 +58,7 @@ func (s *PayNotifyService) CreatePayNotifyTask(ctx context.Context, typeVal int,
 			NotifyURL:       order.NotifyURL,
 		}
 	} else if typeVal == PayNotifyTypeRefund {
-		refund, err := s.q.PayRefund.WithContext(ctx).Where(s.q.PayRefund.ID.Eq(dataId)).First()
+		refund, err := tx.PayRefund.WithContext(ctx).Where(tx.PayRefund.ID.Eq(dataId)).First()
 		if err != nil {
 			return err
 		}
@@ -73,7 +80,7 @@ func (s *PayNotifyService) CreatePayNotifyTask(ctx context.Context, typeVal int,
 	task.NotifyTimes = 0
 	task.MaxNotifyTimes = len(NotifyFrequency) + 1
 
-	return s.q.PayNotifyTask.WithContext(ctx).Create(task)
+	return tx.PayNotifyTask.WithContext(ctx).Create(task)
 }
 
 // ExecuteNotify 执行回调通知 (Called by Job or Manually)
@@ -88,17 +95,20 @@ func (s *PayNotifyService) ExecuteNotify(ctx context.Context) (int, error) {
 		return 0, err
 	}
 
-	count := 0
+	// 并发执行每个任务，并等待全部完成，使任务的超时控制覆盖实际通知过程
+	// 对齐 Java: PayNotifyServiceImpl.executeNotify 中 CountDownLatch 等待所有任务执行完毕
+	var wg sync.WaitGroup
 	for _, task := range tasks {
-		// 异步执行每个任务
+		wg.Add(1)
 		go func(t *pay.PayNotifyTask) {
+			defer wg.Done()
 			if err := s.executeNotifyTaskWithLock(ctx, t); err != nil {
 				s.logger.Error("executeNotifyTask failed", zap.Int64("taskId", t.ID), zap.Error(err))
 			}
 		}(task)
-		count++
 	}
-	return count, nil
+	wg.Wait()
+	return len(tasks), nil
 }
 
 // executeNotifyTaskWithLock 使用分布式锁执行通知任务
@@ -135,16 +145,6 @@ func (s *PayNotifyService) executeNotifyTask(ctx context.Context, task *pay.PayN
 	status := PayNotifyStatusSuccess
 	responseBody := ""
 
-	// Create request body - In specific format required by merchant?
-	// Usually POST with some params. For now, assuming generic empty or simple mapping. All params are in the URL or Body?
-	// Java code uses `PayOrderNotifyReqDTO` or similar.
-	// Simplification: Sending empty body for now as `task.NotifyURL` typically contains params?
-	// Wait, standard is POST FORM or JSON. Java code uses `restTemplate.postForEntity`.
-	// For simplicity, we just POST. The real payload should be defined.
-	// But `PayNotifyTaskDO` doesn't store the content. It seems content is built dynamically from Order/Refund?
-	// Re-checking Java logic: `executeNotifyTask` calls `notifyPayOrder` -> `NotifyPayOrderReqDTO`.
-	// For now, I will send a simple JSON.
-
 	// Prepare Log
 	log := &pay.PayNotifyLog{
 		TaskID:      task.ID,
@@ -153,7 +153,7 @@ func (s *PayNotifyService) executeNotifyTask(ctx context.Context, task *pay.PayN
 	}
 
 	client := &http.Client{Timeout: 10 * time.Second}
-	reqBody := []byte("{}") // TODO: Build actual payload
+	reqBody := s.buildNotifyBody(task)
 	req, _ := http.NewRequest("POST", task.NotifyURL, bytes.NewBuffer(reqBody))
 	req.Header.Set("Content-Type", "application/json")
 
@@ -179,7 +179,7 @@ func (s *PayNotifyService) executeNotifyTask(ctx context.Context, task *pay.PayN
 	// Note: Simple logic here. Ideally check "SUCCESS" string from merchant.
 	// Java: `if ("success".equalsIgnoreCase(response)) status = SUCCESS`
 
-	if responseBody == "success" || responseBody == "SUCCESS" {
+	if responseBody == "success" || responseBody == "SUCCESS" || isNotifySuccessResult(responseBody) {
 		status = PayNotifyStatusSuccess
 	}
 
@@ -211,6 +211,40 @@ func (s *PayNotifyService) executeNotifyTask(ctx context.Context, task *pay.PayN
 	return nil
 }
 
+// buildNotifyBody 构建通知商户的请求内容
+// 对齐 Java: PayOrderNotifyReqDTO / PayRefundNotifyReqDTO
+func (s *PayNotifyService) buildNotifyBody(task *pay.PayNotifyTask) []byte {
+	var body interface{}
+	switch task.Type {
+	case PayNotifyTypeOrder:
+		body = map[string]interface{}{
+			"merchantOrderId": task.MerchantOrderId,
+			"payOrderId":      task.DataID,
+		}
+	case PayNotifyTypeRefund:
+		body = map[string]interface{}{
+			"merchantOrderId":  task.MerchantOrderId,
+			"merchantRefundId": task.MerchantRefundId,
+			"payRefundId":      task.DataID,
+		}
+	default:
+		body = map[string]interface{}{}
+	}
+	data, _ := json.Marshal(body)
+	return data
+}
+
+// isNotifySuccessResult 商户返回 CommonResult 格式时，code 为 0 视为通知成功
+func isNotifySuccessResult(responseBody string) bool {
+	var result struct {
+		Code *int `json:"code"`
+	}
+	if err := json.Unmarshal([]byte(responseBody), &result); err != nil {
+		return false
+	}
+	return result.Code != nil && *result.Code == core.SuccessCode
+}
+
 // GetNotifyTask 获得回调通知
 func (s *PayNotifyService) GetNotifyTask(ctx context.Context, id int64) (*pay.PayNotifyTask, error) {
 	return s.q.PayNotifyTask.WithContext(ctx).Where(s.q.PayNotifyTask.ID.Eq(id)).First()
@@ -5,6 +5,7 @@ import (
 	"backend-go/internal/api/resp"
 	"backend-go/internal/model/pay"
 	"backend-go/internal/pkg/core"
+	"backend-go/internal/pkg/excel"
 	"backend-go/internal/repo/query"
 	"backend-go/internal/service/pay/client"
 	"backend-go/pkg/config"
@@ -12,8 +13,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"time"
 
+	"go.uber.org/zap"
+	"gorm.io/gen"
 	"gorm.io/gorm"
 )
 
@@ -23,15 +27,17 @@ type PayOrderService struct {
 	channelSvc *PayChannelService
 	clientFac  *client.PayClientFactory
 	notifySvc  *PayNotifyService
+	logger     *zap.Logger
 }
 
-func NewPayOrderService(q *query.Query, appSvc *PayAppService, channelSvc *PayChannelService, clientFac *client.PayClientFactory, notifySvc *PayNotifyService) *PayOrderService {
+func NewPayOrderService(q *query.Query, appSvc *PayAppService, channelSvc *PayChannelService, clientFac *client.PayClientFactory, notifySvc *PayNotifyService, logger *zap.Logger) *PayOrderService {
 	return &PayOrderService{
 		q:          q,
 		appSvc:     appSvc,
 		channelSvc: channelSvc,
 		clientFac:  clientFac,
 		notifySvc:  notifySvc,
+		logger:     logger,
 	}
 }
 
@@ -156,21 +162,29 @@ func (s *PayOrderService) SubmitOrder(ctx context.Context, reqVO *req.PayOrderSu
 
 	// Call UnifiedOrder (对齐 Java: 使用渠道特定的回调 URL)
 	unifiedReq := &client.UnifiedOrderReq{
-		UserIP:     userIP,
-		OutTradeNo: no,
-		Subject:    order.Subject,
-		Body:       order.Body,
-		NotifyURL:  s.genChannelOrderNotifyUrl(channel), // 对齐 Java: 渠道回调 URL
-		// ReturnURL:   reqVO.ReturnUrl,
-		Price:       order.Price,
-		ExpireTime:  order.ExpireTime,
-		DisplayMode: reqVO.DisplayMode,
+		UserIP:        userIP,
+		OutTradeNo:    no,
+		Subject:       order.Subject,
+		Body:          order.Body,
+		NotifyURL:     s.genChannelOrderNotifyUrl(channel), // 对齐 Java: 渠道回调 URL
+		ReturnURL:     reqVO.ReturnUrl,
+		Price:         order.Price,
+		ExpireTime:    order.ExpireTime,
+		ChannelExtras: reqVO.ChannelExtras,
+		DisplayMode:   reqVO.DisplayMode,
 	}
 	unifiedResp, err := payClient.UnifiedOrder(ctx, unifiedReq)
 	if err != nil {
 		return nil, err
 	}
 
+	// 同步返回了支付结果 (如条码支付免密成功)，直接按回调逻辑处理
+	if unifiedResp.Status != PayOrderStatusWaiting {
+		if err := s.NotifyOrder(ctx, channel.ID, unifiedResp); err != nil {
+			return nil, err
+		}
+	}
+
 	// Return response
 	return &resp.PayOrderSubmitResp{
 		Status:         unifiedResp.Status,
@@ -238,12 +252,34 @@ func (s *PayOrderService) SyncOrderQuietly(ctx context.Context, id int64) {
 	}
 }
 
+// SyncOrder 同步创建时间不早于 minCreateTime 的待支付拓展单，返回同步为已支付的数量
+// 对齐 Java: PayOrderServiceImpl.syncOrder(LocalDateTime minCreateTime)
+func (s *PayOrderService) SyncOrder(ctx context.Context, minCreateTime time.Time) (int, error) {
+	// 1. 查询指定创建时间内的待支付订单拓展
+	extensions, err := s.q.PayOrderExtension.WithContext(ctx).
+		Where(s.q.PayOrderExtension.Status.Eq(PayOrderStatusWaiting), s.q.PayOrderExtension.CreatedAt.Gte(minCreateTime)).
+		Find()
+	if err != nil {
+		return 0, err
+	}
+
+	// 2. 遍历执行同步
+	count := 0
+	for _, ext := range extensions {
+		if s.syncOrder(ctx, ext) {
+			count++
+		}
+	}
+	return count, nil
+}
+
 // syncOrder 同步单个支付拓展单
 // 对齐 Java: PayOrderServiceImpl.syncOrder(PayOrderExtensionDO)
 func (s *PayOrderService) syncOrder(ctx context.Context, orderExtension *pay.PayOrderExtension) bool {
 	// 1.1 查询支付订单信息
-	payClient := s.clientFac.GetPayClient(orderExtension.ChannelID)
-	if payClient == nil {
+	payClient, err := s.channelSvc.GetPayClient(ctx, orderExtension.ChannelID)
+	if err != nil {
+		s.logger.Error("[syncOrder][渠道编号找不到对应的支付客户端]", zap.Int64("channelId", orderExtension.ChannelID), zap.Error(err))
 		return false
 	}
 
@@ -261,15 +297,15 @@ func (s *PayOrderService) syncOrder(ctx context.Context, orderExtension *pay.Pay
 	}
 
 	// 1.2 回调支付结果
-	s.notifyOrder(ctx, orderExtension.ChannelID, respDTO)
+	s.NotifyOrder(ctx, orderExtension.ChannelID, respDTO)
 
 	// 2. 如果是已支付,则返回 true
 	return respDTO.Status == PayOrderStatusSuccess
 }
 
-// notifyOrder 通知并更新订单的支付结果
-// 对齐 Java: PayOrderServiceImpl.notifyOrder(PayChannelDO, PayOrderRespDTO)
-func (s *PayOrderService) notifyOrder(ctx context.Context, channelID int64, notify *client.OrderResp) error {
+// NotifyOrder 通知并更新订单的支付结果
+// 对齐 Java: PayOrderServiceImpl.notifyOrder(Long channelId, PayOrderRespDTO)
+func (s *PayOrderService) NotifyOrder(ctx context.Context, channelID int64, notify *client.OrderResp) error {
 	// 校验支付渠道是否有效
 	channel, err := s.channelSvc.GetChannel(ctx, channelID)
 	if err != nil {
@@ -443,26 +479,184 @@ func (s *PayOrderService) notifyOrderClosed(ctx context.Context, channel *pay.Pa
 	return nil
 }
 
-// GetOrderList 获得支付订单列表 (Export)
-func (s *PayOrderService) GetOrderList(ctx context.Context, req *req.PayOrderExportReq) ([]*pay.PayOrder, error) {
-	q := s.q.PayOrder.WithContext(ctx)
+// ExpireOrder 关闭已过期的待支付订单，返回关闭的数量
+// 对齐 Java: PayOrderServiceImpl.expireOrder
+func (s *PayOrderService) ExpireOrder(ctx context.Context) (int, error) {
+	// 1. 查询过期的待支付订单
+	orders, err := s.q.PayOrder.WithContext(ctx).
+		Where(s.q.PayOrder.Status.Eq(PayOrderStatusWaiting), s.q.PayOrder.ExpireTime.Lt(time.Now())).
+		Find()
+	if err != nil {
+		return 0, err
+	}
+
+	// 2. 遍历执行
+	count := 0
+	for _, order := range orders {
+		if s.expireOrder(ctx, order) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// expireOrder 关闭单个过期的支付订单，返回是否关闭成功
+func (s *PayOrderService) expireOrder(ctx context.Context, order *pay.PayOrder) bool {
+	// 1. 需要先处理关联的支付拓展单，避免错误的过期已支付 or 已退款的订单
+	extensions, err := s.q.PayOrderExtension.WithContext(ctx).
+		Where(s.q.PayOrderExtension.OrderID.Eq(order.ID)).
+		Find()
+	if err != nil {
+		s.logger.Error("[expireOrder][查询支付拓展单失败]", zap.Int64("orderId", order.ID), zap.Error(err))
+		return false
+	}
+	for _, ext := range extensions {
+		if ext.Status == PayOrderStatusClosed {
+			continue
+		}
+		// 情况一：校验数据库中的 orderExtension 是不是已支付
+		if ext.Status == PayOrderStatusSuccess {
+			s.logger.Error("[expireOrder][支付拓展单已支付，但是支付单未支付，需要人工处理]", zap.Int64("orderId", order.ID), zap.Int64("extensionId", ext.ID))
+			return false
+		}
+		// 情况二：调用三方接口，查询支付单状态（是不是已支付/已退款）
+		payClient, err := s.channelSvc.GetPayClient(ctx, ext.ChannelID)
+		if err != nil {
+			s.logger.Error("[expireOrder][渠道编号找不到对应的支付客户端]", zap.Int64("channelId", ext.ChannelID), zap.Error(err))
+			return false
+		}
+		respDTO, err := payClient.GetOrder(ctx, ext.No)
+		if err != nil {
+			s.logger.Error("[expireOrder][查询渠道支付单失败]", zap.Int64("extensionId", ext.ID), zap.Error(err))
+			return false
+		}
+		if respDTO.Status == PayOrderStatusRefund {
+			s.logger.Error("[expireOrder][渠道支付单已退款，但是支付单未支付，需要人工处理]", zap.Int64("orderId", order.ID), zap.Int64("extensionId", ext.ID))
+			return false
+		}
+		if respDTO.Status == PayOrderStatusSuccess {
+			// 渠道已支付，按回调逻辑更新为已支付，不再过期
+			if err := s.NotifyOrder(ctx, ext.ChannelID, respDTO); err != nil {
+				s.logger.Error("[expireOrder][处理渠道支付结果失败]", zap.Int64("extensionId", ext.ID), zap.Error(err))
+			}
+			return false
+		}
+		// 兜底逻辑：将支付拓展单更新为已关闭
+		notifyDataJSON, _ := json.Marshal(respDTO)
+		result, err := s.q.PayOrderExtension.WithContext(ctx).
+			Where(s.q.PayOrderExtension.ID.Eq(ext.ID), s.q.PayOrderExtension.Status.Eq(PayOrderStatusWaiting)).
+			Updates(map[string]interface{}{
+				"status":              PayOrderStatusClosed,
+				"channel_notify_data": string(notifyDataJSON),
+			})
+		if err != nil || result.RowsAffected == 0 {
+			s.logger.Error("[expireOrder][支付拓展单更新为已关闭失败]", zap.Int64("extensionId", ext.ID), zap.Error(err))
+			return false
+		}
+	}
+
+	// 2. 都没有上述情况，可以安心更新为已关闭
+	result, err := s.q.PayOrder.WithContext(ctx).
+		Where(s.q.PayOrder.ID.Eq(order.ID), s.q.PayOrder.Status.Eq(order.Status)).
+		Update(s.q.PayOrder.Status, PayOrderStatusClosed)
+	if err != nil || result.RowsAffected == 0 {
+		s.logger.Error("[expireOrder][支付单更新为已关闭失败]", zap.Int64("orderId", order.ID), zap.Error(err))
+		return false
+	}
+	return true
+}
+
+// ExportOrderExcel 导出支付订单，按编号分批查询并流式写入 w
+// 对应 Java: PayOrderController#exportOrderExcel
+func (s *PayOrderService) ExportOrderExcel(ctx context.Context, w io.Writer, req *req.PayOrderExportReq, dict excel.DictFormatter) error {
+	o := s.q.PayOrder
+	var conds []gen.Condition
 	if req.AppID > 0 {
-		q = q.Where(s.q.PayOrder.AppID.Eq(req.AppID))
+		conds = append(conds, o.AppID.Eq(req.AppID))
 	}
 	if req.ChannelCode != "" {
-		q = q.Where(s.q.PayOrder.ChannelCode.Eq(req.ChannelCode))
+		conds = append(conds, o.ChannelCode.Eq(req.ChannelCode))
 	}
 	if req.MerchantOrderId != "" {
-		q = q.Where(s.q.PayOrder.MerchantOrderId.Eq(req.MerchantOrderId))
+		conds = append(conds, o.MerchantOrderId.Eq(req.MerchantOrderId))
 	}
 	if req.Subject != "" {
-		q = q.Where(s.q.PayOrder.Subject.Like("%" + req.Subject + "%"))
+		conds = append(conds, o.Subject.Like("%"+req.Subject+"%"))
 	}
 	if req.No != "" {
-		q = q.Where(s.q.PayOrder.No.Eq(req.No))
+		conds = append(conds, o.No.Eq(req.No))
 	}
 	if req.Status != nil {
-		q = q.Where(s.q.PayOrder.Status.Eq(*req.Status))
+		conds = append(conds, o.Status.Eq(*req.Status))
 	}
-	return q.Order(s.q.PayOrder.ID.Desc()).Find()
+
+	appNames := make(map[int64]string)
+	return excel.Export(w, func(cursor int64, limit int) ([]*resp.PayOrderExcelVO, int64, error) {
+		list, err := o.WithContext(ctx).Where(conds...).Where(o.ID.Gt(cursor)).Order(o.ID).Limit(limit).Find()
+		if err != nil || len(list) == 0 {
+			return nil, cursor, err
+		}
+		data := make([]*resp.PayOrderExcelVO, 0, len(list))
+		for _, order := range list {
+			if _, ok := appNames[order.AppID]; !ok {
+				appNames[order.AppID] = ""
+				if app, err := s.appSvc.GetApp(ctx, order.AppID); err == nil {
+					appNames[order.AppID] = app.Name
+				}
+			}
+			data = append(data, &resp.PayOrderExcelVO{
+				ID:              order.ID,
+				CreateTime:      order.CreatedAt,
+				Price:           float64(order.Price) / 100,
+				RefundPrice:     float64(order.RefundPrice) / 100,
+				ChannelFeePrice: float64(order.ChannelFeePrice) / 100,
+				MerchantOrderId: order.MerchantOrderId,
+				No:              order.No,
+				ChannelOrderNo:  order.ChannelOrderNo,
+				Status:          order.Status,
+				ChannelCode:     order.ChannelCode,
+				ExpireTime:      order.ExpireTime,
+				SuccessTime:     order.SuccessTime,
+				AppName:         appNames[order.AppID],
+				Subject:         order.Subject,
+				Body:            order.Body,
+			})
+		}
+		return data, list[len(list)-1].ID, nil
+	}, excel.WithDictFormatter(dict))
+}
+
+// UpdateOrderRefundPrice 更新支付订单的退款金额
+// 对齐 Java: PayOrderServiceImpl.updateOrderRefundPrice
+func (s *PayOrderService) UpdateOrderRefundPrice(ctx context.Context, id int64, incrRefundPrice int) error {
+	return s.UpdateOrderRefundPriceTx(ctx, s.q, id, incrRefundPrice)
+}
+
+// UpdateOrderRefundPriceTx 在事务中更新支付订单的退款金额
+func (s *PayOrderService) UpdateOrderRefundPriceTx(ctx context.Context, tx *query.Query, id int64, incrRefundPrice int) error {
+	order, err := tx.PayOrder.WithContext(ctx).Where(tx.PayOrder.ID.Eq(id)).First()
+	if err != nil {
+		return core.NewBizError(1006004000, "支付订单不存在") // PAY_ORDER_NOT_FOUND
+	}
+	if order.Status != PayOrderStatusSuccess && order.Status != PayOrderStatusRefund {
+		return core.NewBizError(1006004005, "支付订单退款失败，原因：状态不是已支付或已退款") // PAY_ORDER_REFUND_FAIL_STATUS_ERROR
+	}
+	if order.RefundPrice+incrRefundPrice > order.Price {
+		return core.NewBizError(1006006000, "退款金额超过订单可退款金额") // REFUND_PRICE_EXCEED
+	}
+
+	// 使用乐观锁，基于当前状态更新
+	result, err := tx.PayOrder.WithContext(ctx).
+		Where(tx.PayOrder.ID.Eq(id), tx.PayOrder.Status.Eq(order.Status), tx.PayOrder.RefundPrice.Eq(order.RefundPrice)).
+		Updates(map[string]interface{}{
+			"refund_price": order.RefundPrice + incrRefundPrice,
+			"status":       PayOrderStatusRefund,
+		})
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected == 0 {
+		return core.NewBizError(1006004005, "支付订单退款失败，原因：状态不是已支付或已退款") // PAY_ORDER_REFUND_FAIL_STATUS_ERROR
+	}
+	return nil
 }
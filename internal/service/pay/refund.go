@@ -5,15 +5,197 @@ import (
 	"backend-go/internal/model/pay"
 	"backend-go/internal/pkg/core"
 	"backend-go/internal/repo/query"
+	"backend-go/internal/service/pay/client"
+	"backend-go/pkg/config"
 	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
 )
 
 type PayRefundService struct {
-	q *query.Query
+	q          *query.Query
+	appSvc     *PayAppService
+	orderSvc   *PayOrderService
+	channelSvc *PayChannelService
+	notifySvc  *PayNotifyService
+	logger     *zap.Logger
+}
+
+func NewPayRefundService(q *query.Query, appSvc *PayAppService, orderSvc *PayOrderService, channelSvc *PayChannelService, notifySvc *PayNotifyService, logger *zap.Logger) *PayRefundService {
+	return &PayRefundService{
+		q:          q,
+		appSvc:     appSvc,
+		orderSvc:   orderSvc,
+		channelSvc: channelSvc,
+		notifySvc:  notifySvc,
+		logger:     logger,
+	}
+}
+
+// CreateRefund 创建退款单
+// 对齐 Java: PayRefundServiceImpl.createPayRefund
+func (s *PayRefundService) CreateRefund(ctx context.Context, reqDTO *req.PayRefundCreateReq) (int64, error) {
+	// 1.1 校验 App
+	app, err := s.appSvc.ValidPayApp(ctx, reqDTO.AppID)
+	if err != nil {
+		return 0, err
+	}
+	// 1.2 校验支付订单
+	order, err := s.validatePayOrderCanRefund(ctx, app.ID, reqDTO)
+	if err != nil {
+		return 0, err
+	}
+	// 1.3 校验支付渠道是否有效
+	channel, err := s.channelSvc.ValidPayChannel(ctx, order.ChannelID)
+	if err != nil {
+		return 0, err
+	}
+	payClient, err := s.channelSvc.GetPayClient(ctx, channel.ID)
+	if err != nil {
+		return 0, err
+	}
+	// 1.4 校验退款订单是否已经存在
+	count, err := s.q.PayRefund.WithContext(ctx).
+		Where(s.q.PayRefund.AppID.Eq(app.ID), s.q.PayRefund.MerchantRefundId.Eq(reqDTO.MerchantRefundId)).
+		Count()
+	if err != nil {
+		return 0, err
+	}
+	if count > 0 {
+		return 0, core.NewBizError(1006006003, "已经存在退款单") // REFUND_EXISTS
+	}
+
+	// 2.1 插入退款单
+	refund := &pay.PayRefund{
+		No:               s.generateNo(),
+		AppID:            app.ID,
+		ChannelID:        channel.ID,
+		ChannelCode:      channel.Code,
+		OrderID:          order.ID,
+		OrderNo:          order.No,
+		UserID:           order.UserID,
+		UserType:         order.UserType,
+		MerchantOrderId:  reqDTO.MerchantOrderId,
+		MerchantRefundId: reqDTO.MerchantRefundId,
+		NotifyURL:        app.RefundNotifyURL,
+		Status:           PayRefundStatusWaiting,
+		PayPrice:         order.Price,
+		RefundPrice:      reqDTO.Price,
+		Reason:           reqDTO.Reason,
+		UserIP:           reqDTO.UserIP,
+		ChannelOrderNo:   order.ChannelOrderNo,
+	}
+	if err := s.q.PayRefund.WithContext(ctx).Create(refund); err != nil {
+		return 0, err
+	}
+
+	// 2.2 向渠道发起退款申请
+	refundResp, err := payClient.UnifiedRefund(ctx, &client.UnifiedRefundReq{
+		OutTradeNo:  order.No,
+		OutRefundNo: refund.No,
+		Reason:      reqDTO.Reason,
+		PayPrice:    order.Price,
+		RefundPrice: reqDTO.Price,
+		NotifyURL:   s.genChannelRefundNotifyUrl(channel),
+	})
+	if err != nil {
+		// 注意：这里仅打印日志，不返回错误。渠道可能已经受理退款，退款单保持待退款，由 SyncRefund 兜底同步
+		s.logger.Error("[CreateRefund][退款单发起渠道退款失败]", zap.Int64("refundId", refund.ID), zap.Error(err))
+		return refund.ID, nil
+	}
+
+	// 2.3 处理退款返回：同步返回成功或失败时直接按回调逻辑处理，WAITING 则等待渠道异步回调
+	if err := s.NotifyRefund(ctx, channel.ID, refundResp); err != nil {
+		s.logger.Error("[CreateRefund][退款单处理渠道结果失败]", zap.Int64("refundId", refund.ID), zap.Error(err))
+	}
+	return refund.ID, nil
+}
+
+// validatePayOrderCanRefund 校验支付订单是否可以退款
+func (s *PayRefundService) validatePayOrderCanRefund(ctx context.Context, appID int64, reqDTO *req.PayRefundCreateReq) (*pay.PayOrder, error) {
+	order, err := s.q.PayOrder.WithContext(ctx).
+		Where(s.q.PayOrder.AppID.Eq(appID), s.q.PayOrder.MerchantOrderId.Eq(reqDTO.MerchantOrderId)).
+		First()
+	if err != nil {
+		return nil, core.NewBizError(1006004000, "支付订单不存在") // PAY_ORDER_NOT_FOUND
+	}
+	// 校验状态，必须是已支付、或者已退款
+	if order.Status != PayOrderStatusSuccess && order.Status != PayOrderStatusRefund {
+		return nil, core.NewBizError(1006004005, "支付订单退款失败，原因：状态不是已支付或已退款") // PAY_ORDER_REFUND_FAIL_STATUS_ERROR
+	}
+	// 校验金额，累计退款金额不能超过支付金额
+	if reqDTO.Price+order.RefundPrice > order.Price {
+		return nil, core.NewBizError(1006006000, "退款金额超过订单可退款金额") // REFUND_PRICE_EXCEED
+	}
+	// 是否有退款中的退款单，避免并发退款导致累计金额超出
+	count, err := s.q.PayRefund.WithContext(ctx).
+		Where(s.q.PayRefund.AppID.Eq(appID), s.q.PayRefund.OrderID.Eq(order.ID), s.q.PayRefund.Status.Eq(PayRefundStatusWaiting)).
+		Count()
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, core.NewBizError(1006006002, "已经有退款在处理中") // REFUND_HAS_REFUNDING
+	}
+	return order, nil
+}
+
+// genChannelRefundNotifyUrl 根据支付渠道生成退款回调地址
+// 对齐 Java: payProperties.getRefundNotifyUrl() + "/" + channel.getId()
+func (s *PayRefundService) genChannelRefundNotifyUrl(channel *pay.PayChannel) string {
+	return fmt.Sprintf("%s/%d", config.C.Pay.RefundNotifyURL, channel.ID)
+}
+
+func (s *PayRefundService) generateNo() string {
+	return "R" + time.Now().Format("20060102150405") + core.GenerateRandomString(6)
+}
+
+// SyncRefund 同步渠道退款的退款状态，返回同步到终态的退款单数量
+// 对齐 Java: PayRefundServiceImpl.syncRefund
+func (s *PayRefundService) SyncRefund(ctx context.Context) (int, error) {
+	// 1. 查询待退款的退款单
+	refunds, err := s.q.PayRefund.WithContext(ctx).
+		Where(s.q.PayRefund.Status.Eq(PayRefundStatusWaiting)).
+		Find()
+	if err != nil {
+		return 0, err
+	}
+
+	// 2. 遍历执行同步
+	count := 0
+	for _, refund := range refunds {
+		if s.syncRefund(ctx, refund) {
+			count++
+		}
+	}
+	return count, nil
 }
 
-func NewPayRefundService(q *query.Query) *PayRefundService {
-	return &PayRefundService{q: q}
+// syncRefund 同步单个退款单，返回是否同步到终态
+func (s *PayRefundService) syncRefund(ctx context.Context, refund *pay.PayRefund) bool {
+	// 1.1 查询退款订单信息
+	payClient, err := s.channelSvc.GetPayClient(ctx, refund.ChannelID)
+	if err != nil {
+		s.logger.Error("[syncRefund][渠道编号找不到对应的支付客户端]", zap.Int64("refundId", refund.ID), zap.Int64("channelId", refund.ChannelID), zap.Error(err))
+		return false
+	}
+	respDTO, err := payClient.GetRefund(ctx, refund.OrderNo, refund.No)
+	if err != nil {
+		s.logger.Error("[syncRefund][查询渠道退款失败]", zap.Int64("refundId", refund.ID), zap.Error(err))
+		return false
+	}
+
+	// 1.2 回调退款结果
+	if err := s.NotifyRefund(ctx, refund.ChannelID, respDTO); err != nil {
+		s.logger.Error("[syncRefund][处理退款结果失败]", zap.Int64("refundId", refund.ID), zap.Error(err))
+		return false
+	}
+
+	// 2. 如果是退款成功或失败，则返回 true
+	return respDTO.Status == PayRefundStatusSuccess || respDTO.Status == PayRefundStatusFailure
 }
 
 // GetRefund 获得退款订单
@@ -86,3 +268,111 @@ func (s *PayRefundService) GetRefundList(ctx context.Context, req *req.PayRefund
 	}
 	return q.Order(s.q.PayRefund.ID.Desc()).Find()
 }
+
+// NotifyRefund 通知并更新退款单的退款结果
+// 对齐 Java: PayRefundServiceImpl.notifyRefund(Long channelId, PayRefundRespDTO)
+func (s *PayRefundService) NotifyRefund(ctx context.Context, channelID int64, notify *client.RefundResp) error {
+	// 校验支付渠道是否有效
+	channel, err := s.channelSvc.ValidPayChannel(ctx, channelID)
+	if err != nil {
+		return err
+	}
+
+	// 情况一:退款成功
+	if notify.Status == PayRefundStatusSuccess {
+		return s.notifyRefundSuccess(ctx, channel.AppID, notify)
+	}
+	// 情况二:退款失败
+	if notify.Status == PayRefundStatusFailure {
+		return s.notifyRefundFailure(ctx, channel.AppID, notify)
+	}
+	// 情况三:WAITING 无需处理
+	return nil
+}
+
+// notifyRefundSuccess 处理退款成功的回调
+func (s *PayRefundService) notifyRefundSuccess(ctx context.Context, appID int64, notify *client.RefundResp) error {
+	// 1.1 查询 PayRefund
+	refund, err := s.q.PayRefund.WithContext(ctx).
+		Where(s.q.PayRefund.AppID.Eq(appID), s.q.PayRefund.No.Eq(notify.OutRefundNo)).
+		First()
+	if err != nil {
+		return core.NewBizError(1006006004, "支付退款单不存在") // REFUND_NOT_FOUND
+	}
+	// 如果已经是成功,直接返回,不用重复更新
+	if refund.Status == PayRefundStatusSuccess {
+		return nil
+	}
+	if refund.Status != PayRefundStatusWaiting {
+		return core.NewBizError(1006006005, "支付退款单不处于待退款") // REFUND_STATUS_IS_NOT_WAITING
+	}
+
+	// 1.2 更新 PayRefund (使用乐观锁)
+	successTime := notify.SuccessTime
+	if successTime.IsZero() {
+		successTime = time.Now()
+	}
+	notifyDataJSON, _ := json.Marshal(notify)
+	return s.q.Transaction(func(tx *query.Query) error {
+		result, err := tx.PayRefund.WithContext(ctx).
+			Where(tx.PayRefund.ID.Eq(refund.ID), tx.PayRefund.Status.Eq(PayRefundStatusWaiting)).
+			Updates(map[string]interface{}{
+				"status":              PayRefundStatusSuccess,
+				"success_time":        &successTime,
+				"channel_refund_no":   notify.ChannelRefundNo,
+				"channel_notify_data": string(notifyDataJSON),
+			})
+		if err != nil {
+			return err
+		}
+		if result.RowsAffected == 0 {
+			return core.NewBizError(1006006005, "支付退款单不处于待退款") // REFUND_STATUS_IS_NOT_WAITING
+		}
+
+		// 2. 更新订单的退款金额
+		if err := s.orderSvc.UpdateOrderRefundPriceTx(ctx, tx, refund.OrderID, refund.RefundPrice); err != nil {
+			return err
+		}
+
+		// 3. 插入退款通知记录
+		return s.notifySvc.CreatePayNotifyTaskTx(ctx, tx, PayNotifyTypeRefund, refund.ID)
+	})
+}
+
+// notifyRefundFailure 处理退款失败的回调
+func (s *PayRefundService) notifyRefundFailure(ctx context.Context, appID int64, notify *client.RefundResp) error {
+	// 1.1 查询 PayRefund
+	refund, err := s.q.PayRefund.WithContext(ctx).
+		Where(s.q.PayRefund.AppID.Eq(appID), s.q.PayRefund.No.Eq(notify.OutRefundNo)).
+		First()
+	if err != nil {
+		return core.NewBizError(1006006004, "支付退款单不存在") // REFUND_NOT_FOUND
+	}
+	// 如果已经是失败,直接返回,不用重复更新
+	if refund.Status == PayRefundStatusFailure {
+		return nil
+	}
+	if refund.Status != PayRefundStatusWaiting {
+		return core.NewBizError(1006006005, "支付退款单不处于待退款") // REFUND_STATUS_IS_NOT_WAITING
+	}
+
+	// 1.2 更新 PayRefund (使用乐观锁)
+	notifyDataJSON, _ := json.Marshal(notify)
+	result, err := s.q.PayRefund.WithContext(ctx).
+		Where(s.q.PayRefund.ID.Eq(refund.ID), s.q.PayRefund.Status.Eq(PayRefundStatusWaiting)).
+		Updates(map[string]interface{}{
+			"status":              PayRefundStatusFailure,
+			"channel_notify_data": string(notifyDataJSON),
+			"channel_error_code":  notify.ChannelErrorCode,
+			"channel_error_msg":   notify.ChannelErrorMsg,
+		})
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected == 0 {
+		return core.NewBizError(1006006005, "支付退款单不处于待退款") // REFUND_STATUS_IS_NOT_WAITING
+	}
+
+	// 2. 插入退款通知记录
+	return s.notifySvc.CreatePayNotifyTask(ctx, PayNotifyTypeRefund, refund.ID)
+}
@@ -56,8 +56,8 @@ func (s *PayTransferService) CreateTransfer(ctx context.Context, req *PayTransfe
 	}
 
 	// 3. 获得支付客户端
-	payClient := s.clientFactory.GetPayClient(channel.ID)
-	if payClient == nil {
+	payClient, err := s.channelSvc.GetPayClient(ctx, channel.ID)
+	if err != nil || payClient == nil {
 		return nil, core.NewBizError(1006000003, "支付渠道客户端不存在") // PAY_CHANNEL_CLIENT_NOT_FOUND
 	}
 
@@ -0,0 +1,256 @@
+package pay
+
+import (
+	"backend-go/internal/api/req"
+	"backend-go/internal/model/pay"
+	"backend-go/internal/pkg/core"
+	"backend-go/internal/repo/query"
+	"backend-go/internal/service/pay/client"
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"gorm.io/gen"
+	"gorm.io/gorm"
+)
+
+type PayWalletService struct {
+	q *query.Query
+}
+
+func NewPayWalletService(q *query.Query) *PayWalletService {
+	s := &PayWalletService{q: q}
+	// 钱包支付客户端依赖本服务，无法在 client 包的 init 中注册，因此在创建服务时注册
+	client.RegisterCreator(PayChannelCodeWallet, func(channelID int64, channelCode string, config string) (client.PayClient, error) {
+		return NewWalletPayClient(channelID, channelCode, config, s), nil
+	})
+	return s
+}
+
+// GetOrCreateWallet 获得用户钱包，不存在时自动创建
+func (s *PayWalletService) GetOrCreateWallet(ctx context.Context, userId int64, userType int) (*pay.PayWallet, error) {
+	w := s.q.PayWallet
+	wallet, err := w.WithContext(ctx).Where(w.UserID.Eq(userId), w.UserType.Eq(userType)).First()
+	if err == nil {
+		return wallet, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	wallet = &pay.PayWallet{
+		UserID:   userId,
+		UserType: userType,
+	}
+	if err := w.WithContext(ctx).Create(wallet); err != nil {
+		return nil, err
+	}
+	return wallet, nil
+}
+
+// GetWallet 获得钱包
+func (s *PayWalletService) GetWallet(ctx context.Context, id int64) (*pay.PayWallet, error) {
+	return s.q.PayWallet.WithContext(ctx).Where(s.q.PayWallet.ID.Eq(id)).First()
+}
+
+// GetWalletPage 获得钱包分页
+func (s *PayWalletService) GetWalletPage(ctx context.Context, r *req.PayWalletPageReq) (*core.PageResult[*pay.PayWallet], error) {
+	w := s.q.PayWallet
+	q := w.WithContext(ctx)
+	if r.UserID > 0 {
+		q = q.Where(w.UserID.Eq(r.UserID))
+	}
+	if r.UserType != nil {
+		q = q.Where(w.UserType.Eq(*r.UserType))
+	}
+
+	total, err := q.Count()
+	if err != nil {
+		return nil, err
+	}
+	list, err := q.Limit(r.GetLimit()).Offset(r.GetOffset()).Order(w.ID.Desc()).Find()
+	if err != nil {
+		return nil, err
+	}
+	return &core.PageResult[*pay.PayWallet]{
+		List:  list,
+		Total: total,
+	}, nil
+}
+
+// ReduceWalletBalance 扣减钱包余额
+func (s *PayWalletService) ReduceWalletBalance(ctx context.Context, walletId int64, bizId string, bizType int, price int) (*pay.PayWalletTransaction, error) {
+	return s.updateWalletBalance(ctx, walletId, bizId, bizType, -price)
+}
+
+// AddWalletBalance 增加钱包余额，price 为负数时表示扣减 (仅用于后台调整余额)
+func (s *PayWalletService) AddWalletBalance(ctx context.Context, walletId int64, bizId string, bizType int, price int) (*pay.PayWalletTransaction, error) {
+	return s.updateWalletBalance(ctx, walletId, bizId, bizType, price)
+}
+
+// UpdateWalletBalance 后台调整用户钱包余额
+func (s *PayWalletService) UpdateWalletBalance(ctx context.Context, userId int64, userType int, price int) error {
+	wallet, err := s.GetOrCreateWallet(ctx, userId, userType)
+	if err != nil {
+		return err
+	}
+	_, err = s.AddWalletBalance(ctx, wallet.ID, strconv.FormatInt(userId, 10), PayWalletBizTypeUpdateBalance, price)
+	return err
+}
+
+// updateWalletBalance 变更钱包余额并记录流水
+func (s *PayWalletService) updateWalletBalance(ctx context.Context, walletId int64, bizId string, bizType int, price int) (*pay.PayWalletTransaction, error) {
+	var transaction *pay.PayWalletTransaction
+	err := s.q.Transaction(func(tx *query.Query) error {
+		var err error
+		transaction, err = s.updateWalletBalanceTx(ctx, tx, walletId, bizId, bizType, price)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+// updateWalletBalanceTx 在事务中变更钱包余额并记录流水
+// 使用 balance = balance + ? 的条件更新，并发变更时由数据库行锁保证余额正确，无需重试
+func (s *PayWalletService) updateWalletBalanceTx(ctx context.Context, tx *query.Query, walletId int64, bizId string, bizType int, price int) (*pay.PayWalletTransaction, error) {
+	w := tx.PayWallet
+	if _, err := w.WithContext(ctx).Where(w.ID.Eq(walletId)).First(); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, core.NewBizError(1006007000, "用户钱包不存在") // WALLET_NOT_FOUND
+		}
+		return nil, err
+	}
+
+	// 1. 更新余额，同时累计支出 / 充值金额；扣减时要求余额充足
+	updates := map[string]interface{}{
+		"balance": gorm.Expr("balance + ?", price),
+	}
+	switch bizType {
+	case PayWalletBizTypePayment, PayWalletBizTypePaymentRefund:
+		updates["total_expense"] = gorm.Expr("total_expense - ?", price)
+	case PayWalletBizTypeRecharge, PayWalletBizTypeRechargeRefund:
+		updates["total_recharge"] = gorm.Expr("total_recharge + ?", price)
+	}
+	conds := []gen.Condition{w.ID.Eq(walletId)}
+	if price < 0 {
+		conds = append(conds, w.Balance.Gte(-price))
+	}
+	result, err := w.WithContext(ctx).Where(conds...).Updates(updates)
+	if err != nil {
+		return nil, err
+	}
+	if result.RowsAffected == 0 {
+		return nil, core.NewBizError(1006007001, "钱包余额不足") // WALLET_BALANCE_NOT_ENOUGH
+	}
+	// 更新后读取最新余额，用于记录流水
+	wallet, err := w.WithContext(ctx).Where(w.ID.Eq(walletId)).First()
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 记录钱包流水
+	transaction := &pay.PayWalletTransaction{
+		No:       s.generateNo(),
+		WalletID: walletId,
+		BizType:  bizType,
+		BizID:    bizId,
+		Title:    PayWalletBizTypeTitles[bizType],
+		Price:    price,
+		Balance:  wallet.Balance,
+	}
+	if err := tx.PayWalletTransaction.WithContext(ctx).Create(transaction); err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+// orderPay 钱包支付：扣减用户钱包余额
+// 对齐 Java: PayWalletServiceImpl.orderPay
+func (s *PayWalletService) orderPay(ctx context.Context, userId int64, userType int, outTradeNo string, price int) (*pay.PayWalletTransaction, error) {
+	orderExtension, err := s.q.PayOrderExtension.WithContext(ctx).Where(s.q.PayOrderExtension.No.Eq(outTradeNo)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, core.NewBizError(1006004001, "支付交易拓展单不存在") // PAY_ORDER_EXTENSION_NOT_FOUND
+		}
+		return nil, err
+	}
+	wallet, err := s.GetOrCreateWallet(ctx, userId, userType)
+	if err != nil {
+		return nil, err
+	}
+	return s.ReduceWalletBalance(ctx, wallet.ID, strconv.FormatInt(orderExtension.OrderID, 10), PayWalletBizTypePayment, price)
+}
+
+// orderRefund 钱包支付的退款：退回到原支付钱包
+// 对齐 Java: PayWalletServiceImpl.orderRefund
+func (s *PayWalletService) orderRefund(ctx context.Context, outRefundNo string, refundPrice int) (*pay.PayWalletTransaction, error) {
+	refund, err := s.q.PayRefund.WithContext(ctx).Where(s.q.PayRefund.No.Eq(outRefundNo)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, core.NewBizError(1006006004, "支付退款单不存在") // REFUND_NOT_FOUND
+		}
+		return nil, err
+	}
+	refundBizId := strconv.FormatInt(refund.ID, 10)
+	if exists, _ := s.getWalletTransaction(ctx, refundBizId, PayWalletBizTypePaymentRefund); exists != nil {
+		return nil, core.NewBizError(1006007003, "已经存在钱包退款") // WALLET_REFUND_EXIST
+	}
+
+	// 找到原支付流水，确定退款的钱包
+	payTransaction, err := s.getWalletTransaction(ctx, strconv.FormatInt(refund.OrderID, 10), PayWalletBizTypePayment)
+	if err != nil {
+		return nil, err
+	}
+	return s.AddWalletBalance(ctx, payTransaction.WalletID, refundBizId, PayWalletBizTypePaymentRefund, refundPrice)
+}
+
+// getWalletTransaction 根据业务获得钱包流水
+func (s *PayWalletService) getWalletTransaction(ctx context.Context, bizId string, bizType int) (*pay.PayWalletTransaction, error) {
+	t := s.q.PayWalletTransaction
+	transaction, err := t.WithContext(ctx).Where(t.BizID.Eq(bizId), t.BizType.Eq(bizType)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, core.NewBizError(1006007002, "未找到对应的钱包交易") // WALLET_TRANSACTION_NOT_FOUND
+		}
+		return nil, err
+	}
+	return transaction, nil
+}
+
+// GetWalletTransactionPage 获得钱包流水分页
+// walletId 为 0 时不按钱包过滤；txType 为 1 时仅查收入，为 2 时仅查支出
+func (s *PayWalletService) GetWalletTransactionPage(ctx context.Context, walletId int64, txType *int, pageParam *core.PageParam) (*core.PageResult[*pay.PayWalletTransaction], error) {
+	t := s.q.PayWalletTransaction
+	q := t.WithContext(ctx)
+	if walletId > 0 {
+		q = q.Where(t.WalletID.Eq(walletId))
+	}
+	if txType != nil {
+		switch *txType {
+		case 1:
+			q = q.Where(t.Price.Gt(0))
+		case 2:
+			q = q.Where(t.Price.Lt(0))
+		}
+	}
+
+	total, err := q.Count()
+	if err != nil {
+		return nil, err
+	}
+	list, err := q.Limit(pageParam.GetLimit()).Offset(pageParam.GetOffset()).Order(t.ID.Desc()).Find()
+	if err != nil {
+		return nil, err
+	}
+	return &core.PageResult[*pay.PayWalletTransaction]{
+		List:  list,
+		Total: total,
+	}, nil
+}
+
+func (s *PayWalletService) generateNo() string {
+	return "W" + time.Now().Format("20060102150405") + core.GenerateRandomString(6)
+}
@@ -0,0 +1,149 @@
+package pay
+
+import (
+	"backend-go/internal/pkg/core"
+	"backend-go/internal/service/pay/client"
+	"context"
+	"errors"
+	"strconv"
+)
+
+// 钱包支付需要的渠道额外参数，由提交支付订单时根据登录用户填充
+const (
+	WalletPayChannelExtraUserID   = "user_id"
+	WalletPayChannelExtraUserType = "user_type"
+)
+
+// WalletPayClient 钱包支付客户端
+// 对齐 Java: WalletPayClient，支付、退款、转账均同步完成，没有回调
+type WalletPayClient struct {
+	*client.BaseClient
+	walletSvc *PayWalletService
+}
+
+func NewWalletPayClient(channelID int64, channelCode string, config string, walletSvc *PayWalletService) *WalletPayClient {
+	return &WalletPayClient{
+		BaseClient: client.NewBaseClient(channelID, channelCode, config),
+		walletSvc:  walletSvc,
+	}
+}
+
+func (c *WalletPayClient) Init() error {
+	return nil
+}
+
+// UnifiedOrder 扣减钱包余额完成支付
+func (c *WalletPayClient) UnifiedOrder(ctx context.Context, req *client.UnifiedOrderReq) (*client.OrderResp, error) {
+	userId, err := strconv.ParseInt(req.ChannelExtras[WalletPayChannelExtraUserID], 10, 64)
+	if err != nil || userId <= 0 {
+		return nil, core.NewBizError(1006007005, "钱包支付缺少用户信息") // WALLET_PAY_USER_MISSING
+	}
+	userType, _ := strconv.Atoi(req.ChannelExtras[WalletPayChannelExtraUserType])
+
+	transaction, err := c.walletSvc.orderPay(ctx, userId, userType, req.OutTradeNo, req.Price)
+	if err != nil {
+		return nil, err
+	}
+	return &client.OrderResp{
+		Status:         PayOrderStatusSuccess,
+		OutTradeNo:     req.OutTradeNo,
+		ChannelOrderNo: transaction.No,
+		SuccessTime:    transaction.CreatedAt,
+		RawData:        transaction,
+	}, nil
+}
+
+// GetOrder 根据支付流水判断钱包支付结果
+func (c *WalletPayClient) GetOrder(ctx context.Context, outTradeNo string) (*client.OrderResp, error) {
+	q := c.walletSvc.q
+	orderExtension, err := q.PayOrderExtension.WithContext(ctx).Where(q.PayOrderExtension.No.Eq(outTradeNo)).First()
+	if err != nil {
+		// 拓展单不存在，说明未发起过支付，按关闭处理
+		return &client.OrderResp{Status: PayOrderStatusClosed, OutTradeNo: outTradeNo}, nil
+	}
+	transaction, err := c.walletSvc.getWalletTransaction(ctx, strconv.FormatInt(orderExtension.OrderID, 10), PayWalletBizTypePayment)
+	if err != nil {
+		return &client.OrderResp{Status: PayOrderStatusClosed, OutTradeNo: outTradeNo}, nil
+	}
+	return &client.OrderResp{
+		Status:         PayOrderStatusSuccess,
+		OutTradeNo:     outTradeNo,
+		ChannelOrderNo: transaction.No,
+		SuccessTime:    transaction.CreatedAt,
+		RawData:        transaction,
+	}, nil
+}
+
+// UnifiedRefund 退款到原支付钱包
+func (c *WalletPayClient) UnifiedRefund(ctx context.Context, req *client.UnifiedRefundReq) (*client.RefundResp, error) {
+	transaction, err := c.walletSvc.orderRefund(ctx, req.OutRefundNo, req.RefundPrice)
+	if err != nil {
+		var bizErr *core.BizError
+		if errors.As(err, &bizErr) {
+			return &client.RefundResp{
+				Status:           PayRefundStatusFailure,
+				OutTradeNo:       req.OutTradeNo,
+				OutRefundNo:      req.OutRefundNo,
+				ChannelErrorCode: strconv.Itoa(bizErr.Code),
+				ChannelErrorMsg:  bizErr.Msg,
+			}, nil
+		}
+		return nil, err
+	}
+	return &client.RefundResp{
+		Status:          PayRefundStatusSuccess,
+		OutTradeNo:      req.OutTradeNo,
+		OutRefundNo:     req.OutRefundNo,
+		ChannelRefundNo: transaction.No,
+		SuccessTime:     transaction.CreatedAt,
+		RawData:         transaction,
+	}, nil
+}
+
+// GetRefund 根据退款流水判断钱包退款结果
+func (c *WalletPayClient) GetRefund(ctx context.Context, outTradeNo, outRefundNo string) (*client.RefundResp, error) {
+	q := c.walletSvc.q
+	refund, err := q.PayRefund.WithContext(ctx).Where(q.PayRefund.No.Eq(outRefundNo)).First()
+	if err != nil {
+		return &client.RefundResp{Status: PayRefundStatusFailure, OutTradeNo: outTradeNo, OutRefundNo: outRefundNo}, nil
+	}
+	transaction, err := c.walletSvc.getWalletTransaction(ctx, strconv.FormatInt(refund.ID, 10), PayWalletBizTypePaymentRefund)
+	if err != nil {
+		return &client.RefundResp{Status: PayRefundStatusFailure, OutTradeNo: outTradeNo, OutRefundNo: outRefundNo}, nil
+	}
+	return &client.RefundResp{
+		Status:          PayRefundStatusSuccess,
+		OutTradeNo:      outTradeNo,
+		OutRefundNo:     outRefundNo,
+		ChannelRefundNo: transaction.No,
+		SuccessTime:     transaction.CreatedAt,
+		RawData:         transaction,
+	}, nil
+}
+
+func (c *WalletPayClient) ParseOrderNotify(req *client.NotifyData) (*client.OrderResp, error) {
+	return nil, errors.New("钱包支付无支付回调")
+}
+
+func (c *WalletPayClient) ParseRefundNotify(req *client.NotifyData) (*client.RefundResp, error) {
+	return nil, errors.New("钱包支付无退款回调")
+}
+
+// UnifiedTransfer 转账到钱包，UserAccount 为收款钱包编号
+func (c *WalletPayClient) UnifiedTransfer(ctx context.Context, req *client.UnifiedTransferReq) (*client.TransferResp, error) {
+	walletId, err := strconv.ParseInt(req.UserAccount, 10, 64)
+	if err != nil || walletId <= 0 {
+		return nil, core.NewBizError(1006007000, "用户钱包不存在") // WALLET_NOT_FOUND
+	}
+	transaction, err := c.walletSvc.AddWalletBalance(ctx, walletId, req.OutTradeNo, PayWalletBizTypeTransfer, req.Price)
+	if err != nil {
+		return nil, err
+	}
+	return &client.TransferResp{
+		Status:            PayTransferStatusSuccess,
+		OutTradeNo:        req.OutTradeNo,
+		ChannelTransferNo: transaction.No,
+		SuccessTime:       transaction.CreatedAt,
+		RawData:           transaction,
+	}, nil
+}
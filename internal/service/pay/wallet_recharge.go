@@ -0,0 +1,171 @@
+package pay
+
+import (
+	"backend-go/internal/api/req"
+	"backend-go/internal/model/pay"
+	"backend-go/internal/pkg/core"
+	"backend-go/internal/repo/query"
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PayWalletRechargeService struct {
+	q          *query.Query
+	walletSvc  *PayWalletService
+	packageSvc *PayWalletRechargePackageService
+	orderSvc   *PayOrderService
+	appSvc     *PayAppService
+}
+
+func NewPayWalletRechargeService(q *query.Query, walletSvc *PayWalletService, packageSvc *PayWalletRechargePackageService, orderSvc *PayOrderService, appSvc *PayAppService) *PayWalletRechargeService {
+	return &PayWalletRechargeService{
+		q:          q,
+		walletSvc:  walletSvc,
+		packageSvc: packageSvc,
+		orderSvc:   orderSvc,
+		appSvc:     appSvc,
+	}
+}
+
+// CreateWalletRecharge 创建钱包充值记录，并发起支付单
+func (s *PayWalletRechargeService) CreateWalletRecharge(ctx context.Context, userId int64, userType int, userIP string, r *req.AppPayWalletRechargeCreateReq) (*pay.PayWalletRecharge, error) {
+	// 1.1 计算充值金额：套餐优先，否则按自定义金额
+	payPrice, bonusPrice := r.PayPrice, 0
+	if r.PackageID > 0 {
+		pkg, err := s.packageSvc.ValidWalletRechargePackage(ctx, r.PackageID)
+		if err != nil {
+			return nil, err
+		}
+		payPrice, bonusPrice = pkg.PayPrice, pkg.BonusPrice
+	}
+	if payPrice <= 0 {
+		return nil, core.NewBizError(1006008005, "充值金额和充值套餐不能同时为空") // WALLET_RECHARGE_PRICE_AND_PACKAGE_EMPTY
+	}
+	// 1.2 校验充值使用的支付应用
+	app, err := s.appSvc.GetAppByAppKey(ctx, PayWalletRechargeAppKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 创建充值记录
+	wallet, err := s.walletSvc.GetOrCreateWallet(ctx, userId, userType)
+	if err != nil {
+		return nil, err
+	}
+	recharge := &pay.PayWalletRecharge{
+		WalletID:   wallet.ID,
+		TotalPrice: payPrice + bonusPrice,
+		PayPrice:   payPrice,
+		BonusPrice: bonusPrice,
+		PackageID:  r.PackageID,
+	}
+	if err := s.q.PayWalletRecharge.WithContext(ctx).Create(recharge); err != nil {
+		return nil, err
+	}
+
+	// 3. 创建支付单，支付成功后由支付通知回调 UpdateWalletRechargerPaid
+	payOrderId, err := s.orderSvc.CreateOrder(ctx, &req.PayOrderCreateReq{
+		AppID:           app.ID,
+		UserIP:          userIP,
+		MerchantOrderId: strconv.FormatInt(recharge.ID, 10),
+		Subject:         "钱包余额充值",
+		Price:           payPrice,
+	})
+	if err != nil {
+		return nil, err
+	}
+	rc := s.q.PayWalletRecharge
+	if _, err := rc.WithContext(ctx).Where(rc.ID.Eq(recharge.ID)).Update(rc.PayOrderID, payOrderId); err != nil {
+		return nil, err
+	}
+	recharge.PayOrderID = payOrderId
+	return recharge, nil
+}
+
+// UpdateWalletRechargerPaid 更新钱包充值为已支付，并增加钱包余额
+// 对齐 Java: PayWalletRechargeServiceImpl.updateWalletRechargerPaid
+func (s *PayWalletRechargeService) UpdateWalletRechargerPaid(ctx context.Context, id int64, payOrderId int64) error {
+	// 1.1 校验充值记录
+	rc := s.q.PayWalletRecharge
+	recharge, err := rc.WithContext(ctx).Where(rc.ID.Eq(id)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return core.NewBizError(1006008000, "钱包充值记录不存在") // WALLET_RECHARGE_NOT_FOUND
+		}
+		return err
+	}
+	if recharge.PayStatus {
+		// 重复回调，幂等处理
+		if recharge.PayOrderID == payOrderId {
+			return nil
+		}
+		return core.NewBizError(1006008001, "钱包充值更新支付状态失败，钱包充值记录不是【未支付】状态") // WALLET_RECHARGE_UPDATE_PAID_STATUS_NOT_UNPAID
+	}
+	// 1.2 校验支付单
+	payOrder, err := s.validatePayOrderPaid(ctx, recharge, payOrderId)
+	if err != nil {
+		return err
+	}
+
+	// 2. 更新充值记录为已支付，并增加钱包余额 (包含赠送金额)，两者在同一事务中完成
+	now := time.Now()
+	return s.q.Transaction(func(tx *query.Query) error {
+		rc := tx.PayWalletRecharge
+		result, err := rc.WithContext(ctx).Where(rc.ID.Eq(id), rc.PayStatus.Is(false)).Updates(map[string]interface{}{
+			"pay_status":       true,
+			"pay_time":         &now,
+			"pay_channel_code": payOrder.ChannelCode,
+		})
+		if err != nil {
+			return err
+		}
+		if result.RowsAffected == 0 {
+			return core.NewBizError(1006008001, "钱包充值更新支付状态失败，钱包充值记录不是【未支付】状态") // WALLET_RECHARGE_UPDATE_PAID_STATUS_NOT_UNPAID
+		}
+		_, err = s.walletSvc.updateWalletBalanceTx(ctx, tx, recharge.WalletID, strconv.FormatInt(id, 10), PayWalletBizTypeRecharge, recharge.TotalPrice)
+		return err
+	})
+}
+
+func (s *PayWalletRechargeService) validatePayOrderPaid(ctx context.Context, recharge *pay.PayWalletRecharge, payOrderId int64) (*pay.PayOrder, error) {
+	if recharge.PayOrderID != payOrderId {
+		return nil, core.NewBizError(1006008002, "钱包充值更新支付状态失败，支付单编号不匹配") // WALLET_RECHARGE_UPDATE_PAID_PAY_ORDER_ID_ERROR
+	}
+	payOrder, err := s.orderSvc.GetOrder(ctx, payOrderId)
+	if err != nil {
+		return nil, core.NewBizError(1006004000, "支付订单不存在") // PAY_ORDER_NOT_FOUND
+	}
+	if payOrder.Status != PayOrderStatusSuccess {
+		return nil, core.NewBizError(1006008003, "钱包充值更新支付状态失败，支付单状态不是【支付成功】状态") // WALLET_RECHARGE_UPDATE_PAID_PAY_ORDER_STATUS_NOT_SUCCESS
+	}
+	if payOrder.Price != recharge.PayPrice {
+		return nil, core.NewBizError(1006008004, "钱包充值更新支付状态失败，支付单金额不匹配") // WALLET_RECHARGE_UPDATE_PAID_PAY_PRICE_NOT_MATCH
+	}
+	if payOrder.MerchantOrderId != strconv.FormatInt(recharge.ID, 10) {
+		return nil, core.NewBizError(1006008002, "钱包充值更新支付状态失败，支付单编号不匹配") // WALLET_RECHARGE_UPDATE_PAID_PAY_ORDER_ID_ERROR
+	}
+	return payOrder, nil
+}
+
+// GetWalletRechargePage 获得钱包已支付的充值记录分页
+func (s *PayWalletRechargeService) GetWalletRechargePage(ctx context.Context, walletId int64, pageParam *core.PageParam) (*core.PageResult[*pay.PayWalletRecharge], error) {
+	rc := s.q.PayWalletRecharge
+	q := rc.WithContext(ctx).Where(rc.WalletID.Eq(walletId), rc.PayStatus.Is(true))
+
+	total, err := q.Count()
+	if err != nil {
+		return nil, err
+	}
+	list, err := q.Limit(pageParam.GetLimit()).Offset(pageParam.GetOffset()).Order(rc.ID.Desc()).Find()
+	if err != nil {
+		return nil, err
+	}
+	return &core.PageResult[*pay.PayWalletRecharge]{
+		List:  list,
+		Total: total,
+	}, nil
+}
@@ -0,0 +1,134 @@
+package pay
+
+import (
+	"backend-go/internal/api/req"
+	"backend-go/internal/model/pay"
+	"backend-go/internal/pkg/core"
+	"backend-go/internal/repo/query"
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type PayWalletRechargePackageService struct {
+	q *query.Query
+}
+
+func NewPayWalletRechargePackageService(q *query.Query) *PayWalletRechargePackageService {
+	return &PayWalletRechargePackageService{q: q}
+}
+
+// CreateWalletRechargePackage 创建充值套餐
+func (s *PayWalletRechargePackageService) CreateWalletRechargePackage(ctx context.Context, r *req.PayWalletRechargePackageCreateReq) (int64, error) {
+	if err := s.validateNameUnique(ctx, 0, r.Name); err != nil {
+		return 0, err
+	}
+	pkg := &pay.PayWalletRechargePackage{
+		Name:       r.Name,
+		PayPrice:   r.PayPrice,
+		BonusPrice: r.BonusPrice,
+		Status:     r.Status,
+	}
+	if err := s.q.PayWalletRechargePackage.WithContext(ctx).Create(pkg); err != nil {
+		return 0, err
+	}
+	return pkg.ID, nil
+}
+
+// UpdateWalletRechargePackage 更新充值套餐
+func (s *PayWalletRechargePackageService) UpdateWalletRechargePackage(ctx context.Context, r *req.PayWalletRechargePackageUpdateReq) error {
+	if _, err := s.validatePackageExists(ctx, r.ID); err != nil {
+		return err
+	}
+	if err := s.validateNameUnique(ctx, r.ID, r.Name); err != nil {
+		return err
+	}
+	p := s.q.PayWalletRechargePackage
+	_, err := p.WithContext(ctx).Where(p.ID.Eq(r.ID)).Updates(map[string]interface{}{
+		"name":        r.Name,
+		"pay_price":   r.PayPrice,
+		"bonus_price": r.BonusPrice,
+		"status":      r.Status,
+	})
+	return err
+}
+
+// DeleteWalletRechargePackage 删除充值套餐
+func (s *PayWalletRechargePackageService) DeleteWalletRechargePackage(ctx context.Context, id int64) error {
+	if _, err := s.validatePackageExists(ctx, id); err != nil {
+		return err
+	}
+	_, err := s.q.PayWalletRechargePackage.WithContext(ctx).Where(s.q.PayWalletRechargePackage.ID.Eq(id)).Delete()
+	return err
+}
+
+// GetWalletRechargePackage 获得充值套餐
+func (s *PayWalletRechargePackageService) GetWalletRechargePackage(ctx context.Context, id int64) (*pay.PayWalletRechargePackage, error) {
+	return s.q.PayWalletRechargePackage.WithContext(ctx).Where(s.q.PayWalletRechargePackage.ID.Eq(id)).First()
+}
+
+// GetWalletRechargePackagePage 获得充值套餐分页
+func (s *PayWalletRechargePackageService) GetWalletRechargePackagePage(ctx context.Context, r *req.PayWalletRechargePackagePageReq) (*core.PageResult[*pay.PayWalletRechargePackage], error) {
+	p := s.q.PayWalletRechargePackage
+	q := p.WithContext(ctx)
+	if r.Name != "" {
+		q = q.Where(p.Name.Like("%" + r.Name + "%"))
+	}
+	if r.Status != nil {
+		q = q.Where(p.Status.Eq(*r.Status))
+	}
+
+	total, err := q.Count()
+	if err != nil {
+		return nil, err
+	}
+	list, err := q.Limit(r.GetLimit()).Offset(r.GetOffset()).Order(p.PayPrice).Find()
+	if err != nil {
+		return nil, err
+	}
+	return &core.PageResult[*pay.PayWalletRechargePackage]{
+		List:  list,
+		Total: total,
+	}, nil
+}
+
+// GetEnableWalletRechargePackageList 获得开启状态的充值套餐列表
+func (s *PayWalletRechargePackageService) GetEnableWalletRechargePackageList(ctx context.Context) ([]*pay.PayWalletRechargePackage, error) {
+	p := s.q.PayWalletRechargePackage
+	return p.WithContext(ctx).Where(p.Status.Eq(0)).Order(p.PayPrice).Find()
+}
+
+// ValidWalletRechargePackage 校验充值套餐是否有效
+func (s *PayWalletRechargePackageService) ValidWalletRechargePackage(ctx context.Context, id int64) (*pay.PayWalletRechargePackage, error) {
+	pkg, err := s.validatePackageExists(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if pkg.Status != 0 { // 0 = Enabled
+		return nil, core.NewBizError(1006009001, "钱包充值套餐已禁用") // WALLET_RECHARGE_PACKAGE_IS_DISABLE
+	}
+	return pkg, nil
+}
+
+func (s *PayWalletRechargePackageService) validatePackageExists(ctx context.Context, id int64) (*pay.PayWalletRechargePackage, error) {
+	pkg, err := s.GetWalletRechargePackage(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, core.NewBizError(1006009000, "钱包充值套餐不存在") // WALLET_RECHARGE_PACKAGE_NOT_FOUND
+		}
+		return nil, err
+	}
+	return pkg, nil
+}
+
+func (s *PayWalletRechargePackageService) validateNameUnique(ctx context.Context, id int64, name string) error {
+	p := s.q.PayWalletRechargePackage
+	pkg, err := p.WithContext(ctx).Where(p.Name.Eq(name)).First()
+	if err == nil && pkg != nil {
+		if id == 0 || pkg.ID != id {
+			return core.NewBizError(1006009002, "钱包充值套餐名已存在") // WALLET_RECHARGE_PACKAGE_NAME_EXISTS
+		}
+	}
+	return nil
+}
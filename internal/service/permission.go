@@ -2,23 +2,198 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"time"
 
+	"github.com/redis/go-redis/v9"
 	"github.com/samber/lo"
 
 	"backend-go/internal/model"
 	"backend-go/internal/repo/query"
 )
 
+const (
+	// RedisKeyUserPermission 用户权限缓存
+	RedisKeyUserPermission = "user_permission:%d"
+	userPermissionCacheTTL = 5 * time.Minute
+)
+
 type PermissionService struct {
 	q       *query.Query
+	rdb     *redis.Client
 	roleSvc *RoleService
+	deptSvc *DeptService
 }
 
-func NewPermissionService(q *query.Query, roleSvc *RoleService) *PermissionService {
+func NewPermissionService(q *query.Query, rdb *redis.Client, roleSvc *RoleService, deptSvc *DeptService) *PermissionService {
 	return &PermissionService{
 		q:       q,
+		rdb:     rdb,
 		roleSvc: roleSvc,
+		deptSvc: deptSvc,
+	}
+}
+
+// userPermission 用户的权限信息（缓存结构）
+type userPermission struct {
+	SuperAdmin  bool     `json:"superAdmin"`
+	Permissions []string `json:"permissions"`
+}
+
+// HasAnyPermissions 判断用户是否拥有任一权限
+// 对应 Java: PermissionServiceImpl.hasAnyPermissions
+func (s *PermissionService) HasAnyPermissions(ctx context.Context, userId int64, permissions ...string) (bool, error) {
+	// 如果为空，说明已经有权限
+	if len(permissions) == 0 {
+		return true, nil
+	}
+
+	perm, err := s.getUserPermission(ctx, userId)
+	if err != nil {
+		return false, err
 	}
+	// 超级管理员拥有全部权限
+	if perm.SuperAdmin {
+		return true, nil
+	}
+	for _, p := range permissions {
+		if lo.Contains(perm.Permissions, p) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// getUserPermission 获得用户的权限信息，优先从 Redis 缓存读取
+func (s *PermissionService) getUserPermission(ctx context.Context, userId int64) (*userPermission, error) {
+	key := fmt.Sprintf(RedisKeyUserPermission, userId)
+	if s.rdb != nil {
+		if data, err := s.rdb.Get(ctx, key).Bytes(); err == nil {
+			var perm userPermission
+			if err := json.Unmarshal(data, &perm); err == nil {
+				return &perm, nil
+			}
+		}
+	}
+
+	perm, err := s.loadUserPermission(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if s.rdb != nil {
+		if data, err := json.Marshal(perm); err == nil {
+			s.rdb.Set(ctx, key, data, userPermissionCacheTTL)
+		}
+	}
+	return perm, nil
+}
+
+// loadUserPermission 从数据库加载用户的权限信息，仅统计开启的角色与菜单
+func (s *PermissionService) loadUserPermission(ctx context.Context, userId int64) (*userPermission, error) {
+	roleIds, err := s.GetUserRoleIdListByUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	perm := &userPermission{Permissions: []string{}}
+	if len(roleIds) == 0 {
+		return perm, nil
+	}
+
+	// 过滤禁用的角色
+	r := s.q.SystemRole
+	roles, err := r.WithContext(ctx).Where(r.ID.In(roleIds...), r.Status.Eq(0)).Find()
+	if err != nil {
+		return nil, err
+	}
+	enabledRoleIds := lo.Map(roles, func(item *model.SystemRole, _ int) int64 {
+		return item.ID
+	})
+	if len(enabledRoleIds) == 0 {
+		return perm, nil
+	}
+
+	perm.SuperAdmin, err = s.roleSvc.HasAnySuperAdmin(ctx, enabledRoleIds)
+	if err != nil {
+		return nil, err
+	}
+	if perm.SuperAdmin {
+		return perm, nil
+	}
+
+	menuIds, err := s.GetRoleMenuListByRoleId(ctx, enabledRoleIds)
+	if err != nil {
+		return nil, err
+	}
+	if len(menuIds) == 0 {
+		return perm, nil
+	}
+	// 过滤禁用的菜单
+	m := s.q.SystemMenu
+	menus, err := m.WithContext(ctx).Where(m.ID.In(menuIds...), m.Status.Eq(0)).Find()
+	if err != nil {
+		return nil, err
+	}
+	for _, menu := range menus {
+		if menu.Permission != "" {
+			perm.Permissions = append(perm.Permissions, menu.Permission)
+		}
+	}
+	perm.Permissions = lo.Uniq(perm.Permissions)
+	return perm, nil
+}
+
+// evictUserPermission 清除用户的权限缓存
+func (s *PermissionService) evictUserPermission(ctx context.Context, userIds ...int64) {
+	evictUserPermissionCache(ctx, s.rdb, userIds...)
+}
+
+// evictRoleUserPermission 清除拥有该角色的用户的权限缓存
+func (s *PermissionService) evictRoleUserPermission(ctx context.Context, roleId int64) {
+	evictRoleUserPermissionCache(ctx, s.q, s.rdb, roleId)
+}
+
+// evictUserPermissionCache 清除用户的权限缓存
+func evictUserPermissionCache(ctx context.Context, rdb *redis.Client, userIds ...int64) {
+	if rdb == nil || len(userIds) == 0 {
+		return
+	}
+	keys := lo.Map(lo.Uniq(userIds), func(id int64, _ int) string {
+		return fmt.Sprintf(RedisKeyUserPermission, id)
+	})
+	rdb.Del(ctx, keys...)
+}
+
+// evictRoleUserPermissionCache 清除拥有指定角色的用户的权限缓存
+// 角色的状态、删除等变更会影响其下所有用户的权限，由 RoleService 调用
+func evictRoleUserPermissionCache(ctx context.Context, q *query.Query, rdb *redis.Client, roleIds ...int64) {
+	if rdb == nil || len(roleIds) == 0 {
+		return
+	}
+	ur := q.SystemUserRole
+	list, err := ur.WithContext(ctx).Where(ur.RoleID.In(roleIds...)).Find()
+	if err != nil {
+		return
+	}
+	evictUserPermissionCache(ctx, rdb, lo.Map(list, func(item *model.SystemUserRole, _ int) int64 {
+		return item.UserID
+	})...)
+}
+
+// evictMenuUserPermissionCache 清除拥有指定菜单的用户的权限缓存
+// 菜单的权限标识、状态变更会影响分配了该菜单的角色下所有用户，由 MenuService 调用
+func evictMenuUserPermissionCache(ctx context.Context, q *query.Query, rdb *redis.Client, menuId int64) {
+	if rdb == nil {
+		return
+	}
+	rm := q.SystemRoleMenu
+	list, err := rm.WithContext(ctx).Where(rm.MenuID.Eq(menuId)).Find()
+	if err != nil {
+		return
+	}
+	evictRoleUserPermissionCache(ctx, q, rdb, lo.Map(list, func(item *model.SystemRoleMenu, _ int) int64 {
+		return item.RoleID
+	})...)
 }
 
 // GetUserRoleIdListByUserId 获取用户的角色ID列表
@@ -77,6 +252,7 @@ func (s *PermissionService) getAllMenuIds(ctx context.Context) ([]int64, error)
 
 // AssignRoleMenu 赋予角色菜单
 func (s *PermissionService) AssignRoleMenu(ctx context.Context, roleId int64, menuIds []int64) error {
+	defer s.evictRoleUserPermission(ctx, roleId)
 	// Transaction
 	return s.q.Transaction(func(tx *query.Query) error {
 		// 1. Delete old
@@ -110,6 +286,7 @@ func (s *PermissionService) AssignRoleDataScope(ctx context.Context, roleId int6
 
 // AssignUserRole 赋予用户角色
 func (s *PermissionService) AssignUserRole(ctx context.Context, userId int64, roleIds []int64) error {
+	defer s.evictUserPermission(ctx, userId)
 	return s.q.Transaction(func(tx *query.Query) error {
 		ur := tx.SystemUserRole
 		// 1. Delete old
@@ -10,6 +10,7 @@ import (
 	"context"
 
 	"github.com/samber/lo"
+	"gorm.io/gen/field"
 )
 
 type ProductSpuService struct {
@@ -226,6 +227,46 @@ func (s *ProductSpuService) GetSpuPage(ctx context.Context, req *req.ProductSpuP
 	}, nil
 }
 
+// GetAppSpuPage 获得用户 APP 的 SPU 分页，只返回上架的商品
+func (s *ProductSpuService) GetAppSpuPage(ctx context.Context, r *req.AppProductSpuPageReq) (*core.PageResult[*resp.ProductSpuResp], error) {
+	u := s.q.ProductSpu
+	q := u.WithContext(ctx).Where(u.Status.Eq(0)) // 上架
+	if r.CategoryID > 0 {
+		q = q.Where(u.CategoryID.Eq(r.CategoryID))
+	}
+	if r.Keyword != "" {
+		q = q.Where(u.Name.Like("%" + r.Keyword + "%"))
+	}
+
+	var order field.OrderExpr
+	switch r.SortField {
+	case "price":
+		order = u.Price
+	case "salesCount":
+		order = u.SalesCount
+	case "createTime":
+		order = u.CreatedAt
+	}
+	if order == nil {
+		q = q.Order(u.Sort.Desc(), u.ID.Desc())
+	} else if r.SortAsc {
+		q = q.Order(order, u.ID.Desc())
+	} else {
+		q = q.Order(order.Desc(), u.ID.Desc())
+	}
+
+	list, total, err := q.FindByPage((r.PageNo-1)*r.PageSize, r.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	return &core.PageResult[*resp.ProductSpuResp]{
+		List: lo.Map(list, func(item *product.ProductSpu, _ int) *resp.ProductSpuResp {
+			return s.convertResp(item, nil)
+		}),
+		Total: total,
+	}, nil
+}
+
 // GetTabsCount 获得 SPU Tab 统计
 func (s *ProductSpuService) GetTabsCount(ctx context.Context) (map[int]int64, error) {
 	u := s.q.ProductSpu
@@ -24,6 +24,10 @@ type CombinationRecordService interface {
 	ValidateCombinationRecord(ctx context.Context, userID int64, activityID int64, headID int64, skuID int64, count int) (*promotion.PromotionCombinationActivity, *promotion.PromotionCombinationProduct, error)
 	CreateCombinationRecord(ctx context.Context, record *promotion.PromotionCombinationRecord) (int64, error)
 	GetCombinationRecordPageAdmin(ctx context.Context, req *req.CombinationRecordPageReq) (*core.PageResult[*promotion.PromotionCombinationRecord], error)
+
+	// Job
+	ExpireCombinationRecord(ctx context.Context) ([]*promotion.PromotionCombinationRecord, int, error)
+	UpdateCombinationRecordFailed(ctx context.Context, id int64) error
 }
 
 type combinationRecordService struct {
@@ -253,3 +257,74 @@ func (s *combinationRecordService) GetCombinationRecordPageAdmin(ctx context.Con
 	}
 	return &core.PageResult[*promotion.PromotionCombinationRecord]{List: list, Total: total}, nil
 }
+
+// ExpireCombinationRecord 处理已过期的进行中拼团
+// 开启虚拟成团的活动直接成团；其余拼团返回待失败的记录与虚拟成团的数量，
+// 由交易模块取消订单并退款成功后，再调用 UpdateCombinationRecordFailed 逐条标记为失败
+func (s *combinationRecordService) ExpireCombinationRecord(ctx context.Context) ([]*promotion.PromotionCombinationRecord, int, error) {
+	q := s.q.PromotionCombinationRecord
+	// 1. 获取所有进行中的、已过期的团长记录
+	heads, err := q.WithContext(ctx).Where(q.HeadID.Eq(0), q.Status.Eq(0), q.ExpireTime.Lt(time.Now())).Find() // 0: InProgress
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(heads) == 0 {
+		return nil, 0, nil
+	}
+
+	// 2. 获取拼团活动
+	activityIds := make([]int64, 0, len(heads))
+	for _, head := range heads {
+		activityIds = append(activityIds, head.ActivityID)
+	}
+	activities, err := s.q.PromotionCombinationActivity.WithContext(ctx).Where(s.q.PromotionCombinationActivity.ID.In(activityIds...)).Find()
+	if err != nil {
+		return nil, 0, err
+	}
+	activityMap := make(map[int64]*promotion.PromotionCombinationActivity, len(activities))
+	for _, activity := range activities {
+		activityMap[activity.ID] = activity
+	}
+
+	// 3. 逐个处理拼团：过期 or 虚拟成团
+	var failedRecords []*promotion.PromotionCombinationRecord
+	virtualGroupCount := 0
+	for _, head := range heads {
+		members, err := q.WithContext(ctx).Where(q.HeadID.Eq(head.ID)).Find()
+		if err != nil {
+			return failedRecords, virtualGroupCount, err
+		}
+		records := append([]*promotion.PromotionCombinationRecord{head}, members...)
+		recordIds := make([]int64, 0, len(records))
+		for _, r := range records {
+			recordIds = append(recordIds, r.ID)
+		}
+
+		activity := activityMap[head.ActivityID]
+		if activity == nil || !activity.VirtualGroup {
+			// 3.1 拼团失败，待订单取消后再标记
+			failedRecords = append(failedRecords, records...)
+			continue
+		}
+		// 3.2 虚拟成团
+		if _, err := q.WithContext(ctx).Where(q.ID.In(recordIds...), q.Status.Eq(0)).Updates(map[string]interface{}{
+			"status":        1, // Success
+			"virtual_group": true,
+			"end_time":      time.Now(),
+		}); err != nil {
+			return failedRecords, virtualGroupCount, err
+		}
+		virtualGroupCount++
+	}
+	return failedRecords, virtualGroupCount, nil
+}
+
+// UpdateCombinationRecordFailed 将进行中的拼团记录标记为失败
+func (s *combinationRecordService) UpdateCombinationRecordFailed(ctx context.Context, id int64) error {
+	q := s.q.PromotionCombinationRecord
+	_, err := q.WithContext(ctx).Where(q.ID.Eq(id), q.Status.Eq(0)).Updates(map[string]interface{}{
+		"status":   2, // Failed
+		"end_time": time.Now(),
+	})
+	return err
+}
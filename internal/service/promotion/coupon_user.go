@@ -202,6 +202,29 @@ func (s *CouponUserService) UseCoupon(ctx context.Context, userId int64, couponI
 }
 
 // checkScope 检查适用范围
+// GetCouponMatchItems 按优惠券模板的商品范围筛选商品，spuIDs 与 categoryIDs 按商品一一对应，返回适用商品的下标
+// 用于将优惠券金额只分摊到适用的商品上
+func (s *CouponUserService) GetCouponMatchItems(ctx context.Context, userId int64, couponId int64, spuIDs []int64, categoryIDs []int64) ([]int, error) {
+	coupon, err := s.q.PromotionCoupon.WithContext(ctx).Where(s.q.PromotionCoupon.ID.Eq(couponId), s.q.PromotionCoupon.UserID.Eq(userId)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("优惠券不存在")
+		}
+		return nil, err
+	}
+	template, err := s.q.PromotionCouponTemplate.WithContext(ctx).Where(s.q.PromotionCouponTemplate.ID.Eq(coupon.TemplateID)).First()
+	if err != nil {
+		return nil, err
+	}
+	var indexes []int
+	for i := range spuIDs {
+		if s.checkScope(template, spuIDs[i:i+1], categoryIDs[i:i+1]) {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes, nil
+}
+
 func (s *CouponUserService) checkScope(t *promotion.PromotionCouponTemplate, spuIDs []int64, categoryIDs []int64) bool {
 	if t.ProductScope == 1 { // All
 		return true
@@ -308,3 +331,14 @@ func (s *CouponUserService) ReturnCoupon(ctx context.Context, userId int64, coup
 	_, err = s.q.PromotionCoupon.WithContext(ctx).Where(s.q.PromotionCoupon.ID.Eq(couponId)).Updates(updates)
 	return err
 }
+
+// ExpireCoupon 过期已到有效期结束时间的未使用优惠券，返回过期的数量
+func (s *CouponUserService) ExpireCoupon(ctx context.Context) (int, error) {
+	info, err := s.q.PromotionCoupon.WithContext(ctx).
+		Where(s.q.PromotionCoupon.Status.Eq(1), s.q.PromotionCoupon.ValidEndTime.Lte(time.Now())). // 1: Unused
+		Update(s.q.PromotionCoupon.Status, 3)                                                      // 3: Expired
+	if err != nil {
+		return 0, err
+	}
+	return int(info.RowsAffected), nil
+}
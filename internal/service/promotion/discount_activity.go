@@ -10,6 +10,8 @@ import (
 	"backend-go/internal/pkg/core"
 	"backend-go/internal/repo/query"
 	prodSvc "backend-go/internal/service/product"
+
+	"gorm.io/gen"
 )
 
 type DiscountActivityService interface {
@@ -19,6 +21,8 @@ type DiscountActivityService interface {
 	DeleteDiscountActivity(ctx context.Context, id int64) error
 	GetDiscountActivity(ctx context.Context, id int64) (*resp.DiscountActivityRespVO, error)
 	GetDiscountActivityPage(ctx context.Context, req req.DiscountActivityPageReq) (*core.PageResult[*resp.DiscountActivityRespVO], error)
+	GetMatchDiscountProductListBySkuIds(ctx context.Context, skuIDs []int64) ([]*promotion.PromotionDiscountProduct, error)
+	GetMatchDiscountProductListBySpuIds(ctx context.Context, spuIDs []int64) ([]*promotion.PromotionDiscountProduct, error)
 }
 
 type discountActivityService struct {
@@ -289,6 +293,46 @@ func (s *discountActivityService) GetDiscountActivityPage(ctx context.Context, r
 	return &core.PageResult[*resp.DiscountActivityRespVO]{List: result, Total: total}, nil
 }
 
+// GetMatchDiscountProductListBySkuIds 获得 SKU 当前生效的限时折扣：活动开启且处于活动时间内
+// 对应 Java: DiscountActivityServiceImpl#getMatchDiscountProductListBySkuIds
+func (s *discountActivityService) GetMatchDiscountProductListBySkuIds(ctx context.Context, skuIDs []int64) ([]*promotion.PromotionDiscountProduct, error) {
+	if len(skuIDs) == 0 {
+		return []*promotion.PromotionDiscountProduct{}, nil
+	}
+	p := s.q.PromotionDiscountProduct
+	return s.findMatchDiscountProducts(ctx, p.SkuID.In(skuIDs...))
+}
+
+// GetMatchDiscountProductListBySpuIds 获得 SPU 下各 SKU 当前生效的限时折扣，用于商品列表与详情展示
+func (s *discountActivityService) GetMatchDiscountProductListBySpuIds(ctx context.Context, spuIDs []int64) ([]*promotion.PromotionDiscountProduct, error) {
+	if len(spuIDs) == 0 {
+		return []*promotion.PromotionDiscountProduct{}, nil
+	}
+	p := s.q.PromotionDiscountProduct
+	return s.findMatchDiscountProducts(ctx, p.SpuID.In(spuIDs...))
+}
+
+func (s *discountActivityService) findMatchDiscountProducts(ctx context.Context, cond gen.Condition) ([]*promotion.PromotionDiscountProduct, error) {
+	p := s.q.PromotionDiscountProduct
+	now := time.Now()
+	return p.WithContext(ctx).Where(cond, p.ActivityStatus.Eq(1),
+		p.ActivityStartTime.Lte(now), p.ActivityEndTime.Gte(now)).Find()
+}
+
+// CalculateDiscountProductPrice 计算参与限时折扣后的商品总价，price 为商品单价
+// 减价（DiscountType = 1）时每件减去 DiscountPrice；打折（DiscountType = 2）时按 DiscountPercent 百分比计算
+// 对应 Java: TradeDiscountActivityPriceCalculator#calculateActivityPrice
+func CalculateDiscountProductPrice(product *promotion.PromotionDiscountProduct, price int, count int) int {
+	totalPrice := price * count
+	switch product.DiscountType {
+	case 1:
+		totalPrice -= product.DiscountPrice * count
+	case 2:
+		totalPrice = int(int64(totalPrice) * int64(product.DiscountPercent) / 100)
+	}
+	return max(totalPrice, 0)
+}
+
 func (s *discountActivityService) validateDiscountActivityExists(ctx context.Context, id int64) (*promotion.PromotionDiscountActivity, error) {
 	activity, err := s.q.PromotionDiscountActivity.WithContext(ctx).Where(s.q.PromotionDiscountActivity.ID.Eq(id)).First()
 	if err != nil {
@@ -0,0 +1,30 @@
+package promotion
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// CouponExpireJobHandlerName 优惠券过期 Job 的处理器名 (infra_job.handler_name)
+const CouponExpireJobHandlerName = "couponExpireJob"
+
+// CouponExpireJob 优惠券的过期 Job
+type CouponExpireJob struct {
+	couponUserSvc *CouponUserService
+	logger        *zap.Logger
+}
+
+func NewCouponExpireJob(couponUserSvc *CouponUserService, logger *zap.Logger) *CouponExpireJob {
+	return &CouponExpireJob{couponUserSvc: couponUserSvc, logger: logger}
+}
+
+// Execute 实现 service.JobHandler
+func (j *CouponExpireJob) Execute(ctx context.Context, param string) error {
+	count, err := j.couponUserSvc.ExpireCoupon(ctx)
+	if err != nil {
+		return err
+	}
+	j.logger.Info("[CouponExpireJob] 过期优惠券", zap.Int("count", count))
+	return nil
+}
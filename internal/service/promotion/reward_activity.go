@@ -132,7 +132,7 @@ type ActivityMatchItem struct {
 	SkuID      int64
 	SpuID      int64
 	CategoryID int64
-	Price      int
+	PayPrice   int // 商品的应付总金额，已扣除秒杀、限时折扣等优惠
 	Count      int
 }
 
@@ -201,7 +201,7 @@ func (s *RewardActivityService) CalculateRewardActivity(ctx context.Context, ite
 
 			if isMatch {
 				matchedItems = append(matchedItems, item)
-				matchedPrice += item.Price * item.Count
+				matchedPrice += item.PayPrice
 				matchedCount += item.Count
 			}
 		}
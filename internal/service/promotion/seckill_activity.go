@@ -2,6 +2,7 @@ package promotion
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"backend-go/internal/api/req"
@@ -10,6 +11,8 @@ import (
 	"backend-go/internal/repo/query"
 	"backend-go/internal/service/product" // Import Product services
 
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
 	"gorm.io/gen"
 )
 
@@ -18,14 +21,16 @@ type SeckillActivityService struct {
 	configSvc *SeckillConfigService
 	spuSvc    *product.ProductSpuService
 	skuSvc    *product.ProductSkuService // Need SkuService for validation
+	rdb       *redis.Client
 }
 
-func NewSeckillActivityService(q *query.Query, configSvc *SeckillConfigService, spuSvc *product.ProductSpuService, skuSvc *product.ProductSkuService) *SeckillActivityService {
+func NewSeckillActivityService(q *query.Query, configSvc *SeckillConfigService, spuSvc *product.ProductSpuService, skuSvc *product.ProductSkuService, rdb *redis.Client) *SeckillActivityService {
 	return &SeckillActivityService{
 		q:         q,
 		configSvc: configSvc,
 		spuSvc:    spuSvc,
 		skuSvc:    skuSvc,
+		rdb:       rdb,
 	}
 }
 
@@ -107,6 +112,19 @@ func (s *SeckillActivityService) UpdateSeckillActivity(ctx context.Context, r *r
 	if err := s.validateProductConflict(ctx, r.ConfigIds, r.SpuID, r.ID); err != nil {
 		return err
 	}
+	// 秒杀商品重建后，库存缓存需要按新的库存重新加载
+	oldProducts, err := s.GetSeckillProductListByActivityID(ctx, r.ID)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		for _, p := range oldProducts {
+			s.RefreshSeckillStockCache(ctx, r.ID, p.SkuID)
+		}
+		for _, p := range r.Products {
+			s.RefreshSeckillStockCache(ctx, r.ID, p.SkuID)
+		}
+	}()
 
 	return s.q.Transaction(func(tx *query.Query) error {
 		totalStock := 0
@@ -265,50 +283,277 @@ func (s *SeckillActivityService) validateProductConflict(ctx context.Context, co
 
 // GetSeckillActivityAppPage 获得 App 端秒杀活动分页
 func (s *SeckillActivityService) GetSeckillActivityAppPage(ctx context.Context, pageNo, pageSize int, configId int64) (*core.PageResult[*promotion.PromotionSeckillActivity], error) {
+	filtered, err := s.GetSeckillActivityListByConfigId(ctx, configId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Manual Pagination
+	total := int64(len(filtered))
+	start := (pageNo - 1) * pageSize
+	if start >= len(filtered) {
+		return &core.PageResult[*promotion.PromotionSeckillActivity]{List: []*promotion.PromotionSeckillActivity{}, Total: total}, nil
+	}
+	end := start + pageSize
+	if end > len(filtered) {
+		end = len(filtered)
+	}
+
+	return &core.PageResult[*promotion.PromotionSeckillActivity]{
+		List:  filtered[start:end],
+		Total: total,
+	}, nil
+}
+
+// GetSeckillActivityListByConfigId 获得参与秒杀时段的进行中活动，configId 为 0 时不过滤时段
+func (s *SeckillActivityService) GetSeckillActivityListByConfigId(ctx context.Context, configId int64) ([]*promotion.PromotionSeckillActivity, error) {
 	// Java logic: filter by configId, status=ENABLE, now between startTime/endTime
 	q := s.q.PromotionSeckillActivity
 
 	// How to filter JSON configIds contains configId?
 	// Use LIKE? `configIds` stored as `[1,2,3]`.
 	// Simple Like "%1%" is dangerous (matches 10).
-	// Ideally GORM `datatypes.JSONOverlaps` or just fetch and filter in memory if volume is low.
 	// Given Seckill activities are usually limited, memory filter is acceptable.
-
-	// Fetch candidates (Status=Enable, Time Valid)
 	now := time.Now()
 	list, err := q.WithContext(ctx).Where(
 		q.Status.Eq(1),
 		q.StartTime.Lte(now),
 		q.EndTime.Gte(now),
-	).Order(q.Sort.Desc()).Find() // Fetch all active first
-
+	).Order(q.Sort.Desc()).Find()
 	if err != nil {
 		return nil, err
 	}
+	if configId == 0 {
+		return list, nil
+	}
 
-	var filtered []*promotion.PromotionSeckillActivity
+	filtered := make([]*promotion.PromotionSeckillActivity, 0, len(list))
 	for _, item := range list {
-		for _, cid := range item.ConfigIds {
-			if cid == configId {
-				filtered = append(filtered, item)
-				break
-			}
+		if containsConfigId(item.ConfigIds, configId) {
+			filtered = append(filtered, item)
 		}
 	}
+	return filtered, nil
+}
 
-	// Manual Pagination
-	total := int64(len(filtered))
-	start := (pageNo - 1) * pageSize
-	if start >= len(filtered) {
-		return &core.PageResult[*promotion.PromotionSeckillActivity]{List: []*promotion.PromotionSeckillActivity{}, Total: total}, nil
+// GetSeckillProductListByActivityIds 获得多个秒杀活动的商品列表
+func (s *SeckillActivityService) GetSeckillProductListByActivityIds(ctx context.Context, activityIds []int64) ([]*promotion.PromotionSeckillProduct, error) {
+	if len(activityIds) == 0 {
+		return []*promotion.PromotionSeckillProduct{}, nil
 	}
-	end := start + pageSize
-	if end > len(filtered) {
-		end = len(filtered)
+	q := s.q.PromotionSeckillProduct
+	return q.WithContext(ctx).Where(q.ActivityID.In(activityIds...)).Find()
+}
+
+// GetActiveSeckillConfig 获得活动当前所处的秒杀时段，不在任何时段内时返回 nil
+func (s *SeckillActivityService) GetActiveSeckillConfig(ctx context.Context, activity *promotion.PromotionSeckillActivity) (*promotion.PromotionSeckillConfig, error) {
+	configs, err := s.configSvc.GetSeckillConfigListByIds(ctx, activity.ConfigIds)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, config := range configs {
+		if config.Status == 1 && IsSeckillConfigActive(config, now) { // 1=Enabled
+			return config, nil
+		}
 	}
+	return nil, nil
+}
 
-	return &core.PageResult[*promotion.PromotionSeckillActivity]{
-		List:  filtered[start:end],
-		Total: total,
+// ValidateJoinSeckill 校验是否可以参与秒杀：活动开启且处于活动时间与秒杀时段内、商品参与活动、单次限购、库存充足
+// 总限购依赖订单数据，由交易模块校验
+// 对应 Java: SeckillActivityServiceImpl#validateJoinSeckill
+func (s *SeckillActivityService) ValidateJoinSeckill(ctx context.Context, activityId int64, skuId int64, count int) (*promotion.PromotionSeckillActivity, *promotion.PromotionSeckillProduct, error) {
+	// 1. 校验活动
+	q := s.q.PromotionSeckillActivity
+	activity, err := q.WithContext(ctx).Where(q.ID.Eq(activityId)).First()
+	if err != nil {
+		return nil, nil, core.NewBizError(1001002000, "秒杀活动不存在") // SECKILL_ACTIVITY_NOT_EXISTS
+	}
+	if activity.Status != 1 { // 1=Enable
+		return nil, nil, core.NewBizError(1001002006, "秒杀活动已关闭") // SECKILL_JOIN_ACTIVITY_STATUS_CLOSED
+	}
+	now := time.Now()
+	if now.Before(activity.StartTime) || now.After(activity.EndTime) {
+		return nil, nil, core.NewBizError(1001002005, "秒杀活动未开始或已结束") // SECKILL_JOIN_ACTIVITY_TIME_ERROR
+	}
+	config, err := s.GetActiveSeckillConfig(ctx, activity)
+	if err != nil {
+		return nil, nil, err
+	}
+	if config == nil {
+		return nil, nil, core.NewBizError(1001002005, "秒杀活动未开始或已结束") // SECKILL_JOIN_ACTIVITY_TIME_ERROR
+	}
+	if activity.SingleLimitCount > 0 && count > activity.SingleLimitCount {
+		return nil, nil, core.NewBizError(1001002007, fmt.Sprintf("单次限购 %d 件", activity.SingleLimitCount)) // SECKILL_JOIN_ACTIVITY_SINGLE_LIMIT_COUNT_EXCEED
+	}
+
+	// 2. 校验商品与库存
+	p := s.q.PromotionSeckillProduct
+	seckillProduct, err := p.WithContext(ctx).Where(p.ActivityID.Eq(activityId), p.SkuID.Eq(skuId)).First()
+	if err != nil {
+		return nil, nil, core.NewBizError(1001002008, "秒杀商品不存在") // SECKILL_JOIN_ACTIVITY_PRODUCT_NOT_EXISTS
+	}
+	if count > seckillProduct.Stock {
+		return nil, nil, core.NewBizError(1001002009, "秒杀失败，原因：秒杀库存不足") // SECKILL_ACTIVITY_UPDATE_STOCK_FAIL
+	}
+	return activity, seckillProduct, nil
+}
+
+// ========== 秒杀库存 ==========
+// 库存以数据库为准。Redis 中缓存一份剩余库存，下单时先在 Redis 中原子扣减，库存不足的请求直接失败，不再访问数据库；
+// 扣减成功后在订单事务中按条件扣减数据库库存。订单事务回滚、取消订单归还库存时，在缓存上 INCRBY 归还预占的数量；
+// 活动修改后库存重新设定，删除缓存，下次扣减时从数据库重新加载
+
+const seckillStockKeyFormat = "promotion:seckill:stock:%d:%d" // activityId, skuId
+
+// seckillStockDecrScript 原子扣减库存。缓存不存在返回 -2，库存不足返回 -1，否则返回剩余库存
+var seckillStockDecrScript = redis.NewScript(`
+local stock = redis.call("GET", KEYS[1])
+if not stock then
+	return -2
+end
+local count = tonumber(ARGV[1])
+if tonumber(stock) < count then
+	return -1
+end
+return redis.call("DECRBY", KEYS[1], count)
+`)
+
+// seckillStockIncrScript 归还库存。缓存不存在时不处理，下次扣减时从数据库加载的库存已包含归还的数量
+var seckillStockIncrScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return -2
+end
+return redis.call("INCRBY", KEYS[1], ARGV[1])
+`)
+
+// DecrSeckillStock 扣减秒杀库存：先扣减 Redis 缓存，再在事务 tx 中扣减秒杀商品与活动的数据库库存
+// 返回 nil 后 tx 回滚时，调用方需要调用 IncrSeckillStockCache 归还缓存中预占的库存
+// 对应 Java: SeckillActivityServiceImpl#updateSeckillStockDecr
+func (s *SeckillActivityService) DecrSeckillStock(ctx context.Context, tx *query.Query, activityId int64, skuId int64, count int) error {
+	// 1. 扣减 Redis 库存
+	if err := s.decrSeckillStockCache(ctx, activityId, skuId, count); err != nil {
+		return err
+	}
+
+	// 2. 扣减数据库库存，以库存充足为条件；失败时归还缓存中预占的库存
+	if err := s.decrSeckillStockDB(ctx, tx, activityId, skuId, count); err != nil {
+		s.IncrSeckillStockCache(ctx, activityId, skuId, count)
+		return err
+	}
+	return nil
+}
+
+// IncrSeckillStock 在事务 tx 中归还秒杀库存（如取消订单），提交后调用方需要调用 IncrSeckillStockCache
+// 对应 Java: SeckillActivityServiceImpl#updateSeckillStockIncr
+func (s *SeckillActivityService) IncrSeckillStock(ctx context.Context, tx *query.Query, activityId int64, skuId int64, count int) error {
+	p := tx.PromotionSeckillProduct
+	if _, err := p.WithContext(ctx).Where(p.ActivityID.Eq(activityId), p.SkuID.Eq(skuId)).
+		Update(p.Stock, p.Stock.Add(count)); err != nil {
+		return err
+	}
+	a := tx.PromotionSeckillActivity
+	_, err := a.WithContext(ctx).Where(a.ID.Eq(activityId)).Update(a.Stock, a.Stock.Add(count))
+	return err
+}
+
+// IncrSeckillStockCache 在缓存上归还 count 个库存，用于订单事务回滚、取消订单
+func (s *SeckillActivityService) IncrSeckillStockCache(ctx context.Context, activityId int64, skuId int64, count int) {
+	key := fmt.Sprintf(seckillStockKeyFormat, activityId, skuId)
+	if err := seckillStockIncrScript.Run(context.WithoutCancel(ctx), s.rdb, []string{key}, count).Err(); err != nil {
+		zap.L().Error("[IncrSeckillStockCache][归还秒杀库存缓存失败]", zap.String("key", key), zap.Int("count", count), zap.Error(err))
+	}
+}
+
+// RefreshSeckillStockCache 删除库存缓存，下次扣减时从数据库重新加载。仅用于活动修改后库存重新设定
+func (s *SeckillActivityService) RefreshSeckillStockCache(ctx context.Context, activityId int64, skuId int64) {
+	s.rdb.Del(context.WithoutCancel(ctx), fmt.Sprintf(seckillStockKeyFormat, activityId, skuId))
+}
+
+const (
+	seckillUserLockKeyFormat = "promotion:seckill:user:lock:%d:%d" // activityId, userId
+	seckillUserLockTimeout   = 30 * time.Second
+)
+
+// LockSeckillUser 加会员参与秒杀活动的锁，同一会员同一活动的下单串行执行，保证总限购数量的校验与下单之间不被并发穿透
+// 获取成功时返回释放锁的函数，由调用方在订单事务提交后调用
+func (s *SeckillActivityService) LockSeckillUser(ctx context.Context, activityId int64, userId int64) (func(), error) {
+	key := fmt.Sprintf(seckillUserLockKeyFormat, activityId, userId)
+	acquired, err := s.rdb.SetNX(ctx, key, "1", seckillUserLockTimeout).Result()
+	if err != nil {
+		return nil, err
+	}
+	if !acquired {
+		return nil, core.NewBizError(1001002010, "秒杀下单处理中，请勿重复提交") // SECKILL_ORDER_PROCESSING
+	}
+	return func() {
+		s.rdb.Del(context.WithoutCancel(ctx), key)
 	}, nil
 }
+
+func (s *SeckillActivityService) decrSeckillStockCache(ctx context.Context, activityId int64, skuId int64, count int) error {
+	key := fmt.Sprintf(seckillStockKeyFormat, activityId, skuId)
+	for i := 0; i < 2; i++ {
+		result, err := seckillStockDecrScript.Run(ctx, s.rdb, []string{key}, count).Int()
+		if err != nil {
+			return err
+		}
+		switch result {
+		case -1:
+			return core.NewBizError(1001002009, "秒杀失败，原因：秒杀库存不足") // SECKILL_ACTIVITY_UPDATE_STOCK_FAIL
+		case -2:
+			// 缓存不存在，从数据库加载后重试
+			if err := s.loadSeckillStockCache(ctx, key, activityId, skuId); err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+	}
+	return core.NewBizError(1001002009, "秒杀失败，原因：秒杀库存不足") // SECKILL_ACTIVITY_UPDATE_STOCK_FAIL
+}
+
+// loadSeckillStockCache 从数据库加载库存缓存，缓存在活动结束后过期
+func (s *SeckillActivityService) loadSeckillStockCache(ctx context.Context, key string, activityId int64, skuId int64) error {
+	p := s.q.PromotionSeckillProduct
+	seckillProduct, err := p.WithContext(ctx).Where(p.ActivityID.Eq(activityId), p.SkuID.Eq(skuId)).First()
+	if err != nil {
+		return core.NewBizError(1001002008, "秒杀商品不存在") // SECKILL_JOIN_ACTIVITY_PRODUCT_NOT_EXISTS
+	}
+	expiration := time.Until(seckillProduct.ActivityEndTime) + time.Hour
+	if expiration < time.Minute {
+		expiration = time.Minute
+	}
+	return s.rdb.SetNX(ctx, key, seckillProduct.Stock, expiration).Err()
+}
+
+func (s *SeckillActivityService) decrSeckillStockDB(ctx context.Context, tx *query.Query, activityId int64, skuId int64, count int) error {
+	p := tx.PromotionSeckillProduct
+	info, err := p.WithContext(ctx).Where(p.ActivityID.Eq(activityId), p.SkuID.Eq(skuId), p.Stock.Gte(count)).
+		Update(p.Stock, p.Stock.Sub(count))
+	if err != nil {
+		return err
+	}
+	if info.RowsAffected == 0 {
+		return core.NewBizError(1001002009, "秒杀失败，原因：秒杀库存不足") // SECKILL_ACTIVITY_UPDATE_STOCK_FAIL
+	}
+	a := tx.PromotionSeckillActivity
+	info, err = a.WithContext(ctx).Where(a.ID.Eq(activityId), a.Stock.Gte(count)).Update(a.Stock, a.Stock.Sub(count))
+	if err != nil {
+		return err
+	}
+	if info.RowsAffected == 0 {
+		return core.NewBizError(1001002009, "秒杀失败，原因：秒杀库存不足") // SECKILL_ACTIVITY_UPDATE_STOCK_FAIL
+	}
+	return nil
+}
+
+func containsConfigId(configIds []int64, configId int64) bool {
+	for _, id := range configIds {
+		if id == configId {
+			return true
+		}
+	}
+	return false
+}
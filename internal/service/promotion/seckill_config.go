@@ -140,22 +140,62 @@ func (s *SeckillConfigService) GetCurrentSeckillConfig(ctx context.Context) (*pr
 		return nil, err
 	}
 	now := time.Now()
-	currentTimeStr := now.Format("15:04:05")
-
 	for _, config := range list {
-		// Ensure format consistency (add :00 if missing HH:mm -> HH:mm:00)
-		start := config.StartTime
-		end := config.EndTime
-		if len(start) == 5 {
-			start += ":00"
-		}
-		if len(end) == 5 {
-			end += ":00"
+		if IsSeckillConfigActive(config, now) {
+			return config, nil
 		}
+	}
+	return nil, nil
+}
 
-		if currentTimeStr >= start && currentTimeStr <= end {
+// GetNextSeckillConfig 获得今天下一个开始的秒杀时段，没有时返回 nil
+func (s *SeckillConfigService) GetNextSeckillConfig(ctx context.Context) (*promotion.PromotionSeckillConfig, error) {
+	list, err := s.GetSeckillConfigListByStatus(ctx, 1) // 1=Enabled
+	if err != nil {
+		return nil, err
+	}
+	currentTimeStr := time.Now().Format(time.TimeOnly)
+	for _, config := range list { // 已按开始时间排序
+		if normalizeSeckillTime(config.StartTime) > currentTimeStr {
 			return config, nil
 		}
 	}
 	return nil, nil
 }
+
+// GetSeckillConfigListByIds 获得秒杀时段列表
+func (s *SeckillConfigService) GetSeckillConfigListByIds(ctx context.Context, ids []int64) ([]*promotion.PromotionSeckillConfig, error) {
+	if len(ids) == 0 {
+		return []*promotion.PromotionSeckillConfig{}, nil
+	}
+	q := s.q.PromotionSeckillConfig
+	return q.WithContext(ctx).Where(q.ID.In(ids...)).Order(q.StartTime).Find()
+}
+
+// IsSeckillConfigActive 判断 t 是否处于秒杀时段内。时段按每天的时间点配置
+func IsSeckillConfigActive(config *promotion.PromotionSeckillConfig, t time.Time) bool {
+	timeStr := t.Format(time.TimeOnly)
+	return timeStr >= normalizeSeckillTime(config.StartTime) && timeStr <= normalizeSeckillTime(config.EndTime)
+}
+
+// GetSeckillConfigTimeRange 获得秒杀时段在 day 当天的开始、结束时间
+func GetSeckillConfigTimeRange(config *promotion.PromotionSeckillConfig, day time.Time) (time.Time, time.Time) {
+	return seckillTimeOfDay(day, config.StartTime), seckillTimeOfDay(day, config.EndTime)
+}
+
+func seckillTimeOfDay(day time.Time, timeStr string) time.Time {
+	t, err := time.ParseInLocation(time.TimeOnly, normalizeSeckillTime(timeStr), day.Location())
+	if err != nil {
+		return day
+	}
+	y, m, d := day.Date()
+	return time.Date(y, m, d, t.Hour(), t.Minute(), t.Second(), 0, day.Location())
+}
+
+// normalizeSeckillTime 统一时间点格式为 HH:mm:ss (HH:mm -> HH:mm:00)
+func normalizeSeckillTime(timeStr string) string {
+	if len(timeStr) == 5 {
+		return timeStr + ":00"
+	}
+	return timeStr
+}
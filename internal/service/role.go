@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/redis/go-redis/v9"
 	"github.com/samber/lo"
 
 	"backend-go/internal/api/req"
@@ -14,12 +15,14 @@ import (
 )
 
 type RoleService struct {
-	q *query.Query
+	q   *query.Query
+	rdb *redis.Client
 }
 
-func NewRoleService(q *query.Query) *RoleService {
+func NewRoleService(q *query.Query, rdb *redis.Client) *RoleService {
 	return &RoleService{
-		q: q,
+		q:   q,
+		rdb: rdb,
 	}
 }
 
@@ -35,8 +38,8 @@ func (s *RoleService) CreateRole(ctx context.Context, req *req.RoleSaveReq) (int
 		Sort:      req.Sort,
 		Status:    int32(req.Status),
 		Remark:    req.Remark,
-		Type:      2, // Default Custom
-		DataScope: 1, // Default All
+		Type:      2,            // Default Custom
+		DataScope: DataScopeAll, // Default All
 	}
 
 	err := s.q.SystemRole.WithContext(ctx).Create(role)
@@ -66,7 +69,12 @@ func (s *RoleService) UpdateRole(ctx context.Context, req *req.RoleSaveReq) erro
 		Status: int32(req.Status),
 		Remark: req.Remark,
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	// 状态可能变更，清除该角色下用户的权限缓存
+	evictRoleUserPermissionCache(ctx, s.q, s.rdb, req.ID)
+	return nil
 }
 
 // UpdateRoleStatus 更新角色状态
@@ -80,7 +88,12 @@ func (s *RoleService) UpdateRoleStatus(ctx context.Context, req *req.RoleUpdateS
 		return errors.New("内置角色不能修改状态")
 	}
 	_, err = r.WithContext(ctx).Where(r.ID.Eq(req.ID)).Update(r.Status, req.Status)
-	return err
+	if err != nil {
+		return err
+	}
+	// 清除该角色下用户的权限缓存
+	evictRoleUserPermissionCache(ctx, s.q, s.rdb, req.ID)
+	return nil
 }
 
 // UpdateRoleDataScope 更新数据权限
@@ -117,7 +130,12 @@ func (s *RoleService) DeleteRole(ctx context.Context, id int64) error {
 		return errors.New("角色已分配给用户，无法删除")
 	}
 	_, err = r.WithContext(ctx).Where(r.ID.Eq(id)).Delete()
-	return err
+	if err != nil {
+		return err
+	}
+	// 清除该角色下用户的权限缓存
+	evictRoleUserPermissionCache(ctx, s.q, s.rdb, id)
+	return nil
 }
 
 // GetRole 获得角色
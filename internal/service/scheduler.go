@@ -2,17 +2,25 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
 	"backend-go/internal/model"
+	"backend-go/internal/pkg/core"
 	"backend-go/internal/repo/query"
 
 	"github.com/go-co-op/gocron/v2"
+	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
+// schedulerRefreshChannel 任务变更广播频道，消息内容为任务编号
+const schedulerRefreshChannel = "infra:job:refresh"
+
 // JobHandler is the interface for job handlers
 type JobHandler interface {
 	Execute(ctx context.Context, param string) error
@@ -22,24 +30,31 @@ type JobHandler interface {
 type Scheduler struct {
 	scheduler gocron.Scheduler
 	q         *query.Query
+	rdb       *redis.Client
 	log       *zap.Logger
 	handlers  map[string]JobHandler
 	jobMap    map[int64]gocron.Job
 	mu        sync.RWMutex
+	// ctx 任务执行使用的根上下文，由 Start 传入；不使用调用方（如 HTTP 请求）的上下文
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
 // NewScheduler creates a new Scheduler
-func NewScheduler(q *query.Query, log *zap.Logger) (*Scheduler, error) {
-	s, err := gocron.NewScheduler()
+// 多实例部署时通过 Redis 分布式锁保证每次触发只在一个实例上执行
+func NewScheduler(q *query.Query, rdb *redis.Client, log *zap.Logger) (*Scheduler, error) {
+	s, err := gocron.NewScheduler(gocron.WithDistributedLocker(NewSchedulerLocker(rdb)))
 	if err != nil {
 		return nil, err
 	}
 	return &Scheduler{
 		scheduler: s,
 		q:         q,
+		rdb:       rdb,
 		log:       log,
 		handlers:  make(map[string]JobHandler),
 		jobMap:    make(map[int64]gocron.Job),
+		ctx:       context.Background(),
 	}, nil
 }
 
@@ -52,17 +67,25 @@ func (s *Scheduler) RegisterHandler(name string, handler JobHandler) {
 
 // Start loads all enabled jobs from DB and starts the scheduler
 func (s *Scheduler) Start(ctx context.Context) error {
+	s.mu.Lock()
+	s.ctx = context.WithoutCancel(ctx)
+	s.mu.Unlock()
+
 	jobs, err := s.q.InfraJob.WithContext(ctx).Where(s.q.InfraJob.Status.Eq(JobStatusNormal)).Find()
 	if err != nil {
 		return err
 	}
 
 	for _, job := range jobs {
-		if err := s.scheduleJob(ctx, job); err != nil {
+		if err := s.scheduleJob(job); err != nil {
 			s.log.Error("Failed to schedule job", zap.Int64("jobId", job.ID), zap.Error(err))
 		}
 	}
 
+	subCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	s.cancel = cancel
+	go s.subscribeRefresh(subCtx)
+
 	s.scheduler.Start()
 	s.log.Info("Scheduler started", zap.Int("jobCount", len(jobs)))
 	return nil
@@ -70,11 +93,63 @@ func (s *Scheduler) Start(ctx context.Context) error {
 
 // Shutdown stops the scheduler
 func (s *Scheduler) Shutdown() error {
+	if s.cancel != nil {
+		s.cancel()
+	}
 	return s.scheduler.Shutdown()
 }
 
+// subscribeRefresh 订阅任务变更广播，收到后刷新本实例的任务
+func (s *Scheduler) subscribeRefresh(ctx context.Context) {
+	pubsub := s.rdb.Subscribe(ctx, schedulerRefreshChannel)
+	defer pubsub.Close()
+
+	ch := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			jobID, err := strconv.ParseInt(msg.Payload, 10, 64)
+			if err != nil {
+				s.log.Warn("Invalid job refresh message", zap.String("payload", msg.Payload))
+				continue
+			}
+			if err := s.RefreshJob(ctx, jobID); err != nil {
+				s.log.Error("Failed to refresh job", zap.Int64("jobId", jobID), zap.Error(err))
+			}
+		}
+	}
+}
+
+// PublishRefresh 广播任务变更，所有实例（包括本实例）收到后刷新该任务
+// 广播失败时退化为只刷新本实例
+func (s *Scheduler) PublishRefresh(ctx context.Context, jobID int64) error {
+	if err := s.rdb.Publish(ctx, schedulerRefreshChannel, strconv.FormatInt(jobID, 10)).Err(); err != nil {
+		s.log.Error("Failed to publish job refresh", zap.Int64("jobId", jobID), zap.Error(err))
+		return s.RefreshJob(ctx, jobID)
+	}
+	return nil
+}
+
+// RefreshJob 按数据库中的最新配置重新调度本实例的任务；任务已删除或未开启时仅移除
+func (s *Scheduler) RefreshJob(ctx context.Context, jobID int64) error {
+	if err := s.RemoveJob(jobID); err != nil {
+		return err
+	}
+	err := s.AddJob(ctx, jobID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	return err
+}
+
 // scheduleJob adds a single job to the scheduler
-func (s *Scheduler) scheduleJob(ctx context.Context, job *model.InfraJob) error {
+// 任务闭包固定使用 Start 传入的根上下文，调用方的上下文可能是随请求结束而复用的 gin.Context
+func (s *Scheduler) scheduleJob(job *model.InfraJob) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -83,10 +158,12 @@ func (s *Scheduler) scheduleJob(ctx context.Context, job *model.InfraJob) error
 		return fmt.Errorf("handler not found: %s", job.HandlerName)
 	}
 
+	ctx := s.ctx
 	gocronJob, err := s.scheduler.NewJob(
 		gocron.CronJob(job.CronExpression, false),
 		gocron.NewTask(func() {
-			s.executeJob(ctx, job, handler)
+			// 定时任务跨租户执行
+			s.executeJob(core.WithIgnoreTenant(ctx), job, handler)
 		}),
 		gocron.WithName(fmt.Sprintf("job-%d", job.ID)),
 	)
@@ -99,34 +176,68 @@ func (s *Scheduler) scheduleJob(ctx context.Context, job *model.InfraJob) error
 	return nil
 }
 
-// executeJob runs a job and logs the result
+// JobLogStatus 任务日志状态
+const (
+	JobLogStatusRunning = 0 // 运行中
+	JobLogStatusSuccess = 1 // 成功
+	JobLogStatusFailure = 2 // 失败
+)
+
+// executeJob runs a job, retrying failed attempts up to RetryCount times.
+// 对齐 Java: JobHandlerInvoker 按 retryCount / retryInterval 重试，每次执行单独记录一条日志
 func (s *Scheduler) executeJob(ctx context.Context, job *model.InfraJob, handler JobHandler) {
+	for index := 1; index <= job.RetryCount+1; index++ {
+		if index > 1 && job.RetryInterval > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Duration(job.RetryInterval) * time.Millisecond):
+			}
+		}
+		if err := s.executeOnce(ctx, job, handler, index); err == nil {
+			return
+		}
+	}
+}
+
+// executeOnce runs a single attempt of a job and logs the result
+func (s *Scheduler) executeOnce(ctx context.Context, job *model.InfraJob, handler JobHandler, index int) (err error) {
 	beginTime := time.Now()
 
 	logRecord := &model.InfraJobLog{
 		JobID:        job.ID,
 		HandlerName:  job.HandlerName,
 		HandlerParam: job.HandlerParam,
-		ExecuteIndex: 1,
+		ExecuteIndex: index,
 		BeginTime:    beginTime,
-		Status:       0,
+		Status:       JobLogStatusRunning,
 	}
 	_ = s.q.InfraJobLog.WithContext(ctx).Create(logRecord)
 
-	var status int
-	var result string
-	err := handler.Execute(ctx, job.HandlerParam)
+	execCtx := ctx
+	if job.MonitorTimeout != nil && *job.MonitorTimeout > 0 {
+		var cancel context.CancelFunc
+		execCtx, cancel = context.WithTimeout(ctx, time.Duration(*job.MonitorTimeout)*time.Millisecond)
+		defer cancel()
+	}
+
+	err = s.invokeHandler(execCtx, job, handler)
+	if err == nil && execCtx.Err() != nil {
+		err = fmt.Errorf("job execution timeout: %w", execCtx.Err())
+	}
 	endTime := time.Now()
 	duration := int(endTime.Sub(beginTime).Milliseconds())
 
+	var status int
+	var result string
 	if err != nil {
-		status = 2
+		status = JobLogStatusFailure
 		result = err.Error()
-		s.log.Error("Job execution failed", zap.Int64("jobId", job.ID), zap.Error(err))
+		s.log.Error("Job execution failed", zap.Int64("jobId", job.ID), zap.Int("executeIndex", index), zap.Error(err))
 	} else {
-		status = 1
+		status = JobLogStatusSuccess
 		result = "success"
-		s.log.Info("Job execution completed", zap.Int64("jobId", job.ID), zap.Int("duration", duration))
+		s.log.Info("Job execution completed", zap.Int64("jobId", job.ID), zap.Int("executeIndex", index), zap.Int("duration", duration))
 	}
 
 	_, _ = s.q.InfraJobLog.WithContext(ctx).Where(s.q.InfraJobLog.ID.Eq(logRecord.ID)).Updates(map[string]interface{}{
@@ -135,6 +246,18 @@ func (s *Scheduler) executeJob(ctx context.Context, job *model.InfraJob, handler
 		"status":   status,
 		"result":   result,
 	})
+	return err
+}
+
+// invokeHandler calls the handler, converting a panic into an error
+func (s *Scheduler) invokeHandler(ctx context.Context, job *model.InfraJob, handler JobHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.log.Error("Job handler panic", zap.Int64("jobId", job.ID), zap.Any("panic", r), zap.Stack("stack"))
+			err = fmt.Errorf("job handler panic: %v", r)
+		}
+	}()
+	return handler.Execute(ctx, job.HandlerParam)
 }
 
 // AddJob adds a new job to the scheduler
@@ -146,7 +269,7 @@ func (s *Scheduler) AddJob(ctx context.Context, jobID int64) error {
 	if job.Status != JobStatusNormal {
 		return nil
 	}
-	return s.scheduleJob(ctx, job)
+	return s.scheduleJob(job)
 }
 
 // RemoveJob removes a job from the scheduler
@@ -167,12 +290,9 @@ func (s *Scheduler) RemoveJob(jobID int64) error {
 	return nil
 }
 
-// UpdateJobStatus handles status changes
+// UpdateJobStatus handles status changes on all instances
 func (s *Scheduler) UpdateJobStatus(ctx context.Context, jobID int64, status int) error {
-	if status == JobStatusNormal {
-		return s.AddJob(ctx, jobID)
-	}
-	return s.RemoveJob(jobID)
+	return s.PublishRefresh(ctx, jobID)
 }
 
 // TriggerJob executes a job immediately
@@ -189,6 +309,7 @@ func (s *Scheduler) TriggerJob(ctx context.Context, jobID int64) error {
 		return fmt.Errorf("handler not found: %s", job.HandlerName)
 	}
 
-	go s.executeJob(ctx, job, handler)
+	// 脱离请求上下文，避免请求结束后任务被取消
+	go s.executeJob(core.WithIgnoreTenant(context.WithoutCancel(ctx)), job, handler)
 	return nil
 }
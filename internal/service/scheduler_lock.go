@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	schedulerLockKeyPrefix = "infra:job:lock:"
+	// schedulerLockTimeout 锁的最长持有时间，防止实例宕机后锁无法释放
+	schedulerLockTimeout = 10 * time.Minute
+	// schedulerLockMinHold 锁的最短持有时间，避免各实例时钟偏差导致同一触发点被重复执行
+	schedulerLockMinHold = 5 * time.Second
+)
+
+// schedulerUnlockScript 仅当锁仍由自己持有时才释放；未达到最短持有时间的，缩短过期时间而不是立即删除
+var schedulerUnlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+local ttl = tonumber(ARGV[2])
+if ttl > 0 then
+	return redis.call("PEXPIRE", KEYS[1], ttl)
+end
+return redis.call("DEL", KEYS[1])
+`)
+
+// SchedulerLocker 基于 Redis 的定时任务分布式锁，保证多实例部署时每次触发只在一个实例上执行
+type SchedulerLocker struct {
+	rdb *redis.Client
+}
+
+func NewSchedulerLocker(rdb *redis.Client) *SchedulerLocker {
+	return &SchedulerLocker{rdb: rdb}
+}
+
+// Lock 实现 gocron.Locker，key 为任务名称
+func (l *SchedulerLocker) Lock(ctx context.Context, key string) (gocron.Lock, error) {
+	token, err := newSchedulerLockToken()
+	if err != nil {
+		return nil, err
+	}
+	lockKey := schedulerLockKeyPrefix + key
+	acquired, err := l.rdb.SetNX(ctx, lockKey, token, schedulerLockTimeout).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire lock: %w", err)
+	}
+	if !acquired {
+		return nil, fmt.Errorf("lock already held for job %s", key)
+	}
+	return &schedulerLock{
+		rdb:      l.rdb,
+		key:      lockKey,
+		token:    token,
+		lockedAt: time.Now(),
+	}, nil
+}
+
+type schedulerLock struct {
+	rdb      *redis.Client
+	key      string
+	token    string
+	lockedAt time.Time
+}
+
+// Unlock 实现 gocron.Lock
+func (l *schedulerLock) Unlock(ctx context.Context) error {
+	remaining := schedulerLockMinHold - time.Since(l.lockedAt)
+	if remaining < 0 {
+		remaining = 0
+	}
+	return schedulerUnlockScript.Run(ctx, l.rdb, []string{l.key}, l.token, remaining.Milliseconds()).Err()
+}
+
+func newSchedulerLockToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -2,38 +2,264 @@ package aliyun
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"backend-go/internal/model"
 	"backend-go/internal/service/sms/client"
 )
 
+const (
+	// DefaultEndpoint 阿里云短信服务地址
+	DefaultEndpoint = "https://dysmsapi.aliyuncs.com"
+
+	apiVersion = "2017-05-25"
+	regionId   = "cn-hangzhou"
+	// codeOK 阿里云接口成功的响应码
+	codeOK = "OK"
+)
+
+// SmsClient 阿里云短信客户端，使用 RPC 风格接口与 HMAC-SHA1 签名
+// 对应 Java: AliyunSmsClient
 type SmsClient struct {
-	id        int64
-	apiKey    string
-	apiSecret string
-	signature string
+	id         int64
+	apiKey     string // AccessKey ID
+	apiSecret  string // AccessKey Secret
+	signature  string
+	endpoint   string
+	httpClient *http.Client
+}
+
+// Option 客户端选项
+type Option func(*SmsClient)
+
+// WithEndpoint 指定服务地址，用于对接本地模拟服务
+func WithEndpoint(endpoint string) Option {
+	return func(c *SmsClient) {
+		c.endpoint = strings.TrimRight(endpoint, "/")
+	}
 }
 
-func NewSmsClient(channel *model.SystemSmsChannel) *SmsClient {
-	return &SmsClient{
-		id:        channel.ID,
-		apiKey:    channel.ApiKey,
-		apiSecret: channel.ApiSecret,
-		signature: channel.Signature,
+// WithHTTPClient 指定 HTTP 客户端
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *SmsClient) {
+		c.httpClient = httpClient
 	}
 }
 
+func NewSmsClient(channel *model.SystemSmsChannel, opts ...Option) *SmsClient {
+	c := &SmsClient{
+		id:         channel.ID,
+		apiKey:     channel.ApiKey,
+		apiSecret:  channel.ApiSecret,
+		signature:  channel.Signature,
+		endpoint:   DefaultEndpoint,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
 func (c *SmsClient) GetId() int64 {
 	return c.id
 }
 
-func (c *SmsClient) SendSms(ctx context.Context, sendLogId int64, mobile string, apiTemplateId string, templateParams map[string]interface{}) (*client.SmsSendResp, error) {
-	// TODO: Integrate Aliyun SDK
-	// For now, return mock success or error to avoid compilation failure if SDK missing
+// SendSms 发送短信，参考 https://help.aliyun.com/document_detail/419273.html
+func (c *SmsClient) SendSms(ctx context.Context, sendLogId int64, mobile string, apiTemplateId string, templateParams []client.KeyValue) (*client.SmsSendResp, error) {
+	params := make(map[string]interface{}, len(templateParams))
+	for _, param := range templateParams {
+		params[param.Key] = param.Value
+	}
+	templateParam, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Code      string `json:"Code"`
+		Message   string `json:"Message"`
+		RequestId string `json:"RequestId"`
+		BizId     string `json:"BizId"`
+	}
+	if err := c.request(ctx, "SendSms", map[string]string{
+		"PhoneNumbers":  mobile,
+		"SignName":      c.signature,
+		"TemplateCode":  apiTemplateId,
+		"TemplateParam": string(templateParam),
+		"OutId":         strconv.FormatInt(sendLogId, 10),
+	}, &result); err != nil {
+		return nil, err
+	}
 	return &client.SmsSendResp{
-		ApiSendCode:  "SUCCESS",
-		ApiSendMsg:   "Aliyun Mock Success",
-		ApiRequestId: "ALIYUN_MOCK_ID",
-		ApiSerialNo:  "ALIYUN_MOCK_SERIAL",
+		Success:      result.Code == codeOK,
+		ApiSendCode:  result.Code,
+		ApiSendMsg:   result.Message,
+		ApiRequestId: result.RequestId,
+		ApiSerialNo:  result.BizId,
 	}, nil
 }
+
+// ParseSmsReceiveStatus 解析短信状态报告，参考 https://help.aliyun.com/document_detail/101867.html
+func (c *SmsClient) ParseSmsReceiveStatus(ctx context.Context, body []byte) ([]*client.SmsReceiveResp, error) {
+	var reports []struct {
+		PhoneNumber string `json:"phone_number"`
+		ReportTime  string `json:"report_time"`
+		Success     bool   `json:"success"`
+		ErrCode     string `json:"err_code"`
+		ErrMsg      string `json:"err_msg"`
+		BizId       string `json:"biz_id"`
+		OutId       string `json:"out_id"`
+	}
+	if err := json.Unmarshal(body, &reports); err != nil {
+		return nil, fmt.Errorf("parse aliyun sms report failed: %w", err)
+	}
+	list := make([]*client.SmsReceiveResp, 0, len(reports))
+	for _, report := range reports {
+		receiveTime, _ := time.ParseInLocation(time.DateTime, report.ReportTime, time.Local)
+		logId, _ := strconv.ParseInt(report.OutId, 10, 64)
+		list = append(list, &client.SmsReceiveResp{
+			Success:     report.Success,
+			ErrorCode:   report.ErrCode,
+			ErrorMsg:    report.ErrMsg,
+			Mobile:      report.PhoneNumber,
+			ReceiveTime: receiveTime,
+			SerialNo:    report.BizId,
+			LogId:       logId,
+		})
+	}
+	return list, nil
+}
+
+// GetSmsTemplate 查询短信模板审核状态，参考 https://help.aliyun.com/document_detail/419289.html
+func (c *SmsClient) GetSmsTemplate(ctx context.Context, apiTemplateId string) (*client.SmsTemplateResp, error) {
+	var result struct {
+		Code            string `json:"Code"`
+		Message         string `json:"Message"`
+		TemplateCode    string `json:"TemplateCode"`
+		TemplateContent string `json:"TemplateContent"`
+		TemplateStatus  int    `json:"TemplateStatus"`
+		Reason          string `json:"Reason"`
+	}
+	if err := c.request(ctx, "QuerySmsTemplate", map[string]string{
+		"TemplateCode": apiTemplateId,
+	}, &result); err != nil {
+		return nil, err
+	}
+	if result.Code != codeOK {
+		return nil, fmt.Errorf("aliyun query sms template failed: %s %s", result.Code, result.Message)
+	}
+	return &client.SmsTemplateResp{
+		Id:          result.TemplateCode,
+		Content:     result.TemplateContent,
+		AuditStatus: convertAuditStatus(result.TemplateStatus),
+		AuditReason: result.Reason,
+	}, nil
+}
+
+// convertAuditStatus 0：审核中；1：审核通过；2：审核失败
+func convertAuditStatus(status int) int {
+	switch status {
+	case 1:
+		return client.SmsTemplateAuditStatusSuccess
+	case 2:
+		return client.SmsTemplateAuditStatusFail
+	default:
+		return client.SmsTemplateAuditStatusChecking
+	}
+}
+
+// request 发起 RPC 请求。业务失败（Code 非 OK）不返回 error，由调用方根据 Code 判断
+func (c *SmsClient) request(ctx context.Context, action string, bizParams map[string]string, result interface{}) error {
+	nonce, err := signatureNonce()
+	if err != nil {
+		return err
+	}
+	params := map[string]string{
+		"AccessKeyId":      c.apiKey,
+		"Action":           action,
+		"Format":           "JSON",
+		"RegionId":         regionId,
+		"SignatureMethod":  "HMAC-SHA1",
+		"SignatureNonce":   nonce,
+		"SignatureVersion": "1.0",
+		"Timestamp":        time.Now().UTC().Format("2006-01-02T15:04:05Z"),
+		"Version":          apiVersion,
+	}
+	for k, v := range bizParams {
+		params[k] = v
+	}
+	query := canonicalizedQuery(params)
+	query += "&Signature=" + percentEncode(sign(http.MethodPost, query, c.apiSecret))
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+"/", strings.NewReader(query))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("aliyun %s failed: http %d %s", action, httpResp.StatusCode, body)
+	}
+	return nil
+}
+
+// sign 计算 RPC 签名：StringToSign = Method & percentEncode("/") & percentEncode(CanonicalizedQueryString)
+// 参考 https://help.aliyun.com/document_detail/315526.html
+func sign(method, canonicalizedQuery, secret string) string {
+	stringToSign := method + "&" + percentEncode("/") + "&" + percentEncode(canonicalizedQuery)
+	mac := hmac.New(sha1.New, []byte(secret+"&"))
+	mac.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// canonicalizedQuery 按参数名排序后拼接
+func canonicalizedQuery(params map[string]string) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, percentEncode(k)+"="+percentEncode(params[k]))
+	}
+	return strings.Join(pairs, "&")
+}
+
+// percentEncode 阿里云要求的 URL 编码：空格为 %20，* 为 %2A，~ 不编码
+func percentEncode(s string) string {
+	s = url.QueryEscape(s)
+	s = strings.ReplaceAll(s, "+", "%20")
+	s = strings.ReplaceAll(s, "*", "%2A")
+	return strings.ReplaceAll(s, "%7E", "~")
+}
+
+func signatureNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
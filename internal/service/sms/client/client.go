@@ -1,19 +1,62 @@
 package client
 
-import "context"
+import (
+	"context"
+	"time"
+)
+
+// KeyValue 模板参数，保持模板中参数的顺序（腾讯云等渠道按顺序传参）
+type KeyValue struct {
+	Key   string
+	Value interface{}
+}
 
 // SmsSendResp 短信发送结果
 type SmsSendResp struct {
+	Success      bool // 是否成功，失败时 ApiSendCode、ApiSendMsg 为渠道返回的错误码、错误提示
 	ApiSendCode  string
 	ApiSendMsg   string
 	ApiRequestId string
 	ApiSerialNo  string
 }
 
+// SmsReceiveResp 短信接收状态，由渠道回调解析得到
+// 对应 Java: SmsReceiveRespDTO
+type SmsReceiveResp struct {
+	Success     bool      // 是否接收成功
+	ErrorCode   string    // 渠道返回的状态码
+	ErrorMsg    string    // 渠道返回的提示
+	Mobile      string    // 手机号
+	ReceiveTime time.Time // 用户接收时间
+	SerialNo    string    // 发送序号，对应 SystemSmsLog.ApiSerialNo
+	LogId       int64     // 短信日志编号，发送时通过渠道透传
+}
+
+// 短信模板的审核状态
+// 对应 Java: SmsTemplateAuditStatusEnum
+const (
+	SmsTemplateAuditStatusChecking = 1
+	SmsTemplateAuditStatusSuccess  = 2
+	SmsTemplateAuditStatusFail     = 3
+)
+
+// SmsTemplateResp 渠道的短信模板
+// 对应 Java: SmsTemplateRespDTO
+type SmsTemplateResp struct {
+	Id          string `json:"id"`
+	Content     string `json:"content"`
+	AuditStatus int    `json:"auditStatus"`
+	AuditReason string `json:"auditReason"`
+}
+
 // SmsClient 短信客户端接口
 type SmsClient interface {
 	// GetId 获得渠道编号
 	GetId() int64
 	// SendSms 发送消息
-	SendSms(ctx context.Context, sendLogId int64, mobile string, apiTemplateId string, templateParams map[string]interface{}) (*SmsSendResp, error)
+	SendSms(ctx context.Context, sendLogId int64, mobile string, apiTemplateId string, templateParams []KeyValue) (*SmsSendResp, error)
+	// ParseSmsReceiveStatus 解析渠道回调的接收状态
+	ParseSmsReceiveStatus(ctx context.Context, body []byte) ([]*SmsReceiveResp, error)
+	// GetSmsTemplate 查询渠道的短信模板（含审核状态）
+	GetSmsTemplate(ctx context.Context, apiTemplateId string) (*SmsTemplateResp, error)
 }
@@ -29,7 +29,7 @@ func (c *SmsClient) GetId() int64 {
 	return c.id
 }
 
-func (c *SmsClient) SendSms(ctx context.Context, sendLogId int64, mobile string, apiTemplateId string, templateParams map[string]interface{}) (*client.SmsSendResp, error) {
+func (c *SmsClient) SendSms(ctx context.Context, sendLogId int64, mobile string, apiTemplateId string, templateParams []client.KeyValue) (*client.SmsSendResp, error) {
 	zap.L().Info("Debug Sms Client Send Sms",
 		zap.Int64("sendLogId", sendLogId),
 		zap.String("mobile", mobile),
@@ -37,9 +37,23 @@ func (c *SmsClient) SendSms(ctx context.Context, sendLogId int64, mobile string,
 		zap.Any("params", templateParams),
 	)
 	return &client.SmsSendResp{
+		Success:      true,
 		ApiSendCode:  "SUCCESS",
 		ApiSendMsg:   "Debug Send Success",
 		ApiRequestId: "DEBUG_REQUEST_ID",
 		ApiSerialNo:  "DEBUG_SERIAL_NO",
 	}, nil
 }
+
+// ParseSmsReceiveStatus 调试渠道没有回调
+func (c *SmsClient) ParseSmsReceiveStatus(ctx context.Context, body []byte) ([]*client.SmsReceiveResp, error) {
+	return nil, nil
+}
+
+// GetSmsTemplate 调试渠道的模板始终审核通过
+func (c *SmsClient) GetSmsTemplate(ctx context.Context, apiTemplateId string) (*client.SmsTemplateResp, error) {
+	return &client.SmsTemplateResp{
+		Id:          apiTemplateId,
+		AuditStatus: client.SmsTemplateAuditStatusSuccess,
+	}, nil
+}
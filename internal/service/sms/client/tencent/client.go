@@ -1,41 +1,325 @@
 package tencent
 
 import (
+	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"backend-go/internal/model"
 	"backend-go/internal/service/sms/client"
 )
 
+const (
+	// DefaultEndpoint 腾讯云短信服务地址
+	DefaultEndpoint = "https://sms.tencentcloudapi.com"
+
+	service    = "sms"
+	apiVersion = "2021-01-11"
+	region     = "ap-guangzhou"
+	// codeOK 腾讯云发送成功的状态码
+	codeOK = "Ok"
+	// chinaPrefix 腾讯云要求 E.164 格式的手机号
+	chinaPrefix = "+86"
+	contentType = "application/json; charset=utf-8"
+)
+
+// SmsClient 腾讯云短信客户端，使用 API 3.0 与 TC3-HMAC-SHA256 签名
+// 对应 Java: TencentSmsClient
 type SmsClient struct {
-	id        int64
-	sdkAppId  string
-	apiKey    string
-	apiSecret string
-	signature string
+	id         int64
+	sdkAppId   string
+	apiKey     string // SecretId
+	apiSecret  string // SecretKey
+	signature  string
+	endpoint   string
+	httpClient *http.Client
+}
+
+// Option 客户端选项
+type Option func(*SmsClient)
+
+// WithEndpoint 指定服务地址，用于对接本地模拟服务
+func WithEndpoint(endpoint string) Option {
+	return func(c *SmsClient) {
+		c.endpoint = strings.TrimRight(endpoint, "/")
+	}
 }
 
-func NewSmsClient(channel *model.SystemSmsChannel) *SmsClient {
-	return &SmsClient{
-		id:        channel.ID,
-		apiKey:    channel.ApiKey,    // SecretId
-		apiSecret: channel.ApiSecret, // SecretKey
-		signature: channel.Signature,
-		// sdkAppId usually also needed, might be in Extra or Code?
-		// Java uses properties. For now, assume it's part of config or parsed from somewhere.
+// WithHTTPClient 指定 HTTP 客户端
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *SmsClient) {
+		c.httpClient = httpClient
 	}
 }
 
+// NewSmsClient 创建客户端
+// 与 Java 一致，渠道的 apiKey 格式为 "SecretId SdkAppId"，以空格分隔
+func NewSmsClient(channel *model.SystemSmsChannel, opts ...Option) *SmsClient {
+	secretId, sdkAppId, _ := strings.Cut(strings.TrimSpace(channel.ApiKey), " ")
+	c := &SmsClient{
+		id:         channel.ID,
+		sdkAppId:   strings.TrimSpace(sdkAppId),
+		apiKey:     secretId,
+		apiSecret:  channel.ApiSecret,
+		signature:  channel.Signature,
+		endpoint:   DefaultEndpoint,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
 func (c *SmsClient) GetId() int64 {
 	return c.id
 }
 
-func (c *SmsClient) SendSms(ctx context.Context, sendLogId int64, mobile string, apiTemplateId string, templateParams map[string]interface{}) (*client.SmsSendResp, error) {
-	// TODO: Integrate Tencent SDK
+// sessionContext 发送时透传的上下文，回调时原样返回，用于定位短信日志
+type sessionContext struct {
+	LogId int64 `json:"logId"`
+}
+
+// SendSms 发送短信，参考 https://cloud.tencent.com/document/product/382/55981
+func (c *SmsClient) SendSms(ctx context.Context, sendLogId int64, mobile string, apiTemplateId string, templateParams []client.KeyValue) (*client.SmsSendResp, error) {
+	// 腾讯云模板参数按顺序传递，且均为字符串
+	paramSet := make([]string, 0, len(templateParams))
+	for _, param := range templateParams {
+		paramSet = append(paramSet, fmt.Sprint(param.Value))
+	}
+	session, _ := json.Marshal(sessionContext{LogId: sendLogId})
+
+	var result struct {
+		Response struct {
+			Error         *apiError `json:"Error"`
+			RequestId     string    `json:"RequestId"`
+			SendStatusSet []struct {
+				SerialNo    string `json:"SerialNo"`
+				PhoneNumber string `json:"PhoneNumber"`
+				Code        string `json:"Code"`
+				Message     string `json:"Message"`
+			} `json:"SendStatusSet"`
+		} `json:"Response"`
+	}
+	if err := c.request(ctx, "SendSms", map[string]interface{}{
+		"PhoneNumberSet":   []string{chinaPrefix + mobile},
+		"SmsSdkAppId":      c.sdkAppId,
+		"SignName":         c.signature,
+		"TemplateId":       apiTemplateId,
+		"TemplateParamSet": paramSet,
+		"SessionContext":   string(session),
+	}, &result); err != nil {
+		return nil, err
+	}
+
+	response := result.Response
+	if response.Error != nil {
+		return &client.SmsSendResp{
+			Success:      false,
+			ApiSendCode:  response.Error.Code,
+			ApiSendMsg:   response.Error.Message,
+			ApiRequestId: response.RequestId,
+		}, nil
+	}
+	if len(response.SendStatusSet) == 0 {
+		return nil, fmt.Errorf("tencent send sms failed: empty SendStatusSet, requestId %s", response.RequestId)
+	}
+	status := response.SendStatusSet[0]
 	return &client.SmsSendResp{
-		ApiSendCode:  "SUCCESS",
-		ApiSendMsg:   "Tencent Mock Success",
-		ApiRequestId: "TENCENT_MOCK_ID",
-		ApiSerialNo:  "TENCENT_MOCK_SERIAL",
+		Success:      status.Code == codeOK,
+		ApiSendCode:  status.Code,
+		ApiSendMsg:   status.Message,
+		ApiRequestId: response.RequestId,
+		ApiSerialNo:  status.SerialNo,
+	}, nil
+}
+
+// ParseSmsReceiveStatus 解析短信下发状态回调，参考 https://cloud.tencent.com/document/product/382/52077
+func (c *SmsClient) ParseSmsReceiveStatus(ctx context.Context, body []byte) ([]*client.SmsReceiveResp, error) {
+	var reports []struct {
+		UserReceiveTime string          `json:"user_receive_time"`
+		Mobile          string          `json:"mobile"`
+		ReportStatus    string          `json:"report_status"`
+		ErrMsg          string          `json:"errmsg"`
+		Description     string          `json:"description"`
+		Sid             string          `json:"sid"`
+		Ext             json.RawMessage `json:"ext"`
+	}
+	if err := json.Unmarshal(body, &reports); err != nil {
+		return nil, fmt.Errorf("parse tencent sms report failed: %w", err)
+	}
+	list := make([]*client.SmsReceiveResp, 0, len(reports))
+	for _, report := range reports {
+		receiveTime, _ := time.ParseInLocation(time.DateTime, report.UserReceiveTime, time.Local)
+		list = append(list, &client.SmsReceiveResp{
+			Success:     report.ReportStatus == "SUCCESS",
+			ErrorCode:   report.ErrMsg,
+			ErrorMsg:    report.Description,
+			Mobile:      report.Mobile,
+			ReceiveTime: receiveTime,
+			SerialNo:    report.Sid,
+			LogId:       parseSessionLogId(report.Ext),
+		})
+	}
+	return list, nil
+}
+
+// parseSessionLogId 解析回调中的 SessionContext，兼容对象与 JSON 字符串两种格式
+func parseSessionLogId(ext json.RawMessage) int64 {
+	if len(ext) == 0 {
+		return 0
+	}
+	var raw string
+	if err := json.Unmarshal(ext, &raw); err == nil {
+		ext = json.RawMessage(raw)
+	}
+	var session sessionContext
+	if err := json.Unmarshal(ext, &session); err != nil {
+		return 0
+	}
+	return session.LogId
+}
+
+// GetSmsTemplate 查询短信模板审核状态，参考 https://cloud.tencent.com/document/product/382/52067
+func (c *SmsClient) GetSmsTemplate(ctx context.Context, apiTemplateId string) (*client.SmsTemplateResp, error) {
+	templateId, err := strconv.ParseUint(apiTemplateId, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tencent template id: %s", apiTemplateId)
+	}
+	var result struct {
+		Response struct {
+			Error                     *apiError `json:"Error"`
+			DescribeTemplateStatusSet []struct {
+				TemplateId      uint64 `json:"TemplateId"`
+				TemplateContent string `json:"TemplateContent"`
+				StatusCode      int    `json:"StatusCode"`
+				ReviewReply     string `json:"ReviewReply"`
+			} `json:"DescribeTemplateStatusSet"`
+		} `json:"Response"`
+	}
+	if err := c.request(ctx, "DescribeSmsTemplateList", map[string]interface{}{
+		"TemplateIdSet": []uint64{templateId},
+		"International": 0,
+	}, &result); err != nil {
+		return nil, err
+	}
+	if result.Response.Error != nil {
+		return nil, fmt.Errorf("tencent query sms template failed: %s %s", result.Response.Error.Code, result.Response.Error.Message)
+	}
+	if len(result.Response.DescribeTemplateStatusSet) == 0 {
+		return nil, nil
+	}
+	template := result.Response.DescribeTemplateStatusSet[0]
+	return &client.SmsTemplateResp{
+		Id:          strconv.FormatUint(template.TemplateId, 10),
+		Content:     template.TemplateContent,
+		AuditStatus: convertAuditStatus(template.StatusCode),
+		AuditReason: template.ReviewReply,
 	}, nil
 }
+
+// convertAuditStatus 0：审核通过；1：审核中；-1：审核未通过或审核失败
+func convertAuditStatus(status int) int {
+	switch status {
+	case 0:
+		return client.SmsTemplateAuditStatusSuccess
+	case 1:
+		return client.SmsTemplateAuditStatusChecking
+	default:
+		return client.SmsTemplateAuditStatusFail
+	}
+}
+
+// apiError 腾讯云接口错误，参考 https://cloud.tencent.com/document/product/382/55981#6.-.E9.94.99.E8.AF.AF.E7.A0.81
+type apiError struct {
+	Code    string `json:"Code"`
+	Message string `json:"Message"`
+}
+
+// request 发起 API 3.0 请求。业务失败通过 Response.Error 返回，由调用方判断
+func (c *SmsClient) request(ctx context.Context, action string, body map[string]interface{}, result interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	u, err := url.Parse(c.endpoint)
+	if err != nil {
+		return err
+	}
+	timestamp := time.Now().Unix()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+"/", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", contentType)
+	httpReq.Header.Set("Host", u.Host)
+	httpReq.Header.Set("X-TC-Action", action)
+	httpReq.Header.Set("X-TC-Timestamp", strconv.FormatInt(timestamp, 10))
+	httpReq.Header.Set("X-TC-Version", apiVersion)
+	httpReq.Header.Set("X-TC-Region", region)
+	httpReq.Header.Set("Authorization", authorization(c.apiKey, c.apiSecret, u.Host, payload, timestamp))
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(respBody, result); err != nil {
+		return fmt.Errorf("tencent %s failed: http %d %s", action, httpResp.StatusCode, respBody)
+	}
+	return nil
+}
+
+// authorization 计算 TC3-HMAC-SHA256 签名，参考 https://cloud.tencent.com/document/api/382/52072
+func authorization(secretId, secretKey, host string, payload []byte, timestamp int64) string {
+	date := time.Unix(timestamp, 0).UTC().Format(time.DateOnly)
+	signedHeaders := "content-type;host"
+	canonicalRequest := strings.Join([]string{
+		http.MethodPost,
+		"/",
+		"",
+		"content-type:" + contentType + "\n" + "host:" + host + "\n",
+		signedHeaders,
+		sha256Hex(payload),
+	}, "\n")
+	credentialScope := date + "/" + service + "/tc3_request"
+	stringToSign := strings.Join([]string{
+		"TC3-HMAC-SHA256",
+		strconv.FormatInt(timestamp, 10),
+		credentialScope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	secretDate := hmacSHA256([]byte("TC3"+secretKey), date)
+	secretService := hmacSHA256(secretDate, service)
+	secretSigning := hmacSHA256(secretService, "tc3_request")
+	signature := hex.EncodeToString(hmacSHA256(secretSigning, stringToSign))
+	return fmt.Sprintf("TC3-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		secretId, credentialScope, signedHeaders, signature)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"strings"
 	"sync"
 
 	"backend-go/internal/model"
@@ -13,6 +14,8 @@ import (
 type SmsClientFactory struct {
 	// channelId -> SmsClient
 	clients sync.Map
+	// channelId -> 创建客户端时的渠道配置，用于判断配置是否变更
+	properties sync.Map
 }
 
 func NewSmsClientFactory() *SmsClientFactory {
@@ -29,10 +32,25 @@ func (f *SmsClientFactory) GetClient(channelId int64) client.SmsClient {
 func (f *SmsClientFactory) CreateOrUpdateClient(channel *model.SystemSmsChannel) {
 	c := f.createClient(channel)
 	f.clients.Store(channel.ID, c)
+	f.properties.Store(channel.ID, channelProperties(channel))
+}
+
+// GetOrCreateClient 获得渠道对应的客户端，不存在或渠道配置变更时重新创建
+// 对应 Java: SmsClientFactoryImpl#createOrUpdateSmsClient
+func (f *SmsClientFactory) GetOrCreateClient(channel *model.SystemSmsChannel) client.SmsClient {
+	if v, ok := f.properties.Load(channel.ID); !ok || v.(string) != channelProperties(channel) {
+		f.CreateOrUpdateClient(channel)
+	}
+	return f.GetClient(channel.ID)
+}
+
+func channelProperties(channel *model.SystemSmsChannel) string {
+	return strings.Join([]string{channel.Code, channel.ApiKey, channel.ApiSecret, channel.Signature}, "\x00")
 }
 
 func (f *SmsClientFactory) createClient(channel *model.SystemSmsChannel) client.SmsClient {
-	switch channel.Code {
+	// 渠道编码兼容 Java 的大写枚举（ALIYUN、TENCENT、DEBUG_DING_TALK）
+	switch strings.ToLower(channel.Code) {
 	case "aliyun":
 		return aliyun.NewSmsClient(channel)
 	case "tencent":
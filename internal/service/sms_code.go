@@ -17,17 +17,48 @@ const (
 	SmsCodeExpire         = 5 * time.Minute
 )
 
+// 短信验证码的发送场景
+// 对应 Java: SmsSceneEnum
+const (
+	SmsSceneMemberLogin          = 1  // 会员用户 - 手机号登陆
+	SmsSceneMemberUpdateMobile   = 2  // 会员用户 - 修改手机
+	SmsSceneMemberUpdatePassword = 3  // 会员用户 - 修改密码
+	SmsSceneMemberResetPassword  = 4  // 会员用户 - 忘记密码
+	SmsSceneAdminLogin           = 21 // 后台用户 - 手机号登录
+	SmsSceneAdminRegister        = 22 // 后台用户 - 手机号注册
+	SmsSceneAdminResetPassword   = 23 // 后台用户 - 忘记密码
+)
+
+// smsSceneTemplateCodes 验证码场景对应的短信模板编码
+var smsSceneTemplateCodes = map[int]string{
+	SmsSceneMemberLogin:          "user-sms-login",
+	SmsSceneMemberUpdateMobile:   "user-update-mobile",
+	SmsSceneMemberUpdatePassword: "user-update-password",
+	SmsSceneMemberResetPassword:  "user-reset-password",
+	SmsSceneAdminLogin:           "admin-sms-login",
+	SmsSceneAdminRegister:        "admin-sms-register",
+	SmsSceneAdminResetPassword:   "admin-reset-password",
+}
+
+// smsSceneUserType 验证码场景对应的用户类型
+func smsSceneUserType(scene int) int32 {
+	if scene >= SmsSceneAdminLogin {
+		return UserTypeAdmin
+	}
+	return UserTypeMember
+}
+
 type SmsCodeService struct {
-	q       *query.Query
-	rdb     *redis.Client
-	factory *SmsClientFactory
+	q          *query.Query
+	rdb        *redis.Client
+	smsSendSvc *SmsSendService
 }
 
-func NewSmsCodeService(q *query.Query, rdb *redis.Client, factory *SmsClientFactory) *SmsCodeService {
+func NewSmsCodeService(q *query.Query, rdb *redis.Client, smsSendSvc *SmsSendService) *SmsCodeService {
 	return &SmsCodeService{
-		q:       q,
-		rdb:     rdb,
-		factory: factory,
+		q:          q,
+		rdb:        rdb,
+		smsSendSvc: smsSendSvc,
 	}
 }
 
@@ -57,38 +88,15 @@ func (s *SmsCodeService) SendSmsCode(ctx context.Context, mobile string, scene i
 	}
 
 	// 4. 发送短信
-	// 查询启用的渠道
-	channel, err := s.q.SystemSmsChannel.WithContext(ctx).Where(s.q.SystemSmsChannel.Status.Eq(0)).First()
-	if err != nil {
-		zap.L().Error("No enabled SMS channel found", zap.Error(err))
-		// For development, allow fallback or just return error
-		// return err
-	}
-
-	// Get client from factory
-	client := s.factory.GetClient(channel.ID)
-	if client == nil {
-		zap.L().Info("SMS Client not found in factory, initializing...", zap.Int64("channelId", channel.ID))
-		s.factory.CreateOrUpdateClient(channel)
-		client = s.factory.GetClient(channel.ID)
+	templateCode, ok := smsSceneTemplateCodes[scene]
+	if !ok {
+		return fmt.Errorf("验证码场景(%d) 查找不到配置", scene)
 	}
-
-	if client != nil {
-		// Prepare template params (mock)
-		params := map[string]interface{}{
-			"code": code,
-		}
-		// TODO: Retrieve valid apiTemplateId from SystemSmsTemplate based on scene
-
-		_, err := client.SendSms(ctx, 0, mobile, "TEMPLATE_ID", params)
-		if err != nil {
-			zap.L().Error("Send SMS failed", zap.Error(err))
-			return err
-		}
-	} else {
-		zap.L().Warn("No SMS client available for channel", zap.String("code", channel.Code))
+	if _, err := s.smsSendSvc.SendSingleSms(ctx, mobile, 0, smsSceneUserType(scene), templateCode,
+		map[string]interface{}{"code": code}); err != nil {
+		zap.L().Error("Send SMS code failed", zap.String("mobile", mobile), zap.Int("scene", scene), zap.Error(err))
+		return err
 	}
-
 	return nil
 }
 
@@ -7,10 +7,28 @@ import (
 	"backend-go/internal/pkg/core"
 	"backend-go/internal/repo/query"
 	"context"
+	"time"
 
 	"github.com/samber/lo"
 )
 
+// 短信发送状态
+// 对应 Java: SmsSendStatusEnum
+const (
+	SmsSendStatusInit    int32 = 0
+	SmsSendStatusSuccess int32 = 10
+	SmsSendStatusFailure int32 = 20
+	SmsSendStatusIgnore  int32 = 30 // 模板或渠道被禁用，不发送
+)
+
+// 短信接收状态
+// 对应 Java: SmsReceiveStatusEnum
+const (
+	SmsReceiveStatusInit    int32 = 0
+	SmsReceiveStatusSuccess int32 = 10
+	SmsReceiveStatusFailure int32 = 20
+)
+
 type SmsLogService struct {
 	q *query.Query
 }
@@ -58,6 +76,82 @@ func (s *SmsLogService) GetSmsLogPage(ctx context.Context, req *req.SmsLogPageRe
 	}, nil
 }
 
+// CreateSmsLog 创建短信日志，isSend 为 false 时记录为忽略发送
+func (s *SmsLogService) CreateSmsLog(ctx context.Context, mobile string, userId int64, userType int32, isSend bool,
+	template *model.SystemSmsTemplate, content string, params map[string]interface{}) (int64, error) {
+	sendStatus := SmsSendStatusInit
+	if !isSend {
+		sendStatus = SmsSendStatusIgnore
+	}
+	log := &model.SystemSmsLog{
+		ChannelId:       template.ChannelId,
+		ChannelCode:     template.ChannelCode,
+		TemplateId:      template.ID,
+		TemplateCode:    template.Code,
+		TemplateType:    template.Type,
+		TemplateContent: content,
+		TemplateParams:  params,
+		ApiTemplateId:   template.ApiTemplateId,
+		Mobile:          mobile,
+		UserId:          userId,
+		UserType:        userType,
+		SendStatus:      sendStatus,
+		ReceiveStatus:   SmsReceiveStatusInit,
+	}
+	if err := s.q.SystemSmsLog.WithContext(ctx).Create(log); err != nil {
+		return 0, err
+	}
+	return log.ID, nil
+}
+
+// UpdateSmsSendResult 更新短信的发送结果
+func (s *SmsLogService) UpdateSmsSendResult(ctx context.Context, id int64, success bool,
+	apiSendCode, apiSendMsg, apiRequestId, apiSerialNo string) error {
+	sendStatus := SmsSendStatusSuccess
+	if !success {
+		sendStatus = SmsSendStatusFailure
+	}
+	l := s.q.SystemSmsLog
+	_, err := l.WithContext(ctx).Where(l.ID.Eq(id)).Updates(map[string]interface{}{
+		"send_status":    sendStatus,
+		"send_time":      time.Now(),
+		"api_send_code":  apiSendCode,
+		"api_send_msg":   apiSendMsg,
+		"api_request_id": apiRequestId,
+		"api_serial_no":  apiSerialNo,
+	})
+	return err
+}
+
+// UpdateSmsReceiveResult 更新短信的接收结果
+// 优先按日志编号更新；渠道未回传日志编号时，按发送序号更新
+func (s *SmsLogService) UpdateSmsReceiveResult(ctx context.Context, id int64, serialNo string, success bool,
+	receiveTime time.Time, apiReceiveCode, apiReceiveMsg string) error {
+	receiveStatus := SmsReceiveStatusSuccess
+	if !success {
+		receiveStatus = SmsReceiveStatusFailure
+	}
+	l := s.q.SystemSmsLog
+	qb := l.WithContext(ctx)
+	if id > 0 {
+		qb = qb.Where(l.ID.Eq(id))
+	} else if serialNo != "" {
+		qb = qb.Where(l.ApiSerialNo.Eq(serialNo))
+	} else {
+		return nil
+	}
+	if receiveTime.IsZero() {
+		receiveTime = time.Now()
+	}
+	_, err := qb.Updates(map[string]interface{}{
+		"receive_status":   receiveStatus,
+		"receive_time":     receiveTime,
+		"api_receive_code": apiReceiveCode,
+		"api_receive_msg":  apiReceiveMsg,
+	})
+	return err
+}
+
 func (s *SmsLogService) convertResp(item *model.SystemSmsLog) *resp.SmsLogRespVO {
 	return &resp.SmsLogRespVO{
 		ID:              item.ID,
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"backend-go/internal/model"
+	"backend-go/internal/pkg/core"
+	"backend-go/internal/repo/query"
+	"backend-go/internal/service/sms/client"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// smsCommonStatusEnable 模板、渠道的开启状态
+const smsCommonStatusEnable int32 = 0
+
+// SmsSendService 短信发送
+// 对应 Java: SmsSendServiceImpl
+type SmsSendService struct {
+	q         *query.Query
+	factory   *SmsClientFactory
+	smsLogSvc *SmsLogService
+}
+
+func NewSmsSendService(q *query.Query, factory *SmsClientFactory, smsLogSvc *SmsLogService) *SmsSendService {
+	return &SmsSendService{
+		q:         q,
+		factory:   factory,
+		smsLogSvc: smsLogSvc,
+	}
+}
+
+// SendSingleSms 发送单条短信，返回短信日志编号
+// 模板或渠道被禁用时只记录日志，不实际发送；渠道返回的发送结果记录在短信日志中
+func (s *SmsSendService) SendSingleSms(ctx context.Context, mobile string, userId int64, userType int32,
+	templateCode string, templateParams map[string]interface{}) (int64, error) {
+	// 校验短信模板、渠道
+	t := s.q.SystemSmsTemplate
+	template, err := t.WithContext(ctx).Where(t.Code.Eq(templateCode)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, core.NewBizError(1002014000, "短信模板不存在") // SMS_SEND_TEMPLATE_NOT_EXISTS
+	}
+	if err != nil {
+		return 0, err
+	}
+	c := s.q.SystemSmsChannel
+	channel, err := c.WithContext(ctx).Where(c.ID.Eq(template.ChannelId)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, core.NewBizError(1002011000, "短信渠道不存在") // SMS_CHANNEL_NOT_EXISTS
+	}
+	if err != nil {
+		return 0, err
+	}
+	if mobile == "" {
+		return 0, core.NewBizError(1002013000, "手机号不存在") // SMS_SEND_MOBILE_NOT_EXISTS
+	}
+	params, err := buildTemplateParams(template, templateParams)
+	if err != nil {
+		return 0, err
+	}
+
+	// 创建发送日志。模板或渠道被禁用时，不发送短信，只记录日志
+	isSend := template.Status == smsCommonStatusEnable && channel.Status == smsCommonStatusEnable
+	content := formatTemplateContent(template.Content, templateParams)
+	logId, err := s.smsLogSvc.CreateSmsLog(ctx, mobile, userId, userType, isSend, template, content, templateParams)
+	if err != nil {
+		return 0, err
+	}
+	if isSend {
+		s.doSendSms(ctx, logId, mobile, channel, template.ApiTemplateId, params)
+	}
+	return logId, nil
+}
+
+// doSendSms 调用渠道发送短信，并记录发送结果
+func (s *SmsSendService) doSendSms(ctx context.Context, logId int64, mobile string, channel *model.SystemSmsChannel,
+	apiTemplateId string, params []client.KeyValue) {
+	smsClient := s.factory.GetOrCreateClient(channel)
+	sendResp, err := smsClient.SendSms(ctx, logId, mobile, apiTemplateId, params)
+	if err != nil {
+		// 网络异常等，渠道未返回结果
+		zap.L().Error("send sms failed", zap.Int64("logId", logId), zap.String("mobile", mobile), zap.Error(err))
+		sendResp = &client.SmsSendResp{Success: false, ApiSendCode: "EXCEPTION", ApiSendMsg: err.Error()}
+	} else if !sendResp.Success {
+		zap.L().Warn("send sms rejected by channel", zap.Int64("logId", logId), zap.String("mobile", mobile),
+			zap.String("code", sendResp.ApiSendCode), zap.String("msg", sendResp.ApiSendMsg))
+	}
+	if err := s.smsLogSvc.UpdateSmsSendResult(ctx, logId, sendResp.Success, sendResp.ApiSendCode,
+		sendResp.ApiSendMsg, sendResp.ApiRequestId, sendResp.ApiSerialNo); err != nil {
+		zap.L().Error("update sms send result failed", zap.Int64("logId", logId), zap.Error(err))
+	}
+}
+
+// ReceiveSmsStatus 处理渠道的短信接收状态回调
+// 对应 Java: SmsSendServiceImpl#receiveSmsStatus
+func (s *SmsSendService) ReceiveSmsStatus(ctx context.Context, channelCode string, body []byte) error {
+	// 回调不区分具体渠道配置，取该渠道编码下的任一渠道解析即可
+	c := s.q.SystemSmsChannel
+	channel, err := c.WithContext(ctx).Where(c.Code.Lower().Eq(strings.ToLower(channelCode))).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return core.NewBizError(1002011000, "短信渠道不存在") // SMS_CHANNEL_NOT_EXISTS
+	}
+	if err != nil {
+		return err
+	}
+	receiveList, err := s.factory.GetOrCreateClient(channel).ParseSmsReceiveStatus(ctx, body)
+	if err != nil {
+		return err
+	}
+	for _, receive := range receiveList {
+		if err := s.smsLogSvc.UpdateSmsReceiveResult(ctx, receive.LogId, receive.SerialNo, receive.Success,
+			receive.ReceiveTime, receive.ErrorCode, receive.ErrorMsg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildTemplateParams 按模板中参数的顺序构建参数列表
+func buildTemplateParams(template *model.SystemSmsTemplate, templateParams map[string]interface{}) ([]client.KeyValue, error) {
+	params := make([]client.KeyValue, 0, len(template.Params))
+	for _, key := range template.Params {
+		value, ok := templateParams[key]
+		if !ok || value == nil {
+			return nil, core.NewBizError(1002013001, fmt.Sprintf("模板参数(%s)缺失", key)) // SMS_SEND_MOBILE_TEMPLATE_PARAM_MISS
+		}
+		params = append(params, client.KeyValue{Key: key, Value: value})
+	}
+	return params, nil
+}
+
+// formatTemplateContent 替换模板内容中的 {key} 参数
+func formatTemplateContent(content string, params map[string]interface{}) string {
+	for key, value := range params {
+		content = strings.ReplaceAll(content, "{"+key+"}", fmt.Sprint(value))
+	}
+	return content
+}
@@ -14,12 +14,14 @@ import (
 )
 
 type SmsTemplateService struct {
-	q *query.Query
+	q       *query.Query
+	factory *SmsClientFactory
 }
 
-func NewSmsTemplateService(q *query.Query) *SmsTemplateService {
+func NewSmsTemplateService(q *query.Query, factory *SmsClientFactory) *SmsTemplateService {
 	return &SmsTemplateService{
-		q: q,
+		q:       q,
+		factory: factory,
 	}
 }
 
@@ -97,6 +99,34 @@ func (s *SmsTemplateService) GetSmsTemplate(ctx context.Context, id int64) (*res
 	return s.convertResp(item), nil
 }
 
+// GetSmsTemplateAuditStatus 查询短信模板在渠道的审核状态
+// 对应 Java: SmsTemplateServiceImpl#validateApiTemplate
+func (s *SmsTemplateService) GetSmsTemplateAuditStatus(ctx context.Context, id int64) (*resp.SmsTemplateAuditRespVO, error) {
+	t := s.q.SystemSmsTemplate
+	template, err := t.WithContext(ctx).Where(t.ID.Eq(id)).First()
+	if err != nil {
+		return nil, errors.New("短信模板不存在")
+	}
+	c := s.q.SystemSmsChannel
+	channel, err := c.WithContext(ctx).Where(c.ID.Eq(template.ChannelId)).First()
+	if err != nil {
+		return nil, errors.New("短信渠道不存在")
+	}
+	apiTemplate, err := s.factory.GetOrCreateClient(channel).GetSmsTemplate(ctx, template.ApiTemplateId)
+	if err != nil {
+		return nil, err
+	}
+	if apiTemplate == nil {
+		return nil, core.NewBizError(1002012001, "短信 API 模版不存在") // SMS_TEMPLATE_API_NOT_FOUND
+	}
+	return &resp.SmsTemplateAuditRespVO{
+		ApiTemplateId: apiTemplate.Id,
+		Content:       apiTemplate.Content,
+		AuditStatus:   apiTemplate.AuditStatus,
+		AuditReason:   apiTemplate.AuditReason,
+	}, nil
+}
+
 // GetSmsTemplatePage 获得短信模板分页
 func (s *SmsTemplateService) GetSmsTemplatePage(ctx context.Context, req *req.SmsTemplatePageReq) (*core.PageResult[*resp.SmsTemplateRespVO], error) {
 	t := s.q.SystemSmsTemplate
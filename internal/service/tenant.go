@@ -93,18 +93,7 @@ func (s *TenantService) CreateTenant(ctx context.Context, req *req.TenantCreateR
 			RoleID:   role.ID,
 			TenantID: tenantId, // If UserRole has tenant_id
 		}
-		// check if UserRole has TenantID
-		// Assume yes for now, usually multi-tenant systems propagate it.
-		// If not, remove it.
-		// Let's assume standard GORM model without explicit tenant_id in struct if plugin handles it?
-		// But here we are admin creating for other tenant.
-		// We should use `tx.SystemUserRole.WithContext(ctx)` but we might need to be careful if ctx has current admin's tenant id.
-		// Usually creating tenant is done by Platform Admin (TenantID=0 or 1).
-		// The new records must have New Tenant ID.
-
-		// If using GORM MultiTenant plugin, we usually need `SetTenantId` in context or disable hooks.
-		// Since we are setting fields manually, if plugin overwrites them, it's bad.
-		// Assuming we populate fields manually and simple Create works.
+		// 租户插件只填充为空的 tenant_id，这里显式设置为新租户编号
 		if err := tx.SystemUserRole.WithContext(ctx).Create(userRole); err != nil {
 			return err
 		}
@@ -6,23 +6,32 @@ import (
 	"backend-go/internal/model/trade"
 	"backend-go/internal/pkg/core"
 	"backend-go/internal/repo/query"
+	paySvc "backend-go/internal/service/pay"
 	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
 	"time"
+
+	"go.uber.org/zap"
 )
 
 type TradeAfterSaleService struct {
-	q        *query.Query
-	orderSvc *TradeOrderUpdateService
+	q              *query.Query
+	orderSvc       *TradeOrderUpdateService
+	tradeConfigSvc *TradeConfigService
+	payRefundSvc   *paySvc.PayRefundService
+	messageSvc     *TradeMessageService
 }
 
-func NewTradeAfterSaleService(q *query.Query, orderSvc *TradeOrderUpdateService) *TradeAfterSaleService {
+func NewTradeAfterSaleService(q *query.Query, orderSvc *TradeOrderUpdateService, tradeConfigSvc *TradeConfigService, payRefundSvc *paySvc.PayRefundService, messageSvc *TradeMessageService) *TradeAfterSaleService {
 	return &TradeAfterSaleService{
-		q:        q,
-		orderSvc: orderSvc,
+		q:              q,
+		orderSvc:       orderSvc,
+		tradeConfigSvc: tradeConfigSvc,
+		payRefundSvc:   payRefundSvc,
+		messageSvc:     messageSvc,
 	}
 }
 
@@ -38,6 +47,10 @@ func (s *TradeAfterSaleService) CreateAfterSale(ctx context.Context, userId int6
 	if item.AfterSaleStatus != 0 { // 0: None
 		return 0, fmt.Errorf("该订单项已申请售后")
 	}
+	// 退款金额不能超过订单项分摊优惠后的支付金额
+	if r.RefundPrice > item.PayPrice {
+		return 0, core.NewBizError(1011000102, "申请退款金额错误") // AFTER_SALE_CREATE_FAIL_REFUND_PRICE_ERROR
+	}
 
 	// Fetch Order for OrderNo
 	order, err := s.q.TradeOrder.WithContext(ctx).Where(s.q.TradeOrder.ID.Eq(item.OrderID)).First()
@@ -173,7 +186,7 @@ func (s *TradeAfterSaleService) AgreeAfterSale(ctx context.Context, id int64) er
 		return err
 	}
 
-	return s.q.Transaction(func(tx *query.Query) error {
+	err = s.q.Transaction(func(tx *query.Query) error {
 		// Update AfterSale
 		if _, err := tx.AfterSale.WithContext(ctx).Where(tx.AfterSale.ID.Eq(id)).Updates(trade.AfterSale{
 			Status:    20, // Approved
@@ -187,6 +200,15 @@ func (s *TradeAfterSaleService) AgreeAfterSale(ctx context.Context, id int64) er
 		}
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	// 发送售后审核通过消息
+	if err := s.messageSvc.SendAfterSaleAuditMessage(ctx, as, true); err != nil {
+		zap.L().Error("[AgreeAfterSale][发送售后消息失败]", zap.Int64("afterSaleId", id), zap.Error(err))
+	}
+	return nil
 }
 
 // DisagreeAfterSale 拒绝售后 (审核不通过)
@@ -196,7 +218,7 @@ func (s *TradeAfterSaleService) DisagreeAfterSale(ctx context.Context, req *req.
 		return err
 	}
 
-	return s.q.Transaction(func(tx *query.Query) error {
+	err = s.q.Transaction(func(tx *query.Query) error {
 		// Update AfterSale
 		if _, err := tx.AfterSale.WithContext(ctx).Where(tx.AfterSale.ID.Eq(req.ID)).Updates(trade.AfterSale{
 			Status:      50, // Disagree (Audit Failed)
@@ -212,31 +234,76 @@ func (s *TradeAfterSaleService) DisagreeAfterSale(ctx context.Context, req *req.
 		}
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	// 发送售后审核不通过消息
+	as.AuditReason = req.AuditReason
+	if err := s.messageSvc.SendAfterSaleAuditMessage(ctx, as, false); err != nil {
+		zap.L().Error("[DisagreeAfterSale][发送售后消息失败]", zap.Int64("afterSaleId", req.ID), zap.Error(err))
+	}
+	return nil
 }
 
 // RefundAfterSale 退款
-func (s *TradeAfterSaleService) RefundAfterSale(ctx context.Context, id int64) error {
+func (s *TradeAfterSaleService) RefundAfterSale(ctx context.Context, userIP string, id int64) error {
 	as, err := s.q.AfterSale.WithContext(ctx).Where(s.q.AfterSale.ID.Eq(id)).First()
 	if err != nil {
 		return err
 	}
+	if as.Status == 30 { // Already refunded
+		return fmt.Errorf("售后单已退款")
+	}
 
-	// TODO: Call Pay Service to Refund
+	// 发起退款单，商户退款单号为售后单编号，退款结果通过 UpdateRefunded 回调
+	tradeConfig, err := s.tradeConfigSvc.GetTradeConfig(ctx)
+	if err != nil {
+		return err
+	}
+	payRefundId, err := s.payRefundSvc.CreateRefund(ctx, &req.PayRefundCreateReq{
+		AppID:            tradeConfig.AppID,
+		UserIP:           userIP,
+		MerchantOrderId:  strconv.FormatInt(as.OrderID, 10),
+		MerchantRefundId: strconv.FormatInt(as.ID, 10),
+		Reason:           fmt.Sprintf("退款【%s】", as.SpuName),
+		Price:            as.RefundPrice,
+	})
+	if err != nil {
+		return err
+	}
 
-	return s.q.Transaction(func(tx *query.Query) error {
-		// Update AfterSale
-		if _, err := tx.AfterSale.WithContext(ctx).Where(tx.AfterSale.ID.Eq(id)).Updates(trade.AfterSale{
-			Status:     30, // Completed/Refunded
-			RefundTime: time.Now(),
-		}); err != nil {
+	refunded := false
+	err = s.q.Transaction(func(tx *query.Query) error {
+		// Update AfterSale：按状态条件更新，管理员退款与退款回调并发时只有一方生效，避免重复退还积分
+		info, err := tx.AfterSale.WithContext(ctx).Where(tx.AfterSale.ID.Eq(id), tx.AfterSale.Status.Neq(30)).Updates(trade.AfterSale{
+			Status:      30, // Completed/Refunded
+			RefundTime:  time.Now(),
+			PayRefundID: payRefundId,
+		})
+		if err != nil {
 			return err
 		}
+		if info.RowsAffected != 1 {
+			return nil
+		}
+		refunded = true
 		// Update OrderItem Status
 		if _, err := tx.TradeOrderItem.WithContext(ctx).Where(tx.TradeOrderItem.ID.Eq(as.OrderItemID)).Update(tx.TradeOrderItem.AfterSaleStatus, 30); err != nil {
 			return err
 		}
-		return nil
+		// 退还订单项使用的积分，扣回赠送的积分
+		return s.orderSvc.returnOrderItemPoint(ctx, tx, as.OrderItemID)
 	})
+	if err != nil || !refunded {
+		return err
+	}
+
+	// 发送售后退款消息
+	if err := s.messageSvc.SendAfterSaleRefundMessage(ctx, as); err != nil {
+		zap.L().Error("[RefundAfterSale][发送售后消息失败]", zap.Int64("afterSaleId", id), zap.Error(err))
+	}
+	return nil
 }
 
 // GetAfterSaleDetail 获得售后订单详情 (Admin)
@@ -352,21 +419,37 @@ func (s *TradeAfterSaleService) UpdateAfterSaleRefunded(ctx context.Context, aft
 		return nil
 	}
 
-	return s.q.Transaction(func(tx *query.Query) error {
-		// Update AfterSale
-		if _, err := tx.AfterSale.WithContext(ctx).Where(tx.AfterSale.ID.Eq(afterSaleId)).Updates(trade.AfterSale{
+	refunded := false
+	err = s.q.Transaction(func(tx *query.Query) error {
+		// Update AfterSale：按状态条件更新，管理员退款与退款回调并发时只有一方生效，避免重复退还积分
+		info, err := tx.AfterSale.WithContext(ctx).Where(tx.AfterSale.ID.Eq(afterSaleId), tx.AfterSale.Status.Neq(30)).Updates(trade.AfterSale{
 			Status:      30, // Completed/Refunded
 			RefundTime:  time.Now(),
 			PayRefundID: payRefundId,
-		}); err != nil {
+		})
+		if err != nil {
 			return err
 		}
+		if info.RowsAffected != 1 {
+			return nil
+		}
+		refunded = true
 		// Update OrderItem Status
 		if _, err := tx.TradeOrderItem.WithContext(ctx).Where(tx.TradeOrderItem.ID.Eq(as.OrderItemID)).Update(tx.TradeOrderItem.AfterSaleStatus, 30); err != nil {
 			return err
 		}
-		return nil
+		// 退还订单项使用的积分，扣回赠送的积分
+		return s.orderSvc.returnOrderItemPoint(ctx, tx, as.OrderItemID)
 	})
+	if err != nil || !refunded {
+		return err
+	}
+
+	// 发送售后退款消息
+	if err := s.messageSvc.SendAfterSaleRefundMessage(ctx, as); err != nil {
+		zap.L().Error("[UpdateAfterSaleRefunded][发送售后消息失败]", zap.Int64("afterSaleId", afterSaleId), zap.Error(err))
+	}
+	return nil
 }
 
 // UpdateRefunded 更新退款状态 (Unified Facade)
@@ -0,0 +1,30 @@
+package brokerage
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// BrokerageRecordUnfreezeJobHandlerName 佣金解冻 Job 的处理器名 (infra_job.handler_name)
+const BrokerageRecordUnfreezeJobHandlerName = "brokerageRecordUnfreezeJob"
+
+// BrokerageRecordUnfreezeJob 佣金记录的解冻 Job
+type BrokerageRecordUnfreezeJob struct {
+	recordSvc *BrokerageRecordService
+	logger    *zap.Logger
+}
+
+func NewBrokerageRecordUnfreezeJob(recordSvc *BrokerageRecordService, logger *zap.Logger) *BrokerageRecordUnfreezeJob {
+	return &BrokerageRecordUnfreezeJob{recordSvc: recordSvc, logger: logger}
+}
+
+// Execute 实现 service.JobHandler
+func (j *BrokerageRecordUnfreezeJob) Execute(ctx context.Context, param string) error {
+	count, err := j.recordSvc.UnfreezeRecord(ctx)
+	if err != nil {
+		return err
+	}
+	j.logger.Info("[BrokerageRecordUnfreezeJob] 解冻佣金", zap.Int("count", count))
+	return nil
+}
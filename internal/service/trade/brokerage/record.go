@@ -185,6 +185,52 @@ func (s *BrokerageRecordService) ReduceBrokerageForWithdraw(ctx context.Context,
 	return s.q.BrokerageRecord.WithContext(ctx).Create(record)
 }
 
+// UnfreezeRecord 解冻到期的待结算佣金记录，返回解冻的数量
+func (s *BrokerageRecordService) UnfreezeRecord(ctx context.Context) (int, error) {
+	// 1. 查询待结算且到达解冻时间的记录
+	records, err := s.q.BrokerageRecord.WithContext(ctx).
+		Where(s.q.BrokerageRecord.Status.Eq(0), s.q.BrokerageRecord.UnfreezeTime.Lte(time.Now())). // 0: WAIT_SETTLEMENT
+		Find()
+	if err != nil {
+		return 0, err
+	}
+
+	// 2. 逐个解冻，单个失败不影响其它记录
+	count := 0
+	for _, record := range records {
+		if err := s.unfreezeRecord(ctx, record); err != nil {
+			s.logger.Error("[UnfreezeRecord] 解冻佣金记录失败", zap.Int64("recordId", record.ID), zap.Error(err))
+			continue
+		}
+		count++
+	}
+	return count, nil
+}
+
+// unfreezeRecord 解冻单条佣金记录：冻结佣金转为可用佣金
+func (s *BrokerageRecordService) unfreezeRecord(ctx context.Context, record *brokerage.BrokerageRecord) error {
+	return s.q.Transaction(func(tx *query.Query) error {
+		// 1. 更新记录为已结算 (使用乐观锁)
+		info, err := tx.BrokerageRecord.WithContext(ctx).
+			Where(tx.BrokerageRecord.ID.Eq(record.ID), tx.BrokerageRecord.Status.Eq(0)).
+			Update(tx.BrokerageRecord.Status, 1) // 1: SETTLEMENT
+		if err != nil {
+			return err
+		}
+		if info.RowsAffected == 0 {
+			return errors.New("佣金记录不处于待结算状态")
+		}
+
+		// 2. 更新用户的冻结佣金、可用佣金
+		u := tx.BrokerageUser
+		_, err = u.WithContext(ctx).Where(u.ID.Eq(record.UserID)).UpdateSimple(
+			u.FrozenPrice.Sub(record.Price),
+			u.BrokeragePrice.Add(record.Price),
+		)
+		return err
+	})
+}
+
 // CalculateProductBrokeragePrice 计算商品佣金
 func (s *BrokerageRecordService) CalculateProductBrokeragePrice(ctx context.Context, userId int64, spuId int64) (*trade.AppBrokerageProductPriceRespVO, error) {
 	resp := &trade.AppBrokerageProductPriceRespVO{
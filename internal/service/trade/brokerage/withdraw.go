@@ -12,6 +12,7 @@ import (
 	"backend-go/internal/model/trade/brokerage"
 	"backend-go/internal/pkg/core"
 	"backend-go/internal/repo/query"
+	"backend-go/internal/service"
 	"backend-go/internal/service/member"
 	"backend-go/internal/service/pay"
 	"backend-go/internal/service/trade"
@@ -140,7 +141,7 @@ func (s *BrokerageWithdrawService) createPayTransfer(ctx context.Context, withdr
 			"desc": "佣金提现", // Approx
 		}
 	} else if withdraw.Type == 1 { // WALLET
-		channelCode = "wallet"
+		channelCode = pay.PayChannelCodeWallet
 	}
 
 	// 1.2 构建请求
@@ -155,6 +156,14 @@ func (s *BrokerageWithdrawService) createPayTransfer(ctx context.Context, withdr
 		UserIP:             "127.0.0.1", // TODO: Get from context or request
 		ChannelExtras:      channelExtras,
 	}
+	if withdraw.Type == 1 {
+		// 转账到钱包：收款账号为用户钱包编号
+		wallet, err := s.payWalletSvc.GetOrCreateWallet(ctx, withdraw.UserID, service.UserTypeMember)
+		if err != nil {
+			return err
+		}
+		createReq.UserAccount = strconv.FormatInt(wallet.ID, 10)
+	}
 	if channelCode == "wx_pub" || channelCode == "wx_lite" || channelCode == "wx_app" {
 		createReq.OpenID = "TODO" // Need OpenID for WeChat, likely from UserSocial or similar
 	}
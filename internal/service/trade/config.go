@@ -47,6 +47,11 @@ func (s *TradeConfigService) GetTradeConfig(ctx context.Context) (*resp.TradeCon
 			}
 			return strings.Split(config.BrokeragePosterUrls, ",")
 		}(),
+		MessageTemplates:           config.MessageTemplates,
+		PointTradeDeductEnabled:    bool(config.PointTradeDeductEnabled),
+		PointTradeDeductUnitPrice:  config.PointTradeDeductUnitPrice,
+		PointTradeDeductMaxPercent: config.PointTradeDeductMaxPercent,
+		PointTradeGivePoint:        config.PointTradeGivePoint,
 	}, nil
 }
 
@@ -81,6 +86,10 @@ func (s *TradeConfigService) SaveTradeConfig(ctx context.Context, r *req.TradeCo
 		if r.BrokeragePosterUrls != nil {
 			existing.BrokeragePosterUrls = strings.Join(r.BrokeragePosterUrls, ",")
 		}
+		if r.MessageTemplates != nil {
+			existing.MessageTemplates = r.MessageTemplates
+		}
+		setPointConfig(existing, r)
 		return qc.WithContext(ctx).Save(existing)
 	}
 
@@ -97,6 +106,7 @@ func (s *TradeConfigService) SaveTradeConfig(ctx context.Context, r *req.TradeCo
 		BrokerageFirstPercent:       0,
 		BrokerageSecondPercent:      0,
 		BrokeragePosterUrls:         "",
+		MessageTemplates:            r.MessageTemplates,
 	}
 	if r.BrokerageWithdrawMinPrice != nil {
 		newConfig.BrokerageWithdrawMinPrice = *r.BrokerageWithdrawMinPrice
@@ -119,5 +129,22 @@ func (s *TradeConfigService) SaveTradeConfig(ctx context.Context, r *req.TradeCo
 	if r.BrokeragePosterUrls != nil {
 		newConfig.BrokeragePosterUrls = strings.Join(r.BrokeragePosterUrls, ",")
 	}
+	setPointConfig(newConfig, r)
 	return qc.WithContext(ctx).Create(newConfig)
 }
+
+// setPointConfig 设置积分抵扣与赠送配置，未传的字段保持不变
+func setPointConfig(config *trade.TradeConfig, r *req.TradeConfigSaveReq) {
+	if r.PointTradeDeductEnabled != nil {
+		config.PointTradeDeductEnabled = model.BitBool(*r.PointTradeDeductEnabled)
+	}
+	if r.PointTradeDeductUnitPrice != nil {
+		config.PointTradeDeductUnitPrice = *r.PointTradeDeductUnitPrice
+	}
+	if r.PointTradeDeductMaxPercent != nil {
+		config.PointTradeDeductMaxPercent = *r.PointTradeDeductMaxPercent
+	}
+	if r.PointTradeGivePoint != nil {
+		config.PointTradeGivePoint = *r.PointTradeGivePoint
+	}
+}
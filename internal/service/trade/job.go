@@ -0,0 +1,128 @@
+package trade
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"backend-go/internal/pkg/core"
+	"backend-go/internal/repo/query"
+	"backend-go/internal/service/promotion"
+
+	"go.uber.org/zap"
+)
+
+// 交易模块内置 Job 的处理器名 (infra_job.handler_name)
+const (
+	TradeOrderAutoCancelJobHandlerName    = "tradeOrderAutoCancelJob"
+	TradeOrderAutoReceiveJobHandlerName   = "tradeOrderAutoReceiveJob"
+	TradeOrderAutoCommentJobHandlerName   = "tradeOrderAutoCommentJob"
+	CombinationRecordExpireJobHandlerName = "combinationRecordExpireJob"
+)
+
+// executeEachTenant 逐个开启的租户执行 fn，返回处理的总数量。交易配置按租户区分，不能在忽略租户下读取
+// 对齐 Java: @TenantJob
+func executeEachTenant(ctx context.Context, q *query.Query, fn func(ctx context.Context) (int, error)) (int, error) {
+	t := q.SystemTenant
+	var tenantIds []int64
+	if err := t.WithContext(ctx).Where(t.Status.Eq(0)).Pluck(t.ID, &tenantIds); err != nil {
+		return 0, err
+	}
+	total := 0
+	var errs []error
+	for _, tenantId := range tenantIds {
+		count, err := fn(core.WithTenant(ctx, tenantId))
+		total += count
+		if err != nil {
+			errs = append(errs, fmt.Errorf("tenant %d: %w", tenantId, err))
+		}
+	}
+	return total, errors.Join(errs...)
+}
+
+// TradeOrderAutoCancelJob 交易订单的自动过期 Job
+type TradeOrderAutoCancelJob struct {
+	orderSvc *TradeOrderUpdateService
+	logger   *zap.Logger
+}
+
+func NewTradeOrderAutoCancelJob(orderSvc *TradeOrderUpdateService, logger *zap.Logger) *TradeOrderAutoCancelJob {
+	return &TradeOrderAutoCancelJob{orderSvc: orderSvc, logger: logger}
+}
+
+// Execute 实现 service.JobHandler
+func (j *TradeOrderAutoCancelJob) Execute(ctx context.Context, param string) error {
+	count, err := executeEachTenant(ctx, j.orderSvc.q, j.orderSvc.CancelOrderBySystem)
+	j.logger.Info("[TradeOrderAutoCancelJob] 过期订单", zap.Int("count", count))
+	return err
+}
+
+// TradeOrderAutoReceiveJob 交易订单的自动收货 Job
+type TradeOrderAutoReceiveJob struct {
+	orderSvc *TradeOrderUpdateService
+	logger   *zap.Logger
+}
+
+func NewTradeOrderAutoReceiveJob(orderSvc *TradeOrderUpdateService, logger *zap.Logger) *TradeOrderAutoReceiveJob {
+	return &TradeOrderAutoReceiveJob{orderSvc: orderSvc, logger: logger}
+}
+
+// Execute 实现 service.JobHandler
+func (j *TradeOrderAutoReceiveJob) Execute(ctx context.Context, param string) error {
+	count, err := executeEachTenant(ctx, j.orderSvc.q, j.orderSvc.ReceiveOrderBySystem)
+	j.logger.Info("[TradeOrderAutoReceiveJob] 自动收货订单", zap.Int("count", count))
+	return err
+}
+
+// TradeOrderAutoCommentJob 交易订单的自动评论 Job
+type TradeOrderAutoCommentJob struct {
+	orderSvc *TradeOrderUpdateService
+	logger   *zap.Logger
+}
+
+func NewTradeOrderAutoCommentJob(orderSvc *TradeOrderUpdateService, logger *zap.Logger) *TradeOrderAutoCommentJob {
+	return &TradeOrderAutoCommentJob{orderSvc: orderSvc, logger: logger}
+}
+
+// Execute 实现 service.JobHandler
+func (j *TradeOrderAutoCommentJob) Execute(ctx context.Context, param string) error {
+	count, err := executeEachTenant(ctx, j.orderSvc.q, j.orderSvc.CreateOrderItemCommentBySystem)
+	j.logger.Info("[TradeOrderAutoCommentJob] 评论订单", zap.Int("count", count))
+	return err
+}
+
+// CombinationRecordExpireJob 拼团过期 Job
+// 拼团失败需要取消已支付的订单并退款，依赖交易模块，因此放在 trade 包下
+type CombinationRecordExpireJob struct {
+	recordSvc promotion.CombinationRecordService
+	orderSvc  *TradeOrderUpdateService
+	logger    *zap.Logger
+}
+
+func NewCombinationRecordExpireJob(recordSvc promotion.CombinationRecordService, orderSvc *TradeOrderUpdateService, logger *zap.Logger) *CombinationRecordExpireJob {
+	return &CombinationRecordExpireJob{recordSvc: recordSvc, orderSvc: orderSvc, logger: logger}
+}
+
+// Execute 实现 service.JobHandler
+func (j *CombinationRecordExpireJob) Execute(ctx context.Context, param string) error {
+	failedRecords, virtualGroupCount, err := j.recordSvc.ExpireCombinationRecord(ctx)
+	var errs []error
+	if err != nil {
+		errs = append(errs, err)
+	}
+	// 订单取消并退款成功后才标记拼团失败；取消失败的记录保持进行中，由下次执行重试
+	for _, record := range failedRecords {
+		if record.OrderID > 0 {
+			if err := j.orderSvc.CancelPaidOrder(ctx, record.UserID, record.OrderID, 40); err != nil { // 40: Combination Close
+				errs = append(errs, fmt.Errorf("cancel combination order %d: %w", record.OrderID, err))
+				continue
+			}
+		}
+		if err := j.recordSvc.UpdateCombinationRecordFailed(ctx, record.ID); err != nil {
+			errs = append(errs, fmt.Errorf("update combination record %d failed: %w", record.ID, err))
+		}
+	}
+	j.logger.Info("[CombinationRecordExpireJob] 处理过期拼团",
+		zap.Int("failedCount", len(failedRecords)), zap.Int("virtualGroupCount", virtualGroupCount))
+	return errors.Join(errs...)
+}
@@ -2,30 +2,196 @@ package trade
 
 import (
 	"backend-go/internal/model/trade"
+	"backend-go/internal/repo/query"
+	"backend-go/internal/service"
 	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+const (
+	tradeMessageQueueSize = 1024 // 待发送消息队列容量，满时丢弃
+	tradeMessageWorkers   = 4    // 发送消息的后台协程数
 )
 
+// TradeMessageService 交易消息：订单、售后状态变化时，按交易配置中的模板编码发送站内信、短信、邮件
+// 对应 Java: TradeMessageServiceImpl
 type TradeMessageService struct {
+	q                  *query.Query
+	tradeConfigSvc     *TradeConfigService
+	deliveryExpressSvc *DeliveryExpressService
+	notifySvc          *service.NotifyService
+	mailSvc            *service.MailService
+	smsSendSvc         *service.SmsSendService
+	logger             *zap.Logger
+	queue              chan func()
 }
 
-func NewTradeMessageService() *TradeMessageService {
-	return &TradeMessageService{}
+func NewTradeMessageService(q *query.Query, tradeConfigSvc *TradeConfigService, deliveryExpressSvc *DeliveryExpressService,
+	notifySvc *service.NotifyService, mailSvc *service.MailService, smsSendSvc *service.SmsSendService, logger *zap.Logger) *TradeMessageService {
+	s := &TradeMessageService{
+		q:                  q,
+		tradeConfigSvc:     tradeConfigSvc,
+		deliveryExpressSvc: deliveryExpressSvc,
+		notifySvc:          notifySvc,
+		mailSvc:            mailSvc,
+		smsSendSvc:         smsSendSvc,
+		logger:             logger,
+		queue:              make(chan func(), tradeMessageQueueSize),
+	}
+	for i := 0; i < tradeMessageWorkers; i++ {
+		go s.runWorker()
+	}
+	return s
 }
 
 // SendOrderCreateMessage 发送订单创建消息
 func (s *TradeMessageService) SendOrderCreateMessage(ctx context.Context, order *trade.TradeOrder) error {
-	// TODO: Implement message sending logic (SMS, Station Letter)
-	return nil
+	return s.send(ctx, trade.TradeMessageOrderCreate, order.UserID, order.ReceiverMobile, orderMessageParams(order))
 }
 
 // SendOrderPaySuccessMessage 发送订单支付成功消息
 func (s *TradeMessageService) SendOrderPaySuccessMessage(ctx context.Context, order *trade.TradeOrder) error {
-	// TODO: Implement
-	return nil
+	return s.send(ctx, trade.TradeMessageOrderPaySuccess, order.UserID, order.ReceiverMobile, orderMessageParams(order))
 }
 
 // SendOrderDeliveryMessage 发送订单发货消息
 func (s *TradeMessageService) SendOrderDeliveryMessage(ctx context.Context, order *trade.TradeOrder) error {
-	// TODO: Implement
+	params := orderMessageParams(order)
+	params["logisticsNo"] = order.LogisticsNo
+	params["expressName"] = ""
+	if order.LogisticsID > 0 {
+		if express, err := s.deliveryExpressSvc.GetDeliveryExpress(ctx, order.LogisticsID); err == nil {
+			params["expressName"] = express.Name
+		}
+	}
+	params["deliveryMessage"] = fmt.Sprintf("您的订单【%s】已发货，快递公司：%v，快递单号：%s",
+		order.No, params["expressName"], order.LogisticsNo)
+	return s.send(ctx, trade.TradeMessageOrderDelivery, order.UserID, order.ReceiverMobile, params)
+}
+
+// SendOrderPickUpReadyMessage 发送自提订单待提货消息，包含核销码
+func (s *TradeMessageService) SendOrderPickUpReadyMessage(ctx context.Context, order *trade.TradeOrder) error {
+	params := orderMessageParams(order)
+	params["pickUpVerifyCode"] = order.PickUpVerifyCode
+	return s.send(ctx, trade.TradeMessageOrderPickUpReady, order.UserID, order.ReceiverMobile, params)
+}
+
+// SendOrderRefundMessage 发送订单退款成功消息
+func (s *TradeMessageService) SendOrderRefundMessage(ctx context.Context, order *trade.TradeOrder) error {
+	params := orderMessageParams(order)
+	params["refundPrice"] = formatPrice(order.PayPrice)
+	return s.send(ctx, trade.TradeMessageOrderRefund, order.UserID, order.ReceiverMobile, params)
+}
+
+// SendAfterSaleAuditMessage 发送售后审核结果消息
+func (s *TradeMessageService) SendAfterSaleAuditMessage(ctx context.Context, afterSale *trade.AfterSale, agree bool) error {
+	event := trade.TradeMessageAfterSaleAgree
+	if !agree {
+		event = trade.TradeMessageAfterSaleDisagree
+	}
+	return s.send(ctx, event, afterSale.UserID, "", afterSaleMessageParams(afterSale))
+}
+
+// SendAfterSaleRefundMessage 发送售后退款成功消息
+func (s *TradeMessageService) SendAfterSaleRefundMessage(ctx context.Context, afterSale *trade.AfterSale) error {
+	return s.send(ctx, trade.TradeMessageAfterSaleRefund, afterSale.UserID, "", afterSaleMessageParams(afterSale))
+}
+
+func orderMessageParams(order *trade.TradeOrder) map[string]interface{} {
+	return map[string]interface{}{
+		"orderId":  order.ID,
+		"orderNo":  order.No,
+		"payPrice": formatPrice(order.PayPrice),
+	}
+}
+
+func afterSaleMessageParams(afterSale *trade.AfterSale) map[string]interface{} {
+	return map[string]interface{}{
+		"afterSaleId": afterSale.ID,
+		"afterSaleNo": afterSale.No,
+		"orderNo":     afterSale.OrderNo,
+		"spuName":     afterSale.SpuName,
+		"refundPrice": formatPrice(afterSale.RefundPrice),
+		"auditReason": afterSale.AuditReason,
+	}
+}
+
+// send 按事件的模板配置发送消息。未配置的事件或渠道直接跳过
+// 消息由固定数量的后台协程发送，不阻塞订单流程；队列满时丢弃，单个渠道发送失败只记录日志
+func (s *TradeMessageService) send(ctx context.Context, event string, userId int64, fallbackMobile string, params map[string]interface{}) error {
+	template, err := s.getMessageTemplate(ctx, event)
+	if err != nil || template == nil {
+		return err
+	}
+	ctx = context.WithoutCancel(ctx)
+	task := func() {
+		if template.NotifyTemplateCode != "" {
+			if _, err := s.notifySvc.SendNotify(ctx, userId, service.UserTypeMember, template.NotifyTemplateCode, params); err != nil {
+				s.logger.Error("[send][站内信发送失败]", zap.String("event", event), zap.Int64("userId", userId), zap.Error(err))
+			}
+		}
+		if template.SmsTemplateCode != "" {
+			s.sendSms(ctx, event, userId, fallbackMobile, template.SmsTemplateCode, params)
+		}
+		if template.MailTemplateCode != "" {
+			// MailTo 为商家员工邮箱，与下单会员无关，按管理员发送、不关联用户编号
+			for _, mail := range template.MailTo {
+				if _, err := s.mailSvc.SendMail(ctx, 0, service.UserTypeAdmin, mail, template.MailTemplateCode, params); err != nil {
+					s.logger.Error("[send][邮件发送失败]", zap.String("event", event), zap.String("mail", mail), zap.Error(err))
+				}
+			}
+		}
+	}
+	select {
+	case s.queue <- task:
+	default:
+		s.logger.Warn("[send][交易消息队列已满，丢弃]", zap.String("event", event), zap.Int64("userId", userId))
+	}
 	return nil
 }
+
+// runWorker 从队列中取出消息并发送
+func (s *TradeMessageService) runWorker() {
+	for task := range s.queue {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					s.logger.Error("[runWorker][交易消息发送异常]", zap.Any("panic", r))
+				}
+			}()
+			task()
+		}()
+	}
+}
+
+// sendSms 发送短信，优先使用会员手机号，会员未绑定手机时使用收件人手机号
+func (s *TradeMessageService) sendSms(ctx context.Context, event string, userId int64, fallbackMobile string, templateCode string, params map[string]interface{}) {
+	mobile := fallbackMobile
+	u := s.q.MemberUser
+	if user, err := u.WithContext(ctx).Where(u.ID.Eq(userId)).First(); err == nil && user.Mobile != "" {
+		mobile = user.Mobile
+	}
+	if mobile == "" {
+		s.logger.Warn("[sendSms][会员没有手机号，跳过短信]", zap.String("event", event), zap.Int64("userId", userId))
+		return
+	}
+	// 短信模板参数统一为字符串
+	smsParams := make(map[string]interface{}, len(params))
+	for k, v := range params {
+		smsParams[k] = fmt.Sprint(v)
+	}
+	if _, err := s.smsSendSvc.SendSingleSms(ctx, mobile, userId, service.UserTypeMember, templateCode, smsParams); err != nil {
+		s.logger.Error("[sendSms][短信发送失败]", zap.String("event", event), zap.String("mobile", mobile), zap.Error(err))
+	}
+}
+
+// getMessageTemplate 获得消息事件的模板配置，未配置时返回 nil
+func (s *TradeMessageService) getMessageTemplate(ctx context.Context, event string) (*trade.TradeMessageTemplate, error) {
+	config, err := s.tradeConfigSvc.GetTradeConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return config.MessageTemplates[event], nil
+}
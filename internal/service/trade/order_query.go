@@ -6,6 +6,7 @@ import (
 	"backend-go/internal/model/trade"
 	"backend-go/internal/pkg/core"
 	"backend-go/internal/repo/query"
+	"backend-go/internal/service"
 	"backend-go/internal/service/trade/delivery/client"
 	"context"
 )
@@ -14,13 +15,15 @@ type TradeOrderQueryService struct {
 	q                    *query.Query
 	expressClientFactory client.ExpressClientFactory
 	deliveryExpressSvc   *DeliveryExpressService
+	permSvc              *service.PermissionService
 }
 
-func NewTradeOrderQueryService(q *query.Query, expressClientFactory client.ExpressClientFactory, deliveryExpressSvc *DeliveryExpressService) *TradeOrderQueryService {
+func NewTradeOrderQueryService(q *query.Query, expressClientFactory client.ExpressClientFactory, deliveryExpressSvc *DeliveryExpressService, permSvc *service.PermissionService) *TradeOrderQueryService {
 	return &TradeOrderQueryService{
 		q:                    q,
 		expressClientFactory: expressClientFactory,
 		deliveryExpressSvc:   deliveryExpressSvc,
+		permSvc:              permSvc,
 	}
 }
 
@@ -79,6 +82,26 @@ func (s *TradeOrderQueryService) GetOrderCount(ctx context.Context, userId int64
 }
 
 // GetOrderItem 获得交易订单项
+// GetSeckillProductCount 获得会员在秒杀活动中已购买的商品数量，不包括已取消的订单
+// 对应 Java: TradeOrderQueryServiceImpl#getSeckillProductCount
+func (s *TradeOrderQueryService) GetSeckillProductCount(ctx context.Context, userId int64, activityId int64) (int, error) {
+	o := s.q.TradeOrder
+	var orderIds []int64
+	if err := o.WithContext(ctx).Where(o.UserID.Eq(userId), o.SeckillActivityID.Eq(activityId),
+		o.Status.Neq(trade.TradeOrderStatusCanceled)).Pluck(o.ID, &orderIds); err != nil {
+		return 0, err
+	}
+	if len(orderIds) == 0 {
+		return 0, nil
+	}
+	i := s.q.TradeOrderItem
+	var count int
+	if err := i.WithContext(ctx).Select(i.Count.Sum()).Where(i.OrderID.In(orderIds...)).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 func (s *TradeOrderQueryService) GetOrderItem(ctx context.Context, userId int64, id int64) (*trade.TradeOrderItem, error) {
 	return s.q.TradeOrderItem.WithContext(ctx).Where(s.q.TradeOrderItem.ID.Eq(id), s.q.TradeOrderItem.UserID.Eq(userId)).First()
 }
@@ -97,6 +120,12 @@ func (s *TradeOrderQueryService) GetOrderPageForAdmin(ctx context.Context, r *re
 		q = q.Where(s.q.TradeOrder.Status.Eq(*r.Status))
 	}
 	// Add more filters as needed (e.g. create_time, type, etc.)
+	// 数据权限：订单没有部门、管理员字段，无法按部门或本人过滤，只有全部数据权限的管理员可以查看
+	scope, err := s.permSvc.DeptDataScope(ctx, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	q = q.Where(scope...)
 
 	list, total, err := q.Order(s.q.TradeOrder.ID.Desc()).FindByPage(r.GetOffset(), r.PageSize)
 	if err != nil {
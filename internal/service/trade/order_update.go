@@ -3,27 +3,41 @@ package trade
 import (
 	"backend-go/internal/api/req"
 	"backend-go/internal/api/resp"
+	memberModel "backend-go/internal/model/member"
 	"backend-go/internal/model/trade"
+	"backend-go/internal/pkg/area"
+	"backend-go/internal/pkg/core"
+	"backend-go/internal/pkg/utils"
 	"backend-go/internal/repo/query"
 	"backend-go/internal/service/member"
+	paySvc "backend-go/internal/service/pay"
 	"backend-go/internal/service/product"
 	"backend-go/internal/service/promotion"
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
 type TradeOrderUpdateService struct {
-	q          *query.Query
-	skuSvc     *product.ProductSkuService
-	cartSvc    *CartService
-	priceSvc   *TradePriceService
-	addressSvc *member.MemberAddressService
-	couponSvc  *promotion.CouponUserService
-	logSvc     *TradeOrderLogService
+	q              *query.Query
+	skuSvc         *product.ProductSkuService
+	cartSvc        *CartService
+	priceSvc       *TradePriceService
+	addressSvc     *member.MemberAddressService
+	couponSvc      *promotion.CouponUserService
+	logSvc         *TradeOrderLogService
+	tradeConfigSvc *TradeConfigService
+	commentSvc     *product.ProductCommentService
+	payRefundSvc   *paySvc.PayRefundService
+	messageSvc     *TradeMessageService
+	pointRecordSvc *member.MemberPointRecordService
+	seckillSvc     *promotion.SeckillActivityService
 }
 
 func NewTradeOrderUpdateService(
@@ -33,15 +47,27 @@ func NewTradeOrderUpdateService(
 	addressSvc *member.MemberAddressService,
 	couponSvc *promotion.CouponUserService,
 	logSvc *TradeOrderLogService,
+	tradeConfigSvc *TradeConfigService,
+	commentSvc *product.ProductCommentService,
+	payRefundSvc *paySvc.PayRefundService,
+	messageSvc *TradeMessageService,
+	pointRecordSvc *member.MemberPointRecordService,
+	seckillSvc *promotion.SeckillActivityService,
 ) *TradeOrderUpdateService {
 	return &TradeOrderUpdateService{
-		q:          query.Q,
-		skuSvc:     skuSvc,
-		cartSvc:    cartSvc,
-		priceSvc:   priceSvc,
-		addressSvc: addressSvc,
-		couponSvc:  couponSvc,
-		logSvc:     logSvc,
+		q:              query.Q,
+		skuSvc:         skuSvc,
+		cartSvc:        cartSvc,
+		priceSvc:       priceSvc,
+		addressSvc:     addressSvc,
+		couponSvc:      couponSvc,
+		logSvc:         logSvc,
+		tradeConfigSvc: tradeConfigSvc,
+		commentSvc:     commentSvc,
+		payRefundSvc:   payRefundSvc,
+		messageSvc:     messageSvc,
+		pointRecordSvc: pointRecordSvc,
+		seckillSvc:     seckillSvc,
 	}
 }
 
@@ -49,13 +75,14 @@ func NewTradeOrderUpdateService(
 func (s *TradeOrderUpdateService) SettlementOrder(ctx context.Context, uId int64, req *req.AppTradeOrderSettlementReq) (*resp.AppTradeOrderSettlementResp, error) {
 	// 1. Calculate Price
 	calcReq := &TradePriceCalculateReqBO{
-		UserID:        uId,
-		CouponID:      req.CouponID,
-		PointStatus:   req.PointStatus,
-		DeliveryType:  req.DeliveryType,
-		AddressID:     req.AddressID,
-		PickUpStoreID: req.PickUpStoreID,
-		Items:         make([]TradePriceCalculateItemBO, len(req.Items)),
+		UserID:            uId,
+		CouponID:          req.CouponID,
+		PointStatus:       req.PointStatus,
+		DeliveryType:      req.DeliveryType,
+		AddressID:         req.AddressID,
+		PickUpStoreID:     req.PickUpStoreID,
+		SeckillActivityID: req.SeckillActivityID,
+		Items:             make([]TradePriceCalculateItemBO, len(req.Items)),
 	}
 	for i, item := range req.Items {
 		calcReq.Items[i] = TradePriceCalculateItemBO{
@@ -107,7 +134,25 @@ func (s *TradeOrderUpdateService) SettlementOrder(ctx context.Context, uId int64
 			Price:      item.Price,
 			PicURL:     item.PicURL,
 			Properties: item.Properties,
-			// SpuName: item.SpuName,
+			SpuName:    item.SpuName,
+			CategoryID: item.CategoryID,
+		}
+	}
+	r.Promotions = make([]resp.AppTradeOrderSettlementPromotion, len(priceResp.Promotions))
+	for i, promotion := range priceResp.Promotions {
+		items := make([]resp.AppTradeOrderSettlementPromotionItem, len(promotion.Items))
+		for j, item := range promotion.Items {
+			items[j] = resp.AppTradeOrderSettlementPromotionItem(item)
+		}
+		r.Promotions[i] = resp.AppTradeOrderSettlementPromotion{
+			ID:            promotion.ID,
+			Name:          promotion.Name,
+			Type:          promotion.Type,
+			TotalPrice:    promotion.TotalPrice,
+			DiscountPrice: promotion.DiscountPrice,
+			Match:         promotion.Match,
+			Description:   promotion.Description,
+			Items:         items,
 		}
 	}
 
@@ -116,18 +161,28 @@ func (s *TradeOrderUpdateService) SettlementOrder(ctx context.Context, uId int64
 
 // CreateOrder 创建交易订单
 func (s *TradeOrderUpdateService) CreateOrder(ctx context.Context, uId int64, reqVO *req.AppTradeOrderCreateReq) (*trade.TradeOrder, error) {
+	// 0. 秒杀订单按会员加锁，价格计算中的总限购数量校验与订单提交之间不允许同一会员并发下单
+	if reqVO.SeckillActivityID != nil && *reqVO.SeckillActivityID > 0 {
+		unlock, err := s.seckillSvc.LockSeckillUser(ctx, *reqVO.SeckillActivityID, uId)
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+	}
+
 	// 1. Price Calculation
 	// 1. Price Calculation
 	// Call SettlementOrder logic (reuse code or abstract)
 	// Or better, just call price calc directly
 	calcReq := &TradePriceCalculateReqBO{
-		UserID:        uId,
-		CouponID:      reqVO.CouponID,
-		PointStatus:   reqVO.PointStatus,
-		DeliveryType:  reqVO.DeliveryType,
-		AddressID:     reqVO.AddressID,
-		PickUpStoreID: reqVO.PickUpStoreID,
-		Items:         make([]TradePriceCalculateItemBO, len(reqVO.Items)),
+		UserID:            uId,
+		CouponID:          reqVO.CouponID,
+		PointStatus:       reqVO.PointStatus,
+		DeliveryType:      reqVO.DeliveryType,
+		AddressID:         reqVO.AddressID,
+		PickUpStoreID:     reqVO.PickUpStoreID,
+		SeckillActivityID: reqVO.SeckillActivityID,
+		Items:             make([]TradePriceCalculateItemBO, len(reqVO.Items)),
 	}
 	for i, item := range reqVO.Items {
 		calcReq.Items[i] = TradePriceCalculateItemBO{
@@ -144,11 +199,12 @@ func (s *TradeOrderUpdateService) CreateOrder(ctx context.Context, uId int64, re
 
 	// 2. Transaction
 	var order *trade.TradeOrder
+	seckillReserved := false // 是否已在缓存中预占秒杀库存
 	err = s.q.Transaction(func(tx *query.Query) error {
 		// 2.1 Create Order
 		order = &trade.TradeOrder{
 			No:             generateOrderNo(),
-			Type:           1, // Normal order
+			Type:           priceResp.Type,
 			Terminal:       1, // TODO: passed from header/context
 			UserID:         uId,
 			UserIP:         "127.0.0.1", // TODO: from context
@@ -162,6 +218,10 @@ func (s *TradeOrderUpdateService) CreateOrder(ctx context.Context, uId int64, re
 			PayPrice:       priceResp.Price.PayPrice,
 			CouponID:       priceResp.CouponID,
 			CouponPrice:    priceResp.Price.CouponPrice,
+			PointPrice:     priceResp.Price.PointPrice,
+			UsePoint:       priceResp.UsePoint,
+			GivePoint:      priceResp.GivePoint,
+			VipPrice:       priceResp.Price.VipPrice,
 			DeliveryType:   reqVO.DeliveryType,
 			ReceiverName:   reqVO.ReceiverName,
 			ReceiverMobile: reqVO.ReceiverMobile,
@@ -178,6 +238,10 @@ func (s *TradeOrderUpdateService) CreateOrder(ctx context.Context, uId int64, re
 			}
 		}
 
+		if priceResp.Type == trade.TradeOrderTypeSeckill {
+			order.SeckillActivityID = *reqVO.SeckillActivityID
+		}
+
 		if err := tx.TradeOrder.WithContext(ctx).Create(order); err != nil {
 			return err
 		}
@@ -185,24 +249,46 @@ func (s *TradeOrderUpdateService) CreateOrder(ctx context.Context, uId int64, re
 		// 2.2 Create Order Items
 		items := make([]*trade.TradeOrderItem, len(priceResp.Items))
 		for i, item := range priceResp.Items {
+			properties := make([]trade.TradeOrderItemProperty, len(item.Properties))
+			for j, p := range item.Properties {
+				properties[j] = trade.TradeOrderItemProperty(p)
+			}
+			// 各项优惠已按比例分摊到商品，售后退款、分销佣金以商品的支付金额为准
 			items[i] = &trade.TradeOrderItem{
-				UserID:      uId,
-				OrderID:     order.ID,
-				SpuID:       item.SpuID,
-				SkuID:       item.SkuID,
-				Count:       item.Count,
-				Price:       item.Price,
-				PayPrice:    item.PayPrice,
-				PicURL:      item.PicURL,
-				CouponPrice: item.CouponPrice,
-				// Properties: item.Properties (need serialize),
+				UserID:        uId,
+				OrderID:       order.ID,
+				CartID:        item.CartID,
+				SpuID:         item.SpuID,
+				SpuName:       item.SpuName,
+				SkuID:         item.SkuID,
+				Properties:    properties,
+				Count:         item.Count,
+				Price:         item.Price,
+				DiscountPrice: item.DiscountPrice,
+				DeliveryPrice: item.DeliveryPrice,
+				PayPrice:      item.PayPrice,
+				PicURL:        item.PicURL,
+				CouponPrice:   item.CouponPrice,
+				PointPrice:    item.PointPrice,
+				UsePoint:      item.UsePoint,
+				GivePoint:     item.GivePoint,
+				VipPrice:      item.VipPrice,
 			}
 		}
 		if err := tx.TradeOrderItem.WithContext(ctx).Create(items...); err != nil {
 			return err
 		}
 
-		// 2.3 Clear Cart (if cart items)
+		// 2.3 扣减抵扣使用的积分，余额不足时整单回滚
+		// 须在清空购物车、扣减库存、使用优惠券之前执行：这些操作不在 tx 中，无法随事务回滚
+		if order.UsePoint > 0 {
+			if err := s.pointRecordSvc.CreatePointRecordTx(ctx, tx, uId, -order.UsePoint, memberModel.PointBizTypeOrderUse,
+				strconv.FormatInt(order.ID, 10), "订单积分抵扣", fmt.Sprintf("下单使用 %d 积分", order.UsePoint)); err != nil {
+				return err
+			}
+		}
+
+		// 2.4 Clear Cart (if cart items)
 		var cartIds []int64
 		for _, item := range calcReq.Items {
 			if item.CartID > 0 {
@@ -215,7 +301,7 @@ func (s *TradeOrderUpdateService) CreateOrder(ctx context.Context, uId int64, re
 			}
 		}
 
-		// 2.4 Decrease Stock
+		// 2.5 Decrease Stock
 		var stockItems []req.ProductSkuUpdateStockItemReq
 		for _, item := range priceResp.Items {
 			stockItems = append(stockItems, req.ProductSkuUpdateStockItemReq{
@@ -226,22 +312,43 @@ func (s *TradeOrderUpdateService) CreateOrder(ctx context.Context, uId int64, re
 		if err := s.skuSvc.UpdateSkuStock(ctx, &req.ProductSkuUpdateStockReq{Items: stockItems}); err != nil {
 			return err
 		}
+		// 秒杀订单同时扣减秒杀库存
+		if order.SeckillActivityID > 0 {
+			item := priceResp.Items[0]
+			if err := s.seckillSvc.DecrSeckillStock(ctx, tx, order.SeckillActivityID, item.SkuID, item.Count); err != nil {
+				return err
+			}
+			seckillReserved = true
+		}
 
-		// 2.5 Use Coupon
+		// 2.6 Use Coupon
 		if priceResp.CouponID > 0 {
 			if err := s.couponSvc.UseCoupon(ctx, uId, priceResp.CouponID, order.ID); err != nil {
 				return err
 			}
 		}
 
-		// 2.6 Log
+		// 2.7 Log
 		if err := s.createOrderLog(ctx, order, "Create Order", 10); err != nil {
 			return err
 		}
 
 		return nil
 	})
-	return order, err
+	if err != nil {
+		// 事务回滚后，归还缓存中预占的秒杀库存
+		if seckillReserved {
+			item := priceResp.Items[0]
+			s.seckillSvc.IncrSeckillStockCache(ctx, order.SeckillActivityID, item.SkuID, item.Count)
+		}
+		return nil, err
+	}
+
+	// 3. 发送订单创建消息
+	if err := s.messageSvc.SendOrderCreateMessage(ctx, order); err != nil {
+		zap.L().Error("[CreateOrder][发送订单创建消息失败]", zap.Int64("orderId", order.ID), zap.Error(err))
+	}
+	return order, nil
 }
 
 // DeliveryOrder 订单发货
@@ -270,8 +377,19 @@ func (s *TradeOrderUpdateService) DeliveryOrder(ctx context.Context, reqVO *req.
 	// 3. Log
 	logOrder := *order
 	logOrder.Status = 20
+	logOrder.LogisticsID = reqVO.LogisticsID
+	logOrder.LogisticsNo = reqVO.LogisticsNo
+	logOrder.DeliveryTime = &now
 	// OperateType 30 for Delivery (Example)
-	return s.createOrderLog(ctx, &logOrder, "Order Delivered", 30)
+	if err := s.createOrderLog(ctx, &logOrder, "Order Delivered", 30); err != nil {
+		return err
+	}
+
+	// 4. 发送订单发货消息
+	if err := s.messageSvc.SendOrderDeliveryMessage(ctx, &logOrder); err != nil {
+		zap.L().Error("[DeliveryOrder][发送订单发货消息失败]", zap.Int64("orderId", order.ID), zap.Error(err))
+	}
+	return nil
 }
 
 // UpdateOrderPaid 更新订单为已支付
@@ -298,9 +416,22 @@ func (s *TradeOrderUpdateService) UpdateOrderPaid(ctx context.Context, id int64,
 			"pay_time":     &now,
 			"pay_order_id": payOrderId,
 		}
-		if _, err := tx.TradeOrder.WithContext(ctx).Where(tx.TradeOrder.ID.Eq(id)).Updates(updateMap); err != nil {
+		// 以未支付状态作为条件更新，避免重复回调时重复赠送积分
+		result, err := tx.TradeOrder.WithContext(ctx).Where(tx.TradeOrder.ID.Eq(id), tx.TradeOrder.Status.Eq(0), tx.TradeOrder.PayStatus.Is(false)).Updates(updateMap)
+		if err != nil {
 			return err
 		}
+		if result.RowsAffected != 1 {
+			return fmt.Errorf("order status is not unpaid")
+		}
+
+		// 赠送积分
+		if order.GivePoint > 0 {
+			if err := s.pointRecordSvc.CreatePointRecordTx(ctx, tx, order.UserID, order.GivePoint, memberModel.PointBizTypeOrderGive,
+				strconv.FormatInt(order.ID, 10), "订单赠送积分", fmt.Sprintf("下单赠送 %d 积分", order.GivePoint)); err != nil {
+				return err
+			}
+		}
 
 		// Log
 		logOrder := *order
@@ -310,7 +441,24 @@ func (s *TradeOrderUpdateService) UpdateOrderPaid(ctx context.Context, id int64,
 		}
 		return nil
 	})
-	return err
+	if err != nil {
+		return err
+	}
+
+	// 3. 发送支付成功消息，自提订单同时通知到店提货
+	paidOrder := *order
+	paidOrder.Status = 10
+	paidOrder.PayStatus = true
+	paidOrder.PayTime = &now
+	if err := s.messageSvc.SendOrderPaySuccessMessage(ctx, &paidOrder); err != nil {
+		zap.L().Error("[UpdateOrderPaid][发送支付成功消息失败]", zap.Int64("orderId", id), zap.Error(err))
+	}
+	if paidOrder.DeliveryType == 2 {
+		if err := s.messageSvc.SendOrderPickUpReadyMessage(ctx, &paidOrder); err != nil {
+			zap.L().Error("[UpdateOrderPaid][发送待提货消息失败]", zap.Int64("orderId", id), zap.Error(err))
+		}
+	}
+	return nil
 }
 
 func (s *TradeOrderUpdateService) createOrderLog(ctx context.Context, order *trade.TradeOrder, content string, operateType int) error {
@@ -341,57 +489,200 @@ func (s *TradeOrderUpdateService) CancelOrder(ctx context.Context, uId int64, id
 		}
 		return err
 	}
-	if order.Status != 0 { // 0: Unpaid. If we allow cancelling Paid orders, we need Refund logic.
-		// For now, restrict to Unpaid.
+	if order.Status != 0 { // 0: Unpaid. Paid orders are cancelled by CancelPaidOrder with refund.
 		return errors.New("订单状态不允许取消")
 	}
 
-	// 2. Transaction
+	// 2. Cancel
+	return s.cancelOrder(ctx, order, 1, "User Cancelled Order", 40) // 1: User Cancelled
+}
+
+// CancelOrderBySystem 自动取消超时未支付的订单，返回取消的数量
+func (s *TradeOrderUpdateService) CancelOrderBySystem(ctx context.Context) (int, error) {
+	config, err := s.tradeConfigSvc.GetTradeConfig(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if config.PayTimeoutMinutes <= 0 {
+		return 0, nil
+	}
+
+	// 1. 查询超时未支付的订单
+	expireTime := time.Now().Add(-time.Duration(config.PayTimeoutMinutes) * time.Minute)
+	orders, err := s.q.TradeOrder.WithContext(ctx).
+		Where(s.q.TradeOrder.Status.Eq(0), s.q.TradeOrder.CreatedAt.Lt(expireTime)). // 0: Unpaid
+		Find()
+	if err != nil {
+		return 0, err
+	}
+
+	// 2. 逐个取消，单个失败不影响其它订单
+	count := 0
+	var errs []error
+	for _, order := range orders {
+		if err := s.cancelOrder(ctx, order, 10, "System Cancelled Order (Pay Timeout)", 41); err != nil { // 10: Pay Timeout
+			errs = append(errs, fmt.Errorf("cancel order %d: %w", order.ID, err))
+			continue
+		}
+		count++
+	}
+	return count, errors.Join(errs...)
+}
+
+// CancelPaidOrder 取消已支付的订单，并全额退款 (如拼团失败)
+// 退款结果通过 TradeAfterSaleService.UpdateRefunded 回调，商户退款单号为 "order-" + 订单编号
+func (s *TradeOrderUpdateService) CancelPaidOrder(ctx context.Context, uId int64, id int64, cancelType int) error {
+	// 1. Check Order
+	order, err := s.q.TradeOrder.WithContext(ctx).Where(s.q.TradeOrder.ID.Eq(id), s.q.TradeOrder.UserID.Eq(uId)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("订单不存在")
+		}
+		return err
+	}
+	if order.Status != 10 { // 10: Undelivered
+		return errors.New("订单状态不允许取消")
+	}
+
+	config, err := s.tradeConfigSvc.GetTradeConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	// 2. 先取消订单 (状态乐观锁)，再发起全额退款，两者在同一事务中：退款发起失败时取消回滚，避免重复退款
+	var items []*trade.TradeOrderItem
 	err = s.q.Transaction(func(tx *query.Query) error {
-		// 2.1 Update Order Status
-		now := time.Now()
-		if _, err := tx.TradeOrder.WithContext(ctx).Where(tx.TradeOrder.ID.Eq(id)).Updates(trade.TradeOrder{
-			Status:     40, // Cancelled (Closed)
-			CancelTime: &now,
-			CancelType: 1, // User Cancelled
-		}); err != nil {
+		var err error
+		if items, err = s.cancelOrderTx(ctx, tx, order, cancelType, "System Cancelled Paid Order", 41); err != nil {
 			return err
 		}
-
-		// 2.2 Release Stock
-		items, err := tx.TradeOrderItem.WithContext(ctx).Where(tx.TradeOrderItem.OrderID.Eq(id)).Find()
-		if err != nil {
+		if _, err := tx.TradeOrder.WithContext(ctx).Where(tx.TradeOrder.ID.Eq(order.ID)).Update(tx.TradeOrder.RefundPrice, order.PayPrice); err != nil {
 			return err
 		}
-		var stockItems []req.ProductSkuUpdateStockItemReq
+		_, err = s.payRefundSvc.CreateRefund(ctx, &req.PayRefundCreateReq{
+			AppID:            config.AppID,
+			UserIP:           order.UserIP,
+			MerchantOrderId:  strconv.FormatInt(order.ID, 10),
+			MerchantRefundId: fmt.Sprintf("order-%d", order.ID),
+			Reason:           "取消支付订单",
+			Price:            order.PayPrice,
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	s.incrSeckillStockCache(ctx, order, items)
+	return nil
+}
+
+// cancelOrder 取消订单：更新状态、释放库存与秒杀库存、退还优惠券与积分、扣回赠送的积分、记录日志
+func (s *TradeOrderUpdateService) cancelOrder(ctx context.Context, order *trade.TradeOrder, cancelType int, content string, operateType int) error {
+	var items []*trade.TradeOrderItem
+	err := s.q.Transaction(func(tx *query.Query) error {
+		var err error
+		items, err = s.cancelOrderTx(ctx, tx, order, cancelType, content, operateType)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	s.incrSeckillStockCache(ctx, order, items)
+	return nil
+}
+
+// cancelOrderTx 在事务中取消订单，返回订单项供事务提交后归还秒杀库存缓存
+func (s *TradeOrderUpdateService) cancelOrderTx(ctx context.Context, tx *query.Query, order *trade.TradeOrder, cancelType int, content string, operateType int) ([]*trade.TradeOrderItem, error) {
+	// 1. Update Order Status (Optimistic lock on current status)
+	now := time.Now()
+	info, err := tx.TradeOrder.WithContext(ctx).Where(tx.TradeOrder.ID.Eq(order.ID), tx.TradeOrder.Status.Eq(order.Status)).Updates(trade.TradeOrder{
+		Status:     40, // Cancelled (Closed)
+		CancelTime: &now,
+		CancelType: cancelType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if info.RowsAffected == 0 {
+		return nil, errors.New("订单状态不允许取消")
+	}
+
+	// 2. Release Stock
+	items, err := tx.TradeOrderItem.WithContext(ctx).Where(tx.TradeOrderItem.OrderID.Eq(order.ID)).Find()
+	if err != nil {
+		return nil, err
+	}
+	var stockItems []req.ProductSkuUpdateStockItemReq
+	for _, item := range items {
+		stockItems = append(stockItems, req.ProductSkuUpdateStockItemReq{
+			ID:        item.SkuID,
+			IncrCount: item.Count, // Positive to restore stock
+		})
+	}
+	if err := s.skuSvc.UpdateSkuStock(ctx, &req.ProductSkuUpdateStockReq{Items: stockItems}); err != nil {
+		return nil, err
+	}
+	if order.SeckillActivityID > 0 {
 		for _, item := range items {
-			stockItems = append(stockItems, req.ProductSkuUpdateStockItemReq{
-				ID:        item.SkuID,
-				IncrCount: item.Count, // Positive to restore stock
-			})
+			if err := s.seckillSvc.IncrSeckillStock(ctx, tx, order.SeckillActivityID, item.SkuID, item.Count); err != nil {
+				return nil, err
+			}
 		}
-		if err := s.skuSvc.UpdateSkuStock(ctx, &req.ProductSkuUpdateStockReq{Items: stockItems}); err != nil {
-			return err
+	}
+
+	// 3. Refund Coupon
+	if order.CouponID > 0 {
+		if err := s.couponSvc.ReturnCoupon(ctx, order.UserID, order.CouponID); err != nil {
+			return nil, err
 		}
+	}
 
-		// 2.3 Refund Coupon
-		if order.CouponID > 0 {
-			if err := s.couponSvc.ReturnCoupon(ctx, uId, order.CouponID); err != nil {
-				return err
-			}
+	// 4. 退还使用的积分，扣除售后已退还的部分 (RefundPoint)
+	bizId := strconv.FormatInt(order.ID, 10)
+	latest, err := tx.TradeOrder.WithContext(ctx).Where(tx.TradeOrder.ID.Eq(order.ID)).First()
+	if err != nil {
+		return nil, err
+	}
+	if returnPoint := latest.UsePoint - latest.RefundPoint; returnPoint > 0 {
+		if err := s.pointRecordSvc.CreatePointRecordTx(ctx, tx, order.UserID, returnPoint, memberModel.PointBizTypeOrderUseCancel,
+			bizId, "订单取消退还积分", fmt.Sprintf("订单取消，退还 %d 积分", returnPoint)); err != nil {
+			return nil, err
 		}
+	}
 
-		// 2.4 Log
-		logOrder := *order
-		logOrder.Status = 40
-		if err := s.createOrderLog(ctx, &logOrder, "User Cancelled Order", 40); err != nil {
-			return err
+	// 5. 已支付的订单扣回赠送的积分，扣除售后已扣回的订单项部分。会员积分可能已经用掉，扣回失败只记录日志，不影响取消
+	if order.PayStatus {
+		deductPoint := latest.GivePoint
+		for _, item := range items {
+			if item.AfterSaleStatus == 30 { // 售后已退款，赠送积分已由 returnOrderItemPoint 扣回
+				deductPoint -= item.GivePoint
+			}
+		}
+		if deductPoint > 0 {
+			if err := s.deductGivePoint(ctx, tx, order.UserID, deductPoint, memberModel.PointBizTypeOrderGiveCancel, bizId,
+				"订单取消扣回赠送积分"); err != nil {
+				return nil, err
+			}
 		}
+	}
 
-		return nil
-	})
+	// 6. Log
+	logOrder := *order
+	logOrder.Status = 40
+	if err := s.createOrderLog(ctx, &logOrder, content, operateType); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
 
-	return err
+// incrSeckillStockCache 归还的秒杀库存提交后，同步归还缓存
+func (s *TradeOrderUpdateService) incrSeckillStockCache(ctx context.Context, order *trade.TradeOrder, items []*trade.TradeOrderItem) {
+	if order.SeckillActivityID <= 0 {
+		return
+	}
+	for _, item := range items {
+		s.seckillSvc.IncrSeckillStockCache(ctx, order.SeckillActivityID, item.SkuID, item.Count)
+	}
 }
 
 // DeleteOrder 删除订单
@@ -441,19 +732,48 @@ func (s *TradeOrderUpdateService) UpdateOrderPrice(ctx context.Context, req *req
 		"adjust_price": order.AdjustPrice + req.AdjustPrice,
 		"pay_price":    newPayPrice,
 	})
-	return err
+	if err != nil {
+		return err
+	}
+
+	// 记录操作日志
+	core.SetOperateLogAction(ctx, "订单【%s】支付金额从【%s】元修改为【%s】元", order.No, formatPrice(order.PayPrice), formatPrice(newPayPrice))
+	return nil
 }
 
 // UpdateOrderAddress 修改订单收货地址
 func (s *TradeOrderUpdateService) UpdateOrderAddress(ctx context.Context, req *req.TradeOrderUpdateAddressReq) error {
+	order, err := s.q.TradeOrder.WithContext(ctx).Where(s.q.TradeOrder.ID.Eq(req.ID)).First()
+	if err != nil {
+		return err
+	}
 	// Check status (only undelivered?)
-	_, err := s.q.TradeOrder.WithContext(ctx).Where(s.q.TradeOrder.ID.Eq(req.ID)).Updates(map[string]interface{}{
+	_, err = s.q.TradeOrder.WithContext(ctx).Where(s.q.TradeOrder.ID.Eq(req.ID)).Updates(map[string]interface{}{
 		"receiver_name":           req.ReceiverName,
 		"receiver_mobile":         req.ReceiverMobile,
 		"receiver_area_id":        req.ReceiverAreaID,
 		"receiver_detail_address": req.ReceiverDetailAddress,
 	})
-	return err
+	if err != nil {
+		return err
+	}
+
+	// 记录操作日志
+	core.SetOperateLogAction(ctx, "订单【%s】收货地址从【%s】修改为【%s】", order.No,
+		formatReceiver(order.ReceiverName, order.ReceiverMobile, order.ReceiverAreaID, order.ReceiverDetailAddress),
+		formatReceiver(req.ReceiverName, req.ReceiverMobile, req.ReceiverAreaID, req.ReceiverDetailAddress))
+	return nil
+}
+
+// formatPrice 金额（分）格式化为元，如 1050 -> 10.50
+func formatPrice(price int) string {
+	return fmt.Sprintf("%.2f", float64(price)/100)
+}
+
+// formatReceiver 收件人信息格式化，如 张三 138****5678 上海市 上海市 浦东新区 XX 路 1 号
+// 手机号脱敏，操作日志中不记录完整手机号
+func formatReceiver(name, mobile string, areaId int, detailAddress string) string {
+	return strings.Join([]string{name, utils.MaskMobile(mobile), area.Format(areaId), detailAddress}, " ")
 }
 
 // PickUpOrderByAdmin 核销订单 (By ID)
@@ -557,21 +877,152 @@ func (s *TradeOrderUpdateService) ReceiveOrder(ctx context.Context, uId int64, o
 	}
 
 	// 3. Update Order Status
+	return s.receiveOrder(ctx, order, "用户确认收货", 40) // 40: Receive
+}
+
+// ReceiveOrderBySystem 自动收货超时未确认收货的订单，返回收货的数量
+func (s *TradeOrderUpdateService) ReceiveOrderBySystem(ctx context.Context) (int, error) {
+	config, err := s.tradeConfigSvc.GetTradeConfig(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if config.AutoReceiveDays <= 0 {
+		return 0, nil
+	}
+
+	// 1. 查询发货超时的订单
+	expireTime := time.Now().AddDate(0, 0, -config.AutoReceiveDays)
+	orders, err := s.q.TradeOrder.WithContext(ctx).
+		Where(s.q.TradeOrder.Status.Eq(20), s.q.TradeOrder.DeliveryTime.Lt(expireTime)). // 20: Delivered
+		Find()
+	if err != nil {
+		return 0, err
+	}
+
+	// 2. 逐个收货，单个失败不影响其它订单
+	count := 0
+	var errs []error
+	for _, order := range orders {
+		if err := s.receiveOrder(ctx, order, "系统自动确认收货", 31); err != nil { // 31: System Receive
+			errs = append(errs, fmt.Errorf("receive order %d: %w", order.ID, err))
+			continue
+		}
+		count++
+	}
+	return count, errors.Join(errs...)
+}
+
+// receiveOrder 更新订单为已收货
+func (s *TradeOrderUpdateService) receiveOrder(ctx context.Context, order *trade.TradeOrder, content string, operateType int) error {
 	now := time.Now()
-	err = s.q.Transaction(func(tx *query.Query) error {
-		_, err := tx.TradeOrder.WithContext(ctx).Where(tx.TradeOrder.ID.Eq(order.ID)).Updates(trade.TradeOrder{
+	return s.q.Transaction(func(tx *query.Query) error {
+		info, err := tx.TradeOrder.WithContext(ctx).Where(tx.TradeOrder.ID.Eq(order.ID), tx.TradeOrder.Status.Eq(20)).Updates(trade.TradeOrder{
 			Status:      30, // Completed
 			ReceiveTime: &now,
 		})
 		if err != nil {
 			return err
 		}
+		if info.RowsAffected == 0 {
+			return errors.New("订单状态不正确，无法确认收货")
+		}
 		// Log
-		return s.createOrderLog(ctx, order, "用户确认收货", 40) // 40: Receive
+		return s.createOrderLog(ctx, order, content, operateType)
 	})
+}
+
+// CreateOrderItemCommentBySystem 自动好评超时未评价的订单，返回评价的订单数量
+func (s *TradeOrderUpdateService) CreateOrderItemCommentBySystem(ctx context.Context) (int, error) {
+	config, err := s.tradeConfigSvc.GetTradeConfig(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if config.AutoCommentDays <= 0 {
+		return 0, nil
+	}
+
+	// 1. 查询收货超时未评价的订单
+	expireTime := time.Now().AddDate(0, 0, -config.AutoCommentDays)
+	orders, err := s.q.TradeOrder.WithContext(ctx).
+		Where(s.q.TradeOrder.Status.Eq(30), s.q.TradeOrder.CommentStatus.Is(false), s.q.TradeOrder.ReceiveTime.Lt(expireTime)). // 30: Completed
+		Find()
+	if err != nil {
+		return 0, err
+	}
+
+	// 2. 逐个评价，单个失败不影响其它订单
+	count := 0
+	var errs []error
+	for _, order := range orders {
+		if err := s.createOrderItemCommentBySystem(ctx, order); err != nil {
+			errs = append(errs, fmt.Errorf("comment order %d: %w", order.ID, err))
+			continue
+		}
+		count++
+	}
+	return count, errors.Join(errs...)
+}
+
+// createOrderItemCommentBySystem 为订单下未评价的订单项创建默认好评
+func (s *TradeOrderUpdateService) createOrderItemCommentBySystem(ctx context.Context, order *trade.TradeOrder) error {
+	items, err := s.q.TradeOrderItem.WithContext(ctx).
+		Where(s.q.TradeOrderItem.OrderID.Eq(order.ID), s.q.TradeOrderItem.CommentStatus.Is(false)).
+		Find()
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if _, err := s.commentSvc.CreateAppComment(ctx, order.UserID, &req.AppProductCommentCreateReq{
+			OrderItemID:       item.ID,
+			Anonymous:         false,
+			Content:           "",
+			Scores:            5,
+			DescriptionScores: 5,
+			BenefitScores:     5,
+		}); err != nil {
+			return err
+		}
+	}
+	if _, err := s.q.TradeOrder.WithContext(ctx).Where(s.q.TradeOrder.ID.Eq(order.ID)).Update(s.q.TradeOrder.CommentStatus, true); err != nil {
+		return err
+	}
+	return s.createOrderLog(ctx, order, "系统自动好评", 34) // 34: System Comment
+}
+
+// deductGivePoint 扣回赠送的积分，余额不足时跳过并记录日志；其它错误返回，由调用方回滚
+func (s *TradeOrderUpdateService) deductGivePoint(ctx context.Context, tx *query.Query, userId int64, point int, bizType int, bizId string, title string) error {
+	err := s.pointRecordSvc.CreatePointRecordTx(ctx, tx, userId, -point, bizType, bizId, title, fmt.Sprintf("扣回赠送的 %d 积分", point))
+	var bizErr *core.BizError
+	if errors.As(err, &bizErr) && bizErr.Code == 1004014003 { // USER_POINT_NOT_ENOUGH
+		zap.L().Warn("[deductGivePoint][会员积分不足，跳过扣回赠送积分]", zap.Int64("userId", userId), zap.String("bizId", bizId), zap.Int("point", point))
+		return nil
+	}
 	return err
 }
 
+// returnOrderItemPoint 售后退款时，退还订单项分摊使用的积分，并扣回订单项赠送的积分
+func (s *TradeOrderUpdateService) returnOrderItemPoint(ctx context.Context, tx *query.Query, orderItemId int64) error {
+	item, err := tx.TradeOrderItem.WithContext(ctx).Where(tx.TradeOrderItem.ID.Eq(orderItemId)).First()
+	if err != nil {
+		return err
+	}
+	bizId := strconv.FormatInt(item.ID, 10)
+	if item.UsePoint > 0 {
+		if err := s.pointRecordSvc.CreatePointRecordTx(ctx, tx, item.UserID, item.UsePoint, memberModel.PointBizTypeOrderUseCancelItem,
+			bizId, "售后退还积分", fmt.Sprintf("售后退款，退还 %d 积分", item.UsePoint)); err != nil {
+			return err
+		}
+		o := tx.TradeOrder
+		if _, err := o.WithContext(ctx).Where(o.ID.Eq(item.OrderID)).Update(o.RefundPoint, o.RefundPoint.Add(item.UsePoint)); err != nil {
+			return err
+		}
+	}
+	if item.GivePoint > 0 {
+		return s.deductGivePoint(ctx, tx, item.UserID, item.GivePoint, memberModel.PointBizTypeOrderGiveCancelItem, bizId, "售后扣回赠送积分")
+	}
+	return nil
+}
+
 // UpdatePaidOrderRefunded 更新支付订单为已退款 (Callback from Pay)
 func (s *TradeOrderUpdateService) UpdatePaidOrderRefunded(ctx context.Context, orderId int64, payRefundId int64) error {
 	order, err := s.q.TradeOrder.WithContext(ctx).Where(s.q.TradeOrder.ID.Eq(orderId)).First()
@@ -586,7 +1037,7 @@ func (s *TradeOrderUpdateService) UpdatePaidOrderRefunded(ctx context.Context, o
 	// 0: None, 10: Apply, 20: Audit Pass, 30: Refunded?
 	// Assuming 30 is correct for now based on AfterSale logic.
 
-	return s.q.Transaction(func(tx *query.Query) error {
+	err = s.q.Transaction(func(tx *query.Query) error {
 		_, err := tx.TradeOrder.WithContext(ctx).Where(tx.TradeOrder.ID.Eq(orderId)).Updates(map[string]interface{}{
 			"refund_status": 30, // All Refunded
 			// "pay_refund_id": payRefundId, // No field
@@ -597,6 +1048,15 @@ func (s *TradeOrderUpdateService) UpdatePaidOrderRefunded(ctx context.Context, o
 		// Log
 		return s.createOrderLog(ctx, order, "Order Refunded (Pay Callback)", 40)
 	})
+	if err != nil {
+		return err
+	}
+
+	// 发送退款成功消息
+	if err := s.messageSvc.SendOrderRefundMessage(ctx, order); err != nil {
+		zap.L().Error("[UpdatePaidOrderRefunded][发送退款成功消息失败]", zap.Int64("orderId", orderId), zap.Error(err))
+	}
+	return nil
 }
 
 func generateOrderNo() string {
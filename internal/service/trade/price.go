@@ -2,23 +2,22 @@ package trade
 
 import (
 	"backend-go/internal/api/resp"
+	"backend-go/internal/model/trade"
+	"backend-go/internal/pkg/core"
 	memberSvc "backend-go/internal/service/member"
 	"backend-go/internal/service/product"
 	"backend-go/internal/service/promotion"
 	"context"
-	"errors"
+	"sort"
 )
 
 // TradePriceService 价格计算 Service
+// 先按 SKU 构建商品明细，再依次执行价格计算器链（秒杀、限时折扣与会员折扣、满减送、优惠券、积分抵扣、运费、赠送积分）
+// 对应 Java: TradePriceServiceImpl
 type TradePriceService struct {
-	productSkuSvc      *product.ProductSkuService
-	productSpuSvc      *product.ProductSpuService
-	couponSvc          *promotion.CouponUserService
-	rewardActivitySvc  *promotion.RewardActivityService
-	memberUserSvc      *memberSvc.MemberUserService
-	memberLevelSvc     *memberSvc.MemberLevelService
-	deliveryFreightSvc *DeliveryFreightTemplateService // Added
-	memberAddressSvc   *memberSvc.MemberAddressService // Added
+	productSkuSvc *product.ProductSkuService
+	productSpuSvc *product.ProductSpuService
+	calculators   []TradePriceCalculator
 }
 
 func NewTradePriceService(
@@ -26,21 +25,37 @@ func NewTradePriceService(
 	productSpuSvc *product.ProductSpuService,
 	couponSvc *promotion.CouponUserService,
 	rewardActivitySvc *promotion.RewardActivityService,
+	discountActivitySvc promotion.DiscountActivityService,
 	memberUserSvc *memberSvc.MemberUserService,
 	memberLevelSvc *memberSvc.MemberLevelService,
-	deliveryFreightSvc *DeliveryFreightTemplateService, // Added
-	memberAddressSvc *memberSvc.MemberAddressService, // Added
+	deliveryFreightSvc *DeliveryFreightTemplateService,
+	memberAddressSvc *memberSvc.MemberAddressService,
+	tradeConfigSvc *TradeConfigService,
+	seckillActivitySvc *promotion.SeckillActivityService,
+	orderQuerySvc *TradeOrderQueryService,
 ) *TradePriceService {
-	return &TradePriceService{
-		productSkuSvc:      productSkuSvc,
-		productSpuSvc:      productSpuSvc,
-		couponSvc:          couponSvc,
-		rewardActivitySvc:  rewardActivitySvc,
-		memberUserSvc:      memberUserSvc,
-		memberLevelSvc:     memberLevelSvc,
-		deliveryFreightSvc: deliveryFreightSvc,
-		memberAddressSvc:   memberAddressSvc,
+	s := &TradePriceService{
+		productSkuSvc: productSkuSvc,
+		productSpuSvc: productSpuSvc,
 	}
+	s.RegisterCalculator(
+		NewTradeSeckillActivityPriceCalculator(seckillActivitySvc, orderQuerySvc),
+		NewTradeDiscountActivityPriceCalculator(discountActivitySvc, memberUserSvc, memberLevelSvc),
+		NewTradeRewardActivityPriceCalculator(rewardActivitySvc),
+		NewTradeCouponPriceCalculator(couponSvc),
+		NewTradePointUsePriceCalculator(tradeConfigSvc, memberUserSvc),
+		NewTradeDeliveryPriceCalculator(deliveryFreightSvc, memberAddressSvc),
+		NewTradePointGivePriceCalculator(tradeConfigSvc),
+	)
+	return s
+}
+
+// RegisterCalculator 注册价格计算器，按 Order 升序执行；Order 相同时保持注册顺序
+func (s *TradePriceService) RegisterCalculator(calculators ...TradePriceCalculator) {
+	s.calculators = append(s.calculators, calculators...)
+	sort.SliceStable(s.calculators, func(i, j int) bool {
+		return s.calculators[i].Order() < s.calculators[j].Order()
+	})
 }
 
 // TradePriceCalculateReqBO 价格计算 Request BO
@@ -51,7 +66,9 @@ type TradePriceCalculateReqBO struct {
 	DeliveryType  int
 	AddressID     *int64
 	PickUpStoreID *int64
-	Items         []TradePriceCalculateItemBO
+	// SeckillActivityID 秒杀活动编号，非空时为秒杀订单
+	SeckillActivityID *int64
+	Items             []TradePriceCalculateItemBO
 }
 
 type TradePriceCalculateItemBO struct {
@@ -66,6 +83,7 @@ type TradePriceCalculateRespBO struct {
 	Type       int
 	Price      TradePriceCalculatePriceBO
 	Items      []TradePriceCalculateItemRespBO
+	Promotions []TradePriceCalculatePromotionBO
 	CouponID   int64
 	TotalPoint int
 	UsePoint   int
@@ -84,257 +102,134 @@ type TradePriceCalculatePriceBO struct {
 }
 
 type TradePriceCalculateItemRespBO struct {
-	SpuID         int64
+	SpuID              int64
+	SkuID              int64
+	Count              int
+	CartID             int64
+	Selected           bool
+	Price              int
+	DiscountPrice      int
+	DeliveryPrice      int
+	CouponPrice        int
+	PointPrice         int
+	UsePoint           int
+	VipPrice           int
+	PayPrice           int
+	SpuName            string
+	PicURL             string
+	CategoryID         int64
+	DeliveryTypes      []int
+	DeliveryTemplateID int64
+	GivePoint          int
+	Properties         []resp.ProductSkuPropertyResp
+}
+
+// TradePriceCalculatePromotionBO 营销明细，每个生效（或未满足条件）的营销活动一条，供结算页逐条展示
+type TradePriceCalculatePromotionBO struct {
+	ID            int64
+	Name          string
+	Type          int // 营销类型，见 trade.PromotionType*
+	TotalPrice    int // 参与计算的商品总价
+	DiscountPrice int // 优惠金额
+	Match         bool
+	Description   string
+	Items         []TradePriceCalculatePromotionItemBO
+}
+
+// TradePriceCalculatePromotionItemBO 营销明细中的商品
+type TradePriceCalculatePromotionItemBO struct {
 	SkuID         int64
-	Count         int
-	CartID        int64
-	Selected      bool
-	Price         int
-	DiscountPrice int
-	DeliveryPrice int
-	CouponPrice   int
-	PointPrice    int
-	UsePoint      int
-	VipPrice      int
-	PayPrice      int
-	SpuName       string
-	PicURL        string
-	CategoryID    int64
-	DeliveryTypes []int
-	GivePoint     int
-	Properties    []resp.ProductSkuPropertyResp
+	TotalPrice    int // 参与计算的商品金额
+	DiscountPrice int // 分摊到该商品的优惠金额
+	PayPrice      int // 优惠后的商品金额
 }
 
 // CalculateOrderPrice 价格计算
-// simplified version: only calculates base price = SKU Price * Count.
-// Ignores coupons, points, VIP, and shipping fees for now.
 func (s *TradePriceService) CalculateOrderPrice(ctx context.Context, req *TradePriceCalculateReqBO) (*TradePriceCalculateRespBO, error) {
-	// 1. Get SKU IDs
-	var skuIDs []int64
+	// 1. 构建商品明细，初始价格为 SKU 价格 * 数量
+	result, err := s.buildCalculateResp(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 依次执行价格计算器
+	for _, calculator := range s.calculators {
+		if err := calculator.Calculate(ctx, req, result); err != nil {
+			return nil, err
+		}
+	}
+
+	// 3. 支付金额不能小于 0
+	if result.Price.PayPrice < 0 {
+		return nil, core.NewBizError(1011003000, "支付价格计算异常，原因：价格小于 0") // PRICE_CALCULATE_PAY_PRICE_ILLEGAL
+	}
+	return result, nil
+}
+
+// buildCalculateResp 按 SKU 与 SPU 构建商品明细，只包含选中的商品
+func (s *TradePriceService) buildCalculateResp(ctx context.Context, req *TradePriceCalculateReqBO) (*TradePriceCalculateRespBO, error) {
+	skuIDs := make([]int64, 0, len(req.Items))
 	for _, item := range req.Items {
 		skuIDs = append(skuIDs, item.SkuID)
 	}
-
-	// 2. Fetch SKUs
 	skus, err := s.productSkuSvc.GetSkuList(ctx, skuIDs)
 	if err != nil {
 		return nil, err
 	}
-	skuMap := make(map[int64]*resp.ProductSkuResp)
+	skuMap := make(map[int64]*resp.ProductSkuResp, len(skus))
 	var spuIDs []int64
-	spuIdMapKeys := make(map[int64]bool) // To avoid duplicates for query
+	spuIDSet := make(map[int64]bool)
 	for _, sku := range skus {
 		skuMap[sku.ID] = sku
-		if !spuIdMapKeys[sku.SpuID] {
+		if !spuIDSet[sku.SpuID] {
+			spuIDSet[sku.SpuID] = true
 			spuIDs = append(spuIDs, sku.SpuID)
-			spuIdMapKeys[sku.SpuID] = true
 		}
 	}
-
-	// 2.1 Fetch SPUs for CategoryID
 	spus, err := s.productSpuSvc.GetSpuList(ctx, spuIDs)
 	if err != nil {
 		return nil, err
 	}
-	spuMap := make(map[int64]*resp.ProductSpuResp)
+	spuMap := make(map[int64]*resp.ProductSpuResp, len(spus))
 	for _, spu := range spus {
 		spuMap[spu.ID] = spu
 	}
 
-	// 3. Initialize Response
-	respBO := &TradePriceCalculateRespBO{
-		Price:   TradePriceCalculatePriceBO{},
-		Items:   make([]TradePriceCalculateItemRespBO, 0),
-		Success: true,
+	result := &TradePriceCalculateRespBO{
+		Type:       trade.TradeOrderTypeNormal,
+		Items:      make([]TradePriceCalculateItemRespBO, 0, len(req.Items)),
+		Promotions: make([]TradePriceCalculatePromotionBO, 0),
+		Success:    true,
 	}
-
-	var totalPrice, totalPayPrice int
-
-	// 4. Calculate VIP Level Discount
-	levelDiscountPercent := 100
-	if req.UserID > 0 {
-		user, _ := s.memberUserSvc.GetUser(ctx, req.UserID)
-		if user != nil && user.LevelID > 0 {
-			level, _ := s.memberLevelSvc.GetLevel(ctx, user.LevelID)
-			if level != nil && level.Status == 0 {
-				levelDiscountPercent = level.DiscountPercent
-			}
-		}
-	}
-
-	var totalVipPrice int // Only declare new var
-
-	// 4. Loop Items and Calculate
 	for _, item := range req.Items {
 		if !item.Selected {
 			continue
 		}
 		sku, ok := skuMap[item.SkuID]
 		if !ok {
-			return nil, errors.New("商品不存在")
+			return nil, core.NewBizError(1007001001, "商品 SKU 不存在")
 		}
 		spu, ok := spuMap[sku.SpuID]
 		if !ok {
-			return nil, errors.New("商品 SPU 不存在")
+			return nil, core.NewBizError(1008005000, "商品 SPU 不存在") // SPU_NOT_EXISTS
 		}
-
-		// Calculate Item Price
-		itemPrice := sku.Price
-		itemPayPrice := itemPrice * item.Count
-
-		// Calculate VIP Price (Savings)
-		// VipPrice in ItemResp usually means "The Price if VIP" or "The Discount Amount"?
-		// Checked RespBO: VipPrice int.
-		// Let's assume VipPrice field in ItemResp is the "Discount Amount" for consistency with others?
-		// Or is it "Unit Price for VIP"?
-		// Usually in Order Item, we store "Original Price", "Pay Price", "Discount Amount".
-		// Let's settle on: `VipPrice` (in ItemResp) = Total VIP Savings for this item.
-
-		itemVipSavings := 0
-		if levelDiscountPercent < 100 {
-			// Savings = Original * Count * (1 - Discount%)
-			// Avoid slight precision issues by: Price * Count - (Price * Count * Discount / 100)
-			vipTotal := int(int64(itemPrice) * int64(item.Count) * int64(levelDiscountPercent) / 100)
-			itemVipSavings = itemPayPrice - vipTotal
-		}
-
-		itemResp := TradePriceCalculateItemRespBO{
-			SpuID:    sku.SpuID,
-			SkuID:    sku.ID,
-			Count:    item.Count,
-			CartID:   item.CartID,
-			Selected: item.Selected,
-			Price:    itemPrice,
-			PayPrice: itemPayPrice, // Will be reduced later? Or currently logic flow?
-			// Use logic: Calculate Base Pay Price here. Deductions are separate?
-			// Current logic: PayPrice is accumulated.
-			PicURL:     sku.PicURL,
-			Properties: sku.Properties,
-			SpuName:    spu.Name,
-			CategoryID: spu.CategoryID,
-			VipPrice:   itemVipSavings, // Store Savings
-		}
-
-		totalPrice += itemPayPrice
-		totalPayPrice += itemPayPrice
-		totalVipPrice += itemVipSavings
-
-		respBO.Items = append(respBO.Items, itemResp)
-	}
-
-	// 5. Set Totals
-	// 5. Calculate Reward Activity (Full Reduction)
-	matchItems := make([]promotion.ActivityMatchItem, 0)
-	for _, item := range respBO.Items {
-		matchItems = append(matchItems, promotion.ActivityMatchItem{
-			SkuID:      item.SkuID,
-			SpuID:      item.SpuID,
-			CategoryID: item.CategoryID,
-			Price:      item.Price, // Unit Price (Original)
-			Count:      item.Count,
+		result.Items = append(result.Items, TradePriceCalculateItemRespBO{
+			SpuID:              sku.SpuID,
+			SkuID:              sku.ID,
+			Count:              item.Count,
+			CartID:             item.CartID,
+			Selected:           item.Selected,
+			Price:              sku.Price,
+			PayPrice:           sku.Price * item.Count,
+			SpuName:            spu.Name,
+			PicURL:             sku.PicURL,
+			CategoryID:         spu.CategoryID,
+			DeliveryTypes:      spu.DeliveryTypes,
+			DeliveryTemplateID: spu.DeliveryTemplateID,
+			GivePoint:          spu.GiveIntegral * item.Count,
+			Properties:         sku.Properties,
 		})
 	}
-	activityDiscount, _, err := s.rewardActivitySvc.CalculateRewardActivity(ctx, matchItems)
-	if err != nil {
-		return nil, err
-	}
-
-	// 5. Total Price
-	respBO.Price.TotalPrice = totalPrice
-	respBO.Price.DiscountPrice = activityDiscount // Set Activity Discount
-	respBO.Price.VipPrice = totalVipPrice         // Set VIP Discount
-	// 5. Calculate Delivery Price
-	deliveryPrice := 0
-	if req.DeliveryType == 1 && req.AddressID != nil && *req.AddressID > 0 { // Express Delivery
-		// Get Address to get AreaID
-		address, err := s.memberAddressSvc.GetAddress(ctx, req.UserID, *req.AddressID)
-		if err != nil {
-			return nil, err
-		}
-		if address != nil {
-			// Calculate Freight based on items
-			// Group items by TemplateID? SPU has TemplateID usually.
-			// Current Model SPU has DeliveryTemplateID?
-			// Let's assume SPU has DeliveryTemplateId. Need to check ProductSpuResp.
-			// If not available in Resp, need to fetch SPU Entity or assume simpler model.
-			// ProductSpuResp (Step 3936 viewed?) spuMap uses *resp.ProductSpuResp.
-			// Let's check ProductSpuResp definition if possible, or assume it has DeliveryTemplateID.
-			// If not, we might need to fetch it.
-			// For this iteration, I'll group by SPU's TemplateID.
-
-			// Map TemplateID -> Count (or Weight/Volume)
-			// Assuming Count for now as per CalculateFreight logic
-			templateMap := make(map[int64]int)
-			for _, item := range respBO.Items {
-				spu := spuMap[item.SpuID]
-				if spu != nil {
-					// Use SPU's delivery template ID. If 0, assume free or default?
-					// If spu.DeliveryTemplateID is not in VO, we are stuck.
-					// Checking viewed code: Step 3936 line 128 `spuMap[spu.ID] = spu`.
-					// I don't see ProductSpuResp definition.
-					// I will assume it has `DeliveryTemplateId`.
-					templateMap[spu.DeliveryTemplateID] += item.Count
-				}
-			}
-
-			for tplID, count := range templateMap {
-				if tplID > 0 {
-					p, err := s.deliveryFreightSvc.CalculateFreight(ctx, tplID, int(address.AreaID), count)
-					if err != nil {
-						// log error? fail?
-						return nil, err
-					}
-					deliveryPrice += p
-				}
-			}
-		}
-	}
-	respBO.Price.DeliveryPrice = deliveryPrice
-
-	// Initial Pay Price after Activity AND VIP
-	// Note: If Activity + VIP > Total, PayPrice = 0.
-	payPrice := totalPrice - activityDiscount - totalVipPrice
-	if payPrice < 0 {
-		payPrice = 0
-	}
-	respBO.Price.PayPrice = payPrice
-
-	// 6. Calculate Coupon
-	if req.CouponID != nil && *req.CouponID > 0 {
-		// Prepare Context for Coupon Check
-		// Need SPU IDs and Category IDs. We have SKU Map.
-		// Note: ProductSkuResp contains CategoryID inside `ProductSkuResp`?
-		// Let's check ProductSkuResp struct definition if possible. Assuming it has CategoryID.
-		var spuIDs []int64
-		var categoryIDs []int64
-		// Helper map to avoid duplicates
-		spuMap := make(map[int64]bool)
-		catMap := make(map[int64]bool)
-
-		for _, item := range respBO.Items {
-			if !spuMap[item.SpuID] {
-				spuIDs = append(spuIDs, item.SpuID)
-				spuMap[item.SpuID] = true
-			}
-			if !catMap[item.CategoryID] {
-				categoryIDs = append(categoryIDs, item.CategoryID)
-				catMap[item.CategoryID] = true
-			}
-		}
-
-		couponPrice, err := s.couponSvc.CalculateCoupon(ctx, req.UserID, *req.CouponID, int64(respBO.Price.PayPrice), spuIDs, categoryIDs)
-		if err != nil {
-			// If coupon invalid, strictly we should return error or just ignore?
-			// Usually return error to tell user why coupon failed.
-			return nil, err
-		}
-		respBO.CouponID = *req.CouponID
-		respBO.Price.CouponPrice = int(couponPrice)
-		respBO.Price.PayPrice -= int(couponPrice)
-		if respBO.Price.PayPrice < 0 {
-			respBO.Price.PayPrice = 0 // Min 0
-		}
-	}
-
-	return respBO, nil
+	recountAllPrice(result)
+	return result, nil
 }
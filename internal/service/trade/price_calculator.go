@@ -0,0 +1,141 @@
+package trade
+
+import (
+	"context"
+	"sort"
+)
+
+// TradePriceCalculator 价格计算器
+// 每个计算器负责一类营销（或运费），将金额分摊到商品明细后重新汇总订单金额，并通过 addPromotion 记录营销明细
+// 新增营销类型时，实现该接口并在 NewTradePriceService 中注册即可
+// 对应 Java: TradePriceCalculator
+type TradePriceCalculator interface {
+	// Order 执行顺序，越小越先执行，见 TradePriceOrder* 常量
+	Order() int
+	// Calculate 计算价格，修改 result
+	Calculate(ctx context.Context, req *TradePriceCalculateReqBO, result *TradePriceCalculateRespBO) error
+}
+
+// 价格计算器的执行顺序
+// 秒杀活动会改变商品单价，最先执行；
+// 之后是针对商品的折扣，再是针对订单的满减与优惠券，最后计算积分抵扣与运费
+// 砍价、拼团、积分商城下单尚未接入，接入时在此新增执行顺序 (与秒杀相同) 并注册对应的计算器
+const (
+	TradePriceOrderSeckillActivity  = 8
+	TradePriceOrderDiscountActivity = 10
+	TradePriceOrderRewardActivity   = 20
+	TradePriceOrderCoupon           = 30
+	TradePriceOrderPointUse         = 40
+	TradePriceOrderDelivery         = 50
+	TradePriceOrderPointGive        = 999
+)
+
+// recountItemPayPrice 重新计算商品的支付金额
+func recountItemPayPrice(item *TradePriceCalculateItemRespBO) {
+	item.PayPrice = item.Price*item.Count - item.DiscountPrice - item.VipPrice -
+		item.CouponPrice - item.PointPrice + item.DeliveryPrice
+}
+
+// recountAllPrice 重新计算订单金额，由商品明细汇总，保证订单各项金额等于商品分摊之和
+func recountAllPrice(result *TradePriceCalculateRespBO) {
+	price := TradePriceCalculatePriceBO{}
+	for _, item := range result.Items {
+		price.TotalPrice += item.Price * item.Count
+		price.DiscountPrice += item.DiscountPrice
+		price.DeliveryPrice += item.DeliveryPrice
+		price.CouponPrice += item.CouponPrice
+		price.PointPrice += item.PointPrice
+		price.VipPrice += item.VipPrice
+		price.PayPrice += item.PayPrice
+	}
+	result.Price = price
+}
+
+// dividePrice 将 price 按商品当前支付金额的比例分摊到各商品，返回与 items 一一对应的分摊金额
+// 先按比例向下取整，剩余的零头按余数从大到小逐分分配，余数相同时靠前的商品优先，保证分摊之和恰好等于 price
+// 对应 Java: TradePriceCalculatorHelper#dividePrice
+func dividePrice(items []*TradePriceCalculateItemRespBO, price int) []int {
+	prices := make([]int, len(items))
+	if len(items) == 0 || price == 0 {
+		return prices
+	}
+	var total int64
+	for _, item := range items {
+		total += int64(max(item.PayPrice, 0))
+	}
+	// 商品均已免费时无法按比例分摊，全部计入第一个商品
+	if total <= 0 {
+		prices[0] = price
+		return prices
+	}
+
+	remainders := make([]int64, len(items))
+	allocated := 0
+	for i, item := range items {
+		share := int64(price) * int64(max(item.PayPrice, 0))
+		prices[i] = int(share / total)
+		remainders[i] = share % total
+		allocated += prices[i]
+	}
+	indexes := make([]int, len(items))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return remainders[indexes[i]] > remainders[indexes[j]]
+	})
+	for i := 0; allocated < price; i++ {
+		prices[indexes[i%len(indexes)]]++
+		allocated++
+	}
+	return prices
+}
+
+// sumItemPayPrice 商品当前支付金额之和
+func sumItemPayPrice(items []*TradePriceCalculateItemRespBO) int {
+	total := 0
+	for _, item := range items {
+		total += item.PayPrice
+	}
+	return total
+}
+
+// addPromotion 记录营销明细
+// items 为参与该营销的商品，discounts 为对应商品的优惠金额，为 nil 时只记录参与的商品
+// 未满足条件时 discountPrice 为 0、match 为 false，结算页用于提示还差多少满足条件
+func addPromotion(result *TradePriceCalculateRespBO, id int64, name string, promotionType int,
+	items []*TradePriceCalculateItemRespBO, discounts []int, discountPrice int, match bool, description string) {
+	promotion := TradePriceCalculatePromotionBO{
+		ID:            id,
+		Name:          name,
+		Type:          promotionType,
+		DiscountPrice: discountPrice,
+		Match:         match,
+		Description:   description,
+		Items:         make([]TradePriceCalculatePromotionItemBO, len(items)),
+	}
+	for i, item := range items {
+		totalPrice := item.Price * item.Count
+		discount := 0
+		if discounts != nil {
+			discount = discounts[i]
+		}
+		promotion.TotalPrice += totalPrice
+		promotion.Items[i] = TradePriceCalculatePromotionItemBO{
+			SkuID:         item.SkuID,
+			TotalPrice:    totalPrice,
+			DiscountPrice: discount,
+			PayPrice:      totalPrice - discount,
+		}
+	}
+	result.Promotions = append(result.Promotions, promotion)
+}
+
+// itemPointers 返回商品明细的指针，便于计算器直接修改
+func itemPointers(result *TradePriceCalculateRespBO) []*TradePriceCalculateItemRespBO {
+	items := make([]*TradePriceCalculateItemRespBO, len(result.Items))
+	for i := range result.Items {
+		items[i] = &result.Items[i]
+	}
+	return items
+}
@@ -0,0 +1,70 @@
+package trade
+
+import (
+	"backend-go/internal/model/trade"
+	"backend-go/internal/service/promotion"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// TradeCouponPriceCalculator 优惠券价格计算器，在满减送之后、按适用商品的当前支付金额计算优惠券门槛与折扣
+// 对应 Java: TradeCouponPriceCalculator
+type TradeCouponPriceCalculator struct {
+	couponSvc *promotion.CouponUserService
+}
+
+func NewTradeCouponPriceCalculator(couponSvc *promotion.CouponUserService) *TradeCouponPriceCalculator {
+	return &TradeCouponPriceCalculator{couponSvc: couponSvc}
+}
+
+func (c *TradeCouponPriceCalculator) Order() int {
+	return TradePriceOrderCoupon
+}
+
+func (c *TradeCouponPriceCalculator) Calculate(ctx context.Context, req *TradePriceCalculateReqBO, result *TradePriceCalculateRespBO) error {
+	if req.CouponID == nil || *req.CouponID <= 0 {
+		return nil
+	}
+	if result.Type != trade.TradeOrderTypeNormal {
+		return errors.New("只有普通订单，才能使用优惠券")
+	}
+	// 1. 筛选优惠券适用的商品
+	items := itemPointers(result)
+	spuIDs := make([]int64, len(items))
+	categoryIDs := make([]int64, len(items))
+	for i, item := range items {
+		spuIDs[i] = item.SpuID
+		categoryIDs[i] = item.CategoryID
+	}
+	indexes, err := c.couponSvc.GetCouponMatchItems(ctx, req.UserID, *req.CouponID, spuIDs, categoryIDs)
+	if err != nil {
+		return err
+	}
+	matchItems := make([]*TradePriceCalculateItemRespBO, len(indexes))
+	matchSpuIDs := make([]int64, len(indexes))
+	matchCategoryIDs := make([]int64, len(indexes))
+	for i, index := range indexes {
+		matchItems[i] = items[index]
+		matchSpuIDs[i] = spuIDs[index]
+		matchCategoryIDs[i] = categoryIDs[index]
+	}
+
+	// 2. 计算优惠金额，优惠券不可用时返回错误，提示用户原因
+	couponPrice, err := c.couponSvc.CalculateCoupon(ctx, req.UserID, *req.CouponID, int64(sumItemPayPrice(matchItems)), matchSpuIDs, matchCategoryIDs)
+	if err != nil {
+		return err
+	}
+
+	// 3. 按比例分摊到适用的商品
+	discounts := dividePrice(matchItems, int(couponPrice))
+	for i, item := range matchItems {
+		item.CouponPrice += discounts[i]
+		recountItemPayPrice(item)
+	}
+	result.CouponID = *req.CouponID
+	recountAllPrice(result)
+	addPromotion(result, *req.CouponID, "优惠券", trade.PromotionTypeCoupon, matchItems, discounts, int(couponPrice), true,
+		fmt.Sprintf("优惠券：省 %s 元", formatPrice(int(couponPrice))))
+	return nil
+}
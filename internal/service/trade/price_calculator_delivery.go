@@ -0,0 +1,69 @@
+package trade
+
+import (
+	"backend-go/internal/model/trade"
+	memberSvc "backend-go/internal/service/member"
+	"context"
+)
+
+// TradeDeliveryPriceCalculator 运费价格计算器，快递发货时按商品的运费模板与收件地址计算运费，到店自提不收运费
+// 对应 Java: TradeDeliveryPriceCalculator
+type TradeDeliveryPriceCalculator struct {
+	deliveryFreightSvc *DeliveryFreightTemplateService
+	memberAddressSvc   *memberSvc.MemberAddressService
+}
+
+func NewTradeDeliveryPriceCalculator(deliveryFreightSvc *DeliveryFreightTemplateService, memberAddressSvc *memberSvc.MemberAddressService) *TradeDeliveryPriceCalculator {
+	return &TradeDeliveryPriceCalculator{
+		deliveryFreightSvc: deliveryFreightSvc,
+		memberAddressSvc:   memberAddressSvc,
+	}
+}
+
+func (c *TradeDeliveryPriceCalculator) Order() int {
+	return TradePriceOrderDelivery
+}
+
+func (c *TradeDeliveryPriceCalculator) Calculate(ctx context.Context, req *TradePriceCalculateReqBO, result *TradePriceCalculateRespBO) error {
+	if req.DeliveryType != trade.DeliveryTypeExpress || req.AddressID == nil || *req.AddressID <= 0 {
+		return nil
+	}
+	address, err := c.memberAddressSvc.GetAddress(ctx, req.UserID, *req.AddressID)
+	if err != nil {
+		return err
+	}
+	if address == nil {
+		return nil
+	}
+
+	// 按运费模板汇总件数，未设置模板的商品包邮
+	templateItems := make(map[int64][]*TradePriceCalculateItemRespBO)
+	var templateIDs []int64
+	for _, item := range itemPointers(result) {
+		if item.DeliveryTemplateID <= 0 {
+			continue
+		}
+		if _, ok := templateItems[item.DeliveryTemplateID]; !ok {
+			templateIDs = append(templateIDs, item.DeliveryTemplateID)
+		}
+		templateItems[item.DeliveryTemplateID] = append(templateItems[item.DeliveryTemplateID], item)
+	}
+	for _, templateID := range templateIDs {
+		items := templateItems[templateID]
+		count := 0
+		for _, item := range items {
+			count += item.Count
+		}
+		price, err := c.deliveryFreightSvc.CalculateFreight(ctx, templateID, int(address.AreaID), count)
+		if err != nil {
+			return err
+		}
+		// 运费按比例分摊到使用该模板的商品
+		for i, part := range dividePrice(items, price) {
+			items[i].DeliveryPrice += part
+			recountItemPayPrice(items[i])
+		}
+	}
+	recountAllPrice(result)
+	return nil
+}
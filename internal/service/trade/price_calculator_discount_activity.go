@@ -0,0 +1,132 @@
+package trade
+
+import (
+	"backend-go/internal/model/member"
+	promotionModel "backend-go/internal/model/promotion"
+	"backend-go/internal/model/trade"
+	memberSvc "backend-go/internal/service/member"
+	"backend-go/internal/service/promotion"
+	"context"
+	"fmt"
+)
+
+// TradeDiscountActivityPriceCalculator 限时折扣与会员等级折扣价格计算器
+// 两者都作用于单个商品且不能叠加，每个商品取优惠更多的一个：限时折扣计入 DiscountPrice，会员折扣计入 VipPrice
+// 对应 Java: TradeDiscountActivityPriceCalculator
+type TradeDiscountActivityPriceCalculator struct {
+	discountActivitySvc promotion.DiscountActivityService
+	memberUserSvc       *memberSvc.MemberUserService
+	memberLevelSvc      *memberSvc.MemberLevelService
+}
+
+func NewTradeDiscountActivityPriceCalculator(discountActivitySvc promotion.DiscountActivityService,
+	memberUserSvc *memberSvc.MemberUserService, memberLevelSvc *memberSvc.MemberLevelService) *TradeDiscountActivityPriceCalculator {
+	return &TradeDiscountActivityPriceCalculator{
+		discountActivitySvc: discountActivitySvc,
+		memberUserSvc:       memberUserSvc,
+		memberLevelSvc:      memberLevelSvc,
+	}
+}
+
+func (c *TradeDiscountActivityPriceCalculator) Order() int {
+	return TradePriceOrderDiscountActivity
+}
+
+func (c *TradeDiscountActivityPriceCalculator) Calculate(ctx context.Context, req *TradePriceCalculateReqBO, result *TradePriceCalculateRespBO) error {
+	// 只有普通订单参与限时折扣与会员折扣
+	if result.Type != trade.TradeOrderTypeNormal || len(result.Items) == 0 {
+		return nil
+	}
+	// 1. 获得商品生效的限时折扣与会员等级
+	items := itemPointers(result)
+	skuIDs := make([]int64, len(items))
+	for i, item := range items {
+		skuIDs[i] = item.SkuID
+	}
+	products, err := c.discountActivitySvc.GetMatchDiscountProductListBySkuIds(ctx, skuIDs)
+	if err != nil {
+		return err
+	}
+	productMap := make(map[int64]*promotionModel.PromotionDiscountProduct, len(products))
+	for _, product := range products {
+		productMap[product.SkuID] = product
+	}
+	level := c.getMemberLevel(ctx, req.UserID)
+
+	// 2. 逐个商品比较，取优惠更多的一个
+	var vipItems []*TradePriceCalculateItemRespBO
+	var vipDiscounts []int
+	activityItems := make(map[int64][]*TradePriceCalculateItemRespBO)
+	activityDiscounts := make(map[int64][]int)
+	var activities []*promotionModel.PromotionDiscountProduct
+	for _, item := range items {
+		vipPrice := calculateMemberLevelPrice(level, item)
+		discountPrice := 0
+		product := productMap[item.SkuID]
+		if product != nil {
+			discountPrice = item.PayPrice - promotion.CalculateDiscountProductPrice(product, item.Price, item.Count)
+		}
+		switch {
+		case discountPrice > 0 && discountPrice >= vipPrice:
+			item.DiscountPrice += discountPrice
+			if _, ok := activityItems[product.ActivityID]; !ok {
+				activities = append(activities, product)
+			}
+			activityItems[product.ActivityID] = append(activityItems[product.ActivityID], item)
+			activityDiscounts[product.ActivityID] = append(activityDiscounts[product.ActivityID], discountPrice)
+		case vipPrice > 0:
+			item.VipPrice += vipPrice
+			vipItems = append(vipItems, item)
+			vipDiscounts = append(vipDiscounts, vipPrice)
+		default:
+			continue
+		}
+		recountItemPayPrice(item)
+	}
+	recountAllPrice(result)
+
+	// 3. 记录营销明细
+	for _, activity := range activities {
+		discounts := activityDiscounts[activity.ActivityID]
+		addPromotion(result, activity.ActivityID, activity.ActivityName, trade.PromotionTypeDiscountActivity,
+			activityItems[activity.ActivityID], discounts, sumInts(discounts), true,
+			fmt.Sprintf("限时折扣：省 %s 元", formatPrice(sumInts(discounts))))
+	}
+	if len(vipItems) > 0 {
+		addPromotion(result, level.ID, level.Name, trade.PromotionTypeMemberLevel, vipItems, vipDiscounts, sumInts(vipDiscounts), true,
+			fmt.Sprintf("会员等级折扣：省 %s 元", formatPrice(sumInts(vipDiscounts))))
+	}
+	return nil
+}
+
+// getMemberLevel 获得会员享受折扣的等级，会员或等级不存在、等级已关闭、无折扣时返回 nil
+func (c *TradeDiscountActivityPriceCalculator) getMemberLevel(ctx context.Context, userId int64) *member.MemberLevel {
+	if userId <= 0 {
+		return nil
+	}
+	user, _ := c.memberUserSvc.GetUser(ctx, userId)
+	if user == nil || user.LevelID <= 0 {
+		return nil
+	}
+	level, _ := c.memberLevelSvc.GetLevel(ctx, user.LevelID)
+	if level == nil || level.Status != 0 || level.DiscountPercent <= 0 || level.DiscountPercent >= 100 {
+		return nil
+	}
+	return level
+}
+
+// calculateMemberLevelPrice 计算商品的会员折扣金额，先乘后除避免精度损失
+func calculateMemberLevelPrice(level *member.MemberLevel, item *TradePriceCalculateItemRespBO) int {
+	if level == nil {
+		return 0
+	}
+	return item.PayPrice - int(int64(item.PayPrice)*int64(level.DiscountPercent)/100)
+}
+
+func sumInts(values []int) int {
+	total := 0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
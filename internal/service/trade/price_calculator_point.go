@@ -0,0 +1,104 @@
+package trade
+
+import (
+	"backend-go/internal/model/trade"
+	memberSvc "backend-go/internal/service/member"
+	"context"
+	"fmt"
+)
+
+// TradePointUsePriceCalculator 积分抵扣价格计算器
+// 按交易配置，每个积分抵扣 PointTradeDeductUnitPrice 分，最多抵扣商品支付金额的 PointTradeDeductMaxPercent%，且至少保留 1 分
+// 对应 Java: TradePointUsePriceCalculator
+type TradePointUsePriceCalculator struct {
+	tradeConfigSvc *TradeConfigService
+	memberUserSvc  *memberSvc.MemberUserService
+}
+
+func NewTradePointUsePriceCalculator(tradeConfigSvc *TradeConfigService, memberUserSvc *memberSvc.MemberUserService) *TradePointUsePriceCalculator {
+	return &TradePointUsePriceCalculator{
+		tradeConfigSvc: tradeConfigSvc,
+		memberUserSvc:  memberUserSvc,
+	}
+}
+
+func (c *TradePointUsePriceCalculator) Order() int {
+	return TradePriceOrderPointUse
+}
+
+func (c *TradePointUsePriceCalculator) Calculate(ctx context.Context, req *TradePriceCalculateReqBO, result *TradePriceCalculateRespBO) error {
+	if req.UserID <= 0 {
+		return nil
+	}
+	// 1. 结算页展示会员的可用积分
+	user, err := c.memberUserSvc.GetUser(ctx, req.UserID)
+	if err != nil || user == nil {
+		return err
+	}
+	result.TotalPoint = int(user.Point)
+	config, err := c.tradeConfigSvc.GetTradeConfig(ctx)
+	if err != nil {
+		return err
+	}
+	if !config.PointTradeDeductEnabled || config.PointTradeDeductUnitPrice <= 0 || config.PointTradeDeductMaxPercent <= 0 {
+		return nil
+	}
+	if !req.PointStatus || user.Point <= 0 {
+		return nil
+	}
+
+	// 2. 计算可抵扣的积分与金额
+	items := itemPointers(result)
+	payPrice := sumItemPayPrice(items)
+	maxPointPrice := min(payPrice*config.PointTradeDeductMaxPercent/100, payPrice-1)
+	usePoint := min(int(user.Point), maxPointPrice/config.PointTradeDeductUnitPrice)
+	if usePoint <= 0 {
+		return nil
+	}
+	pointPrice := usePoint * config.PointTradeDeductUnitPrice
+
+	// 3. 按比例分摊到商品，售后时按商品退还积分
+	pointPrices := dividePrice(items, pointPrice)
+	usePoints := dividePrice(items, usePoint)
+	for i, item := range items {
+		item.PointPrice += pointPrices[i]
+		item.UsePoint += usePoints[i]
+		recountItemPayPrice(item)
+	}
+	result.UsePoint = usePoint
+	recountAllPrice(result)
+	addPromotion(result, 0, "积分抵扣", trade.PromotionTypePoint, items, pointPrices, pointPrice, true,
+		fmt.Sprintf("积分抵扣：使用 %d 积分，省 %s 元", usePoint, formatPrice(pointPrice)))
+	return nil
+}
+
+// TradePointGivePriceCalculator 赠送积分计算器，在所有优惠之后执行
+// 每个商品赠送 SPU 设置的积分 * 数量，另按交易配置每支付 1 元赠送 PointTradeGivePoint 个积分（不含运费）
+// 对应 Java: TradePointGivePriceCalculator
+type TradePointGivePriceCalculator struct {
+	tradeConfigSvc *TradeConfigService
+}
+
+func NewTradePointGivePriceCalculator(tradeConfigSvc *TradeConfigService) *TradePointGivePriceCalculator {
+	return &TradePointGivePriceCalculator{tradeConfigSvc: tradeConfigSvc}
+}
+
+func (c *TradePointGivePriceCalculator) Order() int {
+	return TradePriceOrderPointGive
+}
+
+func (c *TradePointGivePriceCalculator) Calculate(ctx context.Context, req *TradePriceCalculateReqBO, result *TradePriceCalculateRespBO) error {
+	config, err := c.tradeConfigSvc.GetTradeConfig(ctx)
+	if err != nil {
+		return err
+	}
+	result.GivePoint = 0
+	for i := range result.Items {
+		item := &result.Items[i]
+		if config.PointTradeGivePoint > 0 {
+			item.GivePoint += (item.PayPrice - item.DeliveryPrice) / 100 * config.PointTradeGivePoint
+		}
+		result.GivePoint += item.GivePoint
+	}
+	return nil
+}
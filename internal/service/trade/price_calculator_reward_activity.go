@@ -0,0 +1,69 @@
+package trade
+
+import (
+	"backend-go/internal/model/trade"
+	"backend-go/internal/service/promotion"
+	"context"
+	"fmt"
+)
+
+// TradeRewardActivityPriceCalculator 满减送活动价格计算器
+// 对应 Java: TradeRewardActivityPriceCalculator
+type TradeRewardActivityPriceCalculator struct {
+	rewardActivitySvc *promotion.RewardActivityService
+}
+
+func NewTradeRewardActivityPriceCalculator(rewardActivitySvc *promotion.RewardActivityService) *TradeRewardActivityPriceCalculator {
+	return &TradeRewardActivityPriceCalculator{rewardActivitySvc: rewardActivitySvc}
+}
+
+func (c *TradeRewardActivityPriceCalculator) Order() int {
+	return TradePriceOrderRewardActivity
+}
+
+func (c *TradeRewardActivityPriceCalculator) Calculate(ctx context.Context, req *TradePriceCalculateReqBO, result *TradePriceCalculateRespBO) error {
+	// 只有普通订单参与满减送
+	if result.Type != trade.TradeOrderTypeNormal || len(result.Items) == 0 {
+		return nil
+	}
+	items := itemPointers(result)
+	matchItems := make([]promotion.ActivityMatchItem, len(items))
+	for i, item := range items {
+		matchItems[i] = promotion.ActivityMatchItem{
+			SkuID:      item.SkuID,
+			SpuID:      item.SpuID,
+			CategoryID: item.CategoryID,
+			PayPrice:   item.PayPrice,
+			Count:      item.Count,
+		}
+	}
+	_, activities, err := c.rewardActivitySvc.CalculateRewardActivity(ctx, matchItems)
+	if err != nil {
+		return err
+	}
+
+	// 每个满足条件的活动记录一条营销明细
+	for _, activity := range activities {
+		skuIDs := make(map[int64]bool, len(activity.SkuIDs))
+		for _, skuID := range activity.SkuIDs {
+			skuIDs[skuID] = true
+		}
+		var activityItems []*TradePriceCalculateItemRespBO
+		for _, item := range items {
+			if skuIDs[item.SkuID] {
+				activityItems = append(activityItems, item)
+			}
+		}
+		// 优惠金额按比例分摊到参与活动的商品，不超过商品当前的支付金额
+		discountPrice := min(activity.TotalDiscount, sumItemPayPrice(activityItems))
+		discounts := dividePrice(activityItems, discountPrice)
+		for i, item := range activityItems {
+			item.DiscountPrice += discounts[i]
+			recountItemPayPrice(item)
+		}
+		addPromotion(result, activity.ActivityID, activity.ActivityName, trade.PromotionTypeRewardActivity, activityItems, discounts,
+			discountPrice, true, fmt.Sprintf("满减送：省 %s 元", formatPrice(discountPrice)))
+	}
+	recountAllPrice(result)
+	return nil
+}
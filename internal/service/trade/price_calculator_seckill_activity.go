@@ -0,0 +1,64 @@
+package trade
+
+import (
+	"backend-go/internal/model/trade"
+	"backend-go/internal/pkg/core"
+	"backend-go/internal/service/promotion"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// TradeSeckillActivityPriceCalculator 秒杀活动价格计算器，商品按秒杀价购买
+// 秒杀订单只能购买一个商品，且不再参与限时折扣、满减送、优惠券
+// 对应 Java: TradeSeckillActivityPriceCalculator
+type TradeSeckillActivityPriceCalculator struct {
+	seckillActivitySvc *promotion.SeckillActivityService
+	orderQuerySvc      *TradeOrderQueryService
+}
+
+func NewTradeSeckillActivityPriceCalculator(seckillActivitySvc *promotion.SeckillActivityService, orderQuerySvc *TradeOrderQueryService) *TradeSeckillActivityPriceCalculator {
+	return &TradeSeckillActivityPriceCalculator{
+		seckillActivitySvc: seckillActivitySvc,
+		orderQuerySvc:      orderQuerySvc,
+	}
+}
+
+func (c *TradeSeckillActivityPriceCalculator) Order() int {
+	return TradePriceOrderSeckillActivity
+}
+
+func (c *TradeSeckillActivityPriceCalculator) Calculate(ctx context.Context, req *TradePriceCalculateReqBO, result *TradePriceCalculateRespBO) error {
+	if req.SeckillActivityID == nil || *req.SeckillActivityID <= 0 {
+		return nil
+	}
+	if len(result.Items) != 1 {
+		return errors.New("秒杀时，只允许选择一个商品")
+	}
+	item := &result.Items[0]
+
+	// 1. 校验是否可以参与秒杀，以及会员的总限购数量。下单时 CreateOrder 持有会员的秒杀锁，校验结果在提交前不会被并发下单穿透
+	activity, seckillProduct, err := c.seckillActivitySvc.ValidateJoinSeckill(ctx, *req.SeckillActivityID, item.SkuID, item.Count)
+	if err != nil {
+		return err
+	}
+	if activity.TotalLimitCount > 0 {
+		count, err := c.orderQuerySvc.GetSeckillProductCount(ctx, req.UserID, activity.ID)
+		if err != nil {
+			return err
+		}
+		if count+item.Count > activity.TotalLimitCount {
+			return core.NewBizError(1011003004, "参与秒杀的商品，超过了秒杀总限购数量") // PRICE_CALCULATE_SECKILL_TOTAL_LIMIT_COUNT
+		}
+	}
+
+	// 2. 按秒杀价计算优惠
+	result.Type = trade.TradeOrderTypeSeckill
+	discountPrice := max(item.Price-seckillProduct.SeckillPrice, 0) * item.Count
+	item.DiscountPrice += discountPrice
+	recountItemPayPrice(item)
+	recountAllPrice(result)
+	addPromotion(result, activity.ID, activity.Name, trade.PromotionTypeSeckillActivity, []*TradePriceCalculateItemRespBO{item}, nil,
+		discountPrice, true, fmt.Sprintf("秒杀活动：省 %s 元", formatPrice(discountPrice)))
+	return nil
+}
@@ -3,22 +3,29 @@ package service
 import (
 	"context"
 	"errors"
+	"io"
 
 	"backend-go/internal/api/req"
 	"backend-go/internal/api/resp"
 	"backend-go/internal/model"
 	"backend-go/internal/pkg/core"
+	"backend-go/internal/pkg/excel"
 	"backend-go/internal/pkg/utils"
 	"backend-go/internal/repo/query"
+
+	"gorm.io/gen"
+	"gorm.io/gorm"
 )
 
 type UserService struct {
-	q *query.Query
+	q       *query.Query
+	permSvc *PermissionService
 }
 
-func NewUserService(q *query.Query) *UserService {
+func NewUserService(q *query.Query, permSvc *PermissionService) *UserService {
 	return &UserService{
-		q: q,
+		q:       q,
+		permSvc: permSvc,
 	}
 }
 
@@ -281,6 +288,12 @@ func (s *UserService) GetUserPage(ctx context.Context, req *req.UserPageReq) (*c
 	if req.CreateTimeLe != nil {
 		qb = qb.Where(u.CreatedAt.Lte(*req.CreateTimeLe))
 	}
+	// 数据权限：按部门过滤，仅本人时按用户编号过滤
+	scope, err := s.permSvc.DeptDataScope(ctx, &u.DeptID, &u.ID)
+	if err != nil {
+		return nil, err
+	}
+	qb = qb.Where(scope...)
 
 	total, err := qb.Count()
 	if err != nil {
@@ -344,52 +357,178 @@ func (s *UserService) ResetUserPassword(ctx context.Context, req *req.UserResetP
 	return err
 }
 
-// GetUserList 获得用户列表 (用于导出)
-func (s *UserService) GetUserList(ctx context.Context, req *req.UserExportReq) ([]*resp.UserRespVO, error) {
+// ExportUserExcel 导出用户，按编号分批查询并流式写入 w
+func (s *UserService) ExportUserExcel(ctx context.Context, w io.Writer, req *req.UserExportReq, dict excel.DictFormatter) error {
 	u := s.q.SystemUser
-	qb := u.WithContext(ctx)
-
+	var conds []gen.Condition
 	if req.Username != "" {
-		qb = qb.Where(u.Username.Like("%" + req.Username + "%"))
+		conds = append(conds, u.Username.Like("%"+req.Username+"%"))
 	}
 	if req.Mobile != "" {
-		qb = qb.Where(u.Mobile.Like("%" + req.Mobile + "%"))
+		conds = append(conds, u.Mobile.Like("%"+req.Mobile+"%"))
 	}
 	if req.Status != nil {
-		qb = qb.Where(u.Status.Eq(int32(*req.Status)))
+		conds = append(conds, u.Status.Eq(int32(*req.Status)))
 	}
 	if req.DeptID > 0 {
-		qb = qb.Where(u.DeptID.Eq(req.DeptID))
+		conds = append(conds, u.DeptID.Eq(req.DeptID))
 	}
 	if req.CreateTimeGe != nil {
-		qb = qb.Where(u.CreatedAt.Gte(*req.CreateTimeGe))
+		conds = append(conds, u.CreatedAt.Gte(*req.CreateTimeGe))
 	}
 	if req.CreateTimeLe != nil {
-		qb = qb.Where(u.CreatedAt.Lte(*req.CreateTimeLe))
+		conds = append(conds, u.CreatedAt.Lte(*req.CreateTimeLe))
+	}
+	// 数据权限：与分页查询一致；异步导出的 ctx 仍保留登录用户，可正常解析
+	scope, err := s.permSvc.DeptDataScope(ctx, &u.DeptID, &u.ID)
+	if err != nil {
+		return err
 	}
+	conds = append(conds, scope...)
+
+	return excel.Export(w, func(cursor int64, limit int) ([]*resp.UserExcelVO, int64, error) {
+		list, err := u.WithContext(ctx).Where(conds...).Where(u.ID.Gt(cursor)).Order(u.ID).Limit(limit).Find()
+		if err != nil || len(list) == 0 {
+			return nil, cursor, err
+		}
+		deptNames, err := s.getDeptNames(ctx, list)
+		if err != nil {
+			return nil, cursor, err
+		}
+		data := make([]*resp.UserExcelVO, 0, len(list))
+		for _, item := range list {
+			data = append(data, &resp.UserExcelVO{
+				ID:        item.ID,
+				Username:  item.Username,
+				Nickname:  item.Nickname,
+				DeptName:  deptNames[item.DeptID],
+				Email:     item.Email,
+				Mobile:    item.Mobile,
+				Sex:       item.Sex,
+				Status:    item.Status,
+				LoginIP:   item.LoginIP,
+				LoginDate: item.LoginDate,
+				CreatedAt: item.CreatedAt,
+			})
+		}
+		return data, list[len(list)-1].ID, nil
+	}, excel.WithSheetName("用户数据"), excel.WithDictFormatter(dict))
+}
 
-	list, err := qb.Order(u.ID).Find()
+// getDeptNames 获得用户所在部门的名称
+func (s *UserService) getDeptNames(ctx context.Context, users []*model.SystemUser) (map[int64]string, error) {
+	deptIds := make([]int64, 0, len(users))
+	for _, user := range users {
+		if user.DeptID > 0 {
+			deptIds = append(deptIds, user.DeptID)
+		}
+	}
+	names := make(map[int64]string)
+	if len(deptIds) == 0 {
+		return names, nil
+	}
+	d := s.q.SystemDept
+	depts, err := d.WithContext(ctx).Where(d.ID.In(deptIds...)).Find()
 	if err != nil {
 		return nil, err
 	}
+	for _, dept := range depts {
+		names[dept.ID] = dept.Name
+	}
+	return names, nil
+}
 
-	var data []*resp.UserRespVO
-	for _, item := range list {
-		data = append(data, &resp.UserRespVO{
-			ID:         item.ID,
-			Username:   item.Username,
-			Nickname:   item.Nickname,
-			DeptID:     item.DeptID,
-			Email:      item.Email,
-			Mobile:     item.Mobile,
-			Sex:        item.Sex,
-			Avatar:     item.Avatar,
-			Status:     item.Status,
-			LoginIP:    item.LoginIP,
-			CreateTime: item.CreatedAt,
+// ImportUserList 导入用户，updateSupport 为 true 时更新已存在的用户（不修改密码）
+// 对应 Java: AdminUserServiceImpl#importUserList
+func (s *UserService) ImportUserList(ctx context.Context, r io.Reader, updateSupport bool) (*resp.UserImportRespVO, error) {
+	dict, err := LoadDictResolver(ctx, s.q, "system_user_sex", "common_status")
+	if err != nil {
+		return nil, err
+	}
+	result, err := excel.Read[resp.UserImportExcelVO](r, excel.WithDictResolver(dict))
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Rows) == 0 {
+		return nil, core.NewBizError(1002003004, "导入用户数据不能为空！") // USER_IMPORT_LIST_IS_EMPTY
+	}
+
+	importResult := excel.Import(result, func(row *resp.UserImportExcelVO) string {
+		return row.Username
+	}, func(row *resp.UserImportExcelVO) (excel.ImportAction, error) {
+		return s.importUser(ctx, row, updateSupport)
+	})
+	return &resp.UserImportRespVO{
+		CreateUsernames:  importResult.Creates,
+		UpdateUsernames:  importResult.Updates,
+		FailureUsernames: importResult.Failures,
+	}, nil
+}
+
+// importUser 导入单个用户：不存在则创建，存在且支持更新则更新
+func (s *UserService) importUser(ctx context.Context, row *resp.UserImportExcelVO, updateSupport bool) (excel.ImportAction, error) {
+	if row.DeptID > 0 {
+		d := s.q.SystemDept
+		count, err := d.WithContext(ctx).Where(d.ID.Eq(row.DeptID)).Count()
+		if err != nil {
+			return 0, err
+		}
+		if count == 0 {
+			return 0, errors.New("部门不存在")
+		}
+	}
+
+	u := s.q.SystemUser
+	existUser, err := u.WithContext(ctx).Where(u.Username.Eq(row.Username)).First()
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, err
+	}
+	// 1. 不存在，则创建（默认密码）
+	if existUser == nil {
+		_, err := s.CreateUser(ctx, &req.UserSaveReq{
+			Username: row.Username,
+			Nickname: row.Nickname,
+			Email:    row.Email,
+			Mobile:   row.Mobile,
+			Sex:      row.Sex,
+			DeptID:   row.DeptID,
+			Status:   int(row.Status),
 		})
+		if err != nil {
+			return 0, err
+		}
+		return excel.ImportCreated, nil
+	}
+
+	// 2. 存在，且不支持更新
+	if !updateSupport {
+		return 0, errors.New("用户账号已存在")
+	}
+	// 3. 存在，则更新（保留密码、岗位与角色）
+	if row.Mobile != "" {
+		if err := s.checkMobileUnique(ctx, row.Mobile, existUser.ID); err != nil {
+			return 0, err
+		}
+	}
+	if row.Email != "" {
+		if err := s.checkEmailUnique(ctx, row.Email, existUser.ID); err != nil {
+			return 0, err
+		}
+	}
+	_, err = u.WithContext(ctx).Where(u.ID.Eq(existUser.ID)).
+		Select(u.Nickname, u.Email, u.Mobile, u.Sex, u.Status, u.DeptID).
+		Updates(&model.SystemUser{
+			Nickname: row.Nickname,
+			Email:    row.Email,
+			Mobile:   row.Mobile,
+			Sex:      row.Sex,
+			Status:   row.Status,
+			DeptID:   row.DeptID,
+		})
+	if err != nil {
+		return 0, err
 	}
-	return data, nil
+	return excel.ImportUpdated, nil
 }
 
 // GetImportTemplate 获得导入模板
@@ -400,8 +539,8 @@ func (s *UserService) GetImportTemplate(ctx context.Context) ([]resp.UserImportE
 			Nickname: "张三",
 			Email:    "zhangsan@yudao.cn",
 			Mobile:   "15601691300",
-			Sex:      "1",
-			Status:   "0",
+			Sex:      1,
+			Status:   0,
 			DeptID:   100,
 		},
 		{
@@ -409,8 +548,8 @@ func (s *UserService) GetImportTemplate(ctx context.Context) ([]resp.UserImportE
 			Nickname: "李四",
 			Email:    "lisi@yudao.cn",
 			Mobile:   "15601691301",
-			Sex:      "2",
-			Status:   "0",
+			Sex:      2,
+			Status:   0,
 			DeptID:   100,
 		},
 	}, nil
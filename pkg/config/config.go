@@ -10,13 +10,15 @@ import (
 var C = new(Config)
 
 type Config struct {
-	App   AppConfig   `mapstructure:"app"`
-	HTTP  HTTPConfig  `mapstructure:"http"`
-	Log   LogConfig   `mapstructure:"log"`
-	MySQL MySQLConfig `mapstructure:"mysql"`
-	Redis RedisConfig `mapstructure:"redis"`
-	Trade TradeConfig `mapstructure:"trade"`
-	Pay   PayConfig   `mapstructure:"pay"`
+	App     AppConfig     `mapstructure:"app"`
+	HTTP    HTTPConfig    `mapstructure:"http"`
+	Log     LogConfig     `mapstructure:"log"`
+	MySQL   MySQLConfig   `mapstructure:"mysql"`
+	Redis   RedisConfig   `mapstructure:"redis"`
+	Trade   TradeConfig   `mapstructure:"trade"`
+	Pay     PayConfig     `mapstructure:"pay"`
+	Infra   InfraConfig   `mapstructure:"infra"`
+	Captcha CaptchaConfig `mapstructure:"captcha"`
 }
 
 type AppConfig struct {
@@ -71,6 +73,29 @@ type PayConfig struct {
 	OrderNoPrefix   string `mapstructure:"order_no_prefix"`
 }
 
+type InfraConfig struct {
+	ApiLog ApiLogConfig `mapstructure:"api_log"`
+	File   FileConfig   `mapstructure:"file"`
+}
+
+// FileConfig 文件上传配置
+type FileConfig struct {
+	MaxSize       int64    `mapstructure:"max_size"`       // 单个文件的最大字节数，默认 100MB
+	AllowedTypes  []string `mapstructure:"allowed_types"`  // 允许的文件类型，支持 image/* 通配，默认图片、音视频与常用文档
+	PresignExpire int      `mapstructure:"presign_expire"` // 预签名上传地址的有效秒数，默认 600
+}
+
+// ApiLogConfig API 日志配置
+type ApiLogConfig struct {
+	AccessLogRetainDays int `mapstructure:"access_log_retain_days"` // 访问日志保留天数，默认 14 天
+	ErrorLogRetainDays  int `mapstructure:"error_log_retain_days"`  // 错误日志保留天数，默认 14 天
+}
+
+// CaptchaConfig 验证码配置
+type CaptchaConfig struct {
+	Enable bool `mapstructure:"enable"` // 是否开启验证码，可被参数配置 system.captcha.enable 覆盖
+}
+
 func Load() error {
 	// 读取环境变量
 	env := os.Getenv("GO_ENV")
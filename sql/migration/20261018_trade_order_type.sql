@@ -0,0 +1,10 @@
+-- 交易订单类型 trade_order.type 对齐 Java TradeOrderTypeEnum：0 普通、1 秒杀、2 砍价、3 拼团、4 积分商城
+-- 之前的版本下单时固定写入 type = 1，普通订单会被识别为秒杀订单，这里按活动编号修正历史数据
+UPDATE trade_order SET type = 3
+WHERE type = 1 AND IFNULL(seckill_activity_id, 0) = 0 AND IFNULL(combination_activity_id, 0) > 0;
+
+UPDATE trade_order SET type = 2
+WHERE type = 1 AND IFNULL(seckill_activity_id, 0) = 0 AND IFNULL(bargain_activity_id, 0) > 0;
+
+UPDATE trade_order SET type = 0
+WHERE type = 1 AND IFNULL(seckill_activity_id, 0) = 0;